@@ -0,0 +1,137 @@
+package openplantbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// cacheArchiveVersion is the current cache archive format
+const cacheArchiveVersion = 1
+
+// CacheArchiver is implemented by caches that can be saved to and restored
+// from a portable archive
+//
+// Archives are JSON documents listing every unexpired entry with its
+// remaining TTL. TTLs restart when the archive is imported, so a cache
+// shipped to a CI runner or an air-gapped host stays valid for as long as it
+// did when exported.
+type CacheArchiver interface {
+	Export(w io.Writer) error
+	Import(r io.Reader) error
+}
+
+var (
+	_ CacheArchiver = (*InMemoryCache)(nil)
+	_ CacheArchiver = (*FileCache)(nil)
+)
+
+type cacheArchive struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Entries    []archiveEntry `json:"entries"`
+}
+
+type archiveEntry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+	TTL   string `json:"ttl"`
+}
+
+// ImportCache loads an archive written by CacheArchiver.Export into any Cache
+func ImportCache(dst Cache, r io.Reader) error {
+	return readCacheArchive(r, func(key string, value []byte, ttl time.Duration) error {
+		dst.Set(key, value, ttl)
+		return nil
+	})
+}
+
+// readCacheArchive decodes an archive and calls fn for every unexpired entry
+func readCacheArchive(r io.Reader, fn func(key string, value []byte, ttl time.Duration) error) error {
+	var archive cacheArchive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return fmt.Errorf("decode cache archive: %w", err)
+	}
+	if archive.Version != cacheArchiveVersion {
+		return fmt.Errorf("unsupported cache archive version %d", archive.Version)
+	}
+
+	for _, e := range archive.Entries {
+		ttl, err := time.ParseDuration(e.TTL)
+		if err != nil {
+			return fmt.Errorf("cache archive entry %q: invalid ttl: %w", e.Key, err)
+		}
+		if ttl <= 0 {
+			continue
+		}
+		if err := fn(e.Key, e.Value, ttl); err != nil {
+			return fmt.Errorf("cache archive entry %q: %w", e.Key, err)
+		}
+	}
+	return nil
+}
+
+// writeCacheArchive encodes entries sorted by key for stable output
+func writeCacheArchive(w io.Writer, entries []archiveEntry, now time.Time) error {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	if entries == nil {
+		entries = []archiveEntry{}
+	}
+
+	archive := cacheArchive{
+		Version:    cacheArchiveVersion,
+		ExportedAt: now.UTC(),
+		Entries:    entries,
+	}
+	if err := json.NewEncoder(w).Encode(archive); err != nil {
+		return fmt.Errorf("encode cache archive: %w", err)
+	}
+	return nil
+}
+
+// Export writes every unexpired entry to w as a portable archive
+func (c *InMemoryCache) Export(w io.Writer) error {
+	c.mu.RLock()
+	now := c.clock.Now()
+	entries := make([]archiveEntry, 0, len(c.items))
+	for key, item := range c.items {
+		if ttl := item.expiration.Sub(now); ttl > 0 {
+			entries = append(entries, archiveEntry{Key: key, Value: item.value, TTL: ttl.String()})
+		}
+	}
+	c.mu.RUnlock()
+
+	return writeCacheArchive(w, entries, now)
+}
+
+// Import loads entries from an archive written by Export
+func (c *InMemoryCache) Import(r io.Reader) error {
+	return ImportCache(c, r)
+}
+
+// Export writes every unexpired entry to w as a portable archive
+func (c *FileCache) Export(w io.Writer) error {
+	now := c.clock.Now()
+	var entries []archiveEntry
+	err := c.entries(func(header fileEntryHeader, value []byte) error {
+		entries = append(entries, archiveEntry{Key: header.Key, Value: value, TTL: header.ExpiresAt.Sub(now).String()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("export file cache: %w", err)
+	}
+	return writeCacheArchive(w, entries, now)
+}
+
+// Import loads entries from an archive written by Export
+// Unlike Set, write failures are reported.
+func (c *FileCache) Import(r io.Reader) error {
+	defer c.lock(true).unlock()
+
+	now := c.clock.Now()
+	return readCacheArchive(r, func(key string, value []byte, ttl time.Duration) error {
+		return c.write(fileEntryHeader{Key: key, ExpiresAt: now.Add(ttl)}, value)
+	})
+}
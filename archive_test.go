@@ -0,0 +1,104 @@
+package openplantbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheArchive_RoundTrip(t *testing.T) {
+	newFileCache := func(t *testing.T) Cache {
+		c, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewFileCache() unexpected error: %v", err)
+		}
+		return c
+	}
+	newMemoryCache := func(t *testing.T) Cache {
+		c := NewInMemoryCache()
+		t.Cleanup(c.Close)
+		return c
+	}
+
+	caches := map[string]func(*testing.T) Cache{
+		"memory": newMemoryCache,
+		"file":   newFileCache,
+	}
+
+	for srcName, newSrc := range caches {
+		for dstName, newDst := range caches {
+			t.Run(srcName+" to "+dstName, func(t *testing.T) {
+				src := newSrc(t)
+				src.Set("search:monstera:<nil>", []byte(`{"count":1}`), time.Hour)
+				src.Set("detail:ficus lyrata:<nil>", []byte{0x00, 0xff, 0x10}, 24*time.Hour)
+				src.Set("expired", []byte("gone"), time.Nanosecond)
+				time.Sleep(time.Millisecond)
+
+				var buf bytes.Buffer
+				if err := src.(CacheArchiver).Export(&buf); err != nil {
+					t.Fatalf("Export() unexpected error: %v", err)
+				}
+
+				dst := newDst(t)
+				if err := dst.(CacheArchiver).Import(&buf); err != nil {
+					t.Fatalf("Import() unexpected error: %v", err)
+				}
+
+				if got, ok := dst.Get("search:monstera:<nil>"); !ok || string(got) != `{"count":1}` {
+					t.Errorf("search entry = %q, %v", got, ok)
+				}
+				if got, ok := dst.Get("detail:ficus lyrata:<nil>"); !ok || !bytes.Equal(got, []byte{0x00, 0xff, 0x10}) {
+					t.Errorf("binary entry = %v, %v", got, ok)
+				}
+				if _, ok := dst.Get("expired"); ok {
+					t.Error("expired entry was exported")
+				}
+			})
+		}
+	}
+}
+
+func TestCacheArchive_Format(t *testing.T) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	cache.Set("b", []byte("2"), time.Hour)
+	cache.Set("a", []byte("1"), 2*time.Hour)
+
+	var buf bytes.Buffer
+	if err := cache.Export(&buf); err != nil {
+		t.Fatalf("Export() unexpected error: %v", err)
+	}
+
+	var archive cacheArchive
+	if err := json.Unmarshal(buf.Bytes(), &archive); err != nil {
+		t.Fatalf("archive is not valid JSON: %v", err)
+	}
+	if archive.Version != cacheArchiveVersion {
+		t.Errorf("Version = %d, want %d", archive.Version, cacheArchiveVersion)
+	}
+	if len(archive.Entries) != 2 || archive.Entries[0].Key != "a" {
+		t.Fatalf("Entries = %+v, want a and b sorted by key", archive.Entries)
+	}
+
+	ttl, err := time.ParseDuration(archive.Entries[0].TTL)
+	if err != nil || ttl <= time.Hour || ttl > 2*time.Hour {
+		t.Errorf("TTL = %q, want just under 2h", archive.Entries[0].TTL)
+	}
+}
+
+func TestImportCache_Errors(t *testing.T) {
+	tests := map[string]string{
+		"not json":    "nope",
+		"version":     `{"version":99,"entries":[]}`,
+		"invalid ttl": `{"version":1,"entries":[{"key":"k","value":"","ttl":"soon"}]}`,
+	}
+	for name, archive := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := ImportCache(NewNoOpCache(), strings.NewReader(archive)); err == nil {
+				t.Error("ImportCache() expected error, got nil")
+			}
+		})
+	}
+}
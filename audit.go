@@ -0,0 +1,76 @@
+package openplantbook
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// auditLog appends one JSON line per API request to w (see WithAuditLog)
+type auditLog struct {
+	mu sync.Mutex // serializes writes to w
+	w  io.Writer
+}
+
+// auditEntry is a line of the audit log
+type auditEntry struct {
+	Time       time.Time         `json:"time"`
+	Method     string            `json:"method"`
+	Endpoint   EndpointClass     `json:"endpoint"`
+	Path       string            `json:"path"`
+	Params     map[string]string `json:"params,omitempty"`
+	Status     int               `json:"status"`
+	DurationMS int64             `json:"duration_ms"`
+	Bytes      int               `json:"bytes"`
+	Error      string            `json:"error,omitempty"`
+	Quota      QuotaStatus       `json:"quota"`
+}
+
+// write encodes e as a single line; a failed write is returned but does not
+// affect the request
+func (a *auditLog) write(e auditEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, err = a.w.Write(line)
+	return err
+}
+
+// audit records a completed request in the audit log, if one is configured
+func (c *Client) audit(start time.Time, info ResponseInfo, path string, params map[string][]string) {
+	if c.auditLog == nil {
+		return
+	}
+
+	e := auditEntry{
+		Time:       start.UTC(),
+		Method:     info.Method,
+		Endpoint:   info.Endpoint,
+		Path:       path,
+		Status:     info.StatusCode,
+		DurationMS: info.Duration.Milliseconds(),
+		Bytes:      info.Size,
+		Quota:      c.quota(),
+	}
+	if len(params) > 0 {
+		e.Params = make(map[string]string, len(params))
+		for k, v := range params {
+			if len(v) > 0 {
+				e.Params[k] = c.redactor.String(v[0])
+			}
+		}
+	}
+	if info.Err != nil {
+		e.Error = info.Err.Error()
+	}
+
+	if err := c.auditLog.write(e); err != nil {
+		c.log("audit log write failed", "error", err)
+	}
+}
@@ -0,0 +1,69 @@
+package openplantbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	const apiKey = "super-secret-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/plant/search" {
+			w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+			return
+		}
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := New(WithAPIKey(apiKey), WithBaseURL(server.URL), WithAuditLog(&buf), WithRateLimit(1000))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	client.rateLimiter = rate.NewLimiter(rate.Inf, 1) // keep the 1000/day quota accounting without waiting
+
+	ctx := context.Background()
+	client.SearchPlants(ctx, "fern", &SearchOptions{Limit: 5})
+	client.SearchPlants(ctx, "fern", &SearchOptions{Limit: 5}) // cached, not logged
+	client.GetPlantDetails(ctx, "monstera", nil)
+
+	if strings.Contains(buf.String(), apiKey) {
+		t.Errorf("audit log contains the API key:\n%s", buf.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log has %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	var search, details auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &search); err != nil {
+		t.Fatalf("line 1 is not JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &details); err != nil {
+		t.Fatalf("line 2 is not JSON: %v", err)
+	}
+
+	if search.Path != "/plant/search" || search.Params["alias"] != "fern" || search.Params["limit"] != "5" ||
+		search.Status != http.StatusOK || search.Error != "" {
+		t.Errorf("search entry = %+v", search)
+	}
+	if search.Quota.Used != 1 || search.Quota.Limit != 1000 {
+		t.Errorf("search quota = %+v, want 1 of 1000 used", search.Quota)
+	}
+	if details.Path != "/plant/detail/monstera/" || details.Status != http.StatusInternalServerError || details.Error == "" {
+		t.Errorf("details entry = %+v, want a failed 500", details)
+	}
+	if details.Quota.Used != 2 {
+		t.Errorf("details quota used = %d, want 2", details.Quota.Used)
+	}
+}
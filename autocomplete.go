@@ -0,0 +1,210 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultAutocompleteLimit is the number of suggestions returned when n <= 0
+	DefaultAutocompleteLimit = 10
+
+	// autocompleteFetchLimit is how many results are requested per prefix, so
+	// that the set is usually complete and can serve longer prefixes locally
+	autocompleteFetchLimit = 100
+
+	// autocompleteTTL keeps suggestions fresh while a user is typing
+	autocompleteTTL = 5 * time.Minute
+)
+
+// ErrSuperseded is returned by Autocompleter.Suggest when a newer query
+// replaced the call before it completed
+var ErrSuperseded = errors.New("superseded by a newer autocomplete query")
+
+// Suggestion is a minimal search result for type-ahead display
+type Suggestion struct {
+	PID   string `json:"pid"`
+	Alias string `json:"alias"`
+}
+
+// autocompleteEntry is the cached suggestion set for one prefix
+type autocompleteEntry struct {
+	Suggestions []Suggestion `json:"suggestions"`
+
+	// Complete is true when the API returned every match for the prefix
+	Complete bool `json:"complete"`
+}
+
+// Autocomplete returns up to n suggestions for a type-ahead prefix
+//
+// Suggestions are cached for a few minutes per prefix. When a shorter prefix
+// has a complete cached result set, longer prefixes are answered from it
+// locally: "monst" is served by filtering the cached results for "mons",
+// without an API request. Local filtering matches the prefix against the
+// PID and alias.
+func (c *Client) Autocomplete(ctx context.Context, prefix string, n int) ([]Suggestion, error) {
+	prefix = normalizePrefix(prefix)
+	if prefix == "" {
+		return nil, ErrInvalidInput("prefix cannot be empty")
+	}
+	if n <= 0 {
+		n = DefaultAutocompleteLimit
+	}
+
+	if entry, ok := c.lookupAutocomplete(prefix, n); ok {
+		return limitSuggestions(entry.Suggestions, n), nil
+	}
+
+	response, _, err := c.fetchSearch(ctx, prefix, &SearchOptions{Limit: autocompleteFetchLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := autocompleteEntry{
+		Suggestions: make([]Suggestion, 0, len(response.Results)),
+		Complete:    response.Next == nil && response.Count <= len(response.Results),
+	}
+	for _, r := range response.Results {
+		entry.Suggestions = append(entry.Suggestions, Suggestion{PID: r.PID, Alias: r.Alias})
+	}
+	c.storeAutocomplete(prefix, entry)
+
+	return limitSuggestions(entry.Suggestions, n), nil
+}
+
+// lookupAutocomplete finds cached suggestions for prefix, falling back to the
+// longest shorter prefix with a complete result set
+func (c *Client) lookupAutocomplete(prefix string, n int) (autocompleteEntry, bool) {
+	if !c.cacheEnabled() {
+		return autocompleteEntry{}, false
+	}
+
+	if entry, ok := c.loadAutocomplete(prefix); ok && (entry.Complete || len(entry.Suggestions) >= n) {
+		c.log("cache hit for autocomplete", "prefix", prefix)
+		c.hooks.cacheHit(CacheHitInfo{Key: c.cacheKey(autocompleteKey(prefix)), Endpoint: EndpointSearch})
+		return entry, true
+	}
+
+	for p := trimLastRune(prefix); p != ""; p = trimLastRune(p) {
+		entry, ok := c.loadAutocomplete(p)
+		if !ok || !entry.Complete {
+			continue
+		}
+
+		filtered := autocompleteEntry{Complete: true}
+		for _, s := range entry.Suggestions {
+			if strings.Contains(strings.ToLower(s.Alias), prefix) || strings.Contains(strings.ToLower(s.PID), prefix) {
+				filtered.Suggestions = append(filtered.Suggestions, s)
+			}
+		}
+		c.log("autocomplete served from shorter prefix", "prefix", prefix, "from", p)
+		c.hooks.cacheHit(CacheHitInfo{Key: c.cacheKey(autocompleteKey(p)), Endpoint: EndpointSearch})
+		c.storeAutocomplete(prefix, filtered)
+		return filtered, true
+	}
+
+	return autocompleteEntry{}, false
+}
+
+func (c *Client) loadAutocomplete(prefix string) (autocompleteEntry, bool) {
+	var entry autocompleteEntry
+	cached, ok := c.cache.Get(c.cacheKey(autocompleteKey(prefix)))
+	if !ok || c.serializer.Unmarshal(cached, &entry) != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *Client) storeAutocomplete(prefix string, entry autocompleteEntry) {
+	if !c.cacheEnabled() {
+		return
+	}
+
+	data, err := c.serializer.Marshal(entry)
+	if err != nil {
+		c.log("cache encode failed", "prefix", prefix, "error", err)
+		return
+	}
+	c.cache.Set(c.cacheKey(autocompleteKey(prefix)), data, autocompleteTTL)
+}
+
+// normalizePrefix lowercases and trims a prefix so equivalent input shares
+// cache entries
+func normalizePrefix(prefix string) string {
+	return strings.ToLower(strings.TrimSpace(prefix))
+}
+
+func trimLastRune(s string) string {
+	_, size := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-size]
+}
+
+func limitSuggestions(s []Suggestion, n int) []Suggestion {
+	if len(s) > n {
+		s = s[:n]
+	}
+	return s
+}
+
+// Autocompleter debounces type-ahead queries
+//
+// Each call to Suggest waits for the debounce delay before querying, and a
+// newer call cancels any older one still waiting or in flight, so only the
+// query the user settled on reaches the API. Prefixes that can be answered
+// from the cache are returned immediately.
+type Autocompleter struct {
+	client *Client
+	delay  time.Duration
+	limit  int
+
+	mu     sync.Mutex
+	cancel context.CancelCauseFunc
+}
+
+// NewAutocompleter returns an Autocompleter that waits delay after the last
+// keystroke and returns up to limit suggestions
+func NewAutocompleter(client *Client, delay time.Duration, limit int) *Autocompleter {
+	return &Autocompleter{client: client, delay: delay, limit: limit}
+}
+
+// Suggest returns suggestions for prefix, or ErrSuperseded if a newer call
+// replaced this one
+func (a *Autocompleter) Suggest(ctx context.Context, prefix string) ([]Suggestion, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	a.mu.Lock()
+	if a.cancel != nil {
+		a.cancel(ErrSuperseded)
+	}
+	a.cancel = cancel
+	a.mu.Unlock()
+
+	n := a.limit
+	if n <= 0 {
+		n = DefaultAutocompleteLimit
+	}
+
+	// Cached answers need no debouncing
+	if p := normalizePrefix(prefix); p != "" {
+		if entry, ok := a.client.lookupAutocomplete(p, n); ok {
+			return limitSuggestions(entry.Suggestions, n), nil
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	case <-a.client.clock.After(a.delay):
+	}
+
+	suggestions, err := a.client.Autocomplete(ctx, prefix, n)
+	if err != nil && errors.Is(context.Cause(ctx), ErrSuperseded) {
+		return nil, ErrSuperseded
+	}
+	return suggestions, err
+}
@@ -0,0 +1,170 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var autocompletePlants = []PlantSearchResult{
+	{PID: "monstera deliciosa", Alias: "Swiss cheese plant"},
+	{PID: "monstera adansonii", Alias: "Monkey mask"},
+	{PID: "monarda didyma", Alias: "Bee balm"},
+	{PID: "ficus lyrata", Alias: "Fiddle-leaf fig"},
+}
+
+// autocompleteServer matches the alias parameter against PIDs and aliases
+func autocompleteServer(calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		query := strings.ToLower(r.URL.Query().Get("alias"))
+
+		var matches []PlantSearchResult
+		for _, p := range autocompletePlants {
+			if strings.Contains(p.PID, query) || strings.Contains(strings.ToLower(p.Alias), query) {
+				matches = append(matches, p)
+			}
+		}
+
+		json.NewEncoder(w).Encode(searchResponse{Count: len(matches), Results: matches})
+	}))
+}
+
+func newAutocompleteClient(t *testing.T, url string) *Client {
+	t.Helper()
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(url), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestClient_Autocomplete(t *testing.T) {
+	var calls atomic.Int32
+	server := autocompleteServer(&calls)
+	defer server.Close()
+	client := newAutocompleteClient(t, server.URL)
+	ctx := context.Background()
+
+	got, err := client.Autocomplete(ctx, " Mon ", 0)
+	if err != nil {
+		t.Fatalf("Autocomplete() unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Autocomplete(mon) = %v, want 3 suggestions", got)
+	}
+	if got[0] != (Suggestion{PID: "monstera deliciosa", Alias: "Swiss cheese plant"}) {
+		t.Errorf("first suggestion = %+v", got[0])
+	}
+
+	// Longer prefixes are served from the complete "mon" result set
+	got, err = client.Autocomplete(ctx, "monst", 0)
+	if err != nil {
+		t.Fatalf("Autocomplete(monst) unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Autocomplete(monst) = %v, want 2 suggestions", got)
+	}
+	got, _ = client.Autocomplete(ctx, "monkey", 0)
+	if len(got) != 1 || got[0].PID != "monstera adansonii" {
+		t.Errorf("Autocomplete(monkey) = %v, want monstera adansonii via alias", got)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("API called %d times, want 1", n)
+	}
+
+	// Limit applies to cached results too
+	got, _ = client.Autocomplete(ctx, "mon", 1)
+	if len(got) != 1 {
+		t.Errorf("Autocomplete(mon, 1) returned %d suggestions, want 1", len(got))
+	}
+
+	// Unrelated prefixes still go to the API
+	if _, err := client.Autocomplete(ctx, "fic", 0); err != nil {
+		t.Fatalf("Autocomplete(fic) unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("API called %d times, want 2", n)
+	}
+
+	if _, err := client.Autocomplete(ctx, "  ", 0); err == nil {
+		t.Error("Autocomplete() with blank prefix expected error, got nil")
+	}
+}
+
+func TestClient_AutocompleteIncompleteSuperset(t *testing.T) {
+	var calls atomic.Int32
+	server := autocompleteServer(&calls)
+	defer server.Close()
+	client := newAutocompleteClient(t, server.URL)
+
+	// Store a truncated result set for "mon"
+	client.storeAutocomplete("mon", autocompleteEntry{
+		Suggestions: []Suggestion{{PID: "monstera deliciosa"}},
+		Complete:    false,
+	})
+
+	got, err := client.Autocomplete(context.Background(), "mona", 0)
+	if err != nil {
+		t.Fatalf("Autocomplete() unexpected error: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Error("incomplete superset was reused instead of querying the API")
+	}
+	if len(got) != 1 || got[0].PID != "monarda didyma" {
+		t.Errorf("Autocomplete(mona) = %v, want monarda didyma", got)
+	}
+}
+
+func TestAutocompleter_Debounce(t *testing.T) {
+	var calls atomic.Int32
+	server := autocompleteServer(&calls)
+	defer server.Close()
+	client := newAutocompleteClient(t, server.URL)
+
+	ac := NewAutocompleter(client, 50*time.Millisecond, 5)
+
+	var (
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, firstErr = ac.Suggest(context.Background(), "fi")
+	}()
+
+	// Type another character before the delay expires
+	time.Sleep(10 * time.Millisecond)
+	got, err := ac.Suggest(context.Background(), "fic")
+	wg.Wait()
+
+	if err != nil {
+		t.Fatalf("Suggest(fic) unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].PID != "ficus lyrata" {
+		t.Errorf("Suggest(fic) = %v, want ficus lyrata", got)
+	}
+	if !errors.Is(firstErr, ErrSuperseded) {
+		t.Errorf("Suggest(fi) error = %v, want ErrSuperseded", firstErr)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("API called %d times, want 1", n)
+	}
+
+	// Cached prefixes skip the debounce delay
+	start := time.Now()
+	if _, err := ac.Suggest(context.Background(), "ficu"); err != nil {
+		t.Fatalf("Suggest(ficu) unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Errorf("cached Suggest() took %v, want no debounce delay", elapsed)
+	}
+}
@@ -0,0 +1,45 @@
+package openplantbook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rmrfslashbin/openplantbook-go/parallel"
+)
+
+// BatchDetailsResult is the outcome of fetching one plant in a batch
+type BatchDetailsResult struct {
+	PID     string
+	Details *PlantDetails
+	Err     error
+}
+
+// GetPlantDetailsBatch fetches details for each PID, calling fn with every
+// result as it arrives
+//
+// By default requests are sent one at a time and results arrive in order,
+// since the rate limit (not latency) bounds batch throughput; cached plants
+// return immediately. With WithBatchConcurrency, up to that many requests
+// run at once and results arrive as they complete. fn is never called
+// concurrently. A failure for one PID is passed to fn rather than ending the
+// batch. The batch stops when fn returns an error, which is returned, or
+// when ctx ends.
+//
+// Progress (see WithProgress) is reported as ProgressDetailsBatch after fn
+// returns for each PID.
+func (c *Client) GetPlantDetailsBatch(ctx context.Context, pids []string, opts *DetailOptions, fn func(BatchDetailsResult) error) error {
+	var mu sync.Mutex
+	progress := TrackProgress(ctx, ProgressDetailsBatch, len(pids))
+	return parallel.ForEach(ctx, pids, c.batchConcurrency, func(ctx context.Context, pid string) error {
+		details, err := c.GetPlantDetails(ctx, pid, opts)
+		if err != nil && ctx.Err() != nil {
+			// Interrupted, not a failure of this PID
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		defer progress.Finish(pid, err)
+		return fn(BatchDetailsResult{PID: pid, Details: details, Err: err})
+	})
+}
@@ -0,0 +1,128 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_GetPlantDetailsBatch(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("reports each result in order", func(t *testing.T) {
+		var got []BatchDetailsResult
+		err := client.GetPlantDetailsBatch(context.Background(), []string{"monstera", "missing", "ficus"}, nil,
+			func(r BatchDetailsResult) error {
+				got = append(got, r)
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("GetPlantDetailsBatch() unexpected error: %v", err)
+		}
+
+		if len(got) != 3 {
+			t.Fatalf("got %d results, want 3", len(got))
+		}
+		for i, pid := range []string{"monstera", "missing", "ficus"} {
+			if got[i].PID != pid {
+				t.Errorf("result %d PID = %q, want %q", i, got[i].PID, pid)
+			}
+		}
+		if got[0].Err != nil || got[0].Details == nil {
+			t.Errorf("result 0 = %+v, want details", got[0])
+		}
+		if !errors.Is(got[1].Err, ErrNotFound) {
+			t.Errorf("result 1 error = %v, want ErrNotFound", got[1].Err)
+		}
+	})
+
+	t.Run("stops when fn fails", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := client.GetPlantDetailsBatch(context.Background(), []string{"a", "b", "c"}, nil,
+			func(r BatchDetailsResult) error {
+				calls++
+				return stop
+			})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Errorf("GetPlantDetailsBatch() = %v after %d calls, want stop after 1", err, calls)
+		}
+	})
+
+	t.Run("stops when context ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := client.GetPlantDetailsBatch(ctx, []string{"a", "b", "c"}, nil,
+			func(r BatchDetailsResult) error {
+				calls++
+				cancel()
+				return nil
+			})
+		if !errors.Is(err, context.Canceled) || calls != 1 {
+			t.Errorf("GetPlantDetailsBatch() = %v after %d calls, want context.Canceled after 1", err, calls)
+		}
+	})
+}
+
+func TestClient_GetPlantDetailsBatch_Concurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"pid": "x"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), WithBatchConcurrency(3))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	pids := []string{"a", "b", "c", "d", "e", "f"}
+	seen := make(map[string]bool)
+	err = client.GetPlantDetailsBatch(context.Background(), pids, nil, func(r BatchDetailsResult) error {
+		seen[r.PID] = true // fn is never called concurrently
+		return r.Err
+	})
+	if err != nil {
+		t.Fatalf("GetPlantDetailsBatch() unexpected error: %v", err)
+	}
+	if len(seen) != len(pids) {
+		t.Errorf("got results for %d PIDs, want %d", len(seen), len(pids))
+	}
+	if p := peak.Load(); p < 2 || p > 3 {
+		t.Errorf("peak concurrent requests = %d, want 2-3", p)
+	}
+}
@@ -0,0 +1,82 @@
+package openplantbook
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// budgetKey is the context key for request budgets
+type budgetKey struct{}
+
+// budget is a request allowance attached to a context; parent is the
+// enclosing budget, which every request also draws from
+type budget struct {
+	remaining atomic.Int64
+	parent    *budget
+}
+
+// WithBudget returns a context that allows at most n API requests by calls
+// made with it (or contexts derived from it); further calls fail with
+// ErrBudgetExhausted without contacting the API
+//
+// Only requests sent to the API count; cache hits are free. Budgets nest:
+// a request made under an inner budget also draws from the outer ones, so a
+// feature cannot escape the cap of the operation that started it. Budgets
+// are shared by every goroutine using the context.
+//
+// Example:
+//
+//	ctx := openplantbook.WithBudget(ctx, 20)
+//	for _, pid := range pids {
+//	    details, err := client.GetPlantDetails(ctx, pid, nil)
+//	    if errors.Is(err, openplantbook.ErrBudgetExhausted) {
+//	        break // the job needed more than 20 requests
+//	    }
+//	}
+func WithBudget(ctx context.Context, n int) context.Context {
+	b := &budget{parent: budgetFrom(ctx)}
+	b.remaining.Store(int64(max(n, 0)))
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+// BudgetRemaining returns how many requests the innermost budget of ctx
+// still allows, after accounting for enclosing budgets
+// ok is false when ctx carries no budget.
+func BudgetRemaining(ctx context.Context) (remaining int, ok bool) {
+	b := budgetFrom(ctx)
+	if b == nil {
+		return 0, false
+	}
+	remaining = int(b.remaining.Load())
+	for p := b.parent; p != nil; p = p.parent {
+		remaining = min(remaining, int(p.remaining.Load()))
+	}
+	return max(remaining, 0), true
+}
+
+func budgetFrom(ctx context.Context) *budget {
+	b, _ := ctx.Value(budgetKey{}).(*budget)
+	return b
+}
+
+// spendBudget takes one request from every budget of ctx, taking none if any
+// of them is exhausted
+func spendBudget(ctx context.Context) error {
+	b := budgetFrom(ctx)
+	for cur := b; cur != nil; cur = cur.parent {
+		if cur.remaining.Add(-1) < 0 {
+			for undo := b; undo != cur.parent; undo = undo.parent {
+				undo.remaining.Add(1)
+			}
+			return ErrBudgetExhausted
+		}
+	}
+	return nil
+}
+
+// refundBudget returns a request taken by spendBudget that was never sent
+func refundBudget(ctx context.Context) {
+	for cur := budgetFrom(ctx); cur != nil; cur = cur.parent {
+		cur.remaining.Add(1)
+	}
+}
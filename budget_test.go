@@ -0,0 +1,97 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithBudget(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := WithBudget(context.Background(), 2)
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("first request unexpected error: %v", err)
+	}
+	// Cache hits are free
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("cached request unexpected error: %v", err)
+	}
+	if _, err := client.GetPlantDetails(ctx, "ficus", nil); err != nil {
+		t.Fatalf("second request unexpected error: %v", err)
+	}
+	if remaining, ok := BudgetRemaining(ctx); !ok || remaining != 0 {
+		t.Errorf("BudgetRemaining() = %d, %v, want 0, true", remaining, ok)
+	}
+
+	if _, err := client.GetPlantDetails(ctx, "pothos", nil); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("third request error = %v, want ErrBudgetExhausted", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+
+	// Requests without a budget are unaffected
+	if _, err := client.GetPlantDetails(context.Background(), "pothos", nil); err != nil {
+		t.Errorf("unbudgeted request unexpected error: %v", err)
+	}
+}
+
+func TestWithBudget_Nested(t *testing.T) {
+	outer := WithBudget(context.Background(), 1)
+	inner := WithBudget(outer, 5)
+
+	if remaining, _ := BudgetRemaining(inner); remaining != 1 {
+		t.Errorf("inner BudgetRemaining() = %d, want the outer cap 1", remaining)
+	}
+	if err := spendBudget(inner); err != nil {
+		t.Fatalf("first spend unexpected error: %v", err)
+	}
+	if err := spendBudget(inner); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("second spend error = %v, want ErrBudgetExhausted", err)
+	}
+	// The failed spend must not have drained the inner budget
+	if got := budgetFrom(inner).remaining.Load(); got != 4 {
+		t.Errorf("inner budget = %d, want 4", got)
+	}
+	if _, ok := BudgetRemaining(context.Background()); ok {
+		t.Error("BudgetRemaining() ok = true for a context without budget")
+	}
+}
+
+func TestWithBudget_RefundOnRateLimit(t *testing.T) {
+	client, err := New(WithAPIKey("test-key"), WithRateLimit(1), WithRateLimitBehavior(RateLimitError))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	client.rateLimiter.Allow() // spend the only token
+
+	ctx := WithBudget(context.Background(), 1)
+	var rateErr *ErrRateLimited
+	if err := client.waitRateLimit(ctx, EndpointDetails); !errors.As(err, &rateErr) {
+		t.Fatalf("waitRateLimit() error = %v, want ErrRateLimited", err)
+	}
+	if remaining, _ := BudgetRemaining(ctx); remaining != 1 {
+		t.Errorf("BudgetRemaining() = %d, want 1 after a rate-limited request", remaining)
+	}
+}
@@ -1,11 +1,14 @@
 package openplantbook
 
 import (
+	"context"
+	"io"
 	"sync"
 	"time"
 )
 
 // Cache is the interface for caching API responses
+// Implementations must be safe for concurrent use.
 type Cache interface {
 	// Get retrieves a value from the cache
 	Get(key string) ([]byte, bool)
@@ -20,11 +23,19 @@ type Cache interface {
 	Clear()
 }
 
+// DefaultCleanupInterval is how often NewInMemoryCache removes expired entries
+const DefaultCleanupInterval = 5 * time.Minute
+
 // InMemoryCache implements Cache using an in-memory map
 type InMemoryCache struct {
-	mu    sync.RWMutex
-	items map[string]*cacheItem
-	stop  chan struct{}
+	mu         sync.RWMutex
+	items      map[string]*cacheItem
+	maxEntries int
+	stop       chan struct{}
+	stopOnce   sync.Once
+
+	// clock judges expiry; the default cache of a client uses its clock
+	clock Clock
 }
 
 type cacheItem struct {
@@ -34,13 +45,35 @@ type cacheItem struct {
 
 // NewInMemoryCache creates a new in-memory cache with background cleanup
 func NewInMemoryCache() *InMemoryCache {
+	return NewInMemoryCacheWithOptions(context.Background(), DefaultCleanupInterval, 0)
+}
+
+// NewInMemoryCacheWithOptions creates an in-memory cache with a custom cleanup
+// interval and size bound
+//
+// A cleanupInterval <= 0 starts no background goroutine; expired entries are
+// then dropped when the cache is full. maxEntries <= 0 means unlimited; when a
+// bounded cache is full, expired entries are removed and, failing that, the
+// entry closest to expiry is evicted. Eviction scans every entry, so bounds
+// suit small caches on constrained devices. The cleanup goroutine stops when
+// ctx is done or Close is called.
+func NewInMemoryCacheWithOptions(ctx context.Context, cleanupInterval time.Duration, maxEntries int) *InMemoryCache {
+	return newInMemoryCache(ctx, cleanupInterval, maxEntries, realClock{})
+}
+
+// newInMemoryCache creates an in-memory cache judging expiry by clock
+func newInMemoryCache(ctx context.Context, cleanupInterval time.Duration, maxEntries int, clock Clock) *InMemoryCache {
 	cache := &InMemoryCache{
-		items: make(map[string]*cacheItem),
-		stop:  make(chan struct{}),
+		items:      make(map[string]*cacheItem),
+		maxEntries: maxEntries,
+		stop:       make(chan struct{}),
+		clock:      clock,
 	}
 
 	// Start background cleanup goroutine
-	go cache.cleanup()
+	if cleanupInterval > 0 {
+		go cache.cleanup(ctx, cleanupInterval)
+	}
 
 	return cache
 }
@@ -56,7 +89,7 @@ func (c *InMemoryCache) Get(key string) ([]byte, bool) {
 	}
 
 	// Check expiration
-	if time.Now().After(item.expiration) {
+	if c.clock.Now().After(item.expiration) {
 		return nil, false
 	}
 
@@ -68,12 +101,31 @@ func (c *InMemoryCache) Set(key string, value []byte, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if _, exists := c.items[key]; !exists && c.maxEntries > 0 && len(c.items) >= c.maxEntries {
+		c.evict()
+	}
+
 	c.items[key] = &cacheItem{
 		value:      value,
-		expiration: time.Now().Add(ttl),
+		expiration: c.clock.Now().Add(ttl),
 	}
 }
 
+// Len returns the number of unexpired entries
+func (c *InMemoryCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.clock.Now()
+	n := 0
+	for _, item := range c.items {
+		if !now.After(item.expiration) {
+			n++
+		}
+	}
+	return n
+}
+
 // Delete removes a value from the cache
 func (c *InMemoryCache) Delete(key string) {
 	c.mu.Lock()
@@ -91,13 +143,16 @@ func (c *InMemoryCache) Clear() {
 }
 
 // Close stops the background cleanup goroutine
+// It is safe to call Close more than once.
 func (c *InMemoryCache) Close() {
-	close(c.stop)
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
 }
 
 // cleanup removes expired items periodically
-func (c *InMemoryCache) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
+func (c *InMemoryCache) cleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -106,6 +161,8 @@ func (c *InMemoryCache) cleanup() {
 			c.removeExpired()
 		case <-c.stop:
 			return
+		case <-ctx.Done():
+			return
 		}
 	}
 }
@@ -115,7 +172,12 @@ func (c *InMemoryCache) removeExpired() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
+	c.deleteExpired()
+}
+
+// deleteExpired removes expired items; callers hold the write lock
+func (c *InMemoryCache) deleteExpired() {
+	now := c.clock.Now()
 	for key, item := range c.items {
 		if now.After(item.expiration) {
 			delete(c.items, key)
@@ -123,6 +185,36 @@ func (c *InMemoryCache) removeExpired() {
 	}
 }
 
+// evict makes room for one entry; callers hold the write lock
+func (c *InMemoryCache) evict() {
+	c.deleteExpired()
+	if len(c.items) < c.maxEntries {
+		return
+	}
+
+	var (
+		oldestKey string
+		oldest    *cacheItem
+	)
+	for key, item := range c.items {
+		if oldest == nil || item.expiration.Before(oldest.expiration) {
+			oldestKey, oldest = key, item
+		}
+	}
+	delete(c.items, oldestKey)
+}
+
+// closeCache closes c if it has a Close method
+func closeCache(c Cache) error {
+	switch c := c.(type) {
+	case io.Closer:
+		return c.Close()
+	case interface{ Close() }:
+		c.Close()
+	}
+	return nil
+}
+
 // NoOpCache is a cache that does nothing (useful for disabling caching)
 type NoOpCache struct{}
 
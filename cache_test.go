@@ -1,8 +1,12 @@
 package openplantbook
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
+
+	"go.uber.org/goleak"
 )
 
 func TestInMemoryCache_GetSet(t *testing.T) {
@@ -144,6 +148,102 @@ func TestInMemoryCache_Cleanup(t *testing.T) {
 	}
 }
 
+func TestInMemoryCacheWithOptions_CleanupInterval(t *testing.T) {
+	cache := NewInMemoryCacheWithOptions(context.Background(), 10*time.Millisecond, 0)
+	defer cache.Close()
+
+	cache.Set("key", []byte("value"), time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		cache.mu.RLock()
+		count := len(cache.items)
+		cache.mu.RUnlock()
+		if count == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("expired entry not removed by background cleanup")
+}
+
+func TestInMemoryCacheWithOptions_NoCleanup(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	cache := NewInMemoryCacheWithOptions(context.Background(), 0, 0)
+	cache.Set("key", []byte("value"), time.Hour)
+	if _, ok := cache.Get("key"); !ok {
+		t.Error("Get() returned false for existing key")
+	}
+}
+
+func TestInMemoryCacheWithOptions_ContextShutdown(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := NewInMemoryCacheWithOptions(ctx, time.Hour, 0)
+	cache.Set("key", []byte("value"), time.Hour)
+	cancel()
+
+	// The cache stays usable after its cleanup goroutine stops
+	if _, ok := cache.Get("key"); !ok {
+		t.Error("Get() returned false after context cancellation")
+	}
+}
+
+func TestInMemoryCacheWithOptions_MaxEntries(t *testing.T) {
+	cache := NewInMemoryCacheWithOptions(context.Background(), 0, 3)
+
+	cache.Set("soon", []byte("1"), time.Minute)
+	cache.Set("later", []byte("2"), time.Hour)
+	cache.Set("latest", []byte("3"), 2*time.Hour)
+
+	// Updating an existing key never evicts
+	cache.Set("later", []byte("2b"), time.Hour)
+	if _, ok := cache.Get("soon"); !ok {
+		t.Fatal("update of existing key evicted an entry")
+	}
+
+	// A new key evicts the entry closest to expiry
+	cache.Set("new", []byte("4"), time.Hour)
+	if _, ok := cache.Get("soon"); ok {
+		t.Error("entry closest to expiry was not evicted")
+	}
+	for _, key := range []string{"later", "latest", "new"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("%s evicted, want kept", key)
+		}
+	}
+
+	// Expired entries are removed before live ones
+	cache.Set("expired", []byte("5"), time.Nanosecond) // evicts "later"
+	time.Sleep(time.Millisecond)
+	cache.Set("fresh", []byte("6"), time.Hour)
+	if _, ok := cache.Get("latest"); !ok {
+		t.Error("live entry evicted while an expired one remained")
+	}
+
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	if len(cache.items) > 3 {
+		t.Errorf("cache holds %d entries, want at most 3", len(cache.items))
+	}
+}
+
+func BenchmarkInMemoryCache_SetBounded(b *testing.B) {
+	cache := NewInMemoryCacheWithOptions(context.Background(), 0, 1000)
+	value := []byte("value")
+	for i := 0; b.Loop(); i++ {
+		cache.Set(fmt.Sprintf("key-%d", i), value, time.Hour)
+	}
+}
+
+func TestInMemoryCache_CloseTwice(t *testing.T) {
+	cache := NewInMemoryCache()
+	cache.Close()
+	cache.Close() // must not panic
+}
+
 func TestNoOpCache(t *testing.T) {
 	cache := NewNoOpCache()
 
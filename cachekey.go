@@ -0,0 +1,184 @@
+package openplantbook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Operations for CacheKeyFor
+const (
+	CacheOpSearch       = "search"
+	CacheOpDetails      = "detail"
+	CacheOpAutocomplete = "autocomplete"
+	CacheOpGet          = "get"
+	CacheOpMissing      = "missing"
+)
+
+// CacheKeyFor returns the key under which the client caches the response to
+// operation op with the given request parameters
+//
+// Params are the API parameters as the client normalizes them: "alias"
+// (lowercased, whitespace collapsed), "limit" and "userplant" for
+// CacheOpSearch; "pid", "lang" (lowercased, "_" written as "-", left out
+// for "en") and "userplant" for CacheOpDetails; "prefix" for
+// CacheOpAutocomplete; "path" and the encoded "query" for CacheOpGet. Lookups
+// including user plants, and all of CacheOpGet, add "account": the first 16
+// hex digits of the SHA-256 of the API key or OAuth2 client ID. Empty values
+// are left out. A client with WithCacheNamespace prefixes its keys with the
+// namespace and a slash:
+//
+//	key := "staging/" + openplantbook.CacheKeyFor(openplantbook.CacheOpDetails, map[string]string{
+//		"pid": "monstera deliciosa", "lang": "pt-br", "userplant": "user", "account": account,
+//	})
+//	// key == "staging/detail?account=...&lang=pt-br&pid=monstera+deliciosa&userplant=user"
+//
+// Besides responses the client writes two kinds of entries, which count
+// towards Status().Cache.Entries: each response's fetch and expiry times
+// under "meta:" followed by the response's full key, and PIDs that
+// PlantExists found missing under CacheOpMissing with the trimmed "pid",
+// namespaced like the rest ("staging/missing?pid=ficus").
+func CacheKeyFor(op string, params map[string]string) string {
+	v := url.Values{}
+	for name, value := range params {
+		if value != "" {
+			v.Set(name, value)
+		}
+	}
+	if len(v) == 0 {
+		return op
+	}
+	return op + "?" + v.Encode()
+}
+
+// searchParams returns the API parameters of a search
+// Only options that change the response are included: Dedupe and Match are
+// applied after it arrives, and a zero Limit or nil options mean the API
+// defaults, so searches that differ only in them share a cache entry.
+func searchParams(query string, opts *SearchOptions) map[string]string {
+	params := map[string]string{"alias": normalizeQuery(query)}
+	if opts != nil {
+		if opts.Limit > 0 {
+			params["limit"] = strconv.Itoa(opts.Limit)
+		}
+		if opts.UserPlants {
+			params["userplant"] = "user"
+		}
+	}
+	return params
+}
+
+// detailParams returns the API parameters of a details lookup
+// The language is sent as the caller wrote it; only cache keys normalize it.
+func detailParams(pid string, opts *DetailOptions) map[string]string {
+	params := map[string]string{"pid": strings.TrimSpace(pid), "lang": requestLanguage(opts)}
+	if opts != nil && opts.UserPlants {
+		params["userplant"] = "user"
+	}
+	return params
+}
+
+// normalizeQuery trims a search query and folds letter case and runs of
+// whitespace, which the API's alias search ignores
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+// normalizeLanguage lowercases a language code and writes region subtags
+// with a hyphen, so "pt_BR" and "pt-br" share a cache entry
+func normalizeLanguage(lang string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(lang)), "_", "-")
+}
+
+// searchCacheKey returns the cache key of a search
+func searchCacheKey(query string, opts *SearchOptions) string {
+	return CacheKeyFor(CacheOpSearch, searchParams(query, opts))
+}
+
+// detailCacheKey returns the cache key of a details lookup
+// English is the API default, so asking for it shares the entry of asking
+// for no language.
+func detailCacheKey(pid string, opts *DetailOptions) string {
+	return CacheKeyFor(CacheOpDetails, detailKeyParams(pid, opts))
+}
+
+// detailKeyParams returns the parameters of a details cache key
+func detailKeyParams(pid string, opts *DetailOptions) map[string]string {
+	params := detailParams(pid, opts)
+	if lang := normalizeLanguage(params["lang"]); lang != "en" {
+		params["lang"] = lang
+	} else {
+		delete(params, "lang")
+	}
+	return params
+}
+
+// autocompleteKey returns the cache key of a normalized prefix
+func autocompleteKey(prefix string) string {
+	return CacheKeyFor(CacheOpAutocomplete, map[string]string{"prefix": prefix})
+}
+
+// cacheKey places key in the client's cache namespace (see
+// WithCacheNamespace)
+func (c *Client) cacheKey(key string) string {
+	if c.cacheNamespace == "" {
+		return key
+	}
+	return c.cacheNamespace + "/" + key
+}
+
+// searchKey returns the client's cache key of a search, or "" if it must
+// not be cached
+// Searches including user plants depend on the account, so their key also
+// carries the account's identity (see accountCacheable).
+func (c *Client) searchKey(query string, opts *SearchOptions) string {
+	if opts == nil || !opts.UserPlants {
+		return c.cacheKey(searchCacheKey(query, opts))
+	}
+	if !c.accountCacheable() {
+		return ""
+	}
+	params := searchParams(query, opts)
+	params["account"] = c.identity
+	return c.cacheKey(CacheKeyFor(CacheOpSearch, params))
+}
+
+// detailKey returns the client's cache key of a details lookup, or "" if it
+// must not be cached
+// Lookups including user plants depend on the account, as for searchKey.
+func (c *Client) detailKey(pid string, opts *DetailOptions) string {
+	if opts == nil || !opts.UserPlants {
+		return c.cacheKey(detailCacheKey(pid, opts))
+	}
+	if !c.accountCacheable() {
+		return ""
+	}
+	params := detailKeyParams(pid, opts)
+	params["account"] = c.identity
+	return c.cacheKey(CacheKeyFor(CacheOpDetails, params))
+}
+
+// accountCacheable reports whether responses that depend on the account,
+// such as those including user plants, can be cached
+// Clients authenticating through WithTokenSource, WithHTTPClient or a
+// credential provider have no identity; without a cache namespace their
+// accounts would share entries, so such responses are not cached.
+func (c *Client) accountCacheable() bool {
+	return c.identity != "" || c.cacheNamespace != ""
+}
+
+// credentialIdentity returns a short, non-reversible identity of an API key
+// or OAuth2 client ID, or "" if neither is known
+func credentialIdentity(apiKey, clientID string) string {
+	id := apiKey
+	if id == "" {
+		id = clientID
+	}
+	if id == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,354 @@
+package openplantbook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCacheKeyFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		op     string
+		params map[string]string
+		want   string
+	}{
+		{"details", CacheOpDetails, map[string]string{"pid": "monstera deliciosa"}, "detail?pid=monstera+deliciosa"},
+		{"sorted", CacheOpDetails, map[string]string{"pid": "ficus", "lang": "de"}, "detail?lang=de&pid=ficus"},
+		{"empty values dropped", CacheOpDetails, map[string]string{"pid": "ficus", "lang": ""}, "detail?pid=ficus"},
+		{"escaped", CacheOpSearch, map[string]string{"alias": "a&b=c"}, "search?alias=a%26b%3Dc"},
+		{"no params", CacheOpSearch, nil, "search"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CacheKeyFor(tt.op, tt.params); got != tt.want {
+				t.Errorf("CacheKeyFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheKeyFor_ClientKeys(t *testing.T) {
+	client, err := New(WithAPIKey("key"), WithCacheNamespace("staging"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// The key documented on CacheKeyFor is the one the client writes
+	sum := sha256.Sum256([]byte("key"))
+	want := "staging/" + CacheKeyFor(CacheOpDetails, map[string]string{
+		"pid": "monstera deliciosa", "lang": "pt-br", "userplant": "user", "account": hex.EncodeToString(sum[:])[:16],
+	})
+	if got := client.detailKey(" monstera deliciosa ", &DetailOptions{Language: "pt_BR", UserPlants: true}); got != want {
+		t.Errorf("detailKey() = %q, want %q", got, want)
+	}
+	if got, want := client.detailKey("ficus", &DetailOptions{Language: "EN"}), "staging/detail?pid=ficus"; got != want {
+		t.Errorf("detailKey(en) = %q, want %q", got, want)
+	}
+}
+
+func TestCacheKeys_EquivalentOptions(t *testing.T) {
+	if a, b := detailCacheKey("ficus", nil), detailCacheKey("ficus", &DetailOptions{}); a != b {
+		t.Errorf("nil and empty DetailOptions keys differ: %q, %q", a, b)
+	}
+	if a, b := searchCacheKey("fern", nil), searchCacheKey("fern", &SearchOptions{Dedupe: true, Match: MatchPrefix}); a != b {
+		t.Errorf("options applied after the response change the key: %q, %q", a, b)
+	}
+	if a, b := searchCacheKey("fern", nil), searchCacheKey("fern", &SearchOptions{Limit: 5}); a == b {
+		t.Error("Limit does not change the key")
+	}
+
+	if a, b := searchCacheKey("monstera deliciosa", nil), searchCacheKey("  Monstera   Deliciosa ", &SearchOptions{}); a != b {
+		t.Errorf("case and whitespace change the key: %q, %q", a, b)
+	}
+	if a, b := detailCacheKey("ficus", nil), detailCacheKey(" ficus", &DetailOptions{Language: "EN"}); a != b {
+		t.Errorf("the default language changes the key: %q, %q", a, b)
+	}
+	if a, b := detailCacheKey("ficus", &DetailOptions{Language: "de"}), detailCacheKey("ficus", &DetailOptions{Language: "DE"}); a != b {
+		t.Errorf("language case changes the key: %q, %q", a, b)
+	}
+
+	want := CacheKeyFor(CacheOpSearch, map[string]string{"alias": "fern", "limit": "5", "userplant": "user"})
+	if got := searchCacheKey("fern", &SearchOptions{Limit: 5, UserPlants: true}); got != want {
+		t.Errorf("searchCacheKey() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_EquivalentRequestsShareCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/plant/search" {
+			w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+			return
+		}
+		w.Write([]byte(`{"pid": "ficus"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for _, opts := range []*SearchOptions{nil, {}, {Dedupe: true}} {
+		for _, query := range []string{"fern", " Fern "} {
+			if _, err := client.SearchPlants(ctx, query, opts); err != nil {
+				t.Fatalf("SearchPlants(%q) unexpected error: %v", query, err)
+			}
+		}
+	}
+	for _, opts := range []*DetailOptions{nil, {}, {Language: "en"}} {
+		if _, err := client.GetPlantDetails(ctx, "ficus", opts); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("API calls = %d, want 2 (one search, one details)", n)
+	}
+	if _, err := client.SearchPlants(ctx, "   ", nil); err == nil {
+		t.Error("SearchPlants() with a blank query succeeded")
+	}
+}
+
+func TestWithCacheNamespace(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"pid":"ficus"}`))
+	}))
+	defer server.Close()
+
+	// Two clients, e.g. against different environments, share one cache
+	shared := NewInMemoryCache()
+	defer shared.Close()
+	newClient := func(ns string) *Client {
+		client, err := New(WithAPIKey("key"), WithBaseURL(server.URL), WithCache(shared), WithCacheNamespace(ns), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+	staging, production := newClient("staging"), newClient("production")
+	defer staging.Close()
+	defer production.Close()
+
+	ctx := context.Background()
+	for _, c := range []*Client{staging, production, staging} {
+		if _, err := c.GetPlantDetails(ctx, "ficus", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("requests = %d, want 2 (one per namespace)", n)
+	}
+	if _, ok := shared.Get("staging/" + detailCacheKey("ficus", nil)); !ok {
+		t.Error("entry not stored under the namespaced key")
+	}
+
+	for _, ns := range []string{"", "a/b", "a b"} {
+		if _, err := New(WithAPIKey("key"), WithCacheNamespace(ns)); err == nil {
+			t.Errorf("WithCacheNamespace(%q): expected error", ns)
+		}
+	}
+}
+
+func TestSearchKey_Account(t *testing.T) {
+	alice, _ := New(WithAPIKey("alice-key"))
+	defer alice.Close()
+	bob, _ := New(WithAPIKey("bob-key"))
+	defer bob.Close()
+
+	own := &SearchOptions{UserPlants: true}
+	if alice.searchKey("fern", own) == bob.searchKey("fern", own) {
+		t.Error("user plant searches of different accounts share a key")
+	}
+	if alice.searchKey("fern", nil) != bob.searchKey("fern", nil) {
+		t.Error("public searches of different accounts have different keys")
+	}
+	if key := alice.searchKey("fern", own); strings.Contains(key, "alice-key") {
+		t.Errorf("key %q contains the API key", key)
+	}
+}
+
+func TestDetailKey_Account(t *testing.T) {
+	alice, _ := New(WithAPIKey("alice-key"))
+	defer alice.Close()
+	bob, _ := New(WithAPIKey("bob-key"))
+	defer bob.Close()
+
+	own := &DetailOptions{UserPlants: true}
+	if alice.detailKey("ficus", own) == bob.detailKey("ficus", own) {
+		t.Error("user plant details of different accounts share a key")
+	}
+	if alice.detailKey("ficus", own) == alice.detailKey("ficus", nil) {
+		t.Error("user plant and verified details share a key")
+	}
+	if alice.detailKey("ficus", nil) != bob.detailKey("ficus", nil) {
+		t.Error("verified details of different accounts have different keys")
+	}
+}
+
+func TestClient_UserPlantsWithoutIdentity(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		owner := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		fmt.Fprintf(w, `{"pid":"ficus lyrata","alias":"%s's fig","user_plant":true}`, owner)
+	}))
+	defer server.Close()
+
+	// Clients authenticating with a token source have no identity to tell
+	// their accounts apart in a shared cache
+	shared := NewInMemoryCache()
+	defer shared.Close()
+	newClient := func(token string, opts ...Option) *Client {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		opts = append(opts, WithTokenSource(ts), WithBaseURL(server.URL), WithCache(shared), DisableRateLimit())
+		client, err := New(opts...)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+	alice, bob := newClient("alice"), newClient("bob")
+	defer alice.Close()
+	defer bob.Close()
+
+	ctx := context.Background()
+	own := &DetailOptions{UserPlants: true}
+	for _, c := range []struct {
+		client *Client
+		alias  string
+	}{{alice, "alice's fig"}, {bob, "bob's fig"}, {alice, "alice's fig"}} {
+		details, err := c.client.GetPlantDetails(ctx, "ficus lyrata", own)
+		if err != nil {
+			t.Fatalf("GetPlantDetails(UserPlants) unexpected error: %v", err)
+		}
+		if details.Alias != c.alias {
+			t.Errorf("GetPlantDetails(UserPlants) alias = %q, want %q", details.Alias, c.alias)
+		}
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("requests = %d, want 3 (user plants not cached)", n)
+	}
+	if n := shared.Len(); n != 0 {
+		t.Errorf("cache holds %d entries, want none", n)
+	}
+	if key := alice.searchKey("fig", &SearchOptions{UserPlants: true}); key != "" {
+		t.Errorf("searchKey(UserPlants) = %q, want no key", key)
+	}
+
+	// A cache namespace keeps the accounts apart, so responses are cached
+	carol := newClient("carol", WithCacheNamespace("carol"))
+	defer carol.Close()
+	if carol.detailKey("ficus lyrata", own) == "" {
+		t.Error("detailKey() with a cache namespace = \"\", want a key")
+	}
+}
+
+func TestClient_DetailsUserPlants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("userplant") == "user" {
+			fmt.Fprint(w, `{"pid":"ficus lyrata","alias":"My fig","user_plant":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"pid":"ficus lyrata","alias":"Fiddle-leaf fig"}`)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	own, err := client.GetPlantDetails(ctx, "ficus lyrata", &DetailOptions{UserPlants: true})
+	if err != nil {
+		t.Fatalf("GetPlantDetails(UserPlants) unexpected error: %v", err)
+	}
+	if !own.UserPlant || own.Alias != "My fig" {
+		t.Errorf("GetPlantDetails(UserPlants) = %+v, want the user plant", own)
+	}
+	verified, err := client.GetPlantDetails(ctx, "ficus lyrata", nil)
+	if err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if verified.UserPlant || verified.Alias != "Fiddle-leaf fig" {
+		t.Errorf("GetPlantDetails() = %+v, want the verified record", verified)
+	}
+}
+
+func TestClient_LanguagesCachedApart(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		sent []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := r.URL.Query().Get("lang")
+		mu.Lock()
+		sent = append(sent, lang)
+		mu.Unlock()
+		lang = strings.ReplaceAll(strings.ToLower(lang), "_", "-")
+		alias := map[string]string{"": "Swiss cheese plant", "en": "Swiss cheese plant", "de": "Fensterblatt", "pt-br": "Costela-de-adão"}[lang]
+		fmt.Fprintf(w, `{"pid":"monstera deliciosa","alias":%q}`, alias)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Each call is checked against the alias of its language; cached entries
+	// must never answer a caller asking for another language
+	calls := []struct {
+		lang, alias, language string
+	}{
+		{"de", "Fensterblatt", "de"},
+		{"en", "Swiss cheese plant", "en"},
+		{"", "Swiss cheese plant", ""},
+		{" DE ", "Fensterblatt", "de"},
+		{"pt_BR", "Costela-de-adão", "pt-br"},
+		{"pt-br", "Costela-de-adão", "pt-br"},
+		{"EN", "Swiss cheese plant", "en"},
+	}
+	ctx := context.Background()
+	for _, call := range calls {
+		details, err := client.GetPlantDetails(ctx, "monstera deliciosa", &DetailOptions{Language: call.lang})
+		if err != nil {
+			t.Fatalf("GetPlantDetails(lang=%q) unexpected error: %v", call.lang, err)
+		}
+		if details.Alias != call.alias || details.Language != call.language {
+			t.Errorf("GetPlantDetails(lang=%q) = %q in %q, want %q in %q", call.lang, details.Alias, details.Language, call.alias, call.language)
+		}
+	}
+	// Languages are sent as the caller wrote them; only cache keys normalize
+	// them
+	if want := []string{"de", "en", "pt_BR"}; !slices.Equal(sent, want) {
+		t.Errorf("languages sent = %q, want %q (one request per language)", sent, want)
+	}
+
+	// Translated aliases stay out of the local index of English aliases
+	for _, r := range client.SearchLocal("fensterblatt") {
+		if r.Alias == "Fensterblatt" {
+			t.Error("German alias added to the local index")
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package care
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// AlertRule debounces alerts for one metric, so readings hovering around a
+// threshold do not raise and clear an alert on every sample
+type AlertRule struct {
+	// Hysteresis is how far back inside the range, in the metric's unit, a
+	// reading must be to clear a raised alert
+	Hysteresis float64
+
+	// For is how long a metric must stay out of range before an alert is
+	// raised; a reading back in range restarts the wait
+	For time.Duration
+}
+
+// alertRuleJSON is the JSON form of AlertRule, with For as a duration string
+// such as "2h"
+type alertRuleJSON struct {
+	Hysteresis float64 `json:"hysteresis,omitempty"`
+	For        string  `json:"for,omitempty"`
+}
+
+// MarshalJSON encodes For as a duration string
+func (r AlertRule) MarshalJSON() ([]byte, error) {
+	out := alertRuleJSON{Hysteresis: r.Hysteresis}
+	if r.For != 0 {
+		out.For = r.For.String()
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes an alert rule, rejecting negative values
+func (r *AlertRule) UnmarshalJSON(data []byte) error {
+	var in alertRuleJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	rule := AlertRule{Hysteresis: in.Hysteresis}
+	if in.For != "" {
+		d, err := time.ParseDuration(in.For)
+		if err != nil {
+			return fmt.Errorf("alert rule: %w", err)
+		}
+		rule.For = d
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	*r = rule
+	return nil
+}
+
+// Validate rejects negative hysteresis and durations
+func (r AlertRule) Validate() error {
+	if r.Hysteresis < 0 || r.For < 0 {
+		return errors.New("alert rule: hysteresis and duration cannot be negative")
+	}
+	return nil
+}
+
+// AlertRules holds a plant's alert rules by metric; metrics without a rule
+// alert on the first reading out of range and clear on the first back in it
+//
+//	{"soil_moisture": {"hysteresis": 5, "for": "2h"}, "temperature": {"for": "30m"}}
+type AlertRules map[Metric]AlertRule
+
+// Validate rejects rules for unknown metrics and invalid rules
+func (rules AlertRules) Validate() error {
+	for metric, rule := range rules {
+		if !slices.Contains(Metrics, metric) {
+			return fmt.Errorf("alert rule: unknown metric %q", metric)
+		}
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("%w (%s)", err, metric)
+		}
+	}
+	return nil
+}
+
+// Alert reports a change in a metric's alert state
+type Alert struct {
+	// Violation is the reading that raised or cleared the alert, and the
+	// range it was checked against
+	Violation Violation `json:"violation"`
+
+	// Since is when the metric went out of range
+	Since time.Time `json:"since"`
+
+	// Resolved is false when the alert is raised and true when it clears;
+	// a resolving Violation's Status is the status of the cleared alert
+	Resolved bool `json:"resolved,omitempty"`
+}
+
+// String describes the alert
+func (a Alert) String() string {
+	if !a.Resolved {
+		return a.Violation.String()
+	}
+	v := a.Violation
+	return fmt.Sprintf("%s: %s is back in range (%g%s, want %s%s)",
+		v.Plant, v.Reading.Metric, v.Reading.Value, v.Reading.Metric.Unit(), v.Range, v.Reading.Metric.Unit())
+}
+
+// Alerter tracks out-of-range metrics across calls and reports when alerts
+// are raised and cleared
+// The zero Alerter checks raw thresholds. It is safe for concurrent use.
+type Alerter struct {
+	// Evaluator adjusts thresholds, e.g. for winter dormancy
+	Evaluator Evaluator
+
+	mu     sync.Mutex
+	states map[alertKey]*alertState
+}
+
+// alertKey identifies one tracked metric
+type alertKey struct {
+	pid    string
+	sensor string
+	metric Metric
+}
+
+// alertState is the state of a metric that is out of range or alerting
+type alertState struct {
+	status Status
+	since  time.Time
+	raised bool
+}
+
+// Observe checks readings, in time order, and returns the alerts they raise
+// or clear under the plant's rules
+// Readings are tracked per plant, sensor and metric; readings for metrics
+// without threshold data are ignored.
+func (a *Alerter) Observe(details *openplantbook.PlantDetails, rules AlertRules, readings ...Reading) []Alert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.states == nil {
+		a.states = make(map[alertKey]*alertState)
+	}
+
+	var alerts []Alert
+	for _, reading := range readings {
+		status, r, ok := a.Evaluator.Check(details, reading)
+		if !ok {
+			continue
+		}
+		key := alertKey{details.PID, reading.Sensor, reading.Metric}
+		rule := rules[reading.Metric]
+		v := Violation{PID: details.PID, Plant: plantName(details), Reading: reading, Range: r, Status: status}
+
+		st := a.states[key]
+		switch {
+		case st == nil && status == StatusOK:
+			continue
+		case st == nil || (status != StatusOK && status != st.status):
+			// Newly out of range, or crossed to the other side, which clears
+			// the alert for the side it left
+			if st != nil && st.raised {
+				resolved := v
+				resolved.Status = st.status
+				alerts = append(alerts, Alert{Violation: resolved, Since: st.since, Resolved: true})
+			}
+			st = &alertState{status: status, since: reading.Time}
+			a.states[key] = st
+		case status == StatusOK && !st.raised:
+			// Back in range before the alert was due
+			delete(a.states, key)
+			continue
+		case st.raised && cleared(reading.Value, r, st.status, rule.Hysteresis):
+			v.Status = st.status
+			alerts = append(alerts, Alert{Violation: v, Since: st.since, Resolved: true})
+			delete(a.states, key)
+			continue
+		}
+
+		if !st.raised && status != StatusOK && reading.Time.Sub(st.since) >= max(rule.For, 0) {
+			st.raised = true
+			alerts = append(alerts, Alert{Violation: v, Since: st.since})
+		}
+	}
+	return alerts
+}
+
+// cleared reports whether v is far enough inside r to clear an alert of
+// the given status
+func cleared(v float64, r Range, status Status, hysteresis float64) bool {
+	hysteresis = max(hysteresis, 0)
+	if status == StatusLow {
+		return v >= min(r.Min+hysteresis, r.Max)
+	}
+	return v <= max(r.Max-hysteresis, r.Min)
+}
@@ -0,0 +1,110 @@
+package care
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAlerter_Observe(t *testing.T) {
+	start := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	moisture := func(hours, value float64) Reading {
+		return Reading{Metric: MetricSoilMoisture, Value: value, Time: start.Add(time.Duration(hours * float64(time.Hour)))}
+	}
+	rules := AlertRules{MetricSoilMoisture: {Hysteresis: 5, For: 2 * time.Hour}}
+
+	var a Alerter
+	steps := []struct {
+		name     string
+		reading  Reading
+		want     Status
+		resolved bool
+	}{
+		{"dips below min", moisture(0, 14), StatusOK, false},
+		{"recovers before due", moisture(1, 16), StatusOK, false},
+		{"dips again", moisture(1.5, 14), StatusOK, false},
+		{"below for less than two hours", moisture(3, 13), StatusOK, false},
+		{"below for two hours", moisture(3.5, 12), StatusLow, false},
+		{"still below", moisture(4, 11), StatusOK, false},
+		{"back in range within hysteresis", moisture(5, 17), StatusOK, false},
+		{"below again while raised", moisture(6, 14), StatusOK, false},
+		{"clears past hysteresis", moisture(7, 20), StatusLow, true},
+		{"in range", moisture(8, 30), StatusOK, false},
+	}
+	for _, step := range steps {
+		alerts := a.Observe(testDetails(), rules, step.reading)
+		if step.want == StatusOK {
+			if len(alerts) != 0 {
+				t.Errorf("%s: Observe() = %v, want no alerts", step.name, alerts)
+			}
+			continue
+		}
+		if len(alerts) != 1 || alerts[0].Violation.Status != step.want || alerts[0].Resolved != step.resolved {
+			t.Errorf("%s: Observe() = %+v, want one %v alert (resolved %v)", step.name, alerts, step.want, step.resolved)
+			continue
+		}
+		if want := start.Add(90 * time.Minute); !alerts[0].Since.Equal(want) {
+			t.Errorf("%s: Since = %v, want %v", step.name, alerts[0].Since, want)
+		}
+	}
+}
+
+func TestAlerter_NoRules(t *testing.T) {
+	var a Alerter
+	high := Reading{Metric: MetricTemperature, Value: 35, Sensor: "a"}
+
+	if alerts := a.Observe(testDetails(), nil, high); len(alerts) != 1 || alerts[0].Resolved {
+		t.Fatalf("Observe() = %v, want the alert raised at once", alerts)
+	}
+	if alerts := a.Observe(testDetails(), nil, high); len(alerts) != 0 {
+		t.Errorf("Observe() = %v, want a raised alert to stay quiet", alerts)
+	}
+
+	// Sensors are tracked separately
+	other := high
+	other.Sensor = "b"
+	if alerts := a.Observe(testDetails(), nil, other); len(alerts) != 1 {
+		t.Errorf("Observe() for another sensor = %v, want 1 alert", alerts)
+	}
+
+	// Crossing to the other side clears the old alert and raises a new one
+	low := Reading{Metric: MetricTemperature, Value: 10, Sensor: "a"}
+	alerts := a.Observe(testDetails(), nil, low)
+	if len(alerts) != 2 || !alerts[0].Resolved || alerts[0].Violation.Status != StatusHigh ||
+		alerts[1].Resolved || alerts[1].Violation.Status != StatusLow {
+		t.Errorf("Observe() = %+v, want high resolved then low raised", alerts)
+	}
+
+	ok := Reading{Metric: MetricTemperature, Value: 22, Sensor: "a"}
+	if alerts := a.Observe(testDetails(), nil, ok); len(alerts) != 1 || !alerts[0].Resolved {
+		t.Errorf("Observe() = %v, want the alert cleared at once", alerts)
+	}
+}
+
+func TestAlertRules_JSON(t *testing.T) {
+	rules := AlertRules{MetricSoilMoisture: {Hysteresis: 5, For: 2 * time.Hour}, MetricTemperature: {}}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"soil_moisture":{"hysteresis":5,"for":"2h0m0s"},"temperature":{}}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var got AlertRules
+	if err := json.Unmarshal([]byte(`{"soil_moisture": {"hysteresis": 5, "for": "2h"}}`), &got); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if got[MetricSoilMoisture] != rules[MetricSoilMoisture] {
+		t.Errorf("Unmarshal() = %+v", got)
+	}
+
+	for _, bad := range []string{`{"soil_moisture": {"for": "soon"}}`, `{"soil_moisture": {"hysteresis": -1}}`} {
+		if err := json.Unmarshal([]byte(bad), &got); err == nil {
+			t.Errorf("Unmarshal(%s) expected error, got nil", bad)
+		}
+	}
+	if err := (AlertRules{"co2": {}}).Validate(); err == nil {
+		t.Error("Validate() of an unknown metric expected error, got nil")
+	}
+}
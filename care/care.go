@@ -0,0 +1,238 @@
+// Package care evaluates sensor readings against OpenPlantbook care thresholds.
+//
+// Readings are expressed in the same units Plantbook uses: lux for light,
+// degrees Celsius for temperature, percent for air humidity and soil
+// moisture, and µS/cm for soil electrical conductivity.
+package care
+
+import (
+	"fmt"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// Metric identifies a measured environmental quantity
+type Metric string
+
+const (
+	// MetricLight is illuminance in lux
+	MetricLight Metric = "light"
+	// MetricTemperature is air temperature in °C
+	MetricTemperature Metric = "temperature"
+	// MetricHumidity is relative air humidity in %
+	MetricHumidity Metric = "humidity"
+	// MetricSoilMoisture is volumetric soil moisture in %
+	MetricSoilMoisture Metric = "soil_moisture"
+	// MetricSoilEC is soil electrical conductivity in µS/cm
+	MetricSoilEC Metric = "soil_ec"
+)
+
+// Metrics lists all metrics in display order
+var Metrics = []Metric{MetricLight, MetricTemperature, MetricHumidity, MetricSoilMoisture, MetricSoilEC}
+
+// Unit returns the display unit for the metric
+func (m Metric) Unit() string {
+	switch m {
+	case MetricLight:
+		return "lx"
+	case MetricTemperature:
+		return "°C"
+	case MetricHumidity, MetricSoilMoisture:
+		return "%"
+	case MetricSoilEC:
+		return "µS/cm"
+	default:
+		return ""
+	}
+}
+
+// Range is an inclusive [Min, Max] interval
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Contains reports whether v lies within the range
+func (r Range) Contains(v float64) bool {
+	return v >= r.Min && v <= r.Max
+}
+
+// Valid reports whether the range is non-empty
+func (r Range) Valid() bool {
+	return r.Min <= r.Max
+}
+
+// Width returns the size of the range
+func (r Range) Width() float64 {
+	return r.Max - r.Min
+}
+
+// Intersect returns the overlap of two ranges; ok is false when they are disjoint
+func (r Range) Intersect(other Range) (Range, bool) {
+	out := Range{Min: max(r.Min, other.Min), Max: min(r.Max, other.Max)}
+	return out, out.Valid()
+}
+
+// String formats the range as "min-max"
+func (r Range) String() string {
+	return fmt.Sprintf("%g-%g", r.Min, r.Max)
+}
+
+// RangeFor returns the plant's threshold range for a metric
+// ok is false when the plant has no usable data for the metric.
+func RangeFor(details *openplantbook.PlantDetails, metric Metric) (Range, bool) {
+	if details == nil {
+		return Range{}, false
+	}
+
+	var r Range
+	switch metric {
+	case MetricLight:
+		r = Range{Min: float64(details.MinLightLux), Max: float64(details.MaxLightLux)}
+	case MetricTemperature:
+		r = Range{Min: details.MinTemp, Max: details.MaxTemp}
+	case MetricHumidity:
+		r = Range{Min: float64(details.MinEnvHumid), Max: float64(details.MaxEnvHumid)}
+	case MetricSoilMoisture:
+		r = Range{Min: float64(details.MinSoilMoist), Max: float64(details.MaxSoilMoist)}
+	case MetricSoilEC:
+		r = Range{Min: float64(details.MinSoilEC), Max: float64(details.MaxSoilEC)}
+	default:
+		return Range{}, false
+	}
+
+	// An all-zero range means the record carries no data for this metric
+	if r.Min == 0 && r.Max == 0 {
+		return Range{}, false
+	}
+	return r, r.Valid()
+}
+
+// Reading is a single sensor measurement
+type Reading struct {
+	Metric Metric    `json:"metric"`
+	Value  float64   `json:"value"`
+	Time   time.Time `json:"time"`
+
+	// Sensor optionally identifies the device that produced the reading
+	Sensor string `json:"sensor,omitempty"`
+}
+
+// Status classifies a reading relative to the plant's range
+type Status int
+
+const (
+	// StatusOK means the reading is within range
+	StatusOK Status = iota
+	// StatusLow means the reading is below the minimum
+	StatusLow
+	// StatusHigh means the reading is above the maximum
+	StatusHigh
+)
+
+// String returns the lowercase status name
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusLow:
+		return "low"
+	case StatusHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// MarshalText encodes the status as its name
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a status name
+func (s *Status) UnmarshalText(text []byte) error {
+	for _, candidate := range []Status{StatusOK, StatusLow, StatusHigh} {
+		if candidate.String() == string(text) {
+			*s = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown status %q", text)
+}
+
+// Violation describes a reading outside the plant's range
+type Violation struct {
+	PID     string  `json:"pid"`
+	Plant   string  `json:"plant"`
+	Reading Reading `json:"reading"`
+	Range   Range   `json:"range"`
+	Status  Status  `json:"status"`
+}
+
+// String returns a human-readable description of the violation
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s is %s (%g%s, want %s%s)",
+		v.Plant, v.Reading.Metric, v.Status, v.Reading.Value, v.Reading.Metric.Unit(),
+		v.Range, v.Reading.Metric.Unit())
+}
+
+// Check classifies a single reading; ok is false when the plant has no
+// threshold for the reading's metric
+func Check(details *openplantbook.PlantDetails, reading Reading) (status Status, r Range, ok bool) {
+	r, ok = RangeFor(details, reading.Metric)
+	if !ok {
+		return StatusOK, Range{}, false
+	}
+	return classify(reading.Value, r), r, true
+}
+
+// classify places a value relative to a range
+func classify(v float64, r Range) Status {
+	switch {
+	case v < r.Min:
+		return StatusLow
+	case v > r.Max:
+		return StatusHigh
+	default:
+		return StatusOK
+	}
+}
+
+// Evaluate checks readings against the plant's thresholds and returns any violations
+// Readings for metrics without threshold data are ignored. Use an Evaluator
+// to adjust thresholds for the season.
+func Evaluate(details *openplantbook.PlantDetails, readings ...Reading) []Violation {
+	return evaluate(details, readings, Check)
+}
+
+// evaluate collects the violations reported by check
+func evaluate(details *openplantbook.PlantDetails, readings []Reading,
+	check func(*openplantbook.PlantDetails, Reading) (Status, Range, bool)) []Violation {
+	var violations []Violation
+	for _, reading := range readings {
+		status, r, ok := check(details, reading)
+		if !ok || status == StatusOK {
+			continue
+		}
+		violations = append(violations, Violation{
+			PID:     details.PID,
+			Plant:   plantName(details),
+			Reading: reading,
+			Range:   r,
+			Status:  status,
+		})
+	}
+	return violations
+}
+
+// plantName prefers the common name and falls back to the scientific name
+func plantName(details *openplantbook.PlantDetails) string {
+	if details.Alias != "" {
+		return details.Alias
+	}
+	if details.DisplayPID != "" {
+		return details.DisplayPID
+	}
+	return details.PID
+}
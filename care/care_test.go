@@ -0,0 +1,106 @@
+package care
+
+import (
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func testDetails() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		DisplayPID:   "Monstera deliciosa",
+		Alias:        "Monstera",
+		MaxLightLux:  20000,
+		MinLightLux:  2500,
+		MaxTemp:      30,
+		MinTemp:      15,
+		MaxEnvHumid:  80,
+		MinEnvHumid:  40,
+		MaxSoilMoist: 60,
+		MinSoilMoist: 15,
+		MaxSoilEC:    2000,
+		MinSoilEC:    350,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		reading    Reading
+		wantStatus Status
+		wantOK     bool
+	}{
+		{"temperature in range", Reading{Metric: MetricTemperature, Value: 22}, StatusOK, true},
+		{"temperature on boundary", Reading{Metric: MetricTemperature, Value: 15}, StatusOK, true},
+		{"soil too dry", Reading{Metric: MetricSoilMoisture, Value: 10}, StatusLow, true},
+		{"too bright", Reading{Metric: MetricLight, Value: 50000}, StatusHigh, true},
+		{"unknown metric", Reading{Metric: "co2", Value: 400}, StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _, ok := Check(testDetails(), tt.reading)
+			if ok != tt.wantOK {
+				t.Errorf("Check() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Check() status = %v, want %v", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	details := testDetails()
+	details.MinSoilEC, details.MaxSoilEC = 0, 0 // no EC data
+
+	violations := Evaluate(details,
+		Reading{Metric: MetricTemperature, Value: 22},
+		Reading{Metric: MetricHumidity, Value: 20},
+		Reading{Metric: MetricSoilEC, Value: 5000},
+	)
+
+	if len(violations) != 1 {
+		t.Fatalf("Evaluate() returned %d violations, want 1", len(violations))
+	}
+
+	v := violations[0]
+	if v.Reading.Metric != MetricHumidity || v.Status != StatusLow {
+		t.Errorf("Evaluate() violation = %+v, want low humidity", v)
+	}
+	if v.Plant != "Monstera" {
+		t.Errorf("violation plant = %q, want %q", v.Plant, "Monstera")
+	}
+	if got, want := v.String(), "Monstera: humidity is low (20%, want 40-80%)"; got != want {
+		t.Errorf("Violation.String() = %q, want %q", got, want)
+	}
+}
+
+func TestRange_Intersect(t *testing.T) {
+	a := Range{Min: 15, Max: 30}
+
+	got, ok := a.Intersect(Range{Min: 20, Max: 35})
+	if !ok || got != (Range{Min: 20, Max: 30}) {
+		t.Errorf("Intersect() = %v, %v, want 20-30, true", got, ok)
+	}
+
+	if _, ok := a.Intersect(Range{Min: 31, Max: 40}); ok {
+		t.Error("Intersect() of disjoint ranges returned ok")
+	}
+}
+
+func TestStatus_Text(t *testing.T) {
+	text, err := StatusHigh.MarshalText()
+	if err != nil || string(text) != "high" {
+		t.Fatalf("MarshalText() = %q, %v, want high", text, err)
+	}
+
+	var s Status
+	if err := s.UnmarshalText([]byte("low")); err != nil || s != StatusLow {
+		t.Errorf("UnmarshalText(low) = %v, %v", s, err)
+	}
+	if err := s.UnmarshalText([]byte("bogus")); err == nil {
+		t.Error("UnmarshalText(bogus) expected error, got nil")
+	}
+}
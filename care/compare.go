@@ -0,0 +1,96 @@
+package care
+
+import openplantbook "github.com/rmrfslashbin/openplantbook-go"
+
+// MetricComparison lines up the plants' ranges for one metric
+type MetricComparison struct {
+	Metric Metric `json:"metric"`
+
+	// Ranges holds each plant's range in the order the plants were given;
+	// an entry is nil when that plant has no data for the metric
+	Ranges []*Range `json:"ranges"`
+
+	// Overlap is the range satisfying every plant with data. It is nil when
+	// no plant has data or when the ranges are disjoint.
+	Overlap *Range `json:"overlap,omitempty"`
+
+	// Conflict is true when the ranges are disjoint
+	Conflict bool `json:"conflict"`
+}
+
+// Comparison is a side-by-side view of several plants' care requirements
+type Comparison struct {
+	PIDs    []string           `json:"pids"`
+	Names   []string           `json:"names"`
+	Metrics []MetricComparison `json:"metrics"`
+}
+
+// Compatible reports whether one set of conditions satisfies every plant
+// Metrics without data are not counted against compatibility.
+func (c Comparison) Compatible() bool {
+	for _, m := range c.Metrics {
+		if m.Conflict {
+			return false
+		}
+	}
+	return true
+}
+
+// Conflicts returns the metrics whose ranges are disjoint
+func (c Comparison) Conflicts() []Metric {
+	var out []Metric
+	for _, m := range c.Metrics {
+		if m.Conflict {
+			out = append(out, m.Metric)
+		}
+	}
+	return out
+}
+
+// ComparePlants lines up the care ranges of the given plants for every metric
+// Nil details are treated as plants without data.
+func ComparePlants(plants ...*openplantbook.PlantDetails) Comparison {
+	cmp := Comparison{
+		PIDs:  make([]string, len(plants)),
+		Names: make([]string, len(plants)),
+	}
+	for i, p := range plants {
+		if p != nil {
+			cmp.PIDs[i] = p.PID
+			cmp.Names[i] = plantName(p)
+		}
+	}
+
+	for _, metric := range Metrics {
+		mc := MetricComparison{Metric: metric, Ranges: make([]*Range, len(plants))}
+
+		var (
+			overlap Range
+			count   int
+		)
+		for i, p := range plants {
+			r, ok := RangeFor(p, metric)
+			if !ok {
+				continue
+			}
+			mc.Ranges[i] = &r
+			if count == 0 {
+				overlap = r
+			} else {
+				overlap, _ = overlap.Intersect(r)
+			}
+			count++
+		}
+
+		switch {
+		case count == 0:
+		case overlap.Valid():
+			mc.Overlap = &overlap
+		default:
+			mc.Conflict = true
+		}
+		cmp.Metrics = append(cmp.Metrics, mc)
+	}
+
+	return cmp
+}
@@ -0,0 +1,62 @@
+package care
+
+import (
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func TestComparePlants(t *testing.T) {
+	fern := &openplantbook.PlantDetails{
+		PID:          "nephrolepis exaltata",
+		Alias:        "Boston Fern",
+		MaxLightLux:  10000,
+		MinLightLux:  1000,
+		MaxTemp:      27,
+		MinTemp:      16,
+		MaxEnvHumid:  90,
+		MinEnvHumid:  60,
+		MaxSoilMoist: 70,
+		MinSoilMoist: 30,
+	}
+
+	cmp := ComparePlants(testDetails(), fern)
+
+	if got := cmp.Names; len(got) != 2 || got[0] != "Monstera" || got[1] != "Boston Fern" {
+		t.Errorf("Names = %v, want [Monstera Boston Fern]", got)
+	}
+	if len(cmp.Metrics) != len(Metrics) {
+		t.Fatalf("got %d metrics, want %d", len(cmp.Metrics), len(Metrics))
+	}
+
+	byMetric := make(map[Metric]MetricComparison)
+	for _, m := range cmp.Metrics {
+		byMetric[m.Metric] = m
+	}
+
+	temp := byMetric[MetricTemperature]
+	if temp.Conflict || temp.Overlap == nil || *temp.Overlap != (Range{Min: 16, Max: 27}) {
+		t.Errorf("temperature = %+v, want overlap 16-27", temp)
+	}
+
+	ec := byMetric[MetricSoilEC]
+	if ec.Ranges[0] == nil || ec.Ranges[1] != nil {
+		t.Errorf("soil EC ranges = %v, want data for the first plant only", ec.Ranges)
+	}
+	if ec.Overlap == nil || *ec.Overlap != *ec.Ranges[0] {
+		t.Errorf("soil EC overlap = %v, want the first plant's range", ec.Overlap)
+	}
+
+	if !cmp.Compatible() {
+		t.Errorf("Compatible() = false, conflicts %v", cmp.Conflicts())
+	}
+
+	fern.MinEnvHumid, fern.MaxEnvHumid = 85, 95
+	cmp = ComparePlants(testDetails(), fern)
+	if cmp.Compatible() {
+		t.Error("Compatible() = true for disjoint humidity ranges")
+	}
+	if got := cmp.Conflicts(); len(got) != 1 || got[0] != MetricHumidity {
+		t.Errorf("Conflicts() = %v, want [humidity]", got)
+	}
+}
@@ -0,0 +1,97 @@
+package care
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// LimitingOverlap is the overlap below which a metric is reported as a
+// limiting factor
+const LimitingOverlap = 0.5
+
+// Factor is how well two plants' ranges agree on one metric
+type Factor struct {
+	Metric Metric `json:"metric"`
+	A      Range  `json:"a"`
+	B      Range  `json:"b"`
+
+	// Overlap is the shared part of the ranges as a fraction of the narrower
+	// one: 1 when it lies entirely within the other, 0 when they are disjoint
+	Overlap float64 `json:"overlap"`
+}
+
+// String describes the factor
+func (f Factor) String() string {
+	if f.Overlap == 0 {
+		return fmt.Sprintf("%s: no overlap (%s%s vs %s%s)", f.Metric, f.A, f.Metric.Unit(), f.B, f.Metric.Unit())
+	}
+	return fmt.Sprintf("%s: %.0f%% overlap (%s%s vs %s%s)", f.Metric, f.Overlap*100, f.A, f.Metric.Unit(), f.B, f.Metric.Unit())
+}
+
+// Score rates how well two plants can share conditions
+type Score struct {
+	// Value ranges from 0 (no shared conditions) to 100 (one plant's needs
+	// lie entirely within the other's for every metric)
+	Value int `json:"score"`
+
+	// Factors holds one entry per metric for which both plants have data
+	Factors []Factor `json:"factors"`
+
+	// Limiting lists the factors below LimitingOverlap, worst first
+	Limiting []Factor `json:"limiting,omitempty"`
+}
+
+// Known reports whether the plants share any metric to score
+func (s Score) Known() bool {
+	return len(s.Factors) > 0
+}
+
+// Compatibility scores how well two plants' care ranges overlap
+// Each metric with data for both plants contributes its overlap equally; the
+// score is their mean scaled to 0-100. Metrics missing for either plant are
+// skipped, and the score is 0 when no metric can be compared.
+func Compatibility(a, b *openplantbook.PlantDetails) Score {
+	var (
+		score Score
+		total float64
+	)
+	for _, metric := range Metrics {
+		ra, okA := RangeFor(a, metric)
+		rb, okB := RangeFor(b, metric)
+		if !okA || !okB {
+			continue
+		}
+
+		f := Factor{Metric: metric, A: ra, B: rb, Overlap: overlap(ra, rb)}
+		score.Factors = append(score.Factors, f)
+		if f.Overlap < LimitingOverlap {
+			score.Limiting = append(score.Limiting, f)
+		}
+		total += f.Overlap
+	}
+
+	if len(score.Factors) > 0 {
+		score.Value = int(math.Round(100 * total / float64(len(score.Factors))))
+	}
+	sort.SliceStable(score.Limiting, func(i, j int) bool {
+		return score.Limiting[i].Overlap < score.Limiting[j].Overlap
+	})
+	return score
+}
+
+// overlap returns the intersection of two ranges as a fraction of the narrower
+func overlap(a, b Range) float64 {
+	shared, ok := a.Intersect(b)
+	if !ok {
+		return 0
+	}
+	narrower := min(a.Width(), b.Width())
+	if narrower == 0 {
+		// A single-value range either lies within the other or not
+		return 1
+	}
+	return shared.Width() / narrower
+}
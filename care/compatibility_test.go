@@ -0,0 +1,67 @@
+package care
+
+import (
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func TestCompatibility(t *testing.T) {
+	t.Run("identical plants", func(t *testing.T) {
+		score := Compatibility(testDetails(), testDetails())
+		if score.Value != 100 || len(score.Limiting) != 0 {
+			t.Errorf("Compatibility() = %+v, want 100 with no limiting factors", score)
+		}
+		if len(score.Factors) != len(Metrics) {
+			t.Errorf("got %d factors, want %d", len(score.Factors), len(Metrics))
+		}
+	})
+
+	t.Run("partial overlap", func(t *testing.T) {
+		cactus := &openplantbook.PlantDetails{
+			PID:         "echinocactus grusonii",
+			MaxLightLux: 80000,
+			MinLightLux: 15000, // 5000 of Monstera's 17500 lx span
+			MaxTemp:     35,
+			MinTemp:     10, // contains Monstera's range
+			MaxEnvHumid: 30,
+			MinEnvHumid: 10, // disjoint
+		}
+
+		score := Compatibility(testDetails(), cactus)
+		if len(score.Factors) != 3 {
+			t.Fatalf("got %d factors, want 3 (metrics with data for both)", len(score.Factors))
+		}
+
+		// (5000/17500 + 1 + 0) / 3
+		if score.Value != 43 {
+			t.Errorf("Value = %d, want 43", score.Value)
+		}
+
+		if len(score.Limiting) != 2 {
+			t.Fatalf("Limiting = %v, want humidity and light", score.Limiting)
+		}
+		if score.Limiting[0].Metric != MetricHumidity || score.Limiting[1].Metric != MetricLight {
+			t.Errorf("Limiting order = %v, %v, want humidity then light", score.Limiting[0].Metric, score.Limiting[1].Metric)
+		}
+		if got, want := score.Limiting[0].String(), "humidity: no overlap (40-80% vs 10-30%)"; got != want {
+			t.Errorf("Factor.String() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("no shared data", func(t *testing.T) {
+		score := Compatibility(testDetails(), &openplantbook.PlantDetails{PID: "unknown"})
+		if score.Known() || score.Value != 0 {
+			t.Errorf("Compatibility() = %+v, want unknown score 0", score)
+		}
+	})
+}
+
+func TestOverlap_SingleValue(t *testing.T) {
+	if got := overlap(Range{Min: 20, Max: 20}, Range{Min: 15, Max: 30}); got != 1 {
+		t.Errorf("overlap() = %v, want 1", got)
+	}
+	if got := overlap(Range{Min: 40, Max: 40}, Range{Min: 15, Max: 30}); got != 0 {
+		t.Errorf("overlap() = %v, want 0", got)
+	}
+}
@@ -0,0 +1,169 @@
+package care
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// ECUnit is a unit of electrical conductivity
+type ECUnit string
+
+const (
+	// MicroSiemens is µS/cm, the unit of Plantbook thresholds
+	MicroSiemens ECUnit = "µS/cm"
+	// MilliSiemens is mS/cm (1 mS/cm = 1000 µS/cm)
+	MilliSiemens ECUnit = "mS/cm"
+)
+
+// ParseECUnit parses a unit name such as "µS/cm", "uS/cm", "us" or "mS/cm",
+// ignoring case
+func ParseECUnit(s string) (ECUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "µs/cm", "μs/cm", "us/cm", "µs", "μs", "us":
+		return MicroSiemens, nil
+	case "ms/cm", "ms":
+		return MilliSiemens, nil
+	default:
+		return "", fmt.Errorf("unknown EC unit %q (want µS/cm or mS/cm)", s)
+	}
+}
+
+// ToMicro converts a value in this unit to µS/cm
+func (u ECUnit) ToMicro(v float64) float64 {
+	if u == MilliSiemens {
+		return v * 1000
+	}
+	return v
+}
+
+// FromMicro converts a value in µS/cm to this unit
+func (u ECUnit) FromMicro(v float64) float64 {
+	if u == MilliSiemens {
+		return v / 1000
+	}
+	return v
+}
+
+// Format formats a value in µS/cm in this unit, such as "1.2 mS/cm"
+func (u ECUnit) Format(micro float64) string {
+	if u == "" {
+		u = MicroSiemens
+	}
+	return strconv.FormatFloat(u.FromMicro(micro), 'f', -1, 64) + " " + string(u)
+}
+
+// Calibration converts a sensor's raw EC readings to µS/cm comparable with
+// Plantbook thresholds
+// A raw reading is converted from Unit, scaled by Factor, then shifted by
+// Offset. A zero Factor means 1, so the zero Calibration passes µS/cm
+// readings through unchanged.
+type Calibration struct {
+	Sensor string  `json:"sensor,omitempty"`
+	Unit   ECUnit  `json:"unit,omitempty"`
+	Factor float64 `json:"factor,omitempty"`
+	Offset float64 `json:"offset,omitempty"` // In µS/cm
+}
+
+// Apply returns a raw reading in calibrated µS/cm
+func (c Calibration) Apply(raw float64) float64 {
+	v := c.Unit.ToMicro(raw)
+	if c.Factor != 0 {
+		v *= c.Factor
+	}
+	return v + c.Offset
+}
+
+// Fit returns the calibration with Factor set so that measured, the raw
+// reading of a reference solution, converts to reference µS/cm (such as the
+// common 1413 µS/cm standard)
+func (c Calibration) Fit(reference, measured float64) (Calibration, error) {
+	micro := c.Unit.ToMicro(measured)
+	if micro <= 0 || reference <= c.Offset {
+		return c, fmt.Errorf("cannot calibrate %s: reference %g µS/cm read as %g", c.Sensor, reference, measured)
+	}
+	c.Factor = (reference - c.Offset) / micro
+	return c, nil
+}
+
+// Calibrations holds starting calibrations for common sensors, keyed by
+// lowercase name (see CalibrationFor)
+//
+// Plantbook thresholds were collected with Xiaomi Mi Flora (Flower Care)
+// sensors, whose "fertility" readings need no correction. Ecowitt WH52 probes
+// report bulk soil EC on the same scale. Individual probes drift; Fit a
+// calibration against a reference solution when accuracy matters.
+var Calibrations = map[string]Calibration{
+	"miflora": {Sensor: "Mi Flora", Unit: MicroSiemens, Factor: 1},
+	"ecowitt": {Sensor: "Ecowitt WH52", Unit: MicroSiemens, Factor: 1},
+}
+
+// CalibrationFor looks up a sensor in Calibrations, ignoring case, spaces,
+// hyphens and underscores ("Mi Flora", "mi-flora" and "flower care" all
+// find Mi Flora)
+func CalibrationFor(sensor string) (Calibration, bool) {
+	key := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(sensor))
+	if key == "flowercare" || key == "xiaomi" {
+		key = "miflora"
+	}
+	c, ok := Calibrations[key]
+	return c, ok
+}
+
+// Fertility is guidance for a soil EC reading
+// StatusLow means fertilization is due and StatusHigh that the soil is
+// over-fertilized.
+type Fertility struct {
+	Status Status  `json:"status"`
+	Value  float64 `json:"value"` // In µS/cm
+	Range  Range   `json:"range"`
+	Advice string  `json:"advice"`
+}
+
+// String returns the advice
+func (f Fertility) String() string {
+	return f.Advice
+}
+
+// FertilityGuidance interprets a soil EC reading in µS/cm (calibrated with
+// Calibration.Apply if needed); ok is false when the plant has no soil EC
+// thresholds
+func FertilityGuidance(details *openplantbook.PlantDetails, ec float64) (Fertility, bool) {
+	r, ok := RangeFor(details, MetricSoilEC)
+	if !ok {
+		return Fertility{}, false
+	}
+
+	f := Fertility{Status: classify(ec, r), Value: ec, Range: r}
+	value := MicroSiemens.Format(ec)
+	switch f.Status {
+	case StatusLow:
+		f.Advice = fmt.Sprintf("Fertilization due: soil EC %s is below %s", value, MicroSiemens.Format(r.Min))
+	case StatusHigh:
+		f.Advice = fmt.Sprintf("Over-fertilized: soil EC %s is above %s; flush the soil with plain water and pause feeding",
+			value, MicroSiemens.Format(r.Max))
+	default:
+		f.Advice = fmt.Sprintf("Fertility OK: soil EC %s is within %s %s", value, r, MicroSiemens)
+	}
+	return f, true
+}
+
+// FertilityAdvice describes the plant's soil EC thresholds as feeding
+// guidance; ok is false when the plant has no soil EC thresholds
+func FertilityAdvice(details *openplantbook.PlantDetails) (string, bool) {
+	r, ok := RangeFor(details, MetricSoilEC)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("Fertilize when soil EC falls below %s (%s); above %s (%s), flush with plain water",
+		MicroSiemens.Format(r.Min), MilliSiemens.Format(r.Min),
+		MicroSiemens.Format(r.Max), MilliSiemens.Format(r.Max)), true
+}
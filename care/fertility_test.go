@@ -0,0 +1,82 @@
+package care
+
+import (
+	"strings"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func TestFertilityGuidance(t *testing.T) {
+	tests := []struct {
+		ec         float64
+		wantStatus Status
+		wantAdvice string
+	}{
+		{200, StatusLow, "Fertilization due: soil EC 200 µS/cm is below 350 µS/cm"},
+		{800, StatusOK, "Fertility OK: soil EC 800 µS/cm is within 350-2000 µS/cm"},
+		{2500, StatusHigh, "Over-fertilized: soil EC 2500 µS/cm is above 2000 µS/cm"},
+	}
+	for _, tt := range tests {
+		f, ok := FertilityGuidance(testDetails(), tt.ec)
+		if !ok {
+			t.Fatalf("FertilityGuidance(%g) ok = false", tt.ec)
+		}
+		if f.Status != tt.wantStatus || !strings.HasPrefix(f.Advice, tt.wantAdvice) {
+			t.Errorf("FertilityGuidance(%g) = %v %q, want %v %q", tt.ec, f.Status, f.Advice, tt.wantStatus, tt.wantAdvice)
+		}
+	}
+
+	if _, ok := FertilityGuidance(&openplantbook.PlantDetails{PID: "x"}, 500); ok {
+		t.Error("FertilityGuidance() without EC thresholds ok = true")
+	}
+}
+
+func TestFertilityAdvice(t *testing.T) {
+	advice, ok := FertilityAdvice(testDetails())
+	want := "Fertilize when soil EC falls below 350 µS/cm (0.35 mS/cm); above 2000 µS/cm (2 mS/cm), flush with plain water"
+	if !ok || advice != want {
+		t.Errorf("FertilityAdvice() = %q, %v; want %q", advice, ok, want)
+	}
+}
+
+func TestParseECUnit(t *testing.T) {
+	for in, want := range map[string]ECUnit{"µS/cm": MicroSiemens, "uS/cm": MicroSiemens, "US": MicroSiemens, "mS/cm": MilliSiemens, "ms": MilliSiemens} {
+		if got, err := ParseECUnit(in); err != nil || got != want {
+			t.Errorf("ParseECUnit(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseECUnit("ppm"); err == nil {
+		t.Error("ParseECUnit(ppm) expected error, got nil")
+	}
+}
+
+func TestCalibration(t *testing.T) {
+	if got := (Calibration{}).Apply(800); got != 800 {
+		t.Errorf("zero Calibration.Apply(800) = %g, want 800", got)
+	}
+	c := Calibration{Unit: MilliSiemens, Offset: 50}
+	if got := c.Apply(1.2); got != 1250 {
+		t.Errorf("Apply(1.2 mS/cm) = %g, want 1250", got)
+	}
+
+	fitted, err := c.Fit(1413, 1.1)
+	if err != nil {
+		t.Fatalf("Fit() unexpected error: %v", err)
+	}
+	if got := fitted.Apply(1.1); got < 1412.999 || got > 1413.001 {
+		t.Errorf("fitted Apply(1.1) = %g, want 1413", got)
+	}
+	if _, err := c.Fit(1413, 0); err == nil {
+		t.Error("Fit() with a zero reading expected error, got nil")
+	}
+
+	for _, name := range []string{"Mi Flora", "mi-flora", "Flower Care", "ECOWITT"} {
+		if _, ok := CalibrationFor(name); !ok {
+			t.Errorf("CalibrationFor(%q) not found", name)
+		}
+	}
+	if _, ok := CalibrationFor("unknown"); ok {
+		t.Error("CalibrationFor(unknown) found a calibration")
+	}
+}
@@ -0,0 +1,110 @@
+package care
+
+import (
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/schedule"
+)
+
+// Adjustment changes a range's bounds for one season
+// Each bound is scaled by its factor, then shifted by its offset. A zero
+// factor leaves the bound unscaled, so the zero Adjustment changes nothing.
+type Adjustment struct {
+	MinFactor float64 `json:"min_factor,omitempty"`
+	MaxFactor float64 `json:"max_factor,omitempty"`
+	MinOffset float64 `json:"min_offset,omitempty"`
+	MaxOffset float64 `json:"max_offset,omitempty"`
+}
+
+// Apply returns the adjusted range
+func (a Adjustment) Apply(r Range) Range {
+	if a.MinFactor != 0 {
+		r.Min *= a.MinFactor
+	}
+	if a.MaxFactor != 0 {
+		r.Max *= a.MaxFactor
+	}
+	r.Min += a.MinOffset
+	r.Max += a.MaxOffset
+	return r
+}
+
+// Profile holds seasonal adjustments to care thresholds
+//
+// Plantbook thresholds describe the growing season. During dormancy many
+// plants need less water and light and tolerate cooler air, so evaluating
+// winter readings against raw thresholds raises false alerts. Profiles can be
+// loaded from JSON, keyed by season name:
+//
+//	{"name": "succulents", "seasons": {"winter": {"soil_moisture": {"min_factor": 0.25}, "temperature": {"min_offset": -5}}}}
+type Profile struct {
+	Name    string                                    `json:"name"`
+	Seasons map[schedule.Season]map[Metric]Adjustment `json:"seasons"`
+}
+
+// DefaultProfile suits typical indoor plants in a temperate climate
+// In autumn and winter the soil may dry further between waterings and less
+// light is expected; in winter cooler temperatures are tolerated too.
+var DefaultProfile = &Profile{
+	Name: "temperate-indoor",
+	Seasons: map[schedule.Season]map[Metric]Adjustment{
+		schedule.Autumn: {
+			MetricSoilMoisture: {MinFactor: 0.8},
+			MetricLight:        {MinFactor: 0.75},
+		},
+		schedule.Winter: {
+			MetricSoilMoisture: {MinFactor: 0.6},
+			MetricLight:        {MinFactor: 0.5},
+			MetricTemperature:  {MinOffset: -3},
+		},
+	},
+}
+
+// RangeFor returns the plant's threshold range for a metric, adjusted for
+// the season
+func (p *Profile) RangeFor(details *openplantbook.PlantDetails, metric Metric, season schedule.Season) (Range, bool) {
+	r, ok := RangeFor(details, metric)
+	if !ok || p == nil {
+		return r, ok
+	}
+	if adj, found := p.Seasons[season][metric]; found {
+		r = adj.Apply(r)
+	}
+	return r, r.Valid()
+}
+
+// Evaluator checks readings against thresholds adjusted for the season in
+// which each reading was taken
+// The zero Evaluator uses the raw thresholds, like Evaluate.
+type Evaluator struct {
+	// Profile supplies the seasonal adjustments (nil = none)
+	Profile *Profile
+
+	// Southern selects the southern hemisphere's seasons
+	Southern bool
+}
+
+// Season returns the season at t; a zero t means now
+func (e Evaluator) Season(t time.Time) schedule.Season {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return schedule.SeasonFor(t, e.Southern)
+}
+
+// Check classifies a single reading against the adjusted range; ok is false
+// when the plant has no threshold for the reading's metric
+func (e Evaluator) Check(details *openplantbook.PlantDetails, reading Reading) (status Status, r Range, ok bool) {
+	r, ok = e.Profile.RangeFor(details, reading.Metric, e.Season(reading.Time))
+	if !ok {
+		return StatusOK, Range{}, false
+	}
+	return classify(reading.Value, r), r, true
+}
+
+// Evaluate checks readings against the adjusted thresholds and returns any
+// violations, reporting the adjusted range
+func (e Evaluator) Evaluate(details *openplantbook.PlantDetails, readings ...Reading) []Violation {
+	return evaluate(details, readings, e.Check)
+}
@@ -0,0 +1,68 @@
+package care
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/schedule"
+)
+
+func TestAdjustment_Apply(t *testing.T) {
+	r := Range{Min: 15, Max: 60}
+
+	if got := (Adjustment{}).Apply(r); got != r {
+		t.Errorf("zero Adjustment.Apply() = %v, want %v", got, r)
+	}
+	if got := (Adjustment{MinFactor: 0.6, MaxOffset: -10}).Apply(r); got != (Range{Min: 9, Max: 50}) {
+		t.Errorf("Apply() = %v, want 9-50", got)
+	}
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	january := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	dry := Reading{Metric: MetricSoilMoisture, Value: 12, Time: january} // min is 15
+	cool := Reading{Metric: MetricTemperature, Value: 13, Time: january}
+
+	if got := Evaluate(testDetails(), dry, cool); len(got) != 2 {
+		t.Fatalf("Evaluate() returned %d violations, want 2 with raw thresholds", len(got))
+	}
+
+	north := Evaluator{Profile: DefaultProfile}
+	if got := north.Evaluate(testDetails(), dry, cool); len(got) != 0 {
+		t.Errorf("Evaluate() in northern winter = %v, want none", got)
+	}
+
+	// January is summer in the southern hemisphere, so no adjustment applies
+	south := Evaluator{Profile: DefaultProfile, Southern: true}
+	got := south.Evaluate(testDetails(), dry, cool)
+	if len(got) != 2 {
+		t.Fatalf("Evaluate() in southern summer returned %d violations, want 2", len(got))
+	}
+	if got[0].Range != (Range{Min: 15, Max: 60}) {
+		t.Errorf("violation range = %v, want unadjusted 15-60", got[0].Range)
+	}
+
+	tooDry := Reading{Metric: MetricSoilMoisture, Value: 5, Time: january}
+	got = north.Evaluate(testDetails(), tooDry)
+	if len(got) != 1 || got[0].Range != (Range{Min: 9, Max: 60}) {
+		t.Errorf("Evaluate() = %v, want one violation against winter range 9-60", got)
+	}
+}
+
+func TestProfile_JSON(t *testing.T) {
+	data := []byte(`{"name": "succulents", "seasons": {"winter": {"soil_moisture": {"min_factor": 0.1, "max_factor": 0.5}}}}`)
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	r, ok := p.RangeFor(testDetails(), MetricSoilMoisture, schedule.Winter)
+	if !ok || r != (Range{Min: 1.5, Max: 30}) {
+		t.Errorf("RangeFor() = %v, %v, want 1.5-30", r, ok)
+	}
+	if r, _ := p.RangeFor(testDetails(), MetricSoilMoisture, schedule.Summer); r != (Range{Min: 15, Max: 60}) {
+		t.Errorf("RangeFor(summer) = %v, want unadjusted 15-60", r)
+	}
+}
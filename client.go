@@ -2,9 +2,17 @@ package openplantbook
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"golang.org/x/time/rate"
 )
@@ -15,6 +23,10 @@ const (
 
 	// DefaultRateLimit is the default rate limit (200 requests per day)
 	DefaultRateLimit = 200
+
+	// DefaultMaxResponseSize is the default limit on response bodies (4 MiB),
+	// far above any real OpenPlantbook response
+	DefaultMaxResponseSize = 4 << 20
 )
 
 // RateLimitBehavior defines how the client handles rate limiting
@@ -28,18 +40,130 @@ const (
 )
 
 // Client represents an OpenPlantbook API client
+//
+// A Client is safe for concurrent use by multiple goroutines. Configuration
+// is fixed by New, except for the logger and rate limit behavior which can
+// be changed at runtime with SetLogger and SetRateLimitBehavior.
 type Client struct {
-	httpClient        *http.Client
-	baseURL           string
-	rateLimiter       *rate.Limiter
+	httpClient    *http.Client
+	baseURL       string
+	tokenEndpoint string // OAuth2 token URL; empty derives it from baseURL (see WithEnvironment)
+	rateLimiter   *rate.Limiter
+	cache         Cache
+	serializer    Serializer
+	index         *Index
+
+	// mu guards the settings that may change after New
+	mu                sync.RWMutex
 	rateLimitBehavior RateLimitBehavior
-	cache             Cache
 	logger            Logger
 
 	// Authentication (only ONE should be set)
 	apiKey       string
 	clientID     string
 	clientSecret string
+	scopes       []string           // OAuth2 scopes (see WithOAuth2Scopes)
+	tokenSource  oauth2.TokenSource // alternative to client credentials (see WithTokenSource)
+
+	// auth holds the credentials in use and reauth supplies new ones when
+	// the API rejects them (see WithReauth)
+	auth   *authState
+	reauth ReauthFunc
+
+	// credentialProvider supplies rotating credentials (see WithCredentialProvider)
+	credentialProvider CredentialProvider
+
+	// Transport tuning (applied to the base transport under authentication)
+	transport transportConfig
+
+	// debugWriter receives request/response dumps (see WithHTTPDebug)
+	debugWriter io.Writer
+
+	// redactor keeps credentials out of logs, errors and debug output
+	redactor *redactor
+
+	// maxResponseSize bounds response bodies (see WithMaxResponseSize)
+	maxResponseSize int64
+
+	// hedgeDelay enables hedged GET requests (see WithHedging)
+	hedgeDelay time.Duration
+
+	// staleIfError keeps expired entries for serving on failure (see WithStaleIfError)
+	staleIfError time.Duration
+
+	// batchConcurrency bounds parallel requests in batch APIs (see WithBatchConcurrency)
+	batchConcurrency int
+
+	// classLimiters pace endpoint classes with their own quota instead of
+	// rateLimiter (see WithRateLimits)
+	classLimiters map[EndpointClass]*rate.Limiter
+
+	// auditLog records every API request (see WithAuditLog)
+	auditLog *auditLog
+
+	// redirects adjusts trailing slashes the server redirected (see slashRedirects)
+	redirects slashRedirects
+
+	// redirectPolicy limits the redirects followed (see WithRedirectPolicy)
+	redirectPolicy *RedirectPolicy
+
+	// hooks receive request lifecycle events (see WithHooks)
+	hooks Hooks
+
+	// dryRun reports requests instead of sending them (see WithDryRun)
+	dryRun bool
+
+	// images validates and rewrites image URLs (see WithImageHosts)
+	images imagePolicy
+
+	// cacheNamespace prefixes cache keys (see WithCacheNamespace) and
+	// identity tells apart the accounts of account-specific responses
+	cacheNamespace string
+	identity       string
+
+	// codec decodes responses; nil means encoding/json (see WithJSONCodec)
+	codec Codec
+
+	// clock tells the time for rate limiting, cache freshness and quota
+	// accounting (see WithClock)
+	clock Clock
+
+	// onUpdate is called when refreshed details differ from the cached ones
+	onUpdate func(pid string, old, new *PlantDetails)
+
+	// onInvalidate is called when cached details expire or change;
+	// expiries watches their TTLs (see WithOnInvalidate)
+	onInvalidate func(Invalidation)
+	expiries     *expiryWatch
+
+	// fallback serves details when the API cannot answer (see WithFallback)
+	fallback FallbackFunc
+
+	// defaultSearch replaces nil search options (see WithDefaultSearchOptions)
+	defaultSearch *SearchOptions
+
+	// Usage accounting reported by Status; sharedLimiter also counts the
+	// requests of other clients (see WithSharedRateLimiter)
+	dailyLimit    int
+	sharedLimiter *Limiter
+	requests      requestLog
+	cacheHits     atomic.Int64
+	cacheMisses   atomic.Int64
+
+	// closers release resources owned by the client, in reverse order
+	closeMu   sync.Mutex
+	closers   []func() error
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// transportConfig holds the base transport and connection pooling settings
+type transportConfig struct {
+	set             bool
+	base            http.RoundTripper // WithTransport; replaces http.DefaultTransport
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+	disableHTTP2    bool
 }
 
 // New creates a new OpenPlantbook client with sensible defaults
@@ -48,79 +172,294 @@ func New(opts ...Option) (*Client, error) {
 	client := &Client{
 		baseURL:           DefaultBaseURL,
 		rateLimiter:       rate.NewLimiter(rate.Every(24*time.Hour/DefaultRateLimit), 1),
+		dailyLimit:        DefaultRateLimit,
+		batchConcurrency:  1,
+		maxResponseSize:   DefaultMaxResponseSize,
 		rateLimitBehavior: RateLimitWait, // Default: wait for rate limiter
-		cache:             NewInMemoryCache(),
+		serializer:        JSONSerializer{},
+		clock:             realClock{},
+		index:             NewLimitedIndex(DefaultIndexSize),
 		logger:            nil, // No logging by default (library pattern)
 	}
 
-	// Apply options (sets authentication credentials and other config)
+	// Apply options (sets authentication credentials and other config),
+	// reporting every failing option rather than only the first
+	var errs []error
 	for _, opt := range opts {
 		if err := opt(client); err != nil {
-			return nil, err
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		client.Close() // release caches handed over with WithOwnedCache
+		if len(errs) == 1 {
+			return nil, errs[0]
 		}
+		return nil, errors.Join(errs...)
 	}
+	client.redactor = newRedactor(client.apiKey, client.clientID, client.clientSecret)
+	if client.sharedLimiter != nil && client.clock != Clock(realClock{}) {
+		client.sharedLimiter.setClock(client.clock)
+	}
+
+	// Cached response bodies are decoded with the same codec as fresh ones
+	if s, ok := client.serializer.(JSONSerializer); ok && s.Codec == nil && client.codec != nil {
+		client.serializer = JSONSerializer{Codec: client.codec}
+	}
+
+	// The default cache is created only when no cache option was given, and
+	// is owned (and closed) by the client
+	if client.cache == nil {
+		cache := newInMemoryCache(context.Background(), DefaultCleanupInterval, 0, client.clock)
+		client.cache = cache
+		client.onClose(func() error { return closeCache(cache) })
+	}
+
+	// API paths start with a slash (see newRequest)
+	client.baseURL = strings.TrimRight(client.baseURL, "/")
 
 	// Validate and configure authentication
 	if err := client.configureAuth(); err != nil {
+		client.Close()
 		return nil, err
 	}
+	client.identity = credentialIdentity(client.apiKey, client.clientID)
 
 	// Validate client configuration
 	if err := client.validate(); err != nil {
+		client.Close()
 		return nil, err
 	}
 
+	if client.onInvalidate != nil && client.cacheEnabled() {
+		client.expiries = newExpiryWatch(client.clock, client.onInvalidate)
+		client.onClose(client.expiries.close)
+	}
+
 	return client, nil
 }
 
+// Close releases resources owned by the client
+//
+// It stops the background cleanup goroutine of the default in-memory cache,
+// closes caches passed to WithOwnedCache, and stops any other background work
+// started by the client. Caches passed to WithCache are not closed, since they
+// may be shared with other clients.
+// Close is safe to call more than once; the client must not be used after
+// it returns.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.closeMu.Lock()
+		closers := c.closers
+		c.closers = nil
+		c.closeMu.Unlock()
+
+		var errs []error
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i](); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		c.closeErr = errors.Join(errs...)
+	})
+	return c.closeErr
+}
+
+// onClose registers fn to run when the client is closed
+func (c *Client) onClose(fn func() error) {
+	c.closeMu.Lock()
+	defer c.closeMu.Unlock()
+	c.closers = append(c.closers, fn)
+}
+
 // configureAuth validates auth credentials and configures HTTP client
 func (c *Client) configureAuth() error {
 	hasAPIKey := c.apiKey != ""
 	hasOAuth2 := c.clientID != "" || c.clientSecret != ""
+	hasTokenSource := c.tokenSource != nil
+	hasProvider := c.credentialProvider != nil
 
 	// If HTTP client already provided, skip auth configuration
 	if c.httpClient != nil {
+		if c.transport.set {
+			return optionError("WithHTTPClient", nil, "transport options cannot be combined with WithHTTPClient")
+		}
+		if c.reauth != nil {
+			return optionError("WithReauth", nil, "WithReauth cannot be combined with WithHTTPClient")
+		}
+		if hasProvider {
+			return optionError("WithCredentialProvider", nil, "WithCredentialProvider cannot be combined with WithHTTPClient")
+		}
+		if c.debugWriter != nil {
+			c.enableDebug()
+		}
+		if c.redirectPolicy != nil {
+			hc := *c.httpClient
+			hc.CheckRedirect = c.redirectPolicy.checkRedirect
+			c.httpClient = &hc
+		}
 		c.log("using custom HTTP client")
 		return nil
 	}
 
 	// Validate: exactly ONE auth method must be provided
-	if hasAPIKey && hasOAuth2 {
+	methods := 0
+	for _, has := range []bool{hasAPIKey, hasOAuth2, hasTokenSource, hasProvider} {
+		if has {
+			methods++
+		}
+	}
+	if methods > 1 {
 		return ErrMultipleAuthMethods
 	}
-
-	if !hasAPIKey && !hasOAuth2 {
+	if methods == 0 {
 		return ErrNoAuthProvided
 	}
+	if hasProvider {
+		if err := c.provideCredentials(); err != nil {
+			return err
+		}
+		hasAPIKey, hasOAuth2 = c.apiKey != "", c.clientID != ""
+	}
+	if c.transport.base != nil && (c.transport.maxIdleConns > 0 || c.transport.idleConnTimeout > 0 || c.transport.disableHTTP2) {
+		return optionError("WithTransport", nil, "connection pooling options only apply to the default transport")
+	}
+	if len(c.scopes) > 0 && !hasOAuth2 {
+		return optionError("WithOAuth2Scopes", c.scopes, "scopes require WithOAuth2")
+	}
 
 	// Configure HTTP client based on auth method
+	base := c.baseTransport()
+	policy := RedirectPolicy{}
+	if c.redirectPolicy != nil {
+		policy = *c.redirectPolicy
+	}
 	if hasAPIKey {
 		// API Key authentication: simple HTTP client with custom transport
+		transport := &apiKeyTransport{
+			apiKey:    c.apiKey,
+			transport: base,
+		}
+		c.auth = &authState{apiKey: transport}
+		c.httpClient = &http.Client{Transport: transport}
+		c.log("using API Key authentication")
+	} else if hasTokenSource {
+		source := c.tokenSource
+		tokens := &tokenCache{source: func() oauth2.TokenSource { return source }}
+		c.auth = &authState{tokens: tokens}
 		c.httpClient = &http.Client{
-			Transport: &apiKeyTransport{
-				apiKey:    c.apiKey,
-				transport: http.DefaultTransport,
+			Transport: &oauth2.Transport{
+				Source: tokens,
+				Base:   base,
 			},
 		}
-		c.log("using API Key authentication")
+		c.log("using OAuth2 token source authentication")
 	} else {
 		// OAuth2 authentication: use official SDK
 		if c.clientID == "" || c.clientSecret == "" {
-			return ErrInvalidConfig("both client_id and client_secret required for OAuth2")
+			return optionError("WithOAuth2", nil, "both client_id and client_secret required for OAuth2")
 		}
 
 		oauthConfig := &clientcredentials.Config{
 			ClientID:     c.clientID,
 			ClientSecret: c.clientSecret,
-			TokenURL:     c.baseURL + "/token/",
+			TokenURL:     c.tokenURL(),
+			Scopes:       c.scopes,
+		}
+		// The oauth2 package uses the context's client as the base transport
+		// for token requests
+		tokenClient := &http.Client{Transport: base, CheckRedirect: policy.checkRedirect}
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, tokenClient)
+		tokens := &tokenCache{source: clientCredentialsSource(ctx, oauthConfig)}
+		c.auth = &authState{tokens: tokens, config: oauthConfig, ctx: ctx}
+		if hasProvider {
+			// Each token request asks the provider for the current secret
+			source := &providerTokenSource{ctx: ctx, provider: c.credentialProvider, config: *oauthConfig, redactor: c.redactor}
+			tokens.source = func() oauth2.TokenSource { return source }
+			c.auth = &authState{tokens: tokens}
+		}
+		c.httpClient = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: tokens,
+				Base:   base,
+			},
 		}
-		c.httpClient = oauthConfig.Client(context.Background())
 		c.log("using OAuth2 Client Credentials authentication")
 	}
 
+	// Credentials only go to the API host, whatever the redirect policy
+	apiURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return optionError("WithBaseURL", c.baseURL, err.Error())
+	}
+	c.httpClient.CheckRedirect = policy.checkRedirect
+	c.httpClient.Transport = &hostGuard{host: apiURL.Host, auth: c.httpClient.Transport, base: base}
 	return nil
 }
 
+// baseTransport returns the transport used beneath authentication
+// http.DefaultTransport is shared unless transport options were given.
+func (c *Client) baseTransport() http.RoundTripper {
+	if c.debugWriter != nil {
+		// Beneath authentication, so dumps show the (redacted) credentials
+		return &debugTransport{
+			transport: c.tunedTransport(),
+			w:         c.debugWriter,
+			tokenURL:  c.tokenURL(),
+			redactor:  c.redactor,
+			maxBody:   c.maxResponseSize,
+		}
+	}
+	return c.tunedTransport()
+}
+
+// tunedTransport applies transport options to a clone of http.DefaultTransport
+func (c *Client) tunedTransport() http.RoundTripper {
+	if c.transport.base != nil {
+		return c.transport.base
+	}
+	if !c.transport.set {
+		return http.DefaultTransport
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if c.transport.maxIdleConns > 0 {
+		// All requests go to a single host, so the per-host limit matters most
+		t.MaxIdleConns = c.transport.maxIdleConns
+		t.MaxIdleConnsPerHost = c.transport.maxIdleConns
+	}
+	if c.transport.idleConnTimeout > 0 {
+		t.IdleConnTimeout = c.transport.idleConnTimeout
+	}
+	if c.transport.disableHTTP2 {
+		t.ForceAttemptHTTP2 = false
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP1(true)
+	}
+	return t
+}
+
+// enableDebug wraps a custom HTTP client's transport with a debugTransport
+// A copy of the HTTP client is made so the client passed to WithHTTPClient
+// is not modified.
+func (c *Client) enableDebug() {
+	hc := *c.httpClient
+	transport := hc.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	hc.Transport = &debugTransport{transport: transport, w: c.debugWriter, redactor: c.redactor, maxBody: c.maxResponseSize}
+	c.httpClient = &hc
+}
+
+// tokenURL returns the OAuth2 token endpoint
+func (c *Client) tokenURL() string {
+	if c.tokenEndpoint != "" {
+		return c.tokenEndpoint
+	}
+	return c.baseURL + "/token/"
+}
+
 // validate ensures the client is properly configured
 func (c *Client) validate() error {
 	if c.baseURL == "" {
@@ -132,26 +471,150 @@ func (c *Client) validate() error {
 	if c.cache == nil {
 		return ErrInvalidConfig("cache cannot be nil")
 	}
+	if c.serializer == nil {
+		return ErrInvalidConfig("serializer cannot be nil")
+	}
 	return nil
 }
 
+// SetLogger replaces the client's logger (nil disables logging)
+func (c *Client) SetLogger(logger Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger = logger
+}
+
+// SetRateLimitBehavior changes how the client handles rate limiting
+func (c *Client) SetRateLimitBehavior(behavior RateLimitBehavior) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimitBehavior = behavior
+}
+
 // log is a helper that only logs if a logger is configured
 func (c *Client) log(msg string, args ...interface{}) {
-	if c.logger != nil {
-		c.logger.Debug(msg, args...)
+	c.mu.RLock()
+	logger := c.logger
+	c.mu.RUnlock()
+
+	if logger != nil {
+		logger.Debug(c.redactor.String(msg), c.redactor.Args(args)...)
+	}
+}
+
+// String describes the client without exposing credentials
+func (c *Client) String() string {
+	return fmt.Sprintf("openplantbook.Client{baseURL: %q, auth: %s}", c.baseURL, c.authMethod())
+}
+
+// GoString keeps %#v formatting from printing credential fields
+func (c *Client) GoString() string {
+	return c.String()
+}
+
+// waitRateLimit charges the request to the budgets of ctx (see WithBudget)
+// and applies the configured rate limit behavior
+func (c *Client) waitRateLimit(ctx context.Context, class EndpointClass) error {
+	if c.dryRun {
+		// Nothing will be sent, so nothing is charged
+		return nil
+	}
+	if err := spendBudget(ctx); err != nil {
+		return err
 	}
+	if err := c.waitLimiter(ctx, class, c.limitersFor(class)); err != nil {
+		refundBudget(ctx)
+		return err
+	}
+	return nil
+}
+
+// waitLimiter waits for, or reports the lack of, a token from each limiter
+// pacing class
+func (c *Client) waitLimiter(ctx context.Context, class EndpointClass, limiters []*rate.Limiter) error {
+	if len(limiters) == 0 {
+		return nil
+	}
+
+	c.mu.RLock()
+	behavior := c.rateLimitBehavior
+	c.mu.RUnlock()
+
+	if behavior == RateLimitError {
+		// Check if we can proceed without waiting
+		now := c.clock.Now()
+		reservation, ok := reserve(limiters, now)
+		if !ok {
+			return &ErrRateLimited{
+				RetryAfter: now.Add(24 * time.Hour),
+				Message:    "rate limiter exhausted",
+			}
+		}
+
+		delay := reservation.delayFrom(now)
+		if delay > 0 {
+			// Cancel the reservation and return error
+			reservation.cancelAt(now)
+			return &ErrRateLimited{
+				RetryAfter: now.Add(delay),
+				Message:    "rate limit exceeded, please retry later",
+			}
+		}
+		// If delay is 0, reservation is consumed and we can proceed
+		return nil
+	}
+
+	// Default behavior: wait for rate limiter
+	if err := ctx.Err(); err != nil {
+		return &ErrContextCanceled{Stage: StageRateLimit, Err: err}
+	}
+	start := c.clock.Now()
+	reservation, ok := reserve(limiters, start)
+	if !ok {
+		return errors.New("rate limit wait: rate limiter allows no requests")
+	}
+	delay := reservation.delayFrom(start)
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		// The deadline would pass before a request is allowed
+		reservation.cancelAt(start)
+		return fmt.Errorf("rate limit wait: would exceed context deadline (need to wait %s)", delay)
+	}
+
+	select {
+	case <-c.clock.After(delay):
+	case <-ctx.Done():
+		reservation.cancelAt(c.clock.Now())
+		return &ErrContextCanceled{Stage: StageRateLimit, Elapsed: c.clock.Now().Sub(start), Err: ctx.Err()}
+	}
+	c.hooks.rateLimitWait(RateLimitWaitInfo{Endpoint: class, Wait: c.clock.Now().Sub(start)})
+	return nil
 }
 
 // apiKeyTransport adds API key authentication to requests
 type apiKeyTransport struct {
+	mu        sync.RWMutex // apiKey is replaced by re-authentication
 	apiKey    string
 	transport http.RoundTripper
 }
 
+// setKey replaces the API key sent with later requests
+func (t *apiKeyTransport) setKey(apiKey string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.apiKey = apiKey
+}
+
 // RoundTrip implements the http.RoundTripper interface
 func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	apiKey := t.apiKey
+	t.mu.RUnlock()
+
 	// Clone request to avoid modifying original
 	req = req.Clone(req.Context())
-	req.Header.Set("Authorization", "Token "+t.apiKey)
+	req.Header.Set("Authorization", "Token "+apiKey)
 	return t.transport.RoundTrip(req)
 }
@@ -3,9 +3,19 @@ package openplantbook
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
 )
 
 func TestNew_APIKey(t *testing.T) {
@@ -213,6 +223,132 @@ func TestNew_WithCustomHTTPClient(t *testing.T) {
 	}
 }
 
+func TestNew_TransportOptions(t *testing.T) {
+	opts := []Option{
+		WithMaxIdleConns(64),
+		WithIdleConnTimeout(30 * time.Second),
+		DisableHTTP2(),
+	}
+
+	check := func(t *testing.T, rt http.RoundTripper) {
+		t.Helper()
+		tr, ok := rt.(*http.Transport)
+		if !ok {
+			t.Fatalf("base transport type = %T, want *http.Transport", rt)
+		}
+		if tr == http.DefaultTransport {
+			t.Fatal("base transport is http.DefaultTransport, want a tuned clone")
+		}
+		if tr.MaxIdleConns != 64 || tr.MaxIdleConnsPerHost != 64 {
+			t.Errorf("MaxIdleConns = %d, MaxIdleConnsPerHost = %d, want 64", tr.MaxIdleConns, tr.MaxIdleConnsPerHost)
+		}
+		if tr.IdleConnTimeout != 30*time.Second {
+			t.Errorf("IdleConnTimeout = %v, want 30s", tr.IdleConnTimeout)
+		}
+		if tr.Protocols == nil || tr.Protocols.HTTP2() || !tr.Protocols.HTTP1() {
+			t.Errorf("Protocols = %v, want HTTP/1 only", tr.Protocols)
+		}
+	}
+
+	t.Run("api key", func(t *testing.T) {
+		client, err := New(append(opts, WithAPIKey("test-api-key"))...)
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+		check(t, client.httpClient.Transport.(*hostGuard).auth.(*apiKeyTransport).transport)
+	})
+
+	t.Run("oauth2", func(t *testing.T) {
+		client, err := New(append(opts, WithOAuth2("id", "secret"))...)
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+		check(t, client.httpClient.Transport.(*hostGuard).auth.(*oauth2.Transport).Base)
+	})
+
+	t.Run("custom http client", func(t *testing.T) {
+		_, err := New(WithHTTPClient(&http.Client{}), WithMaxIdleConns(10))
+		var configErr *ConfigError
+		if !errors.As(err, &configErr) {
+			t.Errorf("New() error = %v, want *ConfigError", err)
+		}
+	})
+
+	t.Run("defaults untouched", func(t *testing.T) {
+		client, err := New(WithAPIKey("test-api-key"))
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+		if client.httpClient.Transport.(*hostGuard).auth.(*apiKeyTransport).transport != http.DefaultTransport {
+			t.Error("base transport without options is not http.DefaultTransport")
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		if _, err := New(WithAPIKey("k"), WithMaxIdleConns(0)); err == nil {
+			t.Error("WithMaxIdleConns(0) expected error, got nil")
+		}
+		if _, err := New(WithAPIKey("k"), WithIdleConnTimeout(-time.Second)); err == nil {
+			t.Error("WithIdleConnTimeout(-1s) expected error, got nil")
+		}
+	})
+}
+
+func TestWithTransport(t *testing.T) {
+	var seen []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		seen = append(seen, req.URL.Path+" "+req.Header.Get("Authorization"))
+		body := `{"pid":"monstera","display_pid":"Monstera"}`
+		if strings.HasSuffix(req.URL.Path, "/token/") {
+			body = `{"access_token":"t","token_type":"bearer","expires_in":3600}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	tests := []struct {
+		name string
+		auth Option
+		want []string
+	}{
+		{"api key", WithAPIKey("test-key"), []string{"/api/v1/plant/detail/monstera/ Token test-key"}},
+		{"oauth2", WithOAuth2("id", "secret"), []string{"/api/v1/token/ Basic aWQ6c2VjcmV0", "/api/v1/plant/detail/monstera/ Bearer t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			client, err := New(tt.auth, WithTransport(transport), DisableRateLimit(), DisableCache())
+			if err != nil {
+				t.Fatalf("New() unexpected error: %v", err)
+			}
+			defer client.Close()
+			if _, err := client.GetPlantDetails(context.Background(), "monstera", nil); err != nil {
+				t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+			}
+			if strings.Join(seen, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("transport saw %q, want %q", seen, tt.want)
+			}
+		})
+	}
+
+	t.Run("conflicts", func(t *testing.T) {
+		for name, opts := range map[string][]Option{
+			"nil":         {WithAPIKey("k"), WithTransport(nil)},
+			"http client": {WithHTTPClient(&http.Client{}), WithTransport(transport)},
+			"pooling":     {WithAPIKey("k"), WithTransport(transport), WithMaxIdleConns(10)},
+		} {
+			var configErr *ConfigError
+			if _, err := New(opts...); !errors.As(err, &configErr) {
+				t.Errorf("%s: New() error = %v, want *ConfigError", name, err)
+			}
+		}
+	})
+}
+
 func TestNew_DisableRateLimit(t *testing.T) {
 	client, err := New(
 		WithAPIKey("test-api-key"),
@@ -302,6 +438,45 @@ func TestNew_InvalidBaseURL(t *testing.T) {
 	}
 }
 
+func TestNew_OptionErrors(t *testing.T) {
+	t.Run("names the option and value", func(t *testing.T) {
+		_, err := New(WithAPIKey("test-key"), WithRateLimit(0))
+
+		var cfgErr *ConfigError
+		if !errors.As(err, &cfgErr) {
+			t.Fatalf("New() error = %v, want *ConfigError", err)
+		}
+		if cfgErr.Option != "WithRateLimit" || cfgErr.Value != 0 {
+			t.Errorf("ConfigError = %+v, want Option WithRateLimit, Value 0", cfgErr)
+		}
+	})
+
+	t.Run("reports every failing option", func(t *testing.T) {
+		_, err := New(
+			WithAPIKey("test-key"),
+			WithRateLimit(-1),
+			WithCache(nil),
+			WithHedging(0),
+		)
+		if err == nil {
+			t.Fatal("New() expected error, got nil")
+		}
+
+		for _, option := range []string{"WithRateLimit", "WithCache", "WithHedging"} {
+			if !strings.Contains(err.Error(), option) {
+				t.Errorf("New() error %q does not mention %s", err, option)
+			}
+		}
+	})
+
+	t.Run("does not leak credentials", func(t *testing.T) {
+		_, err := New(WithOAuth2("client-id", ""))
+		if err == nil || strings.Contains(err.Error(), "client-id") {
+			t.Errorf("New() error = %v, want error without credentials", err)
+		}
+	})
+}
+
 func TestNew_WithRequestBody(t *testing.T) {
 	// Test newRequest with body (for Content-Type header coverage)
 	client, err := New(
@@ -322,3 +497,212 @@ func TestNew_WithRequestBody(t *testing.T) {
 		t.Error("Content-Type header not set for request with body")
 	}
 }
+
+// countingLogger is a Logger that is safe for concurrent use
+type countingLogger struct {
+	calls atomic.Int64
+}
+
+func (l *countingLogger) Debug(msg string, args ...interface{}) { l.calls.Add(1) }
+func (l *countingLogger) Info(msg string, args ...interface{})  { l.calls.Add(1) }
+func (l *countingLogger) Warn(msg string, args ...interface{})  { l.calls.Add(1) }
+func (l *countingLogger) Error(msg string, args ...interface{}) { l.calls.Add(1) }
+
+// TestClient_ConcurrentUse exercises a shared client from many goroutines.
+// Run with -race to detect unsynchronized access.
+func TestClient_ConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "search") {
+			w.Write([]byte(`{"count":1,"next":null,"previous":null,"results":[{"pid":"test","display_pid":"Test","alias":"Test Plant","category":"Test"}]}`))
+			return
+		}
+		w.Write([]byte(`{"pid":"test","display_pid":"Test","alias":"Test Plant","max_temp":30,"min_temp":10}`))
+	}))
+	defer server.Close()
+
+	logger := &countingLogger{}
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	const workers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*2)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			query := fmt.Sprintf("plant-%d", i%5)
+			if _, err := client.SearchPlants(context.Background(), query, nil); err != nil {
+				errs <- fmt.Errorf("SearchPlants(%q): %w", query, err)
+			}
+			if _, err := client.GetPlantDetails(context.Background(), query, nil); err != nil {
+				errs <- fmt.Errorf("GetPlantDetails(%q): %w", query, err)
+			}
+		}(i)
+	}
+
+	// Reconfigure while requests are in flight
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < workers; i++ {
+			if i%2 == 0 {
+				client.SetLogger(nil)
+			} else {
+				client.SetLogger(logger)
+			}
+			client.SetRateLimitBehavior(RateLimitWait)
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestClient_SetRateLimitBehavior(t *testing.T) {
+	client, err := New(WithAPIKey("test-key"), WithRateLimit(1))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.SetRateLimitBehavior(RateLimitError)
+
+	// The burst of one is consumed by the first call
+	if err := client.waitRateLimit(context.Background(), EndpointDetails); err != nil {
+		t.Fatalf("first waitRateLimit() unexpected error: %v", err)
+	}
+
+	var rateLimited *ErrRateLimited
+	if err := client.waitRateLimit(context.Background(), EndpointDetails); !errors.As(err, &rateLimited) {
+		t.Errorf("second waitRateLimit() = %v, want *ErrRateLimited", err)
+	}
+}
+
+func TestClient_Close(t *testing.T) {
+	isClosed := func(c *InMemoryCache) bool {
+		select {
+		case <-c.stop:
+			return true
+		default:
+			return false
+		}
+	}
+
+	t.Run("closes default cache", func(t *testing.T) {
+		client, err := New(WithAPIKey("test-key"))
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+		cache := client.cache.(*InMemoryCache)
+
+		if err := client.Close(); err != nil {
+			t.Fatalf("Close() unexpected error: %v", err)
+		}
+		if !isClosed(cache) {
+			t.Error("default cache still running after Close()")
+		}
+		if err := client.Close(); err != nil {
+			t.Errorf("second Close() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("leaves provided cache open", func(t *testing.T) {
+		cache := NewInMemoryCache()
+		defer cache.Close()
+
+		client, err := New(WithAPIKey("test-key"), WithCache(cache))
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+		client.Close()
+		if isClosed(cache) {
+			t.Error("Close() closed a cache passed to WithCache")
+		}
+	})
+
+	t.Run("runs closers in reverse order", func(t *testing.T) {
+		client, err := New(WithAPIKey("test-key"), DisableCache())
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+
+		var order []int
+		client.onClose(func() error { order = append(order, 1); return nil })
+		client.onClose(func() error { order = append(order, 2); return errors.New("flush failed") })
+
+		if err := client.Close(); err == nil || !strings.Contains(err.Error(), "flush failed") {
+			t.Errorf("Close() error = %v, want flush failed", err)
+		}
+		if fmt.Sprint(order) != "[2 1]" {
+			t.Errorf("closer order = %v, want [2 1]", order)
+		}
+	})
+}
+
+func TestClient_CacheOwnership(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	t.Run("default cache", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			client, err := New(WithAPIKey("test-key"))
+			if err != nil {
+				t.Fatalf("New() unexpected error: %v", err)
+			}
+			client.Close()
+		}
+	})
+
+	t.Run("owned cache", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			client, err := New(WithAPIKey("test-key"), WithOwnedCache(NewInMemoryCache()))
+			if err != nil {
+				t.Fatalf("New() unexpected error: %v", err)
+			}
+			client.Close()
+		}
+	})
+
+	t.Run("owned cache closed when New fails", func(t *testing.T) {
+		if _, err := New(WithOwnedCache(NewInMemoryCache()), WithRateLimit(0)); err == nil {
+			t.Fatal("New() expected error, got nil")
+		}
+		if _, err := New(WithOwnedCache(NewInMemoryCache())); err == nil {
+			t.Fatal("New() without credentials expected error, got nil")
+		}
+	})
+
+	t.Run("shared cache", func(t *testing.T) {
+		cache := NewInMemoryCache()
+		defer cache.Close()
+
+		for i := 0; i < 10; i++ {
+			client, err := New(WithAPIKey("test-key"), WithCache(cache))
+			if err != nil {
+				t.Fatalf("New() unexpected error: %v", err)
+			}
+			client.Close()
+		}
+		cache.Set("key", []byte("value"), time.Minute)
+		if _, ok := cache.Get("key"); !ok {
+			t.Error("shared cache unusable after clients closed")
+		}
+	})
+}
+
+func TestWithOwnedCache_Nil(t *testing.T) {
+	_, err := New(WithAPIKey("test-key"), WithOwnedCache(nil))
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Option != "WithOwnedCache" {
+		t.Errorf("New() error = %v, want ConfigError for WithOwnedCache", err)
+	}
+}
@@ -0,0 +1,23 @@
+package openplantbook
+
+import "time"
+
+// Clock is the client's source of time
+// It drives rate limit waits, cache freshness, quota accounting and the
+// hedging and autocomplete delays, so tests can control time with a fake
+// (such as clocktest.Clock) instead of sleeping. Implementations must be safe
+// for concurrent use.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After sends the current time on the returned channel once d has
+	// elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock of the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,150 @@
+package openplantbook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/clocktest"
+)
+
+func TestWithClock_CacheExpiry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"pid": "monstera"}`))
+	}))
+	defer server.Close()
+
+	clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), WithClock(clock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	get := func() {
+		t.Helper()
+		if _, err := client.GetPlantDetails(context.Background(), "monstera", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+
+	get()
+	clock.Advance(23 * time.Hour)
+	get()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("API calls within the TTL = %d, want 1", n)
+	}
+
+	clock.Advance(2 * time.Hour)
+	get()
+	if n := calls.Load(); n != 2 {
+		t.Errorf("API calls after the TTL = %d, want 2", n)
+	}
+	// The first request is more than 24 hours old and no longer counts
+	if q := client.Status().Quota; q.Used != 1 {
+		t.Errorf("quota used = %d, want 1", q.Used)
+	}
+}
+
+func TestWithClock_RateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktest.New(start)
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableCache(),
+		WithRateLimit(24), WithRateLimitBehavior(RateLimitError), WithClock(clock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.SearchPlants(ctx, "a", nil); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+
+	_, err = client.SearchPlants(ctx, "b", nil)
+	rl, ok := err.(*ErrRateLimited)
+	if !ok {
+		t.Fatalf("second request error = %v, want *ErrRateLimited", err)
+	}
+	if want := start.Add(time.Hour); !rl.RetryAfter.Equal(want) {
+		t.Errorf("RetryAfter = %v, want %v", rl.RetryAfter, want)
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := client.SearchPlants(ctx, "b", nil); err != nil {
+		t.Errorf("request after the interval failed: %v", err)
+	}
+}
+
+func TestWithClock_SharedLimiterAndMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pid": "monstera"}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktest.New(start)
+	limiter, _ := NewLimiter(DefaultRateLimit)
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableCache(),
+		WithSharedRateLimiter(limiter), WithClock(clock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Without a cache, the fetch time still comes from the clock
+	result, err := client.GetPlantDetailsWithMeta(context.Background(), "monstera", nil)
+	if err != nil {
+		t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+	}
+	if !result.FetchedAt.Equal(start) {
+		t.Errorf("FetchedAt = %v, want the clock's %v", result.FetchedAt, start)
+	}
+
+	if n := limiter.Used(); n != 1 {
+		t.Errorf("limiter Used() = %d, want 1", n)
+	}
+	clock.Advance(25 * time.Hour)
+	if n := limiter.Used(); n != 0 {
+		t.Errorf("limiter Used() a day later = %d, want 0", n)
+	}
+}
+
+func TestWithClock_CacheExport(t *testing.T) {
+	clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, err := New(WithAPIKey("test-key"), WithClock(clock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	cache := client.cache.(*InMemoryCache)
+	cache.Set("key", []byte(`{}`), time.Hour)
+	clock.Advance(2 * time.Hour)
+
+	var buf bytes.Buffer
+	if err := cache.Export(&buf); err != nil {
+		t.Fatalf("Export() unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), `"key"`) {
+		t.Errorf("Export() = %s, want the entry expired by the clock left out", buf.String())
+	}
+}
+
+func TestWithClock_Nil(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithClock(nil)); err == nil {
+		t.Error("New() with a nil clock succeeded")
+	}
+}
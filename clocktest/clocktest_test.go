@@ -0,0 +1,59 @@
+package clocktest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock_After(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(start)
+
+	ch := c.After(time.Minute)
+	if n := c.Waiters(); n != 1 {
+		t.Fatalf("Waiters() = %d, want 1", n)
+	}
+
+	c.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After fired early")
+	default:
+	}
+
+	c.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		if want := start.Add(time.Minute); !got.Equal(want) {
+			t.Errorf("After sent %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("After did not fire once due")
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("Waiters() = %d after firing, want 0", n)
+	}
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Error("After(0) did not fire immediately")
+	}
+}
+
+func TestClock_BlockUntil(t *testing.T) {
+	c := New(time.Time{})
+	fired := make(chan struct{})
+	go func() {
+		<-c.After(time.Second)
+		close(fired)
+	}()
+
+	c.BlockUntil(1)
+	c.Set(c.Now().Add(time.Second))
+	select {
+	case <-fired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiter not released by Set")
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// readPIDs reads one PID per line, skipping blank lines and # comments
+// Hyphens are converted to spaces as for single PIDs on the command line.
+func readPIDs(r io.Reader) ([]string, error) {
+	var pids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if pid := strings.TrimSpace(line); pid != "" {
+			pids = append(pids, strings.ReplaceAll(pid, "-", " "))
+		}
+	}
+	return pids, scanner.Err()
+}
+
+// readPIDFile reads the PIDs in path (see readPIDs)
+func readPIDFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PID file: %w", err)
+	}
+	defer f.Close()
+	pids, err := readPIDs(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PID file: %w", err)
+	}
+	return pids, nil
+}
+
+// estimateBatchDetails reports the API cost of fetching the job's PIDs
+// without fetching them; with resume, PIDs the journal records as done are
+// left out
+func estimateBatchDetails(job batchJob) error {
+	pids, err := readPIDFile(job.path)
+	if err != nil {
+		return err
+	}
+	if job.resume {
+		done, _, err := loadJournal(job.journalPath, job.journalHeader())
+		if err != nil {
+			return err
+		}
+		pids = slices.DeleteFunc(pids, func(pid string) bool { return done[pid] })
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	var plan openplantbook.BatchPlan
+	for _, pid := range pids {
+		plan.Details = append(plan.Details, openplantbook.PlannedDetails{PID: pid, Options: job.opts})
+	}
+	report := client.EstimateCost(plan)
+
+	switch job.format {
+	case formatJSON:
+		return outputJSON(report)
+	case formatNDJSON:
+		return newNDJSONWriter().Write(report)
+	}
+
+	fmt.Printf("Plants:     %d (%d duplicate, %d cached)\n", report.Lookups, report.Duplicates, report.Cached)
+	fmt.Printf("API calls:  %d\n", report.Calls)
+	if report.Quota.Limit > 0 {
+		fmt.Printf("Quota:      %d of %d remaining today\n", report.Quota.Remaining, report.Quota.Limit)
+		fmt.Printf("Duration:   about %s at the rate limit\n", report.Duration.Round(time.Minute))
+	}
+	if !report.Fits {
+		return fmt.Errorf("batch needs %d requests but only %d remain today", report.Calls, report.Quota.Remaining)
+	}
+	return nil
+}
+
+// batchJob configures runBatchDetails
+type batchJob struct {
+	path         string // PID file
+	failuresPath string // where failed PIDs are written
+	journalPath  string // progress journal, for --resume
+	resume       bool   // skip PIDs the journal records as done
+	format       string
+	opts         *openplantbook.DetailOptions
+}
+
+// journalHeader identifies the job in its journal, so a resumed run uses
+// the same language as the run it continues
+func (j batchJob) journalHeader() journalHeader {
+	langs := append([]string{j.opts.Language}, j.opts.Languages...)
+	return journalHeader{Job: "details", Params: map[string]string{"lang": strings.Join(langs, ",")}}
+}
+
+// runBatchDetails fetches details for every PID in the job's file
+// JSON results (json or ndjson format) are written to stdout as NDJSON as
+// they arrive, since a batch may take hours under the rate limit; failed PIDs
+// are written to failuresPath with the error as a comment, so the file can be
+// used as input to retry them. Progress is journaled so that an interrupted
+// run resumed with --resume only requests the PIDs it had not finished.
+// When stderr is a terminal a progress bar is drawn there.
+func runBatchDetails(ctx context.Context, job batchJob) error {
+	pids, err := readPIDFile(job.path)
+	if err != nil {
+		return err
+	}
+
+	jrnl, err := openJournal(job.journalPath, job.journalHeader(), job.resume)
+	if err != nil {
+		return err
+	}
+	pending := pids[:0:0]
+	for _, pid := range pids {
+		if !jrnl.isDone(pid) {
+			pending = append(pending, pid)
+		}
+	}
+	if skipped := len(pids) - len(pending); skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Resuming: %d of %d plant(s) already fetched\n", skipped, len(pids))
+	}
+
+	client, err := createClient()
+	if err != nil {
+		jrnl.close(false)
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	// Ctrl-C cancels ctx and stops the batch; results so far are written
+	// and PIDs not yet fetched go to the failures file
+	var (
+		details   []*openplantbook.PlantDetails
+		failures  []openplantbook.BatchDetailsResult
+		attempted = make(map[string]bool, len(pending))
+	)
+	stream := job.format != formatTable
+	w := newNDJSONWriter()
+	bar := newProgressBar()
+	if bar != nil {
+		ctx = openplantbook.WithProgress(ctx, bar)
+	}
+	err = client.GetPlantDetailsBatch(ctx, pending, job.opts, func(r openplantbook.BatchDetailsResult) error {
+		attempted[r.PID] = true
+		if r.Err != nil {
+			bar.Clear()
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", r.PID, r.Err)
+			failures = append(failures, r)
+			return jrnl.record(r.PID, r.Err)
+		}
+		if stream {
+			if err := w.Write(r.Details); err != nil {
+				return err
+			}
+		} else {
+			details = append(details, r.Details)
+		}
+		// Recorded after the result is written, so a resumed run never
+		// skips a plant whose output was lost
+		return jrnl.record(r.PID, nil)
+	})
+	bar.Clear()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		jrnl.close(false)
+		return fmt.Errorf("batch failed: %w", err)
+	}
+	for _, pid := range pending {
+		if !attempted[pid] {
+			failures = append(failures, openplantbook.BatchDetailsResult{PID: pid, Err: errors.New("not attempted")})
+			attempted[pid] = true // listed once even if the file repeats it
+		}
+	}
+
+	if !stream {
+		if err := outputBatchDetails(details); err != nil {
+			jrnl.close(false)
+			return err
+		}
+	}
+
+	if err := jrnl.close(len(failures) == 0); err != nil {
+		return fmt.Errorf("failed to close journal: %w", err)
+	}
+	if len(failures) == 0 {
+		os.Remove(job.failuresPath) // a stale report from an earlier run would mislead
+		return nil
+	}
+	if err := writeFailures(job.failuresPath, failures); err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("interrupted with %d of %d plant(s) fetched; resume with --file %s --resume", len(pids)-len(failures), len(pids), job.path)
+	}
+	return fmt.Errorf("%d of %d plant(s) failed; retry them with --file %s --resume (or --file %s)", len(failures), len(pids), job.path, job.failuresPath)
+}
+
+func writeFailures(path string, failures []openplantbook.BatchDetailsResult) error {
+	var b strings.Builder
+	for _, r := range failures {
+		msg := strings.ReplaceAll(r.Err.Error(), "\n", " ")
+		fmt.Fprintf(&b, "%s # %s\n", r.PID, msg)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write failures file: %w", err)
+	}
+	return nil
+}
+
+func outputBatchDetails(details []*openplantbook.PlantDetails) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PID\tLIGHT (LUX)\tTEMP (°C)\tHUMIDITY (%)\tSOIL MOISTURE (%)")
+	fmt.Fprintln(w, "---\t-----------\t---------\t------------\t-----------------")
+	for _, d := range details {
+		fmt.Fprintf(w, "%s\t%d-%d\t%.1f-%.1f\t%d-%d\t%d-%d\n",
+			d.PID, d.MinLightLux, d.MaxLightLux, d.MinTemp, d.MaxTemp,
+			d.MinEnvHumid, d.MaxEnvHumid, d.MinSoilMoist, d.MaxSoilMoist)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// compareReport is the JSON form of the compare command
+type compareReport struct {
+	care.Comparison
+	Compatibility []pairScore `json:"compatibility"`
+}
+
+// pairScore is the compatibility of two of the compared plants
+type pairScore struct {
+	A string `json:"a"`
+	B string `json:"b"`
+	care.Score
+}
+
+func newCompareCmd() *cobra.Command {
+	var (
+		language   string
+		jsonOutput bool
+		output     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare <pid1> <pid2> [pid...]",
+		Short: "Compare the care requirements of two or more plants",
+		Long: `Show the care requirements of several plants side by side, along with
+the conditions that suit all of them, to decide whether they can share a pot
+or a room.
+
+Every pair of plants is also given a compatibility score from 0 to 100,
+based on how much their ranges overlap, with the metrics that limit it.
+Metrics where the plants' ranges do not overlap are marked as conflicts, and
+the command exits non-zero when any conflict is found.
+
+Examples:
+  openplantbook compare monstera-deliciosa nephrolepis-exaltata
+  openplantbook compare monstera-deliciosa pothos epipremnum-aureum --json`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := resolveOutput(output, jsonOutput)
+			if err != nil {
+				return err
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			defer client.Close()
+
+			opts := &openplantbook.DetailOptions{Language: language}
+			plants := make([]*openplantbook.PlantDetails, len(args))
+			for i, arg := range args {
+				pid := strings.ReplaceAll(arg, "-", " ")
+				details, err := client.GetPlantDetails(cmd.Context(), pid, opts)
+				if err != nil {
+					return fmt.Errorf("failed to get details for %s: %w", pid, err)
+				}
+				plants[i] = details
+			}
+
+			report := compareReport{Comparison: care.ComparePlants(plants...)}
+			for i := range plants {
+				for j := i + 1; j < len(plants); j++ {
+					report.Compatibility = append(report.Compatibility, pairScore{
+						A:     report.Names[i],
+						B:     report.Names[j],
+						Score: care.Compatibility(plants[i], plants[j]),
+					})
+				}
+			}
+
+			switch format {
+			case formatJSON:
+				err = outputJSON(report)
+			case formatNDJSON:
+				err = outputNDJSON(report.Metrics)
+			default:
+				err = outputComparison(report)
+			}
+			if err != nil {
+				return err
+			}
+
+			if cmp := report.Comparison; !cmp.Compatible() {
+				return fmt.Errorf("no conditions suit every plant (%d conflict(s))", len(cmp.Conflicts()))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&language, "lang", "en", "Language code (ISO 639-1)")
+	addOutputFlags(cmd, &output, &jsonOutput)
+
+	return cmd
+}
+
+func outputComparison(report compareReport) error {
+	cmp := report.Comparison
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "METRIC\t%s\tSHARED\n", strings.Join(cmp.Names, "\t"))
+
+	for _, m := range cmp.Metrics {
+		cells := make([]string, len(m.Ranges))
+		for i, r := range m.Ranges {
+			cells[i] = "-"
+			if r != nil {
+				cells[i] = r.String()
+			}
+		}
+
+		shared := "-"
+		switch {
+		case m.Conflict:
+			shared = "CONFLICT"
+		case m.Overlap != nil:
+			shared = m.Overlap.String()
+		}
+
+		fmt.Fprintf(w, "%s (%s)\t%s\t%s\n", m.Metric, m.Metric.Unit(), strings.Join(cells, "\t"), shared)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, p := range report.Compatibility {
+		if !p.Known() {
+			fmt.Printf("%s + %s: no shared data to compare\n", p.A, p.B)
+			continue
+		}
+		fmt.Printf("%s + %s: %d/100\n", p.A, p.B, p.Value)
+		for _, f := range p.Limiting {
+			fmt.Printf("  limited by %s\n", f)
+		}
+	}
+	return nil
+}
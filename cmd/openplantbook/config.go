@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pathsReport is the JSON form of the config path command
+type pathsReport struct {
+	Config      string `json:"config"`
+	ConfigFound bool   `json:"config_found"`
+	Cache       string `json:"cache,omitempty"`
+	Collection  string `json:"collection,omitempty"`
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect CLI configuration",
+	}
+
+	cmd.AddCommand(newConfigPathCmd())
+
+	return cmd
+}
+
+func newConfigPathCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "path",
+		Short: "Show where configuration, cache and collection files are kept",
+		Long: `Show the resolved locations of the config file, response cache and
+plant collection.
+
+Configuration is read from the first of these that exists:
+  $XDG_CONFIG_HOME/openplantbook/config.yaml (default ~/.config/openplantbook)
+  ~/.openplantbook.yaml
+  ./.openplantbook.yaml
+
+Responses are cached in $XDG_CACHE_HOME/openplantbook (default
+~/.cache/openplantbook), and the collection is kept in
+$XDG_DATA_HOME/openplantbook/collection.json unless
+~/.openplantbook/collection.json already exists. On macOS and Windows the
+platform's user config and cache directories are used instead.
+
+Examples:
+  openplantbook config path
+  openplantbook config path --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var report pathsReport
+			if used := viper.ConfigFileUsed(); used != "" {
+				_, err := os.Stat(used)
+				report.Config, report.ConfigFound = used, err == nil
+			} else {
+				report.Config, report.ConfigFound = findConfigFile()
+			}
+			report.Cache, _ = cacheDir()
+			report.Collection, _ = collectionPath()
+
+			if jsonOutput {
+				return outputJSON(report)
+			}
+
+			config := report.Config
+			if !report.ConfigFound {
+				config += " (not found)"
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "Config:\t%s\n", config)
+			fmt.Fprintf(w, "Cache:\t%s\n", report.Cache)
+			fmt.Fprintf(w, "Collection:\t%s\n", report.Collection)
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output paths as JSON")
+
+	return cmd
+}
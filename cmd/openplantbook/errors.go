@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// Formats accepted by --error-format
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+// errorReport is the JSON form of a failed command, written to stderr
+type errorReport struct {
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+	Permanent bool   `json:"permanent"`
+
+	StatusCode int    `json:"status_code,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+
+	// RetryAfter is when a rate-limited request can be repeated
+	RetryAfter        *time.Time `json:"retry_after,omitempty"`
+	RetryAfterSeconds int        `json:"retry_after_seconds,omitempty"`
+}
+
+// usageError marks invalid flags or arguments
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usagef formats a usage error
+func usagef(format string, args ...any) error {
+	return &usageError{fmt.Errorf(format, args...)}
+}
+
+// markUsageErrors makes flag and argument errors of cmd and its subcommands
+// report as usage errors
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err}
+	})
+
+	// Commands without Args keep cobra's unknown-command handling
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			if err := args(cmd, a); err != nil {
+				return &usageError{err}
+			}
+			return nil
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		markUsageErrors(sub)
+	}
+}
+
+// newErrorReport describes err for --error-format json
+func newErrorReport(err error) errorReport {
+	r := errorReport{
+		Type:      "error",
+		Message:   err.Error(),
+		Retryable: openplantbook.IsRetryable(err),
+		Permanent: openplantbook.IsPermanent(err),
+	}
+
+	var (
+		usage       *usageError
+		rateLimited *openplantbook.ErrRateLimited
+		apiErr      *openplantbook.APIError
+		validation  *openplantbook.ValidationError
+		config      *openplantbook.ConfigError
+		canceled    *openplantbook.ErrContextCanceled
+		dryRun      *openplantbook.ErrDryRun
+		malformed   *openplantbook.ErrMalformedResponse
+		tooLarge    *openplantbook.ErrResponseTooLarge
+		netErr      net.Error
+	)
+	switch {
+	case errors.As(err, &usage), strings.HasPrefix(r.Message, "unknown command "):
+		r.Type = "usage"
+		r.Permanent = true
+	case errors.As(err, &rateLimited):
+		r.Type = "rate_limited"
+		retryAfter := rateLimited.RetryAfter.UTC()
+		r.RetryAfter = &retryAfter
+		r.RetryAfterSeconds = int(math.Ceil(max(time.Until(retryAfter).Seconds(), 0)))
+	case errors.Is(err, openplantbook.ErrRateLimitExceeded):
+		r.Type = "rate_limited"
+	case errors.Is(err, openplantbook.ErrBudgetExhausted):
+		r.Type = "budget_exhausted"
+	case errors.Is(err, openplantbook.ErrUnauthorized),
+		errors.Is(err, openplantbook.ErrNoAuthProvided),
+		errors.Is(err, openplantbook.ErrMultipleAuthMethods),
+		errors.Is(err, errNoCredentials):
+		r.Type = "auth"
+		r.Permanent = true
+	case errors.Is(err, openplantbook.ErrNotFound):
+		r.Type = "not_found"
+	case errors.As(err, &validation):
+		r.Type = "validation"
+	case errors.As(err, &config):
+		r.Type = "config"
+	case errors.As(err, &canceled):
+		r.Type = "canceled"
+	case errors.As(err, &dryRun):
+		r.Type = "dry_run"
+		r.Endpoint = dryRun.Endpoint.String()
+	case errors.As(err, &malformed):
+		r.Type = "malformed_response"
+		r.StatusCode = malformed.StatusCode
+		r.Endpoint = malformed.Endpoint
+	case errors.As(err, &tooLarge):
+		r.Type = "response_too_large"
+		r.Endpoint = tooLarge.Endpoint
+	case errors.As(err, &apiErr):
+		r.Type = "api"
+		r.StatusCode = apiErr.StatusCode
+		r.Endpoint = apiErr.Endpoint
+	case errors.As(err, &netErr):
+		r.Type = "network"
+	}
+	return r
+}
+
+// errorFormat returns the --error-format setting
+// Flag parsing stops at the first bad flag, so when --error-format was not
+// parsed it is looked up in args directly.
+func errorFormat(cmd *cobra.Command, args []string) string {
+	if f := cmd.PersistentFlags().Lookup("error-format"); f != nil && f.Changed {
+		return f.Value.String()
+	}
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--error-format="); ok {
+			return v
+		}
+		if arg == "--error-format" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return viper.GetString("error-format")
+}
+
+// reportError writes err to w in the given --error-format
+func reportError(w io.Writer, err error, format string) {
+	if format == errorFormatJSON {
+		if data, jsonErr := json.Marshal(newErrorReport(err)); jsonErr == nil {
+			fmt.Fprintln(w, string(data))
+			return
+		}
+	}
+	fmt.Fprintln(w, "Error:", err)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/export/grafana"
+	"github.com/rmrfslashbin/openplantbook-go/export/pdf"
+)
+
+// Limits for plant images embedded in exports
+const (
+	imageTimeout = 15 * time.Second
+	maxImageSize = 10 << 20
+)
+
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export plant data as care cards and dashboards",
+		Long:  `Export plant data as files for printing, sharing and monitoring.`,
+	}
+
+	cmd.AddCommand(newExportPDFCmd(), newExportGrafanaCmd())
+
+	return cmd
+}
+
+func newExportPDFCmd() *cobra.Command {
+	var (
+		language   string
+		pageSize   string
+		outputPath string
+		noImages   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pdf <pid> [pid...]",
+		Short: "Render plant care cards as a PDF",
+		Long: `Render a care card for each plant, one per page, with the plant's names,
+image, care thresholds and a QR code linking to its OpenPlantbook page.
+
+Cards are A6 (postcard size) by default. Images are downloaded from the
+plant records; use --no-images to skip them.
+
+Examples:
+  openplantbook export pdf monstera-deliciosa -o monstera.pdf
+  openplantbook export pdf monstera-deliciosa ficus-lyrata --page-size A5 > cards.pdf`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pageSize = strings.ToUpper(pageSize)
+			switch pageSize {
+			case pdf.PageA4, pdf.PageA5, pdf.PageA6:
+			default:
+				return usagef("invalid --page-size %q (use A4, A5 or A6)", pageSize)
+			}
+			toStdout := outputPath == "" || outputPath == "-"
+			if toStdout && term.IsTerminal(int(os.Stdout.Fd())) {
+				return usagef("refusing to write a PDF to the terminal; use -o or redirect stdout")
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			defer client.Close()
+
+			opts := &openplantbook.DetailOptions{Language: language}
+			plants := make([]*openplantbook.PlantDetails, len(args))
+			for i, arg := range args {
+				pid := strings.ReplaceAll(arg, "-", " ")
+				details, err := client.GetPlantDetails(cmd.Context(), pid, opts)
+				if err != nil {
+					return fmt.Errorf("failed to get details for %s: %w", pid, err)
+				}
+				plants[i] = details
+			}
+
+			cardOpts := &pdf.Options{PageSize: pageSize, Language: language}
+			if !noImages {
+				cardOpts.Image = fetchImage
+			}
+
+			var w io.Writer = os.Stdout
+			if !toStdout {
+				f, err := os.Create(outputPath)
+				if err != nil {
+					return fmt.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			return pdf.Write(w, plants, cardOpts)
+		},
+	}
+
+	cmd.Flags().StringVar(&language, "lang", "en", "Language code (ISO 639-1)")
+	cmd.Flags().StringVar(&pageSize, "page-size", pdf.PageA6, "Card size: A4, A5 or A6")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: stdout)")
+	cmd.Flags().BoolVar(&noImages, "no-images", false, "Leave plant images off the cards")
+
+	return cmd
+}
+
+func newExportGrafanaCmd() *cobra.Command {
+	var (
+		language   string
+		title      string
+		dsType     string
+		series     string
+		outputPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "grafana <pid>",
+		Short: "Generate a Grafana dashboard for a plant's sensors",
+		Long: `Generate a Grafana dashboard JSON with a time series panel per care
+metric, shading the plant's thresholds green and values outside them red.
+
+Panels query the series named by --series, where {{pid}} and {{metric}} are
+replaced with the plant's PID and each of light, temperature, humidity,
+soil_moisture and soil_ec. The datasource is picked when importing the
+dashboard.
+
+Examples:
+  openplantbook export grafana monstera-deliciosa -o monstera.json
+  openplantbook export grafana ficus-lyrata --series 'mqtt_{{metric}}{sensor="office-ficus"}'`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !strings.Contains(series, "{{metric}}") {
+				return usagef("--series must contain {{metric}}")
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			defer client.Close()
+
+			pid := strings.ReplaceAll(args[0], "-", " ")
+			details, err := client.GetPlantDetails(cmd.Context(), pid, &openplantbook.DetailOptions{Language: language})
+			if err != nil {
+				return fmt.Errorf("failed to get details for %s: %w", pid, err)
+			}
+
+			dash := grafana.New(details, &grafana.Options{
+				Title:          title,
+				DatasourceType: dsType,
+				Series: func(d *openplantbook.PlantDetails, m care.Metric) string {
+					return strings.NewReplacer("{{pid}}", d.PID, "{{metric}}", string(m)).Replace(series)
+				},
+			})
+			if len(dash.Panels) == 0 {
+				return fmt.Errorf("%s has no care thresholds to chart", pid)
+			}
+
+			var w io.Writer = os.Stdout
+			if outputPath != "" && outputPath != "-" {
+				f, err := os.Create(outputPath)
+				if err != nil {
+					return fmt.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			return dash.Write(w)
+		},
+	}
+
+	cmd.Flags().StringVar(&language, "lang", "en", "Language code (ISO 639-1)")
+	cmd.Flags().StringVar(&title, "title", "", "Dashboard title (default: the plant's name)")
+	cmd.Flags().StringVar(&dsType, "datasource-type", grafana.DefaultDatasourceType, "Datasource plugin the panels query")
+	cmd.Flags().StringVar(&series, "series", `plant_{{metric}}{pid="{{pid}}"}`, "Series query template")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// fetchImage downloads a plant image, warning on stderr when it fails so
+// the card is rendered without it
+func fetchImage(url string) ([]byte, error) {
+	client := &http.Client{Timeout: imageTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: image skipped: %v\n", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "Warning: image skipped: %s returned %s\n", url, resp.Status)
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxImageSize))
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// Formats accepted by gen docs --format
+const (
+	docsMan      = "man"
+	docsMarkdown = "markdown"
+	docsReST     = "rest"
+	docsYAML     = "yaml"
+)
+
+func newGenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate documentation and shell completion scripts",
+		Long: `Generate man pages, reference documentation and shell completion
+scripts for packaging the CLI.`,
+	}
+
+	cmd.AddCommand(newGenDocsCmd())
+	cmd.AddCommand(newGenCompletionCmd())
+
+	return cmd
+}
+
+func newGenDocsCmd() *cobra.Command {
+	var (
+		dir    string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate man pages or reference documentation",
+		Long: `Write one page per command to a directory, as man pages (section 1),
+Markdown, reStructuredText or YAML.
+
+Man pages are dated from SOURCE_DATE_EPOCH when it is set, so packages can
+be built reproducibly.
+
+Examples:
+  openplantbook gen docs --dir man/man1
+  openplantbook gen docs --format markdown --dir docs/cli`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", dir, err)
+			}
+
+			root := cmd.Root()
+			var err error
+			switch format {
+			case docsMan:
+				err = doc.GenManTree(root, manHeader(), dir)
+			case docsMarkdown:
+				err = doc.GenMarkdownTree(root, dir)
+			case docsReST:
+				err = doc.GenReSTTree(root, dir)
+			case docsYAML:
+				err = doc.GenYamlTree(root, dir)
+			default:
+				return usagef("invalid --format %q (want man, markdown, rest or yaml)", format)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to generate docs: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Wrote %s docs to %s\n", format, dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "docs", "Directory to write pages to")
+	cmd.Flags().StringVar(&format, "format", docsMan, "Page format: man, markdown, rest or yaml")
+	cmd.MarkFlagDirname("dir")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{docsMan, docsMarkdown, docsReST, docsYAML}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// manHeader describes the generated man pages
+func manHeader() *doc.GenManHeader {
+	header := &doc.GenManHeader{
+		Title:   "OPENPLANTBOOK",
+		Section: "1",
+		Source:  "openplantbook " + version,
+		Manual:  "OpenPlantbook CLI Manual",
+	}
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		date := time.Unix(epoch, 0).UTC()
+		header.Date = &date
+	}
+	return header
+}
+
+func newGenCompletionCmd() *cobra.Command {
+	var noDescriptions bool
+
+	cmd := &cobra.Command{
+		Use:   "completion <bash|zsh|fish|powershell>",
+		Short: "Generate a shell completion script",
+		Long: `Write a completion script for the given shell to stdout.
+
+Completions cover commands, flags and flag values such as --output formats.
+
+Examples:
+  # Bash (system-wide, as installed by packages)
+  openplantbook gen completion bash > /usr/share/bash-completion/completions/openplantbook
+
+  # Zsh
+  openplantbook gen completion zsh > "${fpath[1]}/_openplantbook"
+
+  # Fish
+  openplantbook gen completion fish > ~/.config/fish/completions/openplantbook.fish
+
+  # PowerShell
+  openplantbook gen completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, !noDescriptions)
+			case "zsh":
+				if noDescriptions {
+					return root.GenZshCompletionNoDesc(out)
+				}
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, !noDescriptions)
+			default:
+				if noDescriptions {
+					return root.GenPowerShellCompletion(out)
+				}
+				return root.GenPowerShellCompletionWithDesc(out)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&noDescriptions, "no-descriptions", false, "Omit command and flag descriptions from completions")
+
+	return cmd
+}
@@ -0,0 +1,56 @@
+module github.com/rmrfslashbin/openplantbook-go/cmd/openplantbook
+
+go 1.24.0
+
+require (
+	github.com/joho/godotenv v1.5.1
+	github.com/rmrfslashbin/openplantbook-go v0.0.0-00010101000000-000000000000
+	github.com/rmrfslashbin/openplantbook-go/export/pdf v0.0.0-00010101000000-000000000000
+	github.com/rmrfslashbin/openplantbook-go/integrations/miflora v0.0.0-00010101000000-000000000000
+	github.com/rmrfslashbin/openplantbook-go/label v0.0.0-00010101000000-000000000000
+	github.com/spf13/cobra v1.10.1
+	github.com/spf13/viper v1.21.0
+	golang.org/x/term v0.28.0
+)
+
+require (
+	github.com/cpuguy83/go-md2man/v2 v2.0.6 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	github.com/go-ole/go-ole v1.2.6 // indirect
+	github.com/go-pdf/fpdf v0.9.0 // indirect
+	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
+	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	github.com/sagikazarmark/locafero v0.11.0 // indirect
+	github.com/saltosystems/winrt-go v0.0.0-20240509164145-4f7860a3bd2b // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e // indirect
+	github.com/sourcegraph/conc v0.3.1-0.20240121214520-5f936abd7ae8 // indirect
+	github.com/soypat/cyw43439 v0.0.0-20250505012923-830110c8f4af // indirect
+	github.com/soypat/seqs v0.0.0-20250124201400-0d65bc7c1710 // indirect
+	github.com/spf13/afero v1.15.0 // indirect
+	github.com/spf13/cast v1.10.0 // indirect
+	github.com/spf13/pflag v1.0.10 // indirect
+	github.com/subosito/gotenv v1.6.0 // indirect
+	github.com/tinygo-org/cbgo v0.0.4 // indirect
+	github.com/tinygo-org/pio v0.2.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.4 // indirect
+	golang.org/x/exp v0.0.0-20241204233417-43b7b7cde48d // indirect
+	golang.org/x/oauth2 v0.27.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+	golang.org/x/time v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	tinygo.org/x/bluetooth v0.14.0 // indirect
+)
+
+replace github.com/rmrfslashbin/openplantbook-go => ../..
+
+replace github.com/rmrfslashbin/openplantbook-go/label => ../../label
+
+replace github.com/rmrfslashbin/openplantbook-go/export/pdf => ../../export/pdf
+
+replace github.com/rmrfslashbin/openplantbook-go/integrations/miflora => ../../integrations/miflora
@@ -0,0 +1,176 @@
+// Package render formats CLI output for a terminal: ANSI colors, severity
+// marks and unicode bars for value ranges.
+//
+// Color is used only when stdout is a terminal, NO_COLOR is unset and TERM is
+// not "dumb", so piped output stays plain text.
+package render
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Layout bounds
+const (
+	// DefaultWidth is assumed when the terminal width cannot be determined
+	DefaultWidth = 80
+
+	minBarWidth = 10
+	maxBarWidth = 40
+)
+
+// Style is a text attribute
+type Style int
+
+// Styles supported by Paint
+const (
+	Plain Style = iota
+	Bold
+	Dim
+	Green
+	Yellow
+	Red
+	Cyan
+)
+
+// ansi holds the SGR code of each style
+var ansi = map[Style]string{
+	Bold:   "1",
+	Dim:    "2",
+	Green:  "32",
+	Yellow: "33",
+	Red:    "31",
+	Cyan:   "36",
+}
+
+// Severity classifies a value for display
+type Severity int
+
+// Severities, from best to worst
+const (
+	OK Severity = iota
+	Warning
+	Problem
+)
+
+// Renderer formats text for one output stream
+type Renderer struct {
+	// Color enables ANSI escape sequences
+	Color bool
+
+	// Width is the number of terminal columns available
+	Width int
+}
+
+// New creates a renderer for f
+// noColor forces plain output, e.g. from a --no-color flag.
+func New(f *os.File, noColor bool) *Renderer {
+	tty := term.IsTerminal(int(f.Fd()))
+	r := &Renderer{
+		Color: tty && !noColor && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb",
+		Width: DefaultWidth,
+	}
+
+	if w, _, err := term.GetSize(int(f.Fd())); tty && err == nil && w > 0 {
+		r.Width = w
+	} else if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		r.Width = w
+	}
+	return r
+}
+
+// Paint applies style to text when color is enabled
+func (r *Renderer) Paint(style Style, text string) string {
+	code, ok := ansi[style]
+	if !r.Color || !ok || text == "" {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+// Mark returns the symbol for sev, colored when color is enabled
+func (r *Renderer) Mark(sev Severity) string {
+	switch sev {
+	case Warning:
+		return r.Paint(Yellow, "⚠")
+	case Problem:
+		return r.Paint(Red, "✖")
+	}
+	return r.Paint(Green, "✔")
+}
+
+// SeverityStyle returns the color used for sev
+func SeverityStyle(sev Severity) Style {
+	switch sev {
+	case Warning:
+		return Yellow
+	case Problem:
+		return Red
+	}
+	return Green
+}
+
+// BarWidth returns the bar width that fits after reserved columns of other
+// content, clamped to a readable size
+func (r *Renderer) BarWidth(reserved int) int {
+	return min(max(r.Width-reserved, minBarWidth), maxBarWidth)
+}
+
+// Scale maps values onto a bar
+type Scale struct {
+	Min, Max float64
+
+	// Log spaces values logarithmically, for quantities such as light
+	// that span several orders of magnitude
+	Log bool
+}
+
+// position returns where v lies on the scale, from 0 to 1
+func (s Scale) position(v float64) float64 {
+	lo, hi := s.Min, s.Max
+	if s.Log {
+		lo, hi, v = math.Log10(max(lo, 1)), math.Log10(max(hi, 1)), math.Log10(max(v, 1))
+	}
+	if hi <= lo {
+		return 0
+	}
+	return min(max((v-lo)/(hi-lo), 0), 1)
+}
+
+// Bar draws the range lo-hi as a width-cell bar on scale
+// The range is filled with "█" on a "░" track and always covers at least one
+// cell.
+func (r *Renderer) Bar(s Scale, lo, hi float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	start := int(math.Floor(s.position(lo) * float64(width)))
+	end := int(math.Ceil(s.position(hi) * float64(width)))
+	start = min(start, width-1)
+	end = max(end, start+1)
+
+	return r.Paint(Dim, strings.Repeat("░", start)) +
+		r.Paint(Green, strings.Repeat("█", end-start)) +
+		r.Paint(Dim, strings.Repeat("░", width-end))
+}
+
+// Meter draws a width-cell bar filled in proportion to done out of total,
+// for progress
+func (r *Renderer) Meter(done, total, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := width
+	if total > 0 {
+		filled = min(max(done*width/total, 0), width)
+	}
+	return r.Paint(Green, strings.Repeat("█", filled)) + r.Paint(Dim, strings.Repeat("░", width-filled))
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRenderer_Bar(t *testing.T) {
+	r := &Renderer{}
+	linear := Scale{Min: 0, Max: 100}
+
+	tests := []struct {
+		name   string
+		scale  Scale
+		lo, hi float64
+		want   string
+	}{
+		{"middle", linear, 40, 60, "░░░░██░░░░"},
+		{"full", linear, 0, 100, "██████████"},
+		{"point", linear, 50, 50, "░░░░░█░░░░"},
+		{"at max", linear, 100, 100, "░░░░░░░░░█"},
+		{"clamped", linear, -20, 300, "██████████"},
+		{"reversed", linear, 60, 40, "░░░░██░░░░"},
+		{"log", Scale{Min: 10, Max: 100000, Log: true}, 1000, 10000, "░░░░░███░░"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Bar(tt.scale, tt.lo, tt.hi, 10); got != tt.want {
+				t.Errorf("Bar(%g, %g) = %q, want %q", tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_Meter(t *testing.T) {
+	r := &Renderer{}
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{0, 4, "░░░░░░░░░░"},
+		{1, 4, "██░░░░░░░░"},
+		{4, 4, "██████████"},
+		{5, 4, "██████████"},
+		{0, 0, "██████████"},
+	}
+	for _, tt := range tests {
+		if got := r.Meter(tt.done, tt.total, 10); got != tt.want {
+			t.Errorf("Meter(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestRenderer_Paint(t *testing.T) {
+	plain := &Renderer{}
+	if got := plain.Paint(Red, "x"); got != "x" {
+		t.Errorf("Paint() without color = %q, want %q", got, "x")
+	}
+
+	color := &Renderer{Color: true}
+	if got := color.Paint(Red, "x"); got != "\x1b[31mx\x1b[0m" {
+		t.Errorf("Paint() with color = %q", got)
+	}
+	if got := color.Paint(Plain, "x"); got != "x" {
+		t.Errorf("Paint(Plain) = %q, want %q", got, "x")
+	}
+}
+
+func TestRenderer_BarWidth(t *testing.T) {
+	tests := []struct {
+		width, reserved, want int
+	}{
+		{80, 50, 30},
+		{200, 50, maxBarWidth},
+		{40, 50, minBarWidth},
+	}
+	for _, tt := range tests {
+		r := &Renderer{Width: tt.width}
+		if got := r.BarWidth(tt.reserved); got != tt.want {
+			t.Errorf("BarWidth(%d) at width %d = %d, want %d", tt.reserved, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestNew_NoColor(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+
+	// A regular file is never a terminal
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r := New(f, false)
+	if r.Color {
+		t.Error("New() enabled color for a non-terminal")
+	}
+	if r.Width != 120 {
+		t.Errorf("Width = %d, want 120 from COLUMNS", r.Width)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"time"
+)
+
+// journalVersion is the format version written in journal headers
+const journalVersion = 1
+
+// journalHeader is the first line of a journal, identifying the job
+type journalHeader struct {
+	Version int               `json:"version"`
+	Job     string            `json:"job"`
+	Params  map[string]string `json:"params,omitempty"`
+}
+
+// journalEntry records the outcome of one item of a batch job
+type journalEntry struct {
+	Key   string    `json:"key"`
+	OK    bool      `json:"ok"`
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// journal records the progress of a batch job, one JSON line per item, so
+// an interrupted run can resume without spending quota on finished items
+//
+// Lines are appended as items finish, so the journal survives the process
+// being killed; a torn last line is ignored when the journal is read back.
+type journal struct {
+	path string
+	f    *os.File
+	enc  *json.Encoder
+	done map[string]bool
+}
+
+// openJournal starts or resumes the journal at path
+// With resume, items recorded as done in an existing journal for the same
+// job and params are skipped (see isDone); otherwise any existing journal is
+// replaced.
+func openJournal(path string, header journalHeader, resume bool) (*journal, error) {
+	header.Version = journalVersion
+	j := &journal{path: path, done: make(map[string]bool)}
+
+	if resume {
+		done, found, err := loadJournal(path, header)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			j.done = done
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_RDWR, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open journal: %w", err)
+			}
+			if err := endLine(f); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("failed to open journal: %w", err)
+			}
+			j.f, j.enc = f, json.NewEncoder(f)
+			return j, nil
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal: %w", err)
+	}
+	j.f, j.enc = f, json.NewEncoder(f)
+	if err := j.enc.Encode(header); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write journal: %w", err)
+	}
+	return j, nil
+}
+
+// loadJournal reads the outcome of each item from the journal at path
+// Later entries for an item replace earlier ones. found is false when there
+// is no journal to resume.
+func loadJournal(path string, want journalHeader) (done map[string]bool, found bool, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	var header journalHeader
+	if !scanner.Scan() || json.Unmarshal(scanner.Bytes(), &header) != nil || header.Version == 0 {
+		return nil, false, fmt.Errorf("%s is not a batch journal", path)
+	}
+	want.Version = journalVersion
+	if header.Version != want.Version || header.Job != want.Job || !maps.Equal(header.Params, want.Params) {
+		return nil, false, fmt.Errorf("journal %s was written for a different job (%s %v); run without --resume to start over", path, header.Job, header.Params)
+	}
+
+	done = make(map[string]bool)
+	for scanner.Scan() {
+		var e journalEntry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue // torn write from a killed run
+		}
+		done[e.Key] = e.OK
+	}
+	return done, true, scanner.Err()
+}
+
+// endLine terminates a torn last line, so entries appended after it parse
+func endLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] != '\n' {
+		_, err = f.Write([]byte{'\n'})
+	}
+	return err
+}
+
+// isDone reports whether key finished successfully in an earlier run
+func (j *journal) isDone(key string) bool {
+	return j.done[key]
+}
+
+// record appends the outcome of key
+func (j *journal) record(key string, err error) error {
+	e := journalEntry{Key: key, OK: err == nil, Time: time.Now().UTC()}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := j.enc.Encode(e); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// close closes the journal, removing it when the job is complete
+func (j *journal) close(complete bool) error {
+	if err := j.f.Close(); err != nil {
+		return err
+	}
+	if complete {
+		return os.Remove(j.path)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pids.txt.journal")
+	header := journalHeader{Job: "details", Params: map[string]string{"lang": "en"}}
+
+	j, err := openJournal(path, header, false)
+	if err != nil {
+		t.Fatalf("openJournal() unexpected error: %v", err)
+	}
+	j.record("a", nil)
+	j.record("b", errors.New("plant not found"))
+	if err := j.close(false); err != nil {
+		t.Fatalf("close() unexpected error: %v", err)
+	}
+
+	// A run killed mid-write leaves a torn line
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString(`{"key":"c","o`)
+	f.Close()
+
+	j, err = openJournal(path, header, true)
+	if err != nil {
+		t.Fatalf("openJournal(resume) unexpected error: %v", err)
+	}
+	if !j.isDone("a") {
+		t.Error("isDone(a) = false, want true")
+	}
+	if j.isDone("b") || j.isDone("c") {
+		t.Error("failed or torn entries reported as done")
+	}
+
+	// Entries appended after the torn line are read back
+	j.record("b", nil)
+	j.close(false)
+	done, found, err := loadJournal(path, header)
+	if err != nil || !found {
+		t.Fatalf("loadJournal() = %v, %v", found, err)
+	}
+	if !done["b"] {
+		t.Error("entry appended after a torn line was lost")
+	}
+}
+
+func TestJournal_DifferentJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pids.txt.journal")
+
+	j, _ := openJournal(path, journalHeader{Job: "details", Params: map[string]string{"lang": "en"}}, false)
+	j.record("a", nil)
+	j.close(false)
+
+	if _, err := openJournal(path, journalHeader{Job: "details", Params: map[string]string{"lang": "de"}}, true); err == nil {
+		t.Error("openJournal() resumed a journal with different params")
+	}
+
+	// Without resume the journal starts over
+	j, err := openJournal(path, journalHeader{Job: "details", Params: map[string]string{"lang": "de"}}, false)
+	if err != nil {
+		t.Fatalf("openJournal() unexpected error: %v", err)
+	}
+	if j.isDone("a") {
+		t.Error("new journal kept entries from the old one")
+	}
+	if err := j.close(true); err != nil {
+		t.Fatalf("close() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("completed journal was not removed")
+	}
+}
+
+func TestJournal_ResumeWithoutJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pids.txt.journal")
+
+	j, err := openJournal(path, journalHeader{Job: "details"}, true)
+	if err != nil {
+		t.Fatalf("openJournal() unexpected error: %v", err)
+	}
+	defer j.close(true)
+	if j.isDone("a") {
+		t.Error("isDone() = true for an empty journal")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/label"
+)
+
+func newLabelCmd() *cobra.Command {
+	var (
+		language   string
+		qr         bool
+		payload    string
+		size       int
+		outputPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "label <pid>",
+		Short: "Print a plant tag label or QR code",
+		Long: `Print a short text label with the plant's name and care ranges, or with
+--qr a PNG QR code for printing on plant tags.
+
+The QR code links to the plant's OpenPlantbook page, or with --payload json
+carries the care thresholds themselves, so they can be read without a
+network connection.
+
+Examples:
+  openplantbook label monstera-deliciosa
+  openplantbook label monstera-deliciosa --qr -o monstera.png
+  openplantbook label monstera-deliciosa --qr --payload json --size 512 > tag.png`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			qrOpts := &label.Options{Payload: payload, Size: size}
+			if qr {
+				if payload != label.PayloadLink && payload != label.PayloadJSON {
+					return usagef("invalid --payload %q (use link or json)", payload)
+				}
+				if size < 21 {
+					return usagef("--size must be at least 21 pixels")
+				}
+				if (outputPath == "" || outputPath == "-") && term.IsTerminal(int(os.Stdout.Fd())) {
+					return usagef("refusing to write a PNG to the terminal; use -o or redirect stdout")
+				}
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			defer client.Close()
+
+			pid := strings.ReplaceAll(args[0], "-", " ")
+			details, err := client.GetPlantDetails(cmd.Context(), pid, &openplantbook.DetailOptions{Language: language})
+			if err != nil {
+				return fmt.Errorf("failed to get details for %s: %w", pid, err)
+			}
+
+			var data []byte
+			if qr {
+				if data, err = label.QRCode(details, qrOpts); err != nil {
+					return err
+				}
+			} else {
+				data = []byte(label.Text(details))
+			}
+
+			var w io.Writer = os.Stdout
+			if outputPath != "" && outputPath != "-" {
+				f, err := os.Create(outputPath)
+				if err != nil {
+					return fmt.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			_, err = w.Write(data)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&language, "lang", "en", "Language code (ISO 639-1)")
+	cmd.Flags().BoolVar(&qr, "qr", false, "Write a PNG QR code instead of a text label")
+	cmd.Flags().StringVar(&payload, "payload", label.PayloadLink, "QR code contents: link or json")
+	cmd.Flags().IntVar(&size, "size", label.DefaultSize, "QR code width and height in pixels")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: stdout)")
+	cmd.RegisterFlagCompletionFunc("payload", cobra.FixedCompletions(
+		[]string{label.PayloadLink, label.PayloadJSON}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
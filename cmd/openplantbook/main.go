@@ -3,10 +3,13 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 
 	"github.com/joho/godotenv"
@@ -14,18 +17,46 @@ import (
 	"github.com/spf13/viper"
 
 	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/cmd/openplantbook/internal/render"
 )
 
+// exitInterrupted is the exit status after Ctrl-C, following the shell
+// convention of 128 + SIGINT
+const exitInterrupted = 130
+
 var (
 	version = "dev"
 	commit  = "unknown"
 	date    = "unknown"
 
 	cfgFile string
+
+	// errNoCredentials reports that neither an API key nor OAuth2
+	// credentials are configured
+	errNoCredentials = errors.New("no authentication provided: set OPENPLANTBOOK_API_KEY or OPENPLANTBOOK_CLIENT_ID/CLIENT_SECRET")
+
+	// configErr is a configuration problem found by initConfig, reported
+	// before any command runs
+	configErr error
 )
 
 func main() {
-	if err := newRootCmd().Execute(); err != nil {
+	// Ctrl-C and SIGTERM cancel the running command's context, so rate
+	// limit waits end and batch commands can save their progress
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop() // a second Ctrl-C kills the process
+	}()
+
+	rootCmd := newRootCmd()
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		reportError(os.Stderr, err, errorFormat(rootCmd, os.Args[1:]))
+		if ctx.Err() != nil {
+			os.Exit(exitInterrupted)
+		}
 		os.Exit(1)
 	}
 }
@@ -39,33 +70,88 @@ a crowd-sourced database of plant care information.
 
 Get your free API credentials at: https://open.plantbook.io/`,
 		SilenceUsage: true,
+
+		// Errors are written by main, honoring --error-format
+		SilenceErrors: true,
+
+		// Generated docs omit the "Auto generated by spf13/cobra on <date>"
+		// footer so that package builds are reproducible
+		DisableAutoGenTag: true,
+
+		// gen completion replaces the default completion command, which is
+		// kept (hidden) for existing shell setups
+		CompletionOptions: cobra.CompletionOptions{HiddenDefaultCmd: true},
+
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return configErr
+		},
 	}
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.openplantbook.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $XDG_CONFIG_HOME/openplantbook/config.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "Named profile from the config file's profiles section")
 	rootCmd.PersistentFlags().String("api-key", "", "OpenPlantbook API key")
 	rootCmd.PersistentFlags().String("client-id", "", "OAuth2 client ID")
 	rootCmd.PersistentFlags().String("client-secret", "", "OAuth2 client secret")
 	rootCmd.PersistentFlags().String("base-url", "", "API base URL (default: https://open.plantbook.io/api/v1)")
+	rootCmd.PersistentFlags().String("token-url", "", "OAuth2 token URL (default: the base URL plus /token/)")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug logging")
+	rootCmd.PersistentFlags().CountP("verbose", "v", "Report cache hits and misses, rate limit waits and retries on stderr (-vv adds URLs and cache keys)")
+	rootCmd.PersistentFlags().Bool("http-debug", false, "Dump HTTP requests and responses to stderr (credentials redacted)")
+	rootCmd.PersistentFlags().String("audit-log", "", "Append a JSON line per API request to this file")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().String("error-format", errorFormatText, "Error output format on stderr: text or json")
+	rootCmd.RegisterFlagCompletionFunc("error-format", cobra.FixedCompletions(
+		[]string{errorFormatText, errorFormatJSON}, cobra.ShellCompDirectiveNoFileComp))
 
 	// Bind flags to viper
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag("api-key", rootCmd.PersistentFlags().Lookup("api-key"))
 	viper.BindPFlag("client-id", rootCmd.PersistentFlags().Lookup("client-id"))
 	viper.BindPFlag("client-secret", rootCmd.PersistentFlags().Lookup("client-secret"))
 	viper.BindPFlag("base-url", rootCmd.PersistentFlags().Lookup("base-url"))
+	viper.BindPFlag("token-url", rootCmd.PersistentFlags().Lookup("token-url"))
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("http-debug", rootCmd.PersistentFlags().Lookup("http-debug"))
+	viper.BindPFlag("audit-log", rootCmd.PersistentFlags().Lookup("audit-log"))
+	viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
+	viper.BindPFlag("error-format", rootCmd.PersistentFlags().Lookup("error-format"))
+
+	// Add commands, grouped in help output and generated docs
+	rootCmd.AddGroup(
+		&cobra.Group{ID: groupPlants, Title: "Plant Data:"},
+		&cobra.Group{ID: groupCollection, Title: "Your Plants:"},
+		&cobra.Group{ID: groupTools, Title: "Diagnostics and Tools:"},
+	)
+	addGroupCommands(rootCmd, groupPlants, newSearchCmd(), newDetailsCmd(), newCompareCmd(), newExportCmd(), newLabelCmd())
+	addGroupCommands(rootCmd, groupCollection, newMyCmd(), newScheduleCmd(), newSensorCmd())
+	addGroupCommands(rootCmd, groupTools, newStatusCmd(), newConfigCmd(), newGenCmd(), newVersionCmd())
+	rootCmd.SetHelpCommandGroupID(groupTools)
+	rootCmd.SetCompletionCommandGroupID(groupTools)
 
-	// Add commands
-	rootCmd.AddCommand(newSearchCmd())
-	rootCmd.AddCommand(newDetailsCmd())
-	rootCmd.AddCommand(newVersionCmd())
+	markUsageErrors(rootCmd)
 
 	cobra.OnInitialize(initConfig)
 
 	return rootCmd
 }
 
+// Command groups of the root command
+const (
+	groupPlants     = "plants"
+	groupCollection = "collection"
+	groupTools      = "tools"
+)
+
+// addGroupCommands adds cmds to root under the group with the given ID
+func addGroupCommands(root *cobra.Command, group string, cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.GroupID = group
+		root.AddCommand(cmd)
+	}
+}
+
 func initConfig() {
 	// Load .env file if it exists (silently ignore if not found)
 	_ = godotenv.Load()
@@ -84,30 +170,26 @@ func initConfig() {
 		} else if viper.GetBool("debug") {
 			fmt.Fprintf(os.Stderr, "Using config file: %s\n", viper.ConfigFileUsed())
 		}
-	} else {
-		// Search for config in home directory
-		home, err := os.UserHomeDir()
-		if err == nil {
-			viper.AddConfigPath(home)
-		}
-
-		// Also search in current directory
-		viper.AddConfigPath(".")
-		viper.SetConfigName(".openplantbook")
-		viper.SetConfigType("yaml")
-
-		// Try to read config file (ignore error if not found)
-		if err := viper.ReadInConfig(); err == nil && viper.GetBool("debug") {
+	} else if path, found := findConfigFile(); found {
+		viper.SetConfigFile(path)
+		if err := viper.ReadInConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading config file: %v\n", err)
+		} else if viper.GetBool("debug") {
 			fmt.Fprintf(os.Stderr, "Using config file: %s\n", viper.ConfigFileUsed())
 		}
 	}
+
+	configErr = applyProfile(viper.GetString("profile"))
 }
 
 func newSearchCmd() *cobra.Command {
 	var (
 		limit      int
 		userPlants bool
+		dedupe     bool
+		match      string
 		jsonOutput bool
+		output     string
 	)
 
 	cmd := &cobra.Command{
@@ -118,26 +200,46 @@ func newSearchCmd() *cobra.Command {
 Examples:
   openplantbook search monstera
   openplantbook search fern --limit 5
-  openplantbook search monstera --json`,
-		Args: cobra.ExactArgs(1),
+  openplantbook search pothos --dedupe
+  openplantbook search 'mons*'
+  openplantbook search ficus --match exact
+  openplantbook search monstera --json
+  openplantbook search fern --output ndjson | jq -r .pid`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := args[0]
 
+			format, err := resolveOutput(output, jsonOutput)
+			if err != nil {
+				return err
+			}
+			var mode openplantbook.MatchMode
+			if err := mode.UnmarshalText([]byte(match)); err != nil {
+				return &usageError{err}
+			}
+
 			client, err := createClient()
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
 			}
+			defer client.Close()
 
-			results, err := client.SearchPlants(context.Background(), query, &openplantbook.SearchOptions{
+			results, err := client.SearchPlants(cmd.Context(), query, &openplantbook.SearchOptions{
 				Limit:      limit,
 				UserPlants: userPlants,
+				Dedupe:     dedupe,
+				Match:      mode,
 			})
 			if err != nil {
 				return fmt.Errorf("search failed: %w", err)
 			}
 
-			if jsonOutput {
+			switch format {
+			case formatJSON:
 				return outputJSON(results)
+			case formatNDJSON:
+				return outputNDJSON(results)
 			}
 
 			return outputSearchResults(results)
@@ -146,28 +248,116 @@ Examples:
 
 	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of results to return")
 	cmd.Flags().BoolVar(&userPlants, "user-plants", false, "Include user-contributed plants")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
+	cmd.Flags().BoolVar(&dedupe, "dedupe", false, "Merge results with the same scientific name")
+	cmd.Flags().StringVar(&match, "match", "contains", "Match mode: contains, prefix or exact (a trailing * also selects prefix)")
+	cmd.RegisterFlagCompletionFunc("match", cobra.FixedCompletions(
+		[]string{"contains", "prefix", "exact"}, cobra.ShellCompDirectiveNoFileComp))
+	addOutputFlags(cmd, &output, &jsonOutput)
 
 	return cmd
 }
 
 func newDetailsCmd() *cobra.Command {
 	var (
-		language   string
-		jsonOutput bool
+		language     string
+		jsonOutput   bool
+		output       string
+		file         string
+		failuresPath string
+		estimate     bool
+		resume       bool
+		userPlants   bool
+		ec           float64
+		ecUnit       string
+		sensor       string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "details <pid>",
+		Use:   "details <pid> | --file <pids.txt>",
 		Short: "Get detailed care information for a plant",
 		Long: `Retrieve detailed care information for a specific plant by its PID.
 
+With --file, details are fetched for every PID in the file (one per line;
+blank lines and # comments are ignored). With --output json or ndjson,
+results are streamed as NDJSON, one object per line. PIDs that fail are
+written to a failures file (default: <file>.failed) that can be passed back
+to --file to retry them. Progress is journaled in <file>.journal while the
+run is incomplete; --resume continues an interrupted or partly failed run,
+requesting only the PIDs it has not fetched yet. --estimate reports how many
+API requests the file needs, and whether they fit in today's quota, without
+fetching.
+
+With --user-plants, the PID is also looked up among your user-contributed
+plants; such records are marked as unverified.
+
+--lang takes a comma-separated fallback chain: with --lang de,en a name or
+category missing in German is filled in from the English record.
+
+--ec interprets a soil EC reading against the plant's thresholds: whether
+fertilization is due or the soil is over-fertilized. The reading is in µS/cm
+unless --ec-unit says otherwise; --sensor applies the calibration of a common
+sensor (miflora, ecowitt). With --json the guidance is added as "fertility".
+
 Examples:
   openplantbook details monstera-deliciosa
   openplantbook details monstera-deliciosa --lang es
-  openplantbook details monstera-deliciosa --json`,
-		Args: cobra.ExactArgs(1),
+  openplantbook details monstera-deliciosa --lang de,en
+  openplantbook details my-balcony-fig --user-plants
+  openplantbook details monstera-deliciosa --ec 1.2 --ec-unit mS/cm
+  openplantbook details monstera-deliciosa --json
+  openplantbook details --file pids.txt --estimate
+  openplantbook details --file pids.txt --output json > details.ndjson
+  openplantbook details --file pids.txt --resume --output json >> details.ndjson`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := resolveOutput(output, jsonOutput)
+			if err != nil {
+				return err
+			}
+			opts := &openplantbook.DetailOptions{UserPlants: userPlants}
+			opts.Language, opts.Languages = splitLanguages(language)
+
+			if (estimate || resume) && file == "" {
+				return usagef("--estimate and --resume require --file")
+			}
+			var reading *float64
+			switch {
+			case cmd.Flags().Changed("ec"):
+				if file != "" {
+					return usagef("--ec takes a single PID, not --file")
+				}
+				v, err := calibratedEC(ec, ecUnit, sensor)
+				if err != nil {
+					return err
+				}
+				reading = &v
+			case ecUnit != "" || sensor != "":
+				return usagef("--ec-unit and --sensor require --ec")
+			}
+			if file != "" {
+				if failuresPath == "" {
+					failuresPath = file + ".failed"
+				}
+				job := batchJob{
+					path:         file,
+					failuresPath: failuresPath,
+					journalPath:  file + ".journal",
+					resume:       resume,
+					format:       format,
+					opts:         opts,
+				}
+				if estimate {
+					return estimateBatchDetails(job)
+				}
+				return runBatchDetails(cmd.Context(), job)
+			}
+
 			// Normalize PID: convert hyphens to spaces (e.g., "monstera-deliciosa" -> "monstera deliciosa")
 			// This allows users to use either format for convenience
 			pid := strings.ReplaceAll(args[0], "-", " ")
@@ -176,28 +366,71 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
 			}
+			defer client.Close()
 
-			details, err := client.GetPlantDetails(context.Background(), pid, &openplantbook.DetailOptions{
-				Language: language,
-			})
+			details, err := client.GetPlantDetails(cmd.Context(), pid, opts)
 			if err != nil {
 				return fmt.Errorf("failed to get details: %w", err)
 			}
 
-			if jsonOutput {
-				return outputJSON(details)
+			var report any = details
+			if reading != nil {
+				r := detailsReport{PlantDetails: details}
+				if f, ok := care.FertilityGuidance(details, *reading); ok {
+					r.Fertility = &f
+				}
+				report = r
+			}
+			switch format {
+			case formatJSON:
+				return outputJSON(report)
+			case formatNDJSON:
+				return newNDJSONWriter().Write(report)
 			}
 
-			return outputPlantDetails(details)
+			return outputPlantDetails(details, reading)
 		},
 	}
 
-	cmd.Flags().StringVar(&language, "lang", "en", "Language code (ISO 639-1)")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
+	cmd.Flags().StringVar(&language, "lang", "en", "Language code (ISO 639-1), or a comma-separated fallback chain")
+	addOutputFlags(cmd, &output, &jsonOutput)
+	cmd.Flags().StringVar(&file, "file", "", "File of PIDs to fetch, one per line")
+	cmd.Flags().StringVar(&failuresPath, "failures", "", "Where to write PIDs that failed (default: <file>.failed)")
+	cmd.Flags().BoolVar(&estimate, "estimate", false, "Report the API requests --file needs without fetching")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Continue an interrupted --file run, skipping PIDs already fetched")
+	cmd.Flags().BoolVar(&userPlants, "user-plants", false, "Include user-contributed plants")
+	cmd.Flags().Float64Var(&ec, "ec", 0, "Soil EC reading to interpret as fertilization guidance")
+	cmd.Flags().StringVar(&ecUnit, "ec-unit", "", "Unit of --ec: µS/cm (uS/cm) or mS/cm (default: the sensor's, else µS/cm)")
+	cmd.Flags().StringVar(&sensor, "sensor", "", "Sensor whose calibration applies to --ec: miflora or ecowitt")
 
 	return cmd
 }
 
+// detailsReport is the JSON form of the details command with --ec
+type detailsReport struct {
+	*openplantbook.PlantDetails
+	Fertility *care.Fertility `json:"fertility,omitempty"`
+}
+
+// calibratedEC converts an --ec reading to calibrated µS/cm
+func calibratedEC(value float64, unit, sensor string) (float64, error) {
+	var cal care.Calibration
+	if sensor != "" {
+		var ok bool
+		if cal, ok = care.CalibrationFor(sensor); !ok {
+			return 0, usagef("unknown --sensor %q (want miflora or ecowitt)", sensor)
+		}
+	}
+	if unit != "" {
+		u, err := care.ParseECUnit(unit)
+		if err != nil {
+			return 0, usagef("invalid --ec-unit: %v", err)
+		}
+		cal.Unit = u
+	}
+	return cal.Apply(value), nil
+}
+
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
@@ -224,13 +457,25 @@ func createClient() (*openplantbook.Client, error) {
 	} else if clientID != "" && clientSecret != "" {
 		opts = append(opts, openplantbook.WithOAuth2(clientID, clientSecret))
 	} else {
-		return nil, fmt.Errorf("no authentication provided: set OPENPLANTBOOK_API_KEY or OPENPLANTBOOK_CLIENT_ID/CLIENT_SECRET")
+		return nil, errNoCredentials
 	}
 
 	// Optional base URL override
 	if baseURL := viper.GetString("base-url"); baseURL != "" {
 		opts = append(opts, openplantbook.WithBaseURL(baseURL))
 	}
+	if tokenURL := viper.GetString("token-url"); tokenURL != "" {
+		opts = append(opts, openplantbook.WithTokenURL(tokenURL))
+	}
+
+	// Responses are cached on disk so repeated invocations share them
+	if dir, err := cacheDir(); err == nil {
+		if cache, err := openplantbook.NewFileCache(dir); err == nil {
+			opts = append(opts, openplantbook.WithCache(cache))
+		} else if viper.GetBool("debug") {
+			fmt.Fprintf(os.Stderr, "Using in-memory cache: %v\n", err)
+		}
+	}
 
 	// Debug logging
 	if viper.GetBool("debug") {
@@ -239,6 +484,20 @@ func createClient() (*openplantbook.Client, error) {
 		}))}
 		opts = append(opts, openplantbook.WithLogger(logger))
 	}
+	if level := viper.GetInt("verbose"); level > 0 {
+		opts = append(opts, openplantbook.WithHooks(traceHooks(os.Stderr, level)))
+	}
+	if viper.GetBool("http-debug") {
+		opts = append(opts, openplantbook.WithHTTPDebug(os.Stderr))
+	}
+	if path := viper.GetString("audit-log"); path != "" {
+		// Left open until exit; writes are unbuffered
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		opts = append(opts, openplantbook.WithAuditLog(f))
+	}
 
 	return openplantbook.New(opts...)
 }
@@ -253,29 +512,104 @@ func outputSearchResults(results []openplantbook.PlantSearchResult) error {
 	fmt.Fprintln(w, "SCIENTIFIC NAME\tCOMMON NAME\tPID\tCATEGORY")
 	fmt.Fprintln(w, "---------------\t-----------\t---\t--------")
 	for _, plant := range results {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", plant.DisplayPID, plant.Alias, plant.PID, plant.Category)
+		alias := plant.Alias
+		if len(plant.MergedAliases) > 0 {
+			alias += " (" + strings.Join(plant.MergedAliases, ", ") + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", plant.ScientificName(), alias, plant.PID, plant.Category)
 	}
 	w.Flush()
 	fmt.Printf("\nFound %d plant(s)\n", len(results))
 	return nil
 }
 
-func outputPlantDetails(details *openplantbook.PlantDetails) error {
-	fmt.Printf("Plant: %s\n", details.DisplayPID)
-	fmt.Printf("Common Name: %s\n", details.Alias)
-	fmt.Printf("PID: %s\n", details.PID)
-	fmt.Printf("Category: %s\n\n", details.Category)
+// careRow is one line of the care requirements card
+type careRow struct {
+	icon, label string
+	field       string // QualityIssue field
+	value       string
+	lo, hi      float64
+	scale       render.Scale
+}
+
+// careColumns is the width of everything on a care row except the bar
+const careColumns = 42
+
+// outputPlantDetails prints the care card; ec, if set, is a soil EC reading
+// in µS/cm to give fertilization guidance for
+func outputPlantDetails(details *openplantbook.PlantDetails, ec *float64) error {
+	r := render.New(os.Stdout, viper.GetBool("no-color"))
+	quality := details.QualityScore()
+
+	issues := make(map[string]string)
+	for _, issue := range quality.Issues {
+		issues[issue.Field] = issue.Problem
+	}
+
+	fmt.Printf("🌿 %s\n", r.Paint(render.Bold, details.FormattedName(details.Language)))
+	fmt.Println(r.Paint(render.Dim, fmt.Sprintf("   PID: %s · Category: %s", details.PID, details.Category)))
+	if prov := details.Provenance(); prov.UserContributed {
+		fmt.Printf("   %s %s\n", r.Mark(render.Warning), r.Paint(render.SeverityStyle(render.Warning), "Unverified "+prov.String()))
+	}
 
-	fmt.Println("Care Requirements:")
-	fmt.Println("==================")
-	fmt.Printf("Light (Lux):       %d - %d\n", details.MinLightLux, details.MaxLightLux)
-	fmt.Printf("Temperature (°C):  %.1f - %.1f\n", details.MinTemp, details.MaxTemp)
-	fmt.Printf("Humidity (%%):      %d - %d\n", details.MinEnvHumid, details.MaxEnvHumid)
-	fmt.Printf("Soil Moisture (%%): %d - %d\n", details.MinSoilMoist, details.MaxSoilMoist)
-	fmt.Printf("Soil EC (μS/cm):   %d - %d\n", details.MinSoilEC, details.MaxSoilEC)
+	rows := []careRow{
+		{"🔆", "Light", "light_lux", fmt.Sprintf("%d–%d lux", details.MinLightLux, details.MaxLightLux),
+			float64(details.MinLightLux), float64(details.MaxLightLux), render.Scale{Min: 100, Max: 100000, Log: true}},
+		{"🌡️", "Temperature", "temp", fmt.Sprintf("%.1f–%.1f °C", details.MinTemp, details.MaxTemp),
+			details.MinTemp, details.MaxTemp, render.Scale{Min: -10, Max: 45}},
+		{"💧", "Humidity", "env_humid", fmt.Sprintf("%d–%d %%", details.MinEnvHumid, details.MaxEnvHumid),
+			float64(details.MinEnvHumid), float64(details.MaxEnvHumid), render.Scale{Min: 0, Max: 100}},
+		{"🌱", "Soil moisture", "soil_moist", fmt.Sprintf("%d–%d %%", details.MinSoilMoist, details.MaxSoilMoist),
+			float64(details.MinSoilMoist), float64(details.MaxSoilMoist), render.Scale{Min: 0, Max: 100}},
+		{"⚡", "Soil EC", "soil_ec", fmt.Sprintf("%d–%d μS/cm", details.MinSoilEC, details.MaxSoilEC),
+			float64(details.MinSoilEC), float64(details.MaxSoilEC), render.Scale{Min: 0, Max: 3000}},
+	}
+
+	fmt.Printf("\n%s\n", r.Paint(render.Bold, "Care Requirements"))
+	width := r.BarWidth(careColumns)
+	for _, row := range rows {
+		problem, flagged := issues[row.field]
+		if problem == "missing" {
+			fmt.Printf("%s %-14s %s %s\n", row.icon, row.label, r.Mark(render.Problem), r.Paint(render.Dim, "not recorded"))
+			continue
+		}
+
+		mark := " "
+		if flagged {
+			mark = r.Mark(render.Warning)
+		}
+		fmt.Printf("%s %-14s %s %-18s %s\n", row.icon, row.label, mark, row.value, r.Bar(row.scale, row.lo, row.hi, width))
+	}
+
+	if advice, ok := care.FertilityAdvice(details); ok {
+		fmt.Println(r.Paint(render.Dim, "   "+advice))
+	}
+	if ec != nil {
+		if f, ok := care.FertilityGuidance(details, *ec); ok {
+			sev := render.OK
+			if f.Status != care.StatusOK {
+				sev = render.Warning
+			}
+			fmt.Printf("\n%s %s\n", r.Mark(sev), r.Paint(render.SeverityStyle(sev), f.Advice))
+		} else {
+			fmt.Printf("\n%s %s\n", r.Mark(render.Warning), "No soil EC thresholds to compare the reading with")
+		}
+	}
 
 	if details.ImageURL != "" {
-		fmt.Printf("\nImage: %s\n", details.ImageURL)
+		fmt.Printf("\n🖼️ %s\n", r.Paint(render.Cyan, details.ImageURL))
+	}
+
+	sev := render.OK
+	switch {
+	case quality.Score < 50:
+		sev = render.Problem
+	case quality.Score < 80:
+		sev = render.Warning
+	}
+	fmt.Printf("\nRecord quality: %s %s\n", r.Mark(sev), r.Paint(render.SeverityStyle(sev), fmt.Sprintf("%d/100", quality.Score)))
+	for _, issue := range quality.Issues {
+		fmt.Printf("  %s %s\n", r.Mark(render.Warning), issue)
 	}
 	return nil
 }
@@ -286,6 +620,21 @@ func outputJSON(v interface{}) error {
 	return encoder.Encode(v)
 }
 
+// splitLanguages splits a --lang value such as "de,en" into the language
+// and its fallbacks
+func splitLanguages(value string) (string, []string) {
+	var langs []string
+	for _, lang := range strings.Split(value, ",") {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			langs = append(langs, lang)
+		}
+	}
+	if len(langs) == 0 {
+		return "", nil
+	}
+	return langs[0], langs[1:]
+}
+
 // cliLogger implements the openplantbook.Logger interface
 type cliLogger struct {
 	logger *slog.Logger
@@ -0,0 +1,600 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/collection"
+)
+
+func newMyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "my",
+		Short: "Manage your own plant collection",
+		Long: `Manage the plants you own: add them with a nickname and location,
+list them with their care requirements, and remove them again.
+
+The collection is stored locally as JSON (default: $XDG_DATA_HOME/openplantbook/collection.json).`,
+	}
+
+	cmd.PersistentFlags().String("collection", "", "Collection file (default: $XDG_DATA_HOME/openplantbook/collection.json)")
+	viper.BindPFlag("collection", cmd.PersistentFlags().Lookup("collection"))
+
+	cmd.AddCommand(newMyAddCmd())
+	cmd.AddCommand(newMyListCmd())
+	cmd.AddCommand(newMyRemoveCmd())
+	cmd.AddCommand(newMyZonesCmd())
+	cmd.AddCommand(newMyAlertCmd())
+	cmd.AddCommand(newMySyncCmd())
+
+	return cmd
+}
+
+func newMyAddCmd() *cobra.Command {
+	var (
+		nickname string
+		location string
+		acquired string
+		notes    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <pid>",
+		Short: "Add a plant to your collection",
+		Long: `Add a plant to your collection.
+
+Examples:
+  openplantbook my add monstera-deliciosa --nickname Monty --location "Living room"
+  openplantbook my add ficus-lyrata --acquired 2024-05-01`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := openCollection()
+			if err != nil {
+				return err
+			}
+
+			p := collection.Plant{
+				PID:      strings.ReplaceAll(args[0], "-", " "),
+				Nickname: nickname,
+				Location: location,
+				Notes:    notes,
+			}
+			if acquired != "" {
+				p.Acquired, err = time.Parse("2006-01-02", acquired)
+				if err != nil {
+					return usagef("invalid --acquired date (want YYYY-MM-DD): %w", err)
+				}
+			}
+
+			p, err = c.Add(p)
+			if err != nil {
+				return fmt.Errorf("failed to add plant: %w", err)
+			}
+
+			fmt.Printf("Added %s (%s) with id %s\n", p.Name(), p.PID, p.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&nickname, "nickname", "", "Nickname for the plant")
+	cmd.Flags().StringVar(&location, "location", "", "Where the plant is kept (room, windowsill, ...)")
+	cmd.Flags().StringVar(&acquired, "acquired", "", "Acquisition date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&notes, "notes", "", "Free-form notes")
+
+	return cmd
+}
+
+func newMyListCmd() *cobra.Command {
+	var (
+		withDetails bool
+		jsonOutput  bool
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the plants in your collection",
+		Long: `List the plants in your collection.
+
+With --details, care requirements are fetched for each plant (cached
+details are reused, so repeated runs do not consume API quota).
+
+Examples:
+  openplantbook my list
+  openplantbook my list --details
+  openplantbook my list --details --json
+  openplantbook my list --details --output ndjson`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := resolveOutput(output, jsonOutput)
+			if err != nil {
+				return err
+			}
+
+			c, err := openCollection()
+			if err != nil {
+				return err
+			}
+
+			if !withDetails {
+				switch format {
+				case formatJSON:
+					return outputJSON(c.List())
+				case formatNDJSON:
+					return outputNDJSON(c.List())
+				}
+				return outputCollection(c.List())
+			}
+
+			if format == formatNDJSON {
+				return streamCollectionDetails(cmd.Context(), c)
+			}
+
+			entries, err := enrichCollection(cmd.Context(), c)
+			if err != nil {
+				return err
+			}
+
+			if format == formatJSON {
+				return outputJSON(entries)
+			}
+			return outputCollectionDetails(entries)
+		},
+	}
+
+	cmd.Flags().BoolVar(&withDetails, "details", false, "Include care requirements for each plant")
+	addOutputFlags(cmd, &output, &jsonOutput)
+
+	return cmd
+}
+
+func newMyRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove <id|nickname>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove a plant from your collection",
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := openCollection()
+			if err != nil {
+				return err
+			}
+
+			p, err := c.Remove(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to remove %q: %w", args[0], err)
+			}
+
+			fmt.Printf("Removed %s (%s)\n", p.Name(), p.PID)
+			return nil
+		},
+	}
+}
+
+func newMyAlertCmd() *cobra.Command {
+	var (
+		wait       time.Duration
+		hysteresis float64
+		remove     bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "alert <id|nickname> [metric]",
+		Short: "Show or set a plant's alert rules",
+		Long: `Show or set the rules that debounce care alerts for a plant.
+
+--for is how long a metric must stay out of range before an alert is raised,
+and --hysteresis how far back inside the range (in the metric's unit) it must
+return before the alert clears. Together they stop alerts flapping when a
+reading hovers around a threshold. The rules are stored with the collection.
+
+Metrics: light, temperature, humidity, soil_moisture, soil_ec
+
+Examples:
+  openplantbook my alert monty
+  openplantbook my alert monty soil_moisture --for 2h --hysteresis 5
+  openplantbook my alert monty soil_moisture --clear`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := openCollection()
+			if err != nil {
+				return err
+			}
+			p, err := c.Get(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find %q: %w", args[0], err)
+			}
+
+			if len(args) == 2 {
+				metric := care.Metric(args[1])
+				if !slices.Contains(care.Metrics, metric) {
+					return usagef("unknown metric %q", args[1])
+				}
+				p.Alerts = maps.Clone(p.Alerts)
+				if remove {
+					delete(p.Alerts, metric)
+				} else {
+					if p.Alerts == nil {
+						p.Alerts = care.AlertRules{}
+					}
+					p.Alerts[metric] = care.AlertRule{Hysteresis: hysteresis, For: wait}
+				}
+				if err := c.Update(p); err != nil {
+					return fmt.Errorf("failed to update %s: %w", p.Name(), err)
+				}
+			}
+
+			if jsonOutput {
+				return outputJSON(p.Alerts)
+			}
+			if len(p.Alerts) == 0 {
+				fmt.Printf("%s has no alert rules; alerts are raised and cleared immediately\n", p.Name())
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "METRIC\tFOR\tHYSTERESIS")
+			for _, metric := range care.Metrics {
+				if rule, ok := p.Alerts[metric]; ok {
+					fmt.Fprintf(w, "%s\t%s\t%g %s\n", metric, rule.For, rule.Hysteresis, metric.Unit())
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().DurationVar(&wait, "for", 0, "How long a metric must be out of range before alerting")
+	cmd.Flags().Float64Var(&hysteresis, "hysteresis", 0, "How far back inside the range a metric must be to clear an alert")
+	cmd.Flags().BoolVar(&remove, "clear", false, "Remove the metric's rule")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.MarkFlagsMutuallyExclusive("clear", "for")
+	cmd.MarkFlagsMutuallyExclusive("clear", "hysteresis")
+
+	return cmd
+}
+
+// syncActionReport is the JSON form of a sync action
+type syncActionReport struct {
+	Kind     collection.ActionKind `json:"kind"`
+	Name     string                `json:"name"`
+	PID      string                `json:"pid"`
+	ID       string                `json:"id,omitempty"`
+	RemoteID string                `json:"remote_id,omitempty"`
+	Error    string                `json:"error,omitempty"`
+}
+
+func newMySyncCmd() *cobra.Command {
+	var (
+		remotePath string
+		dryRun     bool
+		prefer     string
+		pushOnly   bool
+		pullOnly   bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync --remote <file>",
+		Short: "Synchronize your collection with another collection",
+		Long: `Two-way sync between your collection and a remote collection file, such as
+one in a folder shared between machines. New plants are copied both ways,
+and a plant changed on one side since the last sync overwrites the other.
+Deletions are not propagated.
+
+A plant changed on both sides is a conflict: it is reported and left alone
+unless --prefer picks a side. --dry-run shows the plan without changing
+anything.
+
+The SDK does not yet expose OpenPlantbook's plant instance endpoints, so
+plants cannot be synced with your OpenPlantbook account yet.
+
+Examples:
+  openplantbook my sync --remote ~/Dropbox/plants.json --dry-run
+  openplantbook my sync --remote ~/Dropbox/plants.json
+  openplantbook my sync --remote ~/Dropbox/plants.json --prefer local
+  openplantbook my sync --remote ~/Dropbox/plants.json --pull`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := &collection.SyncOptions{DryRun: dryRun}
+			switch prefer {
+			case "":
+			case "local":
+				opts.Policy = collection.PreferLocal
+			case "remote":
+				opts.Policy = collection.PreferRemote
+			default:
+				return usagef("invalid --prefer %q (want local or remote)", prefer)
+			}
+			switch {
+			case pushOnly:
+				opts.Direction = collection.SyncPush
+			case pullOnly:
+				opts.Direction = collection.SyncPull
+			}
+
+			c, err := openCollection()
+			if err != nil {
+				return err
+			}
+			remote := collection.NewStoreRemote(collection.NewJSONStore(remotePath))
+			report, syncErr := c.Sync(cmd.Context(), remote, opts)
+			if report == nil {
+				return fmt.Errorf("failed to sync: %w", syncErr)
+			}
+
+			if jsonOutput {
+				actions := make([]syncActionReport, 0, len(report.Actions))
+				for _, a := range report.Actions {
+					r := syncActionReport{Kind: a.Kind, Name: a.Local.Name(), PID: a.Local.PID, ID: a.Local.ID, RemoteID: a.Remote.ID}
+					if a.Local.PID == "" {
+						r.Name, r.PID = a.Remote.Name, a.Remote.PID
+					}
+					if a.Err != nil {
+						r.Error = a.Err.Error()
+					}
+					actions = append(actions, r)
+				}
+				if err := outputJSON(actions); err != nil {
+					return err
+				}
+			} else {
+				outputSyncReport(report)
+			}
+			if syncErr != nil {
+				return fmt.Errorf("failed to sync: %w", syncErr)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&remotePath, "remote", "", "Remote collection file to sync with")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the planned changes without applying them")
+	cmd.Flags().StringVar(&prefer, "prefer", "", "Resolve conflicts in favor of \"local\" or \"remote\" (default: skip them)")
+	cmd.Flags().BoolVar(&pushOnly, "push", false, "Only copy local changes to the remote")
+	cmd.Flags().BoolVar(&pullOnly, "pull", false, "Only copy remote changes into your collection")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.MarkFlagRequired("remote")
+	cmd.MarkFlagsMutuallyExclusive("push", "pull")
+
+	return cmd
+}
+
+func outputSyncReport(report *collection.SyncReport) {
+	if len(report.Actions) == 0 {
+		fmt.Println("Already in sync")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tNAME\tPID\tRESULT")
+	for _, a := range report.Actions {
+		name, pid := a.Local.Name(), a.Local.PID
+		if pid == "" {
+			name, pid = a.Remote.Name, a.Remote.PID
+		}
+		result := "ok"
+		switch {
+		case report.DryRun:
+			result = "planned"
+		case a.Kind == collection.ActionConflict:
+			result = "skipped (use --prefer)"
+		case a.Err != nil:
+			result = "failed: " + a.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Kind, name, pid, result)
+	}
+	w.Flush()
+
+	if report.DryRun {
+		fmt.Printf("\n%d change(s) planned (dry run, nothing applied)\n", len(report.Actions))
+	}
+}
+
+func newMyZonesCmd() *cobra.Command {
+	var (
+		jsonOutput bool
+		output     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "zones",
+		Short: "Show combined care requirements per location",
+		Long: `Group your plants by location and show the conditions that satisfy
+every plant in each zone, along with any conflicts where no overlap exists.
+
+Examples:
+  openplantbook my zones
+  openplantbook my zones --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := resolveOutput(output, jsonOutput)
+			if err != nil {
+				return err
+			}
+
+			c, err := openCollection()
+			if err != nil {
+				return err
+			}
+
+			entries, err := enrichCollection(cmd.Context(), c)
+			if err != nil {
+				return err
+			}
+
+			zones := collection.Zones(entries)
+			switch format {
+			case formatJSON:
+				return outputJSON(zones)
+			case formatNDJSON:
+				return outputNDJSON(zones)
+			}
+			return outputZones(zones)
+		},
+	}
+
+	addOutputFlags(cmd, &output, &jsonOutput)
+
+	return cmd
+}
+
+// collectionPath resolves the collection file location
+func collectionPath() (string, error) {
+	if path := viper.GetString("collection"); path != "" {
+		return path, nil
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		legacy := filepath.Join(home, legacyDataDir, "collection.json")
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy, nil
+		}
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine data directory (use --collection): %w", err)
+	}
+	return filepath.Join(dir, "collection.json"), nil
+}
+
+func openCollection() (*collection.Collection, error) {
+	path, err := collectionPath()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := collection.Open(collection.NewJSONStore(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open collection: %w", err)
+	}
+	return c, nil
+}
+
+// enrichCollection fetches details for every plant, warning about failures on stderr
+func enrichCollection(ctx context.Context, c *collection.Collection) ([]collection.Entry, error) {
+	client, err := createClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	entries, err := c.Enrich(ctx, client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details: %w", err)
+	}
+	for _, e := range entries {
+		if e.Err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s (%s): %v\n", e.Plant.Name(), e.Plant.PID, e.Err)
+		}
+	}
+	return entries, nil
+}
+
+// entryLine is an NDJSON collection entry, including any lookup error
+type entryLine struct {
+	collection.Entry
+	Error string `json:"error,omitempty"`
+}
+
+// streamCollectionDetails writes each plant with its details as soon as they
+// are fetched
+func streamCollectionDetails(ctx context.Context, c *collection.Collection) error {
+	client, err := createClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	w := newNDJSONWriter()
+	return c.EnrichEach(ctx, client, nil, func(e collection.Entry) error {
+		line := entryLine{Entry: e}
+		if e.Err != nil {
+			line.Error = e.Err.Error()
+		}
+		return w.Write(line)
+	})
+}
+
+func outputCollection(plants []collection.Plant) error {
+	if len(plants) == 0 {
+		fmt.Println("Your collection is empty (add plants with 'openplantbook my add <pid>')")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPID\tLOCATION\tACQUIRED")
+	fmt.Fprintln(w, "--\t----\t---\t--------\t--------")
+	for _, p := range plants {
+		acquired := ""
+		if !p.Acquired.IsZero() {
+			acquired = p.Acquired.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.ID, p.Name(), p.PID, p.Location, acquired)
+	}
+	w.Flush()
+	fmt.Printf("\n%d plant(s) in collection\n", len(plants))
+	return nil
+}
+
+func outputCollectionDetails(entries []collection.Entry) error {
+	if len(entries) == 0 {
+		fmt.Println("Your collection is empty (add plants with 'openplantbook my add <pid>')")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tLOCATION\tLIGHT (LUX)\tTEMP (°C)\tHUMIDITY (%)\tSOIL MOISTURE (%)")
+	fmt.Fprintln(w, "----\t--------\t-----------\t---------\t------------\t-----------------")
+	for _, e := range entries {
+		d := e.Details
+		if d == nil {
+			fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\n", e.Plant.Name(), e.Plant.Location)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d-%d\t%.1f-%.1f\t%d-%d\t%d-%d\n",
+			e.Plant.Name(), e.Plant.Location,
+			d.MinLightLux, d.MaxLightLux, d.MinTemp, d.MaxTemp,
+			d.MinEnvHumid, d.MaxEnvHumid, d.MinSoilMoist, d.MaxSoilMoist)
+	}
+	return w.Flush()
+}
+
+func outputZones(zones []collection.Zone) error {
+	if len(zones) == 0 {
+		fmt.Println("Your collection is empty (add plants with 'openplantbook my add <pid>')")
+		return nil
+	}
+
+	for i, z := range zones {
+		if i > 0 {
+			fmt.Println()
+		}
+		title := fmt.Sprintf("%s (%d plant(s))", z.Name, len(z.Entries))
+		fmt.Println(title)
+		fmt.Println(strings.Repeat("=", utf8.RuneCountInString(title)))
+
+		for _, req := range z.Requirements {
+			fmt.Printf("  %-14s %s %s\n", req.Metric+":", req.Range, req.Metric.Unit())
+		}
+		for _, c := range z.Conflicts {
+			fmt.Printf("  CONFLICT %s\n", c)
+		}
+	}
+	return nil
+}
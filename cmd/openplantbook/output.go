@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Formats accepted by --output
+const (
+	formatTable  = "table"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// addOutputFlags registers --output and the --json shorthand
+func addOutputFlags(cmd *cobra.Command, output *string, jsonOutput *bool) {
+	cmd.Flags().StringVarP(output, "output", "o", formatTable, "Output format: table, json or ndjson (one JSON object per line)")
+	cmd.Flags().BoolVar(jsonOutput, "json", false, "Output results as JSON (same as --output json)")
+	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{formatTable, formatJSON, formatNDJSON}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// resolveOutput validates --output, applying --json
+func resolveOutput(output string, jsonOutput bool) (string, error) {
+	switch output {
+	case formatTable, formatJSON, formatNDJSON:
+	default:
+		return "", usagef("invalid --output %q (want table, json or ndjson)", output)
+	}
+	if jsonOutput {
+		return formatJSON, nil
+	}
+	return output, nil
+}
+
+// ndjsonWriter writes one JSON object per line to stdout
+// Each line is written as soon as it is encoded, so consumers such as jq see
+// results while a long operation is still running.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONWriter() *ndjsonWriter {
+	return &ndjsonWriter{enc: json.NewEncoder(os.Stdout)}
+}
+
+func (w *ndjsonWriter) Write(v interface{}) error {
+	return w.enc.Encode(v)
+}
+
+// outputNDJSON writes each element of items as its own line
+func outputNDJSON[T any](items []T) error {
+	w := newNDJSONWriter()
+	for _, item := range items {
+		if err := w.Write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
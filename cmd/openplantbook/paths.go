@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/viper"
+)
+
+// appName is the directory name used under the base directories
+const appName = "openplantbook"
+
+// Files from before the CLI followed the XDG base directory layout; they are
+// still used when present
+const (
+	legacyConfigName = ".openplantbook" // plus any extension viper reads
+	legacyDataDir    = ".openplantbook"
+)
+
+// configCandidates lists the config files searched, in order: the XDG config
+// file, the legacy dotfile in the home directory, and one in the working
+// directory
+func configCandidates() []string {
+	var paths []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, appName, "config.yaml"))
+	}
+
+	var legacyDirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		legacyDirs = append(legacyDirs, home)
+	}
+	for _, dir := range append(legacyDirs, ".") {
+		for _, ext := range viper.SupportedExts {
+			paths = append(paths, filepath.Join(dir, legacyConfigName+"."+ext))
+		}
+	}
+	return paths
+}
+
+// findConfigFile returns the first existing config file
+// When none exists, found is false and path is where a new config file
+// belongs (empty if no config directory can be determined).
+func findConfigFile() (path string, found bool) {
+	candidates := configCandidates()
+	for _, p := range candidates {
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, true
+		}
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, appName, "config.yaml"), false
+	}
+	return "", false
+}
+
+// cacheDir resolves the response cache directory: the cache-dir setting, or
+// openplantbook under the user cache directory ($XDG_CACHE_HOME on Linux)
+func cacheDir() (string, error) {
+	if dir := viper.GetString("cache-dir"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine cache directory (set cache-dir): %w", err)
+	}
+	return filepath.Join(dir, appName), nil
+}
+
+// dataDir returns the directory for user data such as the collection:
+// $XDG_DATA_HOME/openplantbook, defaulting to ~/.local/share/openplantbook
+// on Unix and the user config directory on macOS and Windows
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, appName), nil
+	}
+
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, appName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", appName), nil
+}
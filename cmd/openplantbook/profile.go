@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// credentialKeys are the settings that choose how the CLI authenticates
+var credentialKeys = []string{"api-key", "client-id", "client-secret"}
+
+// applyProfile overlays the named profile from the config file's profiles
+// section onto its top-level settings
+//
+// Flags and environment variables still take precedence over the profile. A
+// profile that sets any credential replaces the top-level credentials
+// entirely, so an OAuth2 profile is not shadowed by a default API key.
+func applyProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	profiles := viper.GetStringMap("profiles")
+	raw, ok := profiles[name]
+	settings, isMap := raw.(map[string]interface{})
+	if !ok || !isMap {
+		if viper.ConfigFileUsed() == "" {
+			return fmt.Errorf("profile %q not found: no config file", name)
+		}
+		return fmt.Errorf("profile %q not found in %s (available: %v)", name, viper.ConfigFileUsed(), profileNames(profiles))
+	}
+
+	overlay := make(map[string]interface{}, len(settings)+len(credentialKeys))
+	for _, key := range credentialKeys {
+		if _, ok := settings[key]; ok {
+			for _, k := range credentialKeys {
+				overlay[k] = ""
+			}
+			break
+		}
+	}
+	for k, v := range settings {
+		overlay[k] = v
+	}
+	return viper.MergeConfigMap(overlay)
+}
+
+// profileNames returns the names of the configured profiles in sorted order
+func profileNames(profiles map[string]interface{}) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
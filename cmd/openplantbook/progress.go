@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/cmd/openplantbook/internal/render"
+)
+
+// progressColumns is the width of a progress line without its bar
+const progressColumns = 40
+
+// progressBar draws the progress of a long operation on one stderr line
+// It implements openplantbook.Progress. A nil progressBar, returned when
+// stderr is not a terminal or -v tracing is on, draws nothing.
+type progressBar struct {
+	mu    sync.Mutex
+	r     *render.Renderer
+	start time.Time
+	shown bool
+}
+
+// newProgressBar returns a progress bar for stderr, or nil when it would
+// garble redirected output or -v trace lines
+func newProgressBar() *progressBar {
+	if !term.IsTerminal(int(os.Stderr.Fd())) || viper.GetInt("verbose") > 0 {
+		return nil
+	}
+	return &progressBar{r: render.New(os.Stderr, viper.GetBool("no-color")), start: time.Now()}
+}
+
+// Update redraws the line, e.g. "[████░░░░] 12/40, 1 failed, 3m left"
+func (b *progressBar) Update(e openplantbook.ProgressEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	line := fmt.Sprintf("[%s] %d/%d", b.r.Meter(e.Completed, e.Total, b.r.BarWidth(progressColumns)), e.Completed, e.Total)
+	if e.Failed > 0 {
+		line += fmt.Sprintf(", %d failed", e.Failed)
+	}
+	if e.Completed > 0 && !e.Done() {
+		perItem := time.Since(b.start) / time.Duration(e.Completed)
+		left := perItem * time.Duration(e.Total-e.Completed)
+		line += fmt.Sprintf(", %s left", left.Round(time.Second))
+	}
+	fmt.Fprintf(os.Stderr, "\r\x1b[K%s", line)
+	b.shown = true
+}
+
+// Clear erases the line, so other output can be written to stderr; the next
+// Update draws it again
+func (b *progressBar) Clear() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.shown {
+		fmt.Fprint(os.Stderr, "\r\x1b[K")
+		b.shown = false
+	}
+}
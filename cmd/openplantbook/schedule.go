@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/schedule"
+)
+
+// plantsFile is the on-disk description of the plants to schedule
+type plantsFile struct {
+	Southern bool         `mapstructure:"southern"`
+	Plants   []plantEntry `mapstructure:"plants"`
+}
+
+// plantEntry describes a single plant and where it is kept
+type plantEntry struct {
+	PID      string  `mapstructure:"pid"`
+	Name     string  `mapstructure:"name"`
+	PotCM    float64 `mapstructure:"pot_cm"`
+	Outdoor  bool    `mapstructure:"outdoor"`
+	TempC    float64 `mapstructure:"temp_c"`
+	Humidity float64 `mapstructure:"humidity"`
+	Season   string  `mapstructure:"season"`
+}
+
+func newScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Plan plant care tasks",
+	}
+
+	cmd.AddCommand(newScheduleICSCmd())
+
+	return cmd
+}
+
+func newScheduleICSCmd() *cobra.Command {
+	var (
+		plantsPath string
+		start      string
+		outputPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ics",
+		Short: "Export a watering calendar in iCalendar format",
+		Long: `Export a watering calendar for your plants as an iCalendar (.ics) file
+that can be imported into Google Calendar, Apple Calendar or Outlook.
+
+The plants file lists each plant and how it is kept:
+
+  southern: false
+  plants:
+    - pid: monstera deliciosa
+      name: Living room monstera
+      pot_cm: 20
+      temp_c: 22
+      humidity: 50
+    - pid: ficus lyrata
+      outdoor: true
+      season: summer
+
+Examples:
+  openplantbook schedule ics --plants plants.yaml > plants.ics
+  openplantbook schedule ics --plants plants.yaml --start 2025-04-01 -o plants.ics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pf, err := loadPlantsFile(plantsPath)
+			if err != nil {
+				return err
+			}
+
+			startTime := time.Now().Truncate(time.Hour)
+			if start != "" {
+				startTime, err = time.ParseInLocation("2006-01-02", start, time.Local)
+				if err != nil {
+					return usagef("invalid --start date (want YYYY-MM-DD): %w", err)
+				}
+				startTime = startTime.Add(9 * time.Hour)
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			defer client.Close()
+
+			var recs []schedule.Recommendation
+			for _, p := range pf.Plants {
+				rec, err := recommendFor(cmd.Context(), client, p, startTime, pf.Southern)
+				if err != nil {
+					return err
+				}
+				recs = append(recs, rec)
+			}
+
+			var w io.Writer = os.Stdout
+			if outputPath != "" && outputPath != "-" {
+				f, err := os.Create(outputPath)
+				if err != nil {
+					return fmt.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			return schedule.NewCalendar(startTime, recs...).ToICS(w)
+		},
+	}
+
+	cmd.Flags().StringVar(&plantsPath, "plants", "", "Plants file (YAML, JSON or TOML)")
+	cmd.Flags().StringVar(&start, "start", "", "Date of the first watering (YYYY-MM-DD, default: now)")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: stdout)")
+	cmd.MarkFlagRequired("plants")
+
+	return cmd
+}
+
+// loadPlantsFile reads the plants file using its extension to pick the format
+func loadPlantsFile(path string) (*plantsFile, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read plants file: %w", err)
+	}
+
+	var pf plantsFile
+	if err := v.Unmarshal(&pf); err != nil {
+		return nil, fmt.Errorf("parse plants file: %w", err)
+	}
+	if len(pf.Plants) == 0 {
+		return nil, fmt.Errorf("plants file %s lists no plants", path)
+	}
+	return &pf, nil
+}
+
+// recommendFor fetches details for a plant entry and computes its schedule
+func recommendFor(ctx context.Context, client *openplantbook.Client, p plantEntry, start time.Time, southern bool) (schedule.Recommendation, error) {
+	if p.PID == "" {
+		return schedule.Recommendation{}, fmt.Errorf("plant entry %q has no pid", p.Name)
+	}
+
+	details, err := client.GetPlantDetails(ctx, strings.ReplaceAll(p.PID, "-", " "), nil)
+	if err != nil {
+		return schedule.Recommendation{}, fmt.Errorf("failed to get details for %s: %w", p.PID, err)
+	}
+
+	season := schedule.SeasonFor(start, southern)
+	if p.Season != "" {
+		season, err = parseSeason(p.Season)
+		if err != nil {
+			return schedule.Recommendation{}, err
+		}
+	}
+
+	rec, err := schedule.Recommend(details, schedule.Conditions{
+		PotDiameterCM: p.PotCM,
+		Season:        season,
+		Outdoor:       p.Outdoor,
+		TempC:         p.TempC,
+		Humidity:      p.Humidity,
+	})
+	if err != nil {
+		return schedule.Recommendation{}, err
+	}
+	if p.Name != "" {
+		rec.Name = p.Name
+	}
+	return rec, nil
+}
+
+func parseSeason(s string) (schedule.Season, error) {
+	var season schedule.Season
+	err := season.UnmarshalText([]byte(s))
+	return season, err
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/integrations/miflora"
+)
+
+// sensorReport is the JSON form of sensor read-ble
+type sensorReport struct {
+	Address     string              `json:"address"`
+	Measurement miflora.Measurement `json:"measurement"`
+	Readings    []care.Reading      `json:"readings"`
+	PID         string              `json:"pid,omitempty"`
+	Violations  []care.Violation    `json:"violations,omitempty"`
+	Fertility   *care.Fertility     `json:"fertility,omitempty"`
+}
+
+func newSensorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sensor",
+		Short: "Read plant sensors",
+		Long:  `Read plant sensors and check their readings against a plant's care requirements.`,
+	}
+	cmd.AddCommand(newSensorReadBLECmd())
+	return cmd
+}
+
+func newSensorReadBLECmd() *cobra.Command {
+	var (
+		pid        string
+		timeout    time.Duration
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "read-ble <address>",
+		Short: "Read a Xiaomi Mi Flora sensor over Bluetooth",
+		Long: `Read the light, temperature, soil moisture and soil EC of a Xiaomi Mi Flora
+(Flower Care) sensor over Bluetooth Low Energy, along with its battery level
+and firmware version. Requires Linux with BlueZ.
+
+With --pid, the readings are checked against the plant's care requirements:
+readings out of range are reported, along with fertilization guidance from
+the soil EC. The plant's details count against the API quota unless cached.
+
+Examples:
+  openplantbook sensor read-ble C4:7C:8D:6A:12:34
+  openplantbook sensor read-ble C4:7C:8D:6A:12:34 --pid monstera-deliciosa
+  openplantbook sensor read-ble C4:7C:8D:6A:12:34 --json`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			report := sensorReport{Address: strings.ToUpper(args[0])}
+			m, err := miflora.Read(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read sensor: %w", err)
+			}
+			report.Measurement = m
+			report.Readings = m.Readings(time.Now())
+
+			if pid != "" {
+				client, err := createClient()
+				if err != nil {
+					return fmt.Errorf("failed to create client: %w", err)
+				}
+				defer client.Close()
+
+				details, err := client.GetPlantDetails(cmd.Context(), strings.ReplaceAll(pid, "-", " "), nil)
+				if err != nil {
+					return fmt.Errorf("failed to get details: %w", err)
+				}
+				report.PID = details.PID
+				report.Violations = care.Evaluate(details, report.Readings...)
+				if f, ok := care.FertilityGuidance(details, float64(m.Conductivity)); ok {
+					report.Fertility = &f
+				}
+			}
+
+			if jsonOutput {
+				return outputJSON(report)
+			}
+			outputSensorReport(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&pid, "pid", "", "Check the readings against this plant's care requirements")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "How long to look for and read the sensor")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func outputSensorReport(report sensorReport) {
+	m := report.Measurement
+	fmt.Printf("Mi Flora %s (battery %d%%, firmware %s)\n\n", report.Address, m.Battery, m.Firmware)
+
+	status := make(map[care.Metric]care.Status)
+	for _, v := range report.Violations {
+		status[v.Reading.Metric] = v.Status
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, r := range report.Readings {
+		line := fmt.Sprintf("%s\t%g %s", r.Metric, r.Value, r.Metric.Unit())
+		if report.PID != "" {
+			line += "\t" + status[r.Metric].String()
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+
+	if report.PID == "" {
+		return
+	}
+	fmt.Println()
+	for _, v := range report.Violations {
+		fmt.Println(v)
+	}
+	if report.Fertility != nil {
+		fmt.Println(report.Fertility)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// statusReport is the JSON form of the status command
+type statusReport struct {
+	openplantbook.Status
+	Profile string     `json:"profile,omitempty"`
+	Ping    pingResult `json:"ping"`
+}
+
+type pingResult struct {
+	Skipped bool          `json:"skipped,omitempty"`
+	OK      bool          `json:"ok"`
+	Latency time.Duration `json:"latency_ns,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func newStatusCmd() *cobra.Command {
+	var (
+		noPing     bool
+		timeout    time.Duration
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show configuration, quota usage and API reachability",
+		Long: `Show the configured authentication method (credentials are never
+printed), base URL, cache and quota usage, and check that the API is
+reachable and accepts the credentials.
+
+The ping sends one minimal search request, which counts against the daily
+quota; use --no-ping to skip it. Quota usage covers this process only.
+
+Examples:
+  openplantbook status
+  openplantbook status --no-ping
+  openplantbook status --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			defer client.Close()
+
+			report := statusReport{Ping: pingResult{Skipped: noPing}}
+			if !noPing {
+				ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+				defer cancel()
+
+				latency, err := client.Ping(ctx)
+				report.Ping.OK = err == nil
+				report.Ping.Latency = latency
+				if err != nil {
+					report.Ping.Error = err.Error()
+				}
+			}
+			report.Status = client.Status()
+			report.Profile = viper.GetString("profile")
+
+			if jsonOutput {
+				if err := outputJSON(report); err != nil {
+					return err
+				}
+			} else {
+				outputStatus(report)
+			}
+
+			if !report.Ping.Skipped && !report.Ping.OK {
+				return fmt.Errorf("API check failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&noPing, "no-ping", false, "Skip the live API check")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "Timeout for the live API check")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output status as JSON")
+
+	return cmd
+}
+
+func outputStatus(r statusReport) {
+	auth := r.Auth
+	switch r.Auth {
+	case "api-key":
+		auth = "API key (redacted)"
+	case "oauth2":
+		auth = "OAuth2 client credentials (redacted)"
+	}
+
+	cache := "disabled"
+	if r.Cache.Enabled {
+		entries := "unknown size"
+		if r.Cache.Entries >= 0 {
+			entries = fmt.Sprintf("%d entries", r.Cache.Entries)
+		}
+		cache = fmt.Sprintf("enabled, %s, %d hits, %d misses", entries, r.Cache.Hits, r.Cache.Misses)
+	}
+
+	quota := fmt.Sprintf("%d requests in the last 24h, no client-side limit", r.Quota.Used)
+	if r.Quota.Limit > 0 {
+		quota = fmt.Sprintf("%d of %d requests used in the last 24h, %d remaining", r.Quota.Used, r.Quota.Limit, r.Quota.Remaining)
+	}
+
+	ping := "skipped"
+	switch {
+	case r.Ping.OK:
+		ping = fmt.Sprintf("OK (%s)", r.Ping.Latency.Round(time.Millisecond))
+	case !r.Ping.Skipped:
+		ping = "FAILED: " + r.Ping.Error
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if r.Profile != "" {
+		fmt.Fprintf(w, "Profile:\t%s\n", r.Profile)
+	}
+	fmt.Fprintf(w, "Base URL:\t%s\n", r.BaseURL)
+	fmt.Fprintf(w, "Auth:\t%s\n", auth)
+	fmt.Fprintf(w, "Cache:\t%s\n", cache)
+	fmt.Fprintf(w, "Quota:\t%s (this process)\n", quota)
+	fmt.Fprintf(w, "API:\t%s\n", ping)
+	w.Flush()
+}
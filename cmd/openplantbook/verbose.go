@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// Verbosity levels of -v
+const (
+	verboseSummary = 1 // -v: cache decisions, rate limit waits, responses
+	verboseTrace   = 2 // -vv: also URLs and cache keys
+)
+
+// tracer reports client activity for -v
+type tracer struct {
+	mu       sync.Mutex
+	w        io.Writer
+	level    int
+	start    time.Time
+	inflight map[string]int // URL -> requests sent and not yet finished
+}
+
+// traceHooks returns hooks that report cache hits and misses, rate limit
+// waits, hedged retries and request outcomes to w
+func traceHooks(w io.Writer, level int) openplantbook.Hooks {
+	t := &tracer{w: w, level: level, start: time.Now(), inflight: make(map[string]int)}
+	return openplantbook.Hooks{
+		OnRequest:       t.request,
+		OnResponse:      t.done,
+		OnError:         t.done,
+		OnCacheHit:      t.cacheHit,
+		OnRateLimitWait: t.rateLimitWait,
+	}
+}
+
+// printf writes one line prefixed with the time since the tracer started
+func (t *tracer) printf(format string, args ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.printfLocked(format, args...)
+}
+
+func (t *tracer) printfLocked(format string, args ...any) {
+	elapsed := time.Since(t.start).Seconds()
+	fmt.Fprintf(t.w, "[%7.3fs] %s\n", elapsed, fmt.Sprintf(format, args...))
+}
+
+func (t *tracer) request(info openplantbook.RequestInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inflight[info.URL]++
+	switch {
+	case t.inflight[info.URL] > 1:
+		t.printfLocked("retry: hedged %s %s request (first attempt is slow)", info.Method, info.Endpoint)
+	case t.level >= verboseTrace:
+		t.printfLocked("cache miss: %s %s", info.Method, info.URL)
+	default:
+		t.printfLocked("cache miss: %s request sent", info.Endpoint)
+	}
+}
+
+func (t *tracer) done(info openplantbook.ResponseInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.inflight[info.URL]--; t.inflight[info.URL] <= 0 {
+		delete(t.inflight, info.URL)
+	}
+
+	target := info.Endpoint.String()
+	if t.level >= verboseTrace {
+		target = info.Method + " " + info.URL
+	}
+	duration := info.Duration.Round(time.Millisecond)
+	if info.Err != nil {
+		t.printfLocked("failed: %s after %s: %v", target, duration, info.Err)
+		return
+	}
+	t.printfLocked("response: %s %d, %d bytes in %s", target, info.StatusCode, info.Size, duration)
+}
+
+func (t *tracer) cacheHit(info openplantbook.CacheHitInfo) {
+	msg := "cache hit: " + info.Endpoint.String()
+	if !info.FetchedAt.IsZero() {
+		msg += fmt.Sprintf(" (fetched %s ago)", time.Since(info.FetchedAt).Round(time.Second))
+	}
+	if info.Stale {
+		msg += ", stale copy served because the API failed"
+	}
+	if t.level >= verboseTrace {
+		msg += fmt.Sprintf(" key=%q", info.Key)
+	}
+	t.printf("%s", msg)
+}
+
+func (t *tracer) rateLimitWait(info openplantbook.RateLimitWaitInfo) {
+	t.printf("rate limit: waited %s before a %s request", info.Wait.Round(time.Millisecond), info.Endpoint)
+}
@@ -0,0 +1,343 @@
+// Command plantbook-sync refreshes a plant dataset file from the
+// OpenPlantbook API
+//
+// It fetches the PIDs listed in -pids that are missing from the dataset or
+// were last checked more than -max-age ago, missing ones first and then the
+// least recently checked, sending at most -limit requests so a refresh fits
+// the daily quota; run it again the next day to continue. Records whose
+// details did not change are left as they are, so a run without upstream
+// changes rewrites the dataset byte for byte. Records of PIDs no longer
+// listed are dropped, and records that fail to refresh are kept.
+//
+// When each PID was last checked is kept in a state file beside the dataset
+// (-state), not in the dataset itself.
+//
+// Credentials are read from OPENPLANTBOOK_API_KEY, or OPENPLANTBOOK_CLIENT_ID
+// and OPENPLANTBOOK_CLIENT_SECRET; OPENPLANTBOOK_BASE_URL overrides the API
+// address.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// datasetFile is the format of a dataset file
+type datasetFile struct {
+	// GeneratedAt is the UpdatedAt of the newest record
+	GeneratedAt time.Time       `json:"generated_at"`
+	Plants      []datasetRecord `json:"plants"`
+}
+
+// datasetRecord is a plant's details and when they last changed
+type datasetRecord struct {
+	openplantbook.PlantDetails
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// config holds the command-line flags
+type config struct {
+	pidsPath  string
+	outPath   string
+	statePath string
+	maxAge    time.Duration
+	limit     int
+	dryRun    bool
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.pidsPath, "pids", "pids.txt", "File listing the PIDs to include, one per line")
+	flag.StringVar(&cfg.outPath, "o", "plants.json", "Dataset file to refresh")
+	flag.StringVar(&cfg.statePath, "state", "", "State file recording when PIDs were checked (default: <dataset>.state.json)")
+	flag.DurationVar(&cfg.maxAge, "max-age", 30*24*time.Hour, "Recheck records last checked longer ago than this")
+	flag.IntVar(&cfg.limit, "limit", 150, "Maximum API requests to send")
+	flag.BoolVar(&cfg.dryRun, "dry-run", false, "Report changes without writing files")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("plantbook-sync: ")
+
+	if cfg.statePath == "" {
+		cfg.statePath = strings.TrimSuffix(cfg.outPath, filepath.Ext(cfg.outPath)) + ".state.json"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	client, err := newClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	if err := run(ctx, client, cfg, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// state records when each PID was last checked against the API
+type state struct {
+	Checked map[string]time.Time `json:"checked"`
+}
+
+func run(ctx context.Context, client *openplantbook.Client, cfg config, out io.Writer) error {
+	pids, err := readPIDs(cfg.pidsPath)
+	if err != nil {
+		return err
+	}
+	var (
+		file datasetFile
+		st   state
+	)
+	if err := readJSON(cfg.outPath, &file); err != nil {
+		return err
+	}
+	if err := readJSON(cfg.statePath, &st); err != nil {
+		return err
+	}
+	if st.Checked == nil {
+		st.Checked = make(map[string]time.Time)
+	}
+
+	records := make(map[string]datasetRecord, len(file.Plants))
+	for _, r := range file.Plants {
+		records[r.PID] = r
+	}
+	listed := make(map[string]bool, len(pids))
+	for _, pid := range pids {
+		listed[pid] = true
+	}
+	var s summary
+	for pid := range records {
+		if !listed[pid] {
+			s.removed = append(s.removed, pid+" (no longer listed)")
+			delete(records, pid)
+		}
+	}
+	for pid := range st.Checked {
+		if !listed[pid] {
+			delete(st.Checked, pid)
+		}
+	}
+
+	// Missing records first, then the least recently checked
+	lastChecked := func(pid string) time.Time {
+		if t, ok := st.Checked[pid]; ok {
+			return t
+		}
+		return records[pid].UpdatedAt
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	var due []string
+	for _, pid := range pids {
+		if _, ok := records[pid]; !ok || now.Sub(lastChecked(pid)) > cfg.maxAge {
+			due = append(due, pid)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		_, hi := records[due[i]]
+		_, hj := records[due[j]]
+		if hi != hj {
+			return !hi
+		}
+		return lastChecked(due[i]).Before(lastChecked(due[j]))
+	})
+
+	ctx = openplantbook.WithBudget(ctx, cfg.limit)
+	processed := 0
+	for _, pid := range due {
+		details, err := client.GetPlantDetails(ctx, pid, nil)
+		if s.stopped = stopErr(ctx, err); s.stopped != nil {
+			break
+		}
+		processed++
+		switch {
+		case err == nil && details.PID != pid:
+			s.failed = append(s.failed, fmt.Sprintf("%s (the API calls it %q; update %s)", pid, details.PID, cfg.pidsPath))
+		case err == nil:
+			details.Language = ""
+			st.Checked[pid] = now
+			s.checked++
+			old, ok := records[pid]
+			switch fields := changedFields(old.PlantDetails, *details); {
+			case !ok:
+				s.added = append(s.added, pid)
+			case len(fields) > 0:
+				s.changed = append(s.changed, fmt.Sprintf("%s (%s)", pid, strings.Join(fields, ", ")))
+			default:
+				continue
+			}
+			records[pid] = datasetRecord{PlantDetails: *details, UpdatedAt: now}
+		case errors.Is(err, openplantbook.ErrNotFound):
+			s.removed = append(s.removed, pid+" (not found)")
+			delete(records, pid)
+			delete(st.Checked, pid)
+		default:
+			s.failed = append(s.failed, fmt.Sprintf("%s (%v)", pid, err))
+		}
+	}
+	s.remaining = len(due) - processed
+	s.print(out, len(records), len(pids))
+
+	if cfg.dryRun {
+		return nil
+	}
+	if err := writeJSON(cfg.outPath, newFile(records)); err != nil {
+		return err
+	}
+	return writeJSON(cfg.statePath, &st)
+}
+
+// stopErr returns err when it ends the run: the request budget or the quota
+// is spent, or the run was interrupted
+func stopErr(ctx context.Context, err error) error {
+	if err != nil && (errors.Is(err, openplantbook.ErrBudgetExhausted) ||
+		errors.Is(err, openplantbook.ErrRateLimitExceeded) || ctx.Err() != nil) {
+		return err
+	}
+	return nil
+}
+
+// newFile returns a dataset of the records sorted by PID, dated by its
+// newest record so that it depends on the records alone
+func newFile(records map[string]datasetRecord) *datasetFile {
+	f := &datasetFile{Plants: make([]datasetRecord, 0, len(records))}
+	for _, r := range records {
+		f.Plants = append(f.Plants, r)
+		if r.UpdatedAt.After(f.GeneratedAt) {
+			f.GeneratedAt = r.UpdatedAt
+		}
+	}
+	sort.Slice(f.Plants, func(i, j int) bool { return f.Plants[i].PID < f.Plants[j].PID })
+	return f
+}
+
+// changedFields returns the JSON names of the fields that differ between old
+// and new, with both values
+func changedFields(old, new openplantbook.PlantDetails) []string {
+	var fields []string
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	for i := range ov.NumField() {
+		a, b := ov.Field(i).Interface(), nv.Field(i).Interface()
+		if a != b {
+			name, _, _ := strings.Cut(ov.Type().Field(i).Tag.Get("json"), ",")
+			fields = append(fields, fmt.Sprintf("%s %v → %v", name, a, b))
+		}
+	}
+	return fields
+}
+
+// summary is the outcome of a run
+type summary struct {
+	checked                 int
+	added, changed, removed []string
+	failed                  []string
+	stopped                 error
+	remaining               int
+}
+
+func (s *summary) print(w io.Writer, records, listed int) {
+	for _, group := range []struct {
+		label string
+		pids  []string
+	}{{"added", s.added}, {"changed", s.changed}, {"removed", s.removed}, {"failed", s.failed}} {
+		sort.Strings(group.pids)
+		for _, pid := range group.pids {
+			fmt.Fprintf(w, "%-8s %s\n", group.label, pid)
+		}
+	}
+	if s.stopped != nil {
+		fmt.Fprintf(w, "stopped: %v\n", s.stopped)
+	}
+	fmt.Fprintf(w, "checked %d, %d still due; %d of %d listed plants in the dataset\n",
+		s.checked, s.remaining, records, listed)
+}
+
+// newClient returns a client without client-side pacing: -limit bounds the
+// requests, and the API's 429 ends the run when the quota is spent
+func newClient() (*openplantbook.Client, error) {
+	opts := []openplantbook.Option{openplantbook.DisableRateLimit()}
+	if url := os.Getenv("OPENPLANTBOOK_BASE_URL"); url != "" {
+		opts = append(opts, openplantbook.WithBaseURL(url))
+	}
+	if key := os.Getenv("OPENPLANTBOOK_API_KEY"); key != "" {
+		return openplantbook.New(append(opts, openplantbook.WithAPIKey(key))...)
+	}
+	id, secret := os.Getenv("OPENPLANTBOOK_CLIENT_ID"), os.Getenv("OPENPLANTBOOK_CLIENT_SECRET")
+	if id == "" || secret == "" {
+		return nil, errors.New("set OPENPLANTBOOK_API_KEY or OPENPLANTBOOK_CLIENT_ID/CLIENT_SECRET")
+	}
+	return openplantbook.New(append(opts, openplantbook.WithOAuth2(id, secret))...)
+}
+
+// readPIDs reads one PID per line, skipping blank lines and # comments
+func readPIDs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pids []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		pid := strings.ToLower(strings.TrimSpace(line))
+		if pid != "" && !seen[pid] {
+			seen[pid] = true
+			pids = append(pids, pid)
+		}
+	}
+	return pids, scanner.Err()
+}
+
+// readJSON decodes the file at path into v, leaving v alone when the file
+// does not exist yet
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON replaces the file at path, so an interrupted run leaves the old
+// file intact
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// testServer serves details for any PID, with maxTemp as max_temp, and 404
+// for "no such plant"
+func testServer(t *testing.T, maxTemp *atomic.Int32) (*openplantbook.Client, *[]string) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/plant/detail/"), "/")
+		requested = append(requested, pid)
+		if pid == "no such plant" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"pid": pid, "display_pid": pid, "max_temp": maxTemp.Load()})
+	}))
+	t.Cleanup(server.Close)
+	client, err := openplantbook.New(
+		openplantbook.WithAPIKey("test-key"),
+		openplantbook.WithBaseURL(server.URL),
+		openplantbook.DisableRateLimit(),
+		openplantbook.DisableCache(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client, &requested
+}
+
+func testConfig(t *testing.T, pids string) config {
+	dir := t.TempDir()
+	cfg := config{
+		pidsPath:  filepath.Join(dir, "pids.txt"),
+		outPath:   filepath.Join(dir, "plants.json"),
+		statePath: filepath.Join(dir, "plants.state.json"),
+		maxAge:    30 * 24 * time.Hour,
+		limit:     10,
+	}
+	if err := os.WriteFile(cfg.pidsPath, []byte(pids), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestRun(t *testing.T) {
+	var maxTemp atomic.Int32
+	maxTemp.Store(30)
+	client, requested := testServer(t, &maxTemp)
+	cfg := testConfig(t, "# test\nficus lyrata\nMonstera Deliciosa\npilea peperomioides\nno such plant\n")
+
+	// ficus is current, pilea was checked long ago and hedera is no longer listed
+	long := time.Now().UTC().Add(-200 * 24 * time.Hour).Truncate(time.Second)
+	old := datasetFile{Plants: []datasetRecord{
+		{PlantDetails: openplantbook.PlantDetails{PID: "ficus lyrata"}, UpdatedAt: long},
+		{PlantDetails: openplantbook.PlantDetails{PID: "pilea peperomioides", DisplayPID: "pilea peperomioides", MaxTemp: 25}, UpdatedAt: long},
+		{PlantDetails: openplantbook.PlantDetails{PID: "hedera helix"}, UpdatedAt: long},
+	}}
+	writeJSON(cfg.outPath, &old)
+	writeJSON(cfg.statePath, &state{Checked: map[string]time.Time{"ficus lyrata": time.Now()}})
+
+	var out bytes.Buffer
+	if err := run(context.Background(), client, cfg, &out); err != nil {
+		t.Fatalf("run() unexpected error: %v", err)
+	}
+	if want := "monstera deliciosa,no such plant,pilea peperomioides"; strings.Join(*requested, ",") != want {
+		t.Errorf("requested %q, want missing records first, then the stale one", *requested)
+	}
+	for _, want := range []string{
+		"added    monstera deliciosa",
+		"changed  pilea peperomioides (max_temp 25 → 30)",
+		"removed  hedera helix (no longer listed)",
+		"removed  no such plant (not found)",
+		"checked 2, 0 still due; 3 of 4 listed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("summary missing %q:\n%s", want, out.String())
+		}
+	}
+
+	var got datasetFile
+	readJSON(cfg.outPath, &got)
+	var pids []string
+	for _, r := range got.Plants {
+		pids = append(pids, r.PID)
+	}
+	if want := "ficus lyrata,monstera deliciosa,pilea peperomioides"; strings.Join(pids, ",") != want {
+		t.Errorf("dataset has %q, want %s", pids, want)
+	}
+	if got.Plants[0].UpdatedAt != long || got.GeneratedAt != got.Plants[2].UpdatedAt || !got.GeneratedAt.After(long) {
+		t.Errorf("dataset dates = %v, %+v", got.GeneratedAt, got.Plants)
+	}
+}
+
+func TestRun_Reproducible(t *testing.T) {
+	var maxTemp atomic.Int32
+	maxTemp.Store(30)
+	client, _ := testServer(t, &maxTemp)
+	cfg := testConfig(t, "ficus lyrata\nmonstera deliciosa\n")
+
+	if err := run(context.Background(), client, cfg, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(cfg.outPath)
+
+	// Rechecking unchanged records leaves the dataset as it was
+	cfg.maxAge = 0
+	time.Sleep(1100 * time.Millisecond)
+	var out bytes.Buffer
+	if err := run(context.Background(), client, cfg, &out); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(cfg.outPath)
+	if !bytes.Equal(first, second) {
+		t.Errorf("unchanged records rewrote the dataset:\n%s\n%s", first, second)
+	}
+	if !strings.Contains(out.String(), "checked 2") || strings.Contains(out.String(), "changed") {
+		t.Errorf("summary = %s", out.String())
+	}
+}
+
+func TestRun_Limit(t *testing.T) {
+	var maxTemp atomic.Int32
+	client, requested := testServer(t, &maxTemp)
+	cfg := testConfig(t, "a\nb\nc\nd\n")
+	cfg.limit = 2
+
+	var out bytes.Buffer
+	if err := run(context.Background(), client, cfg, &out); err != nil {
+		t.Fatalf("run() unexpected error: %v", err)
+	}
+	if len(*requested) != 2 {
+		t.Errorf("sent %d requests, want 2", len(*requested))
+	}
+	if !strings.Contains(out.String(), "stopped: request budget exhausted") || !strings.Contains(out.String(), "2 still due") {
+		t.Errorf("summary = %s", out.String())
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	var maxTemp atomic.Int32
+	client, _ := testServer(t, &maxTemp)
+	cfg := testConfig(t, "ficus lyrata\n")
+	cfg.dryRun = true
+
+	var out bytes.Buffer
+	if err := run(context.Background(), client, cfg, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "added    ficus lyrata") {
+		t.Errorf("summary = %s", out.String())
+	}
+	if _, err := os.Stat(cfg.outPath); !os.IsNotExist(err) {
+		t.Error("dry run wrote the dataset")
+	}
+}
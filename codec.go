@@ -0,0 +1,20 @@
+package openplantbook
+
+import "encoding/json"
+
+// Codec encodes and decodes JSON
+// Faster JSON libraries satisfy it directly, e.g.
+// jsoniter.ConfigCompatibleWithStandardLibrary or sonic.ConfigStd.
+// Implementations must behave like encoding/json, including its struct tags.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// decodeJSON decodes an API response body with the client's codec
+func (c *Client) decodeJSON(data []byte, v any) error {
+	if c.codec != nil {
+		return c.codec.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,64 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingCodec is encoding/json, counting decodes
+type countingCodec struct {
+	decodes atomic.Int32
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.decodes.Add(1)
+	return json.Unmarshal(data, v)
+}
+
+func TestWithJSONCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pid": "monstera", "alias": "Monstera"}`))
+	}))
+	defer server.Close()
+
+	codec := &countingCodec{}
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), WithJSONCodec(codec))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i, source := range []string{"response", "cache hit"} {
+		details, err := client.GetPlantDetails(context.Background(), "monstera", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+		if details.Alias != "Monstera" {
+			t.Errorf("Alias from %s = %q, want Monstera", source, details.Alias)
+		}
+		if n := codec.decodes.Load(); n != int32(i+1) {
+			t.Errorf("codec decodes after %s = %d, want %d", source, n, i+1)
+		}
+	}
+}
+
+func TestWithJSONCodec_KeepsCustomSerializer(t *testing.T) {
+	client, err := New(WithAPIKey("test-key"), WithJSONCodec(&countingCodec{}), WithSerializer(GobSerializer{}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, ok := client.serializer.(GobSerializer); !ok {
+		t.Errorf("serializer = %T, want GobSerializer", client.serializer)
+	}
+	if _, err := New(WithAPIKey("test-key"), WithJSONCodec(nil)); err == nil {
+		t.Error("New() with a nil codec succeeded")
+	}
+}
@@ -0,0 +1,329 @@
+// Package collection manages a user's own plants ("my plants").
+//
+// A Collection tracks which plants a user owns, under what nickname, where
+// they are kept and when they were acquired. Entries reference OpenPlantbook
+// PIDs and can be enriched with care details through the client cache.
+//
+//	c, err := collection.Open(collection.NewJSONStore("plants.json"))
+//	p, err := c.Add(collection.Plant{PID: "monstera deliciosa", Nickname: "Monty", Location: "Living room"})
+//	plants, err := c.Enrich(ctx, client, nil)
+package collection
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// ErrPlantNotFound is returned when no collection entry matches
+var ErrPlantNotFound = errors.New("plant not found in collection")
+
+// ErrDuplicateNickname is returned when a nickname is already used by
+// another plant, or when a nickname matches several plants
+var ErrDuplicateNickname = errors.New("duplicate nickname in collection")
+
+// Plant is a single plant owned by the user
+type Plant struct {
+	ID       string    `json:"id"`
+	PID      string    `json:"pid"`
+	Nickname string    `json:"nickname,omitempty"`
+	Location string    `json:"location,omitempty"`
+	Acquired time.Time `json:"acquired,omitzero"`
+	Notes    string    `json:"notes,omitempty"`
+
+	// Updated is when the entry was last modified locally
+	Updated time.Time `json:"updated,omitzero"`
+
+	// RemoteID links the entry to an OpenPlantbook plant instance
+	RemoteID string `json:"remote_id,omitempty"`
+
+	// SyncedAt is when the entry was last synchronized with the remote
+	SyncedAt time.Time `json:"synced_at,omitzero"`
+
+	// Alerts debounces care alerts for the plant's readings (see care.Alerter)
+	Alerts care.AlertRules `json:"alerts,omitempty"`
+}
+
+// Name returns the nickname, falling back to the PID
+func (p Plant) Name() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.PID
+}
+
+// Store persists collection entries
+type Store interface {
+	// Load returns all stored plants (an empty store returns no error)
+	Load() ([]Plant, error)
+
+	// Save replaces the stored plants
+	Save(plants []Plant) error
+}
+
+// DetailsGetter retrieves plant details (implemented by *openplantbook.Client)
+type DetailsGetter interface {
+	GetPlantDetails(ctx context.Context, pid string, opts *openplantbook.DetailOptions) (*openplantbook.PlantDetails, error)
+}
+
+// Collection is a persistent set of plants
+// It is safe for concurrent use.
+type Collection struct {
+	mu     sync.RWMutex
+	store  Store
+	plants []Plant
+}
+
+// Open loads a collection from a store
+func Open(store Store) (*Collection, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	plants, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load collection: %w", err)
+	}
+
+	return &Collection{store: store, plants: plants}, nil
+}
+
+// Add inserts a plant, assigning an ID when none is set, and persists the collection
+func (c *Collection) Add(p Plant) (Plant, error) {
+	p.PID = strings.TrimSpace(p.PID)
+	if p.PID == "" {
+		return Plant{}, openplantbook.ErrInvalidInput("pid cannot be empty")
+	}
+	if err := p.Alerts.Validate(); err != nil {
+		return Plant{}, openplantbook.ErrInvalidInput(err.Error())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p.ID == "" {
+		id, err := newID()
+		if err != nil {
+			return Plant{}, err
+		}
+		p.ID = id
+	} else if c.indexOf(p.ID) >= 0 {
+		return Plant{}, fmt.Errorf("plant with id %q already exists", p.ID)
+	}
+	if nicknameTaken(c.plants, p) {
+		return Plant{}, fmt.Errorf("%w: %q", ErrDuplicateNickname, p.Nickname)
+	}
+	p.Updated = time.Now()
+
+	plants := append(append([]Plant(nil), c.plants...), p)
+	if err := c.store.Save(plants); err != nil {
+		return Plant{}, fmt.Errorf("save collection: %w", err)
+	}
+	c.plants = plants
+
+	return p, nil
+}
+
+// Update replaces an existing plant (matched by ID) and persists the collection
+func (c *Collection) Update(p Plant) error {
+	if err := p.Alerts.Validate(); err != nil {
+		return openplantbook.ErrInvalidInput(err.Error())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.indexOf(p.ID)
+	if i < 0 {
+		return ErrPlantNotFound
+	}
+	if nicknameTaken(c.plants, p) {
+		return fmt.Errorf("%w: %q", ErrDuplicateNickname, p.Nickname)
+	}
+
+	p.Updated = time.Now()
+	plants := append([]Plant(nil), c.plants...)
+	plants[i] = p
+	if err := c.store.Save(plants); err != nil {
+		return fmt.Errorf("save collection: %w", err)
+	}
+	c.plants = plants
+
+	return nil
+}
+
+// Remove deletes a plant by ID or nickname and persists the collection
+func (c *Collection) Remove(idOrNickname string) (Plant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.find(idOrNickname)
+	if err != nil {
+		return Plant{}, err
+	}
+
+	removed := c.plants[i]
+	plants := append(append([]Plant(nil), c.plants[:i]...), c.plants[i+1:]...)
+	if err := c.store.Save(plants); err != nil {
+		return Plant{}, fmt.Errorf("save collection: %w", err)
+	}
+	c.plants = plants
+
+	return removed, nil
+}
+
+// Get returns a plant by ID or nickname
+func (c *Collection) Get(idOrNickname string) (Plant, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, err := c.find(idOrNickname)
+	if err != nil {
+		return Plant{}, err
+	}
+	return c.plants[i], nil
+}
+
+// List returns all plants sorted by location, then name
+func (c *Collection) List() []Plant {
+	c.mu.RLock()
+	plants := append([]Plant(nil), c.plants...)
+	c.mu.RUnlock()
+
+	sort.SliceStable(plants, func(i, j int) bool {
+		if plants[i].Location != plants[j].Location {
+			return plants[i].Location < plants[j].Location
+		}
+		return strings.ToLower(plants[i].Name()) < strings.ToLower(plants[j].Name())
+	})
+	return plants
+}
+
+// AlertRules returns the alert rules of the first plant of the species pid
+// that has any, e.g. for ingest.Receiver.AlertRules
+func (c *Collection) AlertRules(pid string) care.AlertRules {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, p := range c.plants {
+		if strings.EqualFold(p.PID, pid) && len(p.Alerts) > 0 {
+			return p.Alerts
+		}
+	}
+	return nil
+}
+
+// ProgressEnrich is the operation reported by Enrich and EnrichEach
+const ProgressEnrich = "enrich collection"
+
+// Entry is a collection plant together with its care details
+type Entry struct {
+	Plant   Plant                       `json:"plant"`
+	Details *openplantbook.PlantDetails `json:"details,omitempty"`
+	Err     error                       `json:"-"`
+}
+
+// Enrich fetches care details for every plant
+// Details are retrieved through the getter (typically a cached client), so
+// repeated PIDs cost a single API call. Per-plant failures are reported in
+// Entry.Err rather than aborting the whole operation.
+func (c *Collection) Enrich(ctx context.Context, getter DetailsGetter, opts *openplantbook.DetailOptions) ([]Entry, error) {
+	entries := []Entry{}
+	err := c.EnrichEach(ctx, getter, opts, func(e Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+// EnrichEach is Enrich, calling fn with each entry as soon as its details
+// arrive instead of collecting them
+// It stops when fn returns an error, which is returned, or when ctx ends.
+// Progress (see openplantbook.WithProgress) is reported as ProgressEnrich.
+func (c *Collection) EnrichEach(ctx context.Context, getter DetailsGetter, opts *openplantbook.DetailOptions, fn func(Entry) error) error {
+	plants := c.List()
+	progress := openplantbook.TrackProgress(ctx, ProgressEnrich, len(plants))
+	for _, p := range plants {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		details, err := getter.GetPlantDetails(ctx, p.PID, opts)
+		fnErr := fn(Entry{Plant: p, Details: details, Err: err})
+		progress.Finish(p.ID, err)
+		if fnErr != nil {
+			return fnErr
+		}
+	}
+
+	return nil
+}
+
+// indexOf returns the index of the plant with the given ID, or -1
+func (c *Collection) indexOf(id string) int {
+	return indexByID(c.plants, id)
+}
+
+func indexByID(plants []Plant, id string) int {
+	for i, p := range plants {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// find matches by ID first, then case-insensitively by nickname
+// A nickname shared by several plants, e.g. in a file edited by hand, is
+// reported as ErrDuplicateNickname rather than matching the first of them.
+func (c *Collection) find(idOrNickname string) (int, error) {
+	if i := c.indexOf(idOrNickname); i >= 0 {
+		return i, nil
+	}
+	found := -1
+	for i, p := range c.plants {
+		if p.Nickname == "" || !strings.EqualFold(p.Nickname, idOrNickname) {
+			continue
+		}
+		if found >= 0 {
+			return -1, fmt.Errorf("%w: %q", ErrDuplicateNickname, idOrNickname)
+		}
+		found = i
+	}
+	if found < 0 {
+		return -1, ErrPlantNotFound
+	}
+	return found, nil
+}
+
+// nicknameTaken reports whether another of plants uses p's nickname,
+// ignoring case
+func nicknameTaken(plants []Plant, p Plant) bool {
+	if p.Nickname == "" {
+		return false
+	}
+	for _, other := range plants {
+		if other.ID != p.ID && strings.EqualFold(other.Nickname, p.Nickname) {
+			return true
+		}
+	}
+	return false
+}
+
+// newID returns a short random identifier
+func newID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,208 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// fakeGetter returns canned details and counts calls
+type fakeGetter struct {
+	calls   int
+	details map[string]*openplantbook.PlantDetails
+}
+
+func (f *fakeGetter) GetPlantDetails(ctx context.Context, pid string, opts *openplantbook.DetailOptions) (*openplantbook.PlantDetails, error) {
+	f.calls++
+	if d, ok := f.details[pid]; ok {
+		return d, nil
+	}
+	return nil, openplantbook.ErrNotFound
+}
+
+func TestCollection_AddListRemove(t *testing.T) {
+	c, err := Open(NewMemoryStore())
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+
+	monty, err := c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty", Location: "Living room"})
+	if err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	if monty.ID == "" {
+		t.Error("Add() did not assign an ID")
+	}
+
+	if _, err := c.Add(Plant{PID: "ficus lyrata", Location: "Bedroom"}); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+
+	if _, err := c.Add(Plant{PID: "  "}); err == nil {
+		t.Error("Add() with empty PID expected error, got nil")
+	}
+	if _, err := c.Add(Plant{ID: monty.ID, PID: "x"}); err == nil {
+		t.Error("Add() with duplicate ID expected error, got nil")
+	}
+
+	list := c.List()
+	if len(list) != 2 {
+		t.Fatalf("List() returned %d plants, want 2", len(list))
+	}
+	if list[0].Location != "Bedroom" {
+		t.Errorf("List() not sorted by location: %+v", list)
+	}
+
+	removed, err := c.Remove("monty")
+	if err != nil {
+		t.Fatalf("Remove() by nickname unexpected error: %v", err)
+	}
+	if removed.ID != monty.ID {
+		t.Errorf("Remove() removed %q, want %q", removed.ID, monty.ID)
+	}
+
+	if _, err := c.Remove(monty.ID); !errors.Is(err, ErrPlantNotFound) {
+		t.Errorf("Remove() twice error = %v, want ErrPlantNotFound", err)
+	}
+}
+
+func TestCollection_DuplicateNicknames(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	monty, _ := c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+
+	if _, err := c.Add(Plant{PID: "ficus lyrata", Nickname: "monty"}); !errors.Is(err, ErrDuplicateNickname) {
+		t.Errorf("Add() with a taken nickname error = %v, want ErrDuplicateNickname", err)
+	}
+	fig, _ := c.Add(Plant{PID: "ficus lyrata", Nickname: "Fig"})
+	fig.Nickname = "MONTY"
+	if err := c.Update(fig); !errors.Is(err, ErrDuplicateNickname) {
+		t.Errorf("Update() to a taken nickname error = %v, want ErrDuplicateNickname", err)
+	}
+	monty.Location = "Kitchen"
+	if err := c.Update(monty); err != nil {
+		t.Errorf("Update() keeping its own nickname unexpected error: %v", err)
+	}
+
+	// Duplicates already in the store are ambiguous rather than matched in order
+	store := NewMemoryStore()
+	store.Save([]Plant{{ID: "a", PID: "x", Nickname: "Twin"}, {ID: "b", PID: "y", Nickname: "twin"}})
+	c, _ = Open(store)
+	if _, err := c.Remove("twin"); !errors.Is(err, ErrDuplicateNickname) {
+		t.Errorf("Remove() of an ambiguous nickname error = %v, want ErrDuplicateNickname", err)
+	}
+	if len(c.List()) != 2 {
+		t.Error("Remove() of an ambiguous nickname deleted a plant")
+	}
+	if _, err := c.Get("b"); err != nil {
+		t.Errorf("Get() by ID unexpected error: %v", err)
+	}
+}
+
+func TestCollection_AlertRules(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	rules := care.AlertRules{care.MetricSoilMoisture: {Hysteresis: 5}}
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "Big Monty", Alerts: rules})
+
+	if got := c.AlertRules("Monstera Deliciosa"); got[care.MetricSoilMoisture] != rules[care.MetricSoilMoisture] {
+		t.Errorf("AlertRules() = %+v, want %+v", got, rules)
+	}
+	if got := c.AlertRules("ficus lyrata"); got != nil {
+		t.Errorf("AlertRules() of a plant not in the collection = %+v, want nil", got)
+	}
+}
+
+func TestCollection_Persistence(t *testing.T) {
+	store := NewMemoryStore()
+	c, _ := Open(store)
+	p, _ := c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+
+	reopened, err := Open(store)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	got, err := reopened.Get(p.ID)
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if got.Nickname != "Monty" {
+		t.Errorf("Get() nickname = %q, want Monty", got.Nickname)
+	}
+
+	got.Location = "Kitchen"
+	if err := reopened.Update(got); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if err := reopened.Update(Plant{ID: "missing"}); !errors.Is(err, ErrPlantNotFound) {
+		t.Errorf("Update() missing error = %v, want ErrPlantNotFound", err)
+	}
+}
+
+func TestCollection_Enrich(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	c.Add(Plant{PID: "monstera deliciosa"})
+	c.Add(Plant{PID: "unknown"})
+
+	getter := &fakeGetter{details: map[string]*openplantbook.PlantDetails{
+		"monstera deliciosa": {PID: "monstera deliciosa", Alias: "Monstera"},
+	}}
+
+	entries, err := c.Enrich(context.Background(), getter, nil)
+	if err != nil {
+		t.Fatalf("Enrich() unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Enrich() returned %d entries, want 2", len(entries))
+	}
+
+	var found, failed int
+	for _, e := range entries {
+		if e.Details != nil {
+			found++
+		}
+		if errors.Is(e.Err, openplantbook.ErrNotFound) {
+			failed++
+		}
+	}
+	if found != 1 || failed != 1 {
+		t.Errorf("Enrich() found=%d failed=%d, want 1 and 1", found, failed)
+	}
+}
+
+func TestCollection_EnrichEach(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "a"})
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "b"})
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "c"})
+
+	getter := &fakeGetter{details: map[string]*openplantbook.PlantDetails{
+		"monstera deliciosa": {PID: "monstera deliciosa"},
+	}}
+
+	var progress []openplantbook.ProgressEvent
+	ctx := openplantbook.WithProgress(context.Background(), openplantbook.ProgressFunc(func(e openplantbook.ProgressEvent) {
+		progress = append(progress, e)
+	}))
+
+	stop := errors.New("stop")
+	var seen []string
+	err := c.EnrichEach(ctx, getter, nil, func(e Entry) error {
+		seen = append(seen, e.Plant.Nickname)
+		if len(seen) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("EnrichEach() error = %v, want stop", err)
+	}
+	if len(seen) != 2 || seen[0] != "a" || seen[1] != "b" {
+		t.Errorf("EnrichEach() visited %v, want [a b]", seen)
+	}
+	if n := len(progress); n != 3 || progress[n-1].Completed != 2 || progress[n-1].Total != 3 {
+		t.Errorf("EnrichEach() progress = %+v, want 2 of 3 completed", progress)
+	}
+}
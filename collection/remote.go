@@ -0,0 +1,83 @@
+package collection
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StoreRemote is a Remote backed by another collection store, such as a
+// collection file in a folder shared between machines
+// Remote instance IDs are the entry IDs of the other store.
+type StoreRemote struct {
+	mu    sync.Mutex
+	store Store
+}
+
+var _ Remote = (*StoreRemote)(nil)
+
+// NewStoreRemote creates a remote reading and writing store
+func NewStoreRemote(store Store) *StoreRemote {
+	return &StoreRemote{store: store}
+}
+
+// ListPlants implements Remote
+func (r *StoreRemote) ListPlants(ctx context.Context) ([]RemotePlant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plants, err := r.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	remotes := make([]RemotePlant, 0, len(plants))
+	for _, p := range plants {
+		remotes = append(remotes, RemotePlant{ID: p.ID, PID: p.PID, Name: p.Nickname, Location: p.Location, Updated: p.Updated})
+	}
+	return remotes, nil
+}
+
+// CreatePlant implements Remote
+func (r *StoreRemote) CreatePlant(ctx context.Context, p RemotePlant) (RemotePlant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plants, err := r.store.Load()
+	if err != nil {
+		return RemotePlant{}, err
+	}
+	if p.ID, err = newID(); err != nil {
+		return RemotePlant{}, err
+	}
+	p.Updated = time.Now()
+	plants = append(plants, Plant{ID: p.ID, PID: p.PID, Nickname: p.Name, Location: p.Location, Updated: p.Updated})
+	if err := r.store.Save(plants); err != nil {
+		return RemotePlant{}, err
+	}
+	return p, nil
+}
+
+// UpdatePlant implements Remote; fields the remote does not carry, such as
+// notes, are kept
+func (r *StoreRemote) UpdatePlant(ctx context.Context, p RemotePlant) (RemotePlant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plants, err := r.store.Load()
+	if err != nil {
+		return RemotePlant{}, err
+	}
+	i := indexByID(plants, p.ID)
+	if i < 0 {
+		return RemotePlant{}, ErrPlantNotFound
+	}
+	p.Updated = time.Now()
+	plants[i].PID = p.PID
+	plants[i].Nickname = p.Name
+	plants[i].Location = p.Location
+	plants[i].Updated = p.Updated
+	if err := r.store.Save(plants); err != nil {
+		return RemotePlant{}, err
+	}
+	return p, nil
+}
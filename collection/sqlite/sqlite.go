@@ -0,0 +1,171 @@
+// Package sqlite persists a plant collection in a SQLite database.
+//
+// It is an alternative to collection.JSONStore for collections shared with
+// other tools, or large enough that rewriting a JSON file on every change
+// is wasteful:
+//
+//	store, err := sqlite.Open("plants.db")
+//	...
+//	defer store.Close()
+//	c, err := collection.Open(store)
+//
+// It is a separate module, so the core SDK does not depend on a SQLite
+// driver (it uses modernc.org/sqlite, which needs no cgo):
+//
+//	go get github.com/rmrfslashbin/openplantbook-go/collection/sqlite
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/rmrfslashbin/openplantbook-go/collection"
+)
+
+// schema creates the plants table; position keeps the collection's order
+const schema = `CREATE TABLE IF NOT EXISTS plants (
+	id        TEXT PRIMARY KEY,
+	position  INTEGER NOT NULL,
+	pid       TEXT NOT NULL,
+	nickname  TEXT NOT NULL DEFAULT '',
+	location  TEXT NOT NULL DEFAULT '',
+	acquired  TEXT NOT NULL DEFAULT '',
+	notes     TEXT NOT NULL DEFAULT '',
+	updated   TEXT NOT NULL DEFAULT '',
+	remote_id TEXT NOT NULL DEFAULT '',
+	synced_at TEXT NOT NULL DEFAULT '',
+	alerts    TEXT NOT NULL DEFAULT ''
+)`
+
+// Store persists a collection in a SQLite database
+// Save replaces every row in a single transaction, so a failed save leaves
+// the previous collection intact.
+type Store struct {
+	db   *sql.DB
+	path string
+}
+
+var _ collection.Store = (*Store)(nil)
+
+// Open opens or creates the database at path, creating its directory and
+// the plants table as needed
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer; a single connection avoids "database is locked"
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema in %s: %w", path, err)
+	}
+	return &Store{db: db, path: path}, nil
+}
+
+// Path returns the database file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Load implements collection.Store; an empty database is an empty collection
+func (s *Store) Load() ([]collection.Plant, error) {
+	rows, err := s.db.Query(`SELECT id, pid, nickname, location, acquired, notes, updated, remote_id, synced_at, alerts
+		FROM plants ORDER BY position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plants []collection.Plant
+	for rows.Next() {
+		var (
+			p                           collection.Plant
+			acquired, updated, syncedAt string
+			alerts                      string
+		)
+		if err := rows.Scan(&p.ID, &p.PID, &p.Nickname, &p.Location, &acquired, &p.Notes,
+			&updated, &p.RemoteID, &syncedAt, &alerts); err != nil {
+			return nil, err
+		}
+		for _, t := range []struct {
+			dst  *time.Time
+			text string
+		}{{&p.Acquired, acquired}, {&p.Updated, updated}, {&p.SyncedAt, syncedAt}} {
+			if *t.dst, err = parseTime(t.text); err != nil {
+				return nil, fmt.Errorf("decode plant %s: %w", p.ID, err)
+			}
+		}
+		if alerts != "" {
+			if err := json.Unmarshal([]byte(alerts), &p.Alerts); err != nil {
+				return nil, fmt.Errorf("decode plant %s alerts: %w", p.ID, err)
+			}
+		}
+		plants = append(plants, p)
+	}
+	return plants, rows.Err()
+}
+
+// Save implements collection.Store
+func (s *Store) Save(plants []collection.Plant) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM plants`); err != nil {
+		return err
+	}
+	insert, err := tx.Prepare(`INSERT INTO plants
+		(id, position, pid, nickname, location, acquired, notes, updated, remote_id, synced_at, alerts)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for i, p := range plants {
+		var alerts []byte
+		if len(p.Alerts) > 0 {
+			if alerts, err = json.Marshal(p.Alerts); err != nil {
+				return fmt.Errorf("encode plant %s alerts: %w", p.ID, err)
+			}
+		}
+		if _, err := insert.Exec(p.ID, i, p.PID, p.Nickname, p.Location, formatTime(p.Acquired), p.Notes,
+			formatTime(p.Updated), p.RemoteID, formatTime(p.SyncedAt), string(alerts)); err != nil {
+			return fmt.Errorf("save plant %s: %w", p.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// formatTime encodes t as RFC 3339, or "" if it is zero
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// parseTime decodes a time written by formatTime
+func parseTime(text string) (time.Time, error) {
+	if text == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, text)
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/collection"
+)
+
+func TestStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "plants.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+
+	// A new database is an empty collection
+	plants, err := store.Load()
+	if err != nil || len(plants) != 0 {
+		t.Fatalf("Load() of a new database = %v, %v", plants, err)
+	}
+
+	acquired := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	rules := care.AlertRules{care.MetricSoilMoisture: {Hysteresis: 5, For: 2 * time.Hour}}
+	c, err := collection.Open(store)
+	if err != nil {
+		t.Fatalf("collection.Open() unexpected error: %v", err)
+	}
+	monty, err := c.Add(collection.Plant{PID: "monstera deliciosa", Nickname: "Monty", Acquired: acquired, Alerts: rules})
+	if err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	if _, err := c.Add(collection.Plant{PID: "ficus lyrata", Location: "Bedroom"}); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	store.Close()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != monty.ID || got[1].PID != "ficus lyrata" {
+		t.Fatalf("Load() = %+v, want both plants in order", got)
+	}
+	p := got[0]
+	if p.Nickname != "Monty" || !p.Acquired.Equal(acquired) || !p.Updated.Equal(monty.Updated) ||
+		!p.SyncedAt.IsZero() || p.Alerts[care.MetricSoilMoisture] != rules[care.MetricSoilMoisture] {
+		t.Errorf("Load() = %+v, want %+v", p, monty)
+	}
+
+	// Saving replaces the collection
+	if err := reopened.Save(got[1:]); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	if got, _ := reopened.Load(); len(got) != 1 || got[0].PID != "ficus lyrata" {
+		t.Errorf("Load() after Save() = %+v, want only the ficus", got)
+	}
+}
@@ -0,0 +1,102 @@
+package collection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JSONStore persists a collection as a JSON file
+// Writes are atomic: data is written to a temporary file and renamed into place.
+type JSONStore struct {
+	path string
+}
+
+// NewJSONStore creates a store backed by the JSON file at path
+func NewJSONStore(path string) *JSONStore {
+	return &JSONStore{path: path}
+}
+
+// Path returns the backing file path
+func (s *JSONStore) Path() string {
+	return s.path
+}
+
+// collectionFile is the on-disk layout
+type collectionFile struct {
+	Version int     `json:"version"`
+	Plants  []Plant `json:"plants"`
+}
+
+// Load implements Store; a missing file is an empty collection
+func (s *JSONStore) Load() ([]Plant, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f collectionFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", s.path, err)
+	}
+	return f.Plants, nil
+}
+
+// Save implements Store
+func (s *JSONStore) Save(plants []Plant) error {
+	data, err := json.MarshalIndent(collectionFile{Version: 1, Plants: plants}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// MemoryStore keeps a collection in memory (useful for tests)
+type MemoryStore struct {
+	mu     sync.Mutex
+	plants []Plant
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Load implements Store
+func (s *MemoryStore) Load() ([]Plant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Plant(nil), s.plants...), nil
+}
+
+// Save implements Store
+func (s *MemoryStore) Save(plants []Plant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plants = append([]Plant(nil), plants...)
+	return nil
+}
@@ -0,0 +1,77 @@
+package collection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func TestJSONStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "plants.json")
+	store := NewJSONStore(path)
+
+	// Missing file is an empty collection
+	plants, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() on missing file unexpected error: %v", err)
+	}
+	if len(plants) != 0 {
+		t.Fatalf("Load() on missing file returned %d plants", len(plants))
+	}
+
+	acquired := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	want := []Plant{{ID: "a1", PID: "monstera deliciosa", Nickname: "Monty", Acquired: acquired}}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Nickname != "Monty" || !got[0].Acquired.Equal(acquired) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory contains %d entries, want 1", len(entries))
+	}
+}
+
+func TestJSONStore_AlertRules(t *testing.T) {
+	store := NewJSONStore(filepath.Join(t.TempDir(), "plants.json"))
+	c, _ := Open(store)
+	rules := care.AlertRules{care.MetricSoilMoisture: {Hysteresis: 5, For: 2 * time.Hour}}
+	p, err := c.Add(Plant{PID: "monstera deliciosa", Alerts: rules})
+	if err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+
+	reopened, err := Open(store)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	got, _ := reopened.Get(p.ID)
+	if got.Alerts[care.MetricSoilMoisture] != rules[care.MetricSoilMoisture] {
+		t.Errorf("reloaded alert rules = %+v, want %+v", got.Alerts, rules)
+	}
+
+	got.Alerts = care.AlertRules{care.MetricTemperature: {For: -time.Hour}}
+	if err := reopened.Update(got); err == nil {
+		t.Error("Update() with a negative duration expected error, got nil")
+	}
+}
+
+func TestJSONStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plants.json")
+	os.WriteFile(path, []byte("{not json"), 0o644)
+
+	if _, err := NewJSONStore(path).Load(); err == nil {
+		t.Error("Load() of corrupt file expected error, got nil")
+	}
+}
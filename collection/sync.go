@@ -0,0 +1,324 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// errChangedDuringSync reports a pull skipped because the local entry was
+// modified after the sync was planned
+var errChangedDuringSync = errors.New("changed locally during sync")
+
+// RemotePlant is a server-side plant instance
+type RemotePlant struct {
+	ID       string
+	PID      string
+	Name     string
+	Location string
+	Updated  time.Time
+}
+
+// Remote is the server side of a collection sync
+//
+// The SDK does not yet expose OpenPlantbook's plant instance endpoints, so
+// callers provide an implementation. Once the client supports them it will
+// satisfy this interface directly.
+type Remote interface {
+	ListPlants(ctx context.Context) ([]RemotePlant, error)
+	CreatePlant(ctx context.Context, p RemotePlant) (RemotePlant, error)
+	UpdatePlant(ctx context.Context, p RemotePlant) (RemotePlant, error)
+}
+
+// ConflictPolicy decides which side wins when both changed since the last sync
+type ConflictPolicy int
+
+const (
+	// SkipConflicts leaves both sides untouched and reports the conflict (default)
+	SkipConflicts ConflictPolicy = iota
+	// PreferLocal overwrites the remote with the local entry
+	PreferLocal
+	// PreferRemote overwrites the local entry with the remote
+	PreferRemote
+)
+
+// SyncDirection limits which way changes flow
+type SyncDirection int
+
+const (
+	// SyncBoth pushes local changes and pulls remote changes (default)
+	SyncBoth SyncDirection = iota
+	// SyncPush only pushes local changes to the remote
+	SyncPush
+	// SyncPull only pulls remote changes into the collection
+	SyncPull
+)
+
+// SyncOptions configures Sync
+type SyncOptions struct {
+	Policy    ConflictPolicy
+	Direction SyncDirection
+
+	// DryRun computes the plan without changing either side
+	DryRun bool
+}
+
+// ActionKind is the type of change a sync performs
+type ActionKind string
+
+const (
+	// ActionPushCreate creates a remote instance for a local entry
+	ActionPushCreate ActionKind = "push-create"
+	// ActionPushUpdate overwrites a remote instance with the local entry
+	ActionPushUpdate ActionKind = "push-update"
+	// ActionPullCreate adds a local entry for a remote instance
+	ActionPullCreate ActionKind = "pull-create"
+	// ActionPullUpdate overwrites a local entry with the remote instance
+	ActionPullUpdate ActionKind = "pull-update"
+	// ActionConflict reports an entry changed on both sides (nothing is applied)
+	ActionConflict ActionKind = "conflict"
+)
+
+// SyncAction is a single planned or applied change
+type SyncAction struct {
+	Kind   ActionKind
+	Local  Plant
+	Remote RemotePlant
+
+	// Err is set when applying the action failed
+	Err error
+}
+
+// SyncReport lists the actions of a sync run
+type SyncReport struct {
+	Actions []SyncAction
+	DryRun  bool
+}
+
+// Failed returns the actions that could not be applied
+func (r *SyncReport) Failed() []SyncAction {
+	var failed []SyncAction
+	for _, a := range r.Actions {
+		if a.Err != nil {
+			failed = append(failed, a)
+		}
+	}
+	return failed
+}
+
+// Sync reconciles the collection with the remote
+//
+// Entries are linked through Plant.RemoteID. A side counts as changed when
+// its modification time is after the entry's last sync. Deletions are not
+// propagated: removing a local entry leaves the remote instance in place
+// (use SyncPush to avoid pulling it back). The collection is not locked
+// during remote calls; entries changed meanwhile are not overwritten by
+// pulls, and stay pending for the next sync. Pulls that would give a plant
+// the nickname of another fail with ErrDuplicateNickname.
+func (c *Collection) Sync(ctx context.Context, remote Remote, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	remotes, err := remote.ListPlants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list remote plants: %w", err)
+	}
+
+	plan := c.plan(remotes, opts)
+	report := &SyncReport{Actions: plan, DryRun: opts.DryRun}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	// Push to the remote without holding the lock, so the collection stays
+	// usable during slow network calls
+	for i := range report.Actions {
+		a := &report.Actions[i]
+		if a.Kind != ActionPushCreate && a.Kind != ActionPushUpdate {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			a.Err = err
+			continue
+		}
+		if a.Kind == ActionPushCreate {
+			a.Remote, a.Err = remote.CreatePlant(ctx, toRemote(a.Local))
+		} else {
+			a.Remote, a.Err = remote.UpdatePlant(ctx, toRemote(a.Local))
+		}
+	}
+
+	if err := c.apply(report.Actions); err != nil {
+		return report, err
+	}
+
+	var errs []error
+	for _, a := range report.Failed() {
+		errs = append(errs, fmt.Errorf("%s %s: %w", a.Kind, a.Local.Name(), a.Err))
+	}
+	return report, errors.Join(errs...)
+}
+
+// apply records the outcome of actions in the collection and persists it
+// Entries changed or removed since the plan was made keep their current
+// state: a pull does not overwrite them, and a push links the remote
+// instance without marking the newer local change as synced.
+func (c *Collection) apply(actions []SyncAction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	plants := append([]Plant(nil), c.plants...)
+	now := time.Now()
+
+	for i := range actions {
+		a := &actions[i]
+		if a.Err != nil || a.Kind == ActionConflict {
+			continue
+		}
+
+		j := -1
+		if a.Kind != ActionPullCreate {
+			if j = indexByID(plants, a.Local.ID); j < 0 {
+				a.Err = ErrPlantNotFound
+				continue
+			}
+		}
+		unchanged := j >= 0 && plants[j].Updated.Equal(a.Local.Updated)
+
+		switch a.Kind {
+		case ActionPushCreate, ActionPushUpdate:
+			plants[j].RemoteID = a.Remote.ID
+			if unchanged {
+				// The remote's own timestamp for this write must not look like a later change
+				plants[j].SyncedAt = now
+				if a.Remote.Updated.After(now) {
+					plants[j].SyncedAt = a.Remote.Updated
+				}
+			}
+			a.Local = plants[j]
+
+		case ActionPullCreate:
+			if slices.ContainsFunc(plants, func(p Plant) bool { return p.RemoteID == a.Remote.ID }) {
+				// Pulled by a concurrent sync
+				continue
+			}
+			id, err := newID()
+			if err != nil {
+				a.Err = err
+				continue
+			}
+			p := fromRemote(Plant{ID: id}, a.Remote, now)
+			a.Local = p
+			if nicknameTaken(plants, p) {
+				a.Err = fmt.Errorf("%w: %q", ErrDuplicateNickname, p.Nickname)
+				continue
+			}
+			plants = append(plants, p)
+
+		case ActionPullUpdate:
+			if !unchanged {
+				a.Err = errChangedDuringSync
+				continue
+			}
+			p := fromRemote(plants[j], a.Remote, now)
+			if nicknameTaken(plants, p) {
+				a.Err = fmt.Errorf("%w: %q", ErrDuplicateNickname, p.Nickname)
+				continue
+			}
+			plants[j] = p
+			a.Local = p
+		}
+	}
+
+	if err := c.store.Save(plants); err != nil {
+		return fmt.Errorf("save collection: %w", err)
+	}
+	c.plants = plants
+	return nil
+}
+
+// plan computes the actions needed to reconcile both sides
+func (c *Collection) plan(remotes []RemotePlant, opts *SyncOptions) []SyncAction {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	push := opts.Direction != SyncPull
+	pull := opts.Direction != SyncPush
+
+	byID := make(map[string]RemotePlant, len(remotes))
+	for _, r := range remotes {
+		byID[r.ID] = r
+	}
+
+	var actions []SyncAction
+	linked := make(map[string]bool)
+
+	for _, p := range c.plants {
+		r, ok := byID[p.RemoteID]
+		if p.RemoteID == "" || !ok {
+			// Never synced, or the remote instance is gone: (re)create it
+			if push {
+				actions = append(actions, SyncAction{Kind: ActionPushCreate, Local: p})
+			}
+			continue
+		}
+		linked[r.ID] = true
+
+		localChanged := p.Updated.After(p.SyncedAt)
+		remoteChanged := r.Updated.After(p.SyncedAt)
+
+		switch {
+		case localChanged && remoteChanged:
+			switch opts.Policy {
+			case PreferLocal:
+				if push {
+					actions = append(actions, SyncAction{Kind: ActionPushUpdate, Local: p, Remote: r})
+				}
+			case PreferRemote:
+				if pull {
+					actions = append(actions, SyncAction{Kind: ActionPullUpdate, Local: p, Remote: r})
+				}
+			default:
+				actions = append(actions, SyncAction{Kind: ActionConflict, Local: p, Remote: r})
+			}
+		case localChanged && push:
+			actions = append(actions, SyncAction{Kind: ActionPushUpdate, Local: p, Remote: r})
+		case remoteChanged && pull:
+			actions = append(actions, SyncAction{Kind: ActionPullUpdate, Local: p, Remote: r})
+		}
+	}
+
+	if pull {
+		for _, r := range remotes {
+			if !linked[r.ID] {
+				actions = append(actions, SyncAction{Kind: ActionPullCreate, Remote: r})
+			}
+		}
+	}
+
+	return actions
+}
+
+func toRemote(p Plant) RemotePlant {
+	return RemotePlant{
+		ID:       p.RemoteID,
+		PID:      p.PID,
+		Name:     p.Nickname,
+		Location: p.Location,
+		Updated:  p.Updated,
+	}
+}
+
+// fromRemote overlays remote fields onto a local entry and marks it synced
+func fromRemote(p Plant, r RemotePlant, syncedAt time.Time) Plant {
+	p.RemoteID = r.ID
+	p.PID = r.PID
+	p.Nickname = r.Name
+	p.Location = r.Location
+	p.Updated = syncedAt
+	p.SyncedAt = syncedAt
+	return p
+}
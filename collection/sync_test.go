@@ -0,0 +1,297 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeRemote is an in-memory Remote
+type fakeRemote struct {
+	plants    map[string]RemotePlant
+	next      int
+	failWrite bool
+
+	// onWrite, if set, runs during each create or update
+	onWrite func()
+}
+
+func newFakeRemote(plants ...RemotePlant) *fakeRemote {
+	r := &fakeRemote{plants: make(map[string]RemotePlant)}
+	for _, p := range plants {
+		r.plants[p.ID] = p
+	}
+	return r
+}
+
+func (r *fakeRemote) ListPlants(ctx context.Context) ([]RemotePlant, error) {
+	var out []RemotePlant
+	for _, p := range r.plants {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (r *fakeRemote) CreatePlant(ctx context.Context, p RemotePlant) (RemotePlant, error) {
+	if r.onWrite != nil {
+		r.onWrite()
+	}
+	if r.failWrite {
+		return RemotePlant{}, errors.New("write failed")
+	}
+	r.next++
+	p.ID = fmt.Sprintf("r%d", r.next)
+	p.Updated = time.Now()
+	r.plants[p.ID] = p
+	return p, nil
+}
+
+func (r *fakeRemote) UpdatePlant(ctx context.Context, p RemotePlant) (RemotePlant, error) {
+	if r.onWrite != nil {
+		r.onWrite()
+	}
+	if r.failWrite {
+		return RemotePlant{}, errors.New("write failed")
+	}
+	p.Updated = time.Now()
+	r.plants[p.ID] = p
+	return p, nil
+}
+
+func countKinds(report *SyncReport) map[ActionKind]int {
+	counts := make(map[ActionKind]int)
+	for _, a := range report.Actions {
+		counts[a.Kind]++
+	}
+	return counts
+}
+
+func TestCollection_Sync(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+	remote := newFakeRemote(RemotePlant{ID: "existing", PID: "ficus lyrata", Name: "Fig", Updated: time.Now()})
+
+	// Dry run changes nothing
+	report, err := c.Sync(context.Background(), remote, &SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync() dry run unexpected error: %v", err)
+	}
+	counts := countKinds(report)
+	if counts[ActionPushCreate] != 1 || counts[ActionPullCreate] != 1 {
+		t.Errorf("dry run plan = %v, want 1 push-create and 1 pull-create", counts)
+	}
+	if len(c.List()) != 1 || len(remote.plants) != 1 {
+		t.Fatal("dry run modified local or remote state")
+	}
+
+	// Real run applies both directions
+	if _, err := c.Sync(context.Background(), remote, nil); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if len(c.List()) != 2 || len(remote.plants) != 2 {
+		t.Fatalf("after sync local=%d remote=%d, want 2 and 2", len(c.List()), len(remote.plants))
+	}
+	for _, p := range c.List() {
+		if p.RemoteID == "" {
+			t.Errorf("plant %s not linked to remote", p.Name())
+		}
+	}
+
+	// A second sync is a no-op
+	report, err = c.Sync(context.Background(), remote, nil)
+	if err != nil {
+		t.Fatalf("second Sync() unexpected error: %v", err)
+	}
+	if len(report.Actions) != 0 {
+		t.Errorf("second Sync() planned %d actions, want 0: %+v", len(report.Actions), report.Actions)
+	}
+}
+
+func TestCollection_SyncConflicts(t *testing.T) {
+	setup := func() (*Collection, *fakeRemote, Plant) {
+		c, _ := Open(NewMemoryStore())
+		remote := newFakeRemote()
+		c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+		c.Sync(context.Background(), remote, nil)
+
+		// Change both sides after the sync
+		time.Sleep(2 * time.Millisecond)
+		p := c.List()[0]
+		p.Location = "Kitchen"
+		c.Update(p)
+		r := remote.plants[p.RemoteID]
+		r.Location = "Office"
+		r.Updated = time.Now()
+		remote.plants[r.ID] = r
+		return c, remote, p
+	}
+
+	tests := []struct {
+		name         string
+		policy       ConflictPolicy
+		wantLocal    string
+		wantRemote   string
+		wantConflict bool
+	}{
+		{"skip", SkipConflicts, "Kitchen", "Office", true},
+		{"prefer local", PreferLocal, "Kitchen", "Kitchen", false},
+		{"prefer remote", PreferRemote, "Office", "Office", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, remote, p := setup()
+			report, err := c.Sync(context.Background(), remote, &SyncOptions{Policy: tt.policy})
+			if err != nil {
+				t.Fatalf("Sync() unexpected error: %v", err)
+			}
+			if got := countKinds(report)[ActionConflict] > 0; got != tt.wantConflict {
+				t.Errorf("conflict reported = %v, want %v", got, tt.wantConflict)
+			}
+
+			local, _ := c.Get(p.ID)
+			if local.Location != tt.wantLocal {
+				t.Errorf("local location = %q, want %q", local.Location, tt.wantLocal)
+			}
+			if got := remote.plants[p.RemoteID].Location; got != tt.wantRemote {
+				t.Errorf("remote location = %q, want %q", got, tt.wantRemote)
+			}
+		})
+	}
+}
+
+func TestCollection_SyncErrors(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	c.Add(Plant{PID: "monstera deliciosa"})
+	remote := newFakeRemote()
+	remote.failWrite = true
+
+	report, err := c.Sync(context.Background(), remote, &SyncOptions{Direction: SyncPush})
+	if err == nil {
+		t.Fatal("Sync() expected error for failed push, got nil")
+	}
+	if len(report.Failed()) != 1 {
+		t.Errorf("Failed() = %d actions, want 1", len(report.Failed()))
+	}
+	if c.List()[0].RemoteID != "" {
+		t.Error("failed push linked the plant to a remote")
+	}
+}
+
+func TestCollection_SyncConcurrentUpdate(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	remote := newFakeRemote()
+	monty, _ := c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+	c.Sync(context.Background(), remote, nil)
+	monty, _ = c.Get(monty.ID)
+
+	time.Sleep(2 * time.Millisecond)
+	fig, _ := c.Add(Plant{PID: "ficus lyrata", Nickname: "Fig"})
+	r := remote.plants[monty.RemoteID]
+	r.Location = "Office"
+	r.Updated = time.Now()
+	remote.plants[r.ID] = r
+
+	// Both plants change locally while the sync pushes Fig; the collection
+	// must not be locked during the remote call
+	remote.onWrite = func() {
+		remote.onWrite = nil
+		time.Sleep(2 * time.Millisecond)
+		for id, location := range map[string]string{monty.ID: "Hall", fig.ID: "Bedroom"} {
+			p, _ := c.Get(id)
+			p.Location = location
+			if err := c.Update(p); err != nil {
+				t.Errorf("Update() during sync unexpected error: %v", err)
+			}
+		}
+	}
+
+	report, err := c.Sync(context.Background(), remote, nil)
+	if err == nil || len(report.Failed()) != 1 || report.Failed()[0].Kind != ActionPullUpdate {
+		t.Fatalf("Sync() = %+v, %v, want the stale pull reported", report.Failed(), err)
+	}
+	if got, _ := c.Get(monty.ID); got.Location != "Hall" {
+		t.Errorf("pull overwrote a concurrent update: location = %q, want Hall", got.Location)
+	}
+	got, _ := c.Get(fig.ID)
+	if got.Location != "Bedroom" || got.RemoteID == "" || !got.Updated.After(got.SyncedAt) {
+		t.Errorf("pushed entry = %+v, want it linked with its newer change still pending", got)
+	}
+}
+
+func TestCollection_SyncDuplicateNicknames(t *testing.T) {
+	c, _ := Open(NewMemoryStore())
+	fig, _ := c.Add(Plant{PID: "ficus lyrata", Nickname: "Fig"})
+	c.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty"})
+	remote := newFakeRemote(
+		RemotePlant{ID: "r1", PID: "monstera adansonii", Name: "monty", Updated: time.Now()},
+		RemotePlant{ID: "r2", PID: "pilea peperomioides", Name: "Pip", Updated: time.Now()},
+	)
+
+	report, err := c.Sync(context.Background(), remote, &SyncOptions{Direction: SyncPull})
+	if !errors.Is(err, ErrDuplicateNickname) {
+		t.Fatalf("Sync() error = %v, want ErrDuplicateNickname", err)
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Kind != ActionPullCreate || failed[0].Remote.ID != "r1" {
+		t.Errorf("Failed() = %+v, want the pull of the second Monty", failed)
+	}
+	if n := len(c.List()); n != 3 {
+		t.Errorf("collection has %d plants, want 3 (Pip pulled, Monty skipped)", n)
+	}
+
+	// An update renaming a linked entry to a taken nickname fails too
+	time.Sleep(2 * time.Millisecond)
+	c.Sync(context.Background(), remote, &SyncOptions{Direction: SyncPush})
+	fig, _ = c.Get(fig.ID)
+	r := remote.plants[fig.RemoteID]
+	r.Name, r.Updated = "Pip", time.Now().Add(time.Second)
+	remote.plants[r.ID] = r
+	report, _ = c.Sync(context.Background(), remote, &SyncOptions{Direction: SyncPull})
+	if !slices.ContainsFunc(report.Failed(), func(a SyncAction) bool {
+		return a.Kind == ActionPullUpdate && errors.Is(a.Err, ErrDuplicateNickname)
+	}) {
+		t.Errorf("Failed() = %+v, want the rename to Pip reported", report.Failed())
+	}
+	if got, _ := c.Get(fig.ID); got.Nickname != "Fig" {
+		t.Errorf("nickname = %q, want Fig", got.Nickname)
+	}
+	if _, err := c.Get("pip"); err != nil {
+		t.Errorf("Get(pip) unexpected error: %v", err)
+	}
+}
+
+func TestStoreRemote(t *testing.T) {
+	shared := NewMemoryStore()
+	laptop, _ := Open(NewMemoryStore())
+	desktop, _ := Open(NewMemoryStore())
+	remote := NewStoreRemote(shared)
+
+	monty, _ := laptop.Add(Plant{PID: "monstera deliciosa", Nickname: "Monty", Notes: "laptop only"})
+	if _, err := laptop.Sync(context.Background(), remote, nil); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if _, err := desktop.Sync(context.Background(), remote, nil); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	list := desktop.List()
+	if len(list) != 1 || list[0].Nickname != "Monty" || list[0].Notes != "" {
+		t.Fatalf("desktop collection = %+v, want Monty without laptop notes", list)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	monty, _ = laptop.Get(monty.ID)
+	monty.Location = "Kitchen"
+	laptop.Update(monty)
+	laptop.Sync(context.Background(), remote, nil)
+	desktop.Sync(context.Background(), remote, nil)
+	if got := desktop.List()[0]; got.Location != "Kitchen" {
+		t.Errorf("desktop location = %q, want Kitchen", got.Location)
+	}
+
+	if _, err := remote.UpdatePlant(context.Background(), RemotePlant{ID: "missing"}); !errors.Is(err, ErrPlantNotFound) {
+		t.Errorf("UpdatePlant() of a missing plant error = %v, want ErrPlantNotFound", err)
+	}
+}
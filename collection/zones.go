@@ -0,0 +1,126 @@
+package collection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// UnassignedZone is the zone name for plants without a location
+const UnassignedZone = "Unassigned"
+
+// Requirement is the combined range satisfying every plant in a zone for one metric
+type Requirement struct {
+	Metric care.Metric `json:"metric"`
+	Range  care.Range  `json:"range"`
+
+	// Plants is the number of plants with data for this metric
+	Plants int `json:"plants"`
+}
+
+// Conflict reports a metric for which no value satisfies every plant in a zone
+type Conflict struct {
+	Metric care.Metric `json:"metric"`
+
+	// High is the plant with the highest minimum
+	High string `json:"high"`
+	// HighRange is that plant's range
+	HighRange care.Range `json:"high_range"`
+
+	// Low is the plant with the lowest maximum
+	Low string `json:"low"`
+	// LowRange is that plant's range
+	LowRange care.Range `json:"low_range"`
+}
+
+// String describes the conflict
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s needs at least %g%s but %s tolerates at most %g%s",
+		c.Metric, c.High, c.HighRange.Min, c.Metric.Unit(), c.Low, c.LowRange.Max, c.Metric.Unit())
+}
+
+// Zone is a group of plants sharing a location
+type Zone struct {
+	Name         string        `json:"name"`
+	Entries      []Entry       `json:"entries"`
+	Requirements []Requirement `json:"requirements"`
+	Conflicts    []Conflict    `json:"conflicts,omitempty"`
+}
+
+// Compatible reports whether one set of conditions satisfies every plant
+func (z Zone) Compatible() bool {
+	return len(z.Conflicts) == 0
+}
+
+// Zones groups entries by location and computes each zone's requirements
+// Entries without details are listed in their zone but do not contribute
+// to requirements. Zones are returned sorted by name.
+func Zones(entries []Entry) []Zone {
+	groups := make(map[string][]Entry)
+	for _, e := range entries {
+		name := e.Plant.Location
+		if name == "" {
+			name = UnassignedZone
+		}
+		groups[name] = append(groups[name], e)
+	}
+
+	zones := make([]Zone, 0, len(groups))
+	for name, members := range groups {
+		zones = append(zones, AnalyzeZone(name, members))
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+	return zones
+}
+
+// AnalyzeZone intersects the care ranges of all entries for every metric
+func AnalyzeZone(name string, entries []Entry) Zone {
+	zone := Zone{Name: name, Entries: entries}
+
+	for _, metric := range care.Metrics {
+		var (
+			combined        care.Range
+			count           int
+			high, low       string
+			highRng, lowRng care.Range
+		)
+
+		for _, e := range entries {
+			r, ok := care.RangeFor(e.Details, metric)
+			if !ok {
+				continue
+			}
+
+			if count == 0 || r.Min > highRng.Min {
+				high, highRng = e.Plant.Name(), r
+			}
+			if count == 0 || r.Max < lowRng.Max {
+				low, lowRng = e.Plant.Name(), r
+			}
+			if count == 0 {
+				combined = r
+			} else {
+				combined, _ = combined.Intersect(r)
+			}
+			count++
+		}
+
+		if count == 0 {
+			continue
+		}
+		if !combined.Valid() {
+			zone.Conflicts = append(zone.Conflicts, Conflict{
+				Metric:    metric,
+				High:      high,
+				HighRange: highRng,
+				Low:       low,
+				LowRange:  lowRng,
+			})
+			continue
+		}
+		zone.Requirements = append(zone.Requirements, Requirement{Metric: metric, Range: combined, Plants: count})
+	}
+
+	return zone
+}
@@ -0,0 +1,78 @@
+package collection
+
+import (
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func entry(name, location string, minTemp, maxTemp float64, minHumid, maxHumid int) Entry {
+	return Entry{
+		Plant: Plant{PID: name, Nickname: name, Location: location},
+		Details: &openplantbook.PlantDetails{
+			PID:         name,
+			MinTemp:     minTemp,
+			MaxTemp:     maxTemp,
+			MinEnvHumid: minHumid,
+			MaxEnvHumid: maxHumid,
+		},
+	}
+}
+
+func TestZones(t *testing.T) {
+	entries := []Entry{
+		entry("monstera", "Windowsill", 15, 30, 40, 80),
+		entry("calathea", "Windowsill", 18, 24, 60, 90),
+		entry("cactus", "Office", 10, 35, 10, 30),
+		entry("fern", "Office", 16, 24, 60, 90),
+		{Plant: Plant{PID: "mystery"}}, // no details, no location
+	}
+
+	zones := Zones(entries)
+	if len(zones) != 3 {
+		t.Fatalf("Zones() returned %d zones, want 3", len(zones))
+	}
+
+	byName := make(map[string]Zone)
+	for _, z := range zones {
+		byName[z.Name] = z
+	}
+
+	sill := byName["Windowsill"]
+	if !sill.Compatible() {
+		t.Errorf("Windowsill has conflicts: %v", sill.Conflicts)
+	}
+	want := map[care.Metric]care.Range{
+		care.MetricTemperature: {Min: 18, Max: 24},
+		care.MetricHumidity:    {Min: 60, Max: 80},
+	}
+	if len(sill.Requirements) != len(want) {
+		t.Fatalf("Windowsill has %d requirements, want %d", len(sill.Requirements), len(want))
+	}
+	for _, req := range sill.Requirements {
+		if req.Range != want[req.Metric] {
+			t.Errorf("Windowsill %s = %v, want %v", req.Metric, req.Range, want[req.Metric])
+		}
+		if req.Plants != 2 {
+			t.Errorf("Windowsill %s counted %d plants, want 2", req.Metric, req.Plants)
+		}
+	}
+
+	office := byName["Office"]
+	if office.Compatible() {
+		t.Fatal("Office should have a humidity conflict")
+	}
+	c := office.Conflicts[0]
+	if c.Metric != care.MetricHumidity || c.High != "fern" || c.Low != "cactus" {
+		t.Errorf("Office conflict = %+v, want humidity fern vs cactus", c)
+	}
+	if got, want := c.String(), "humidity: fern needs at least 60% but cactus tolerates at most 30%"; got != want {
+		t.Errorf("Conflict.String() = %q, want %q", got, want)
+	}
+
+	unassigned := byName[UnassignedZone]
+	if len(unassigned.Entries) != 1 || len(unassigned.Requirements) != 0 {
+		t.Errorf("Unassigned zone = %+v, want 1 entry and no requirements", unassigned)
+	}
+}
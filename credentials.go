@@ -0,0 +1,84 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// CredentialProvider supplies the client's current credentials, e.g. from a
+// secrets manager such as Vault or AWS Secrets Manager (see
+// WithCredentialProvider)
+// It returns either an APIKey or a ClientID and ClientSecret, and must keep
+// returning the same kind.
+type CredentialProvider func(ctx context.Context) (Credentials, error)
+
+// WithCredentialProvider authenticates with credentials fetched from fn, so
+// rotated secrets are picked up without recreating the client
+// fn is called once by New to set up authentication, then whenever the
+// credentials need refreshing: before each OAuth2 token request, and when the
+// API rejects the credentials in use (unless WithReauth sets another hook).
+// It is an authentication method of its own, so it cannot be combined with
+// WithAPIKey, WithOAuth2 or WithTokenSource.
+func WithCredentialProvider(fn CredentialProvider) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return optionError("WithCredentialProvider", nil, "credential provider cannot be nil")
+		}
+		c.credentialProvider = fn
+		return nil
+	}
+}
+
+// provideCredentials fetches the initial credentials from the credential
+// provider, setting the client's authentication method
+func (c *Client) provideCredentials() error {
+	creds, err := c.credentialProvider(context.Background())
+	if err != nil {
+		return fmt.Errorf("credential provider: %w", c.redactor.Error(err))
+	}
+	c.redactor.addCredentials(creds.APIKey, creds.ClientID, creds.ClientSecret)
+
+	switch {
+	case creds.APIKey != "" && (creds.ClientID != "" || creds.ClientSecret != ""):
+		return ErrMultipleAuthMethods
+	case creds.APIKey != "":
+		c.apiKey = creds.APIKey
+		if c.reauth == nil {
+			c.reauth = ReauthFunc(c.credentialProvider)
+		}
+	case creds.ClientID != "" && creds.ClientSecret != "":
+		c.clientID, c.clientSecret = creds.ClientID, creds.ClientSecret
+	default:
+		return optionError("WithCredentialProvider", nil, "provider returned neither an API key nor OAuth2 client credentials")
+	}
+	return nil
+}
+
+// providerTokenSource fetches OAuth2 tokens with the client credentials
+// current at each request
+type providerTokenSource struct {
+	ctx      context.Context // carries the token endpoint's HTTP client
+	provider CredentialProvider
+	config   clientcredentials.Config // ClientID and ClientSecret are filled in per token
+	redactor *redactor
+}
+
+// Token implements oauth2.TokenSource
+func (s *providerTokenSource) Token() (*oauth2.Token, error) {
+	creds, err := s.provider(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("credential provider: %w", s.redactor.Error(err))
+	}
+	if creds.ClientID == "" || creds.ClientSecret == "" {
+		return nil, errors.New("credential provider: OAuth2 client credentials missing")
+	}
+	s.redactor.addCredentials("", creds.ClientID, creds.ClientSecret)
+
+	config := s.config
+	config.ClientID, config.ClientSecret = creds.ClientID, creds.ClientSecret
+	return config.Token(s.ctx)
+}
@@ -0,0 +1,139 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// rotatingProvider hands out credentials built from a generation counter
+type rotatingProvider struct {
+	calls atomic.Int32
+	gen   atomic.Int32
+	oauth bool
+}
+
+func (p *rotatingProvider) provide(ctx context.Context) (Credentials, error) {
+	p.calls.Add(1)
+	if p.oauth {
+		return Credentials{ClientID: "id", ClientSecret: fmt.Sprintf("secret-%d", p.gen.Load())}, nil
+	}
+	return Credentials{APIKey: fmt.Sprintf("api-key-%d", p.gen.Load())}, nil
+}
+
+func TestWithCredentialProvider_APIKeyRotation(t *testing.T) {
+	var requests atomic.Int32
+	var current atomic.Value
+	current.Store("api-key-0")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Token "+current.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer server.Close()
+
+	provider := &rotatingProvider{}
+	client, err := New(WithCredentialProvider(provider.provide), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+
+	// The key is rotated in the secrets manager; the old one stops working
+	provider.gen.Store(1)
+	current.Store("api-key-1")
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() after rotation unexpected error: %v", err)
+	}
+	if n := provider.calls.Load(); n != 2 {
+		t.Errorf("provider calls = %d, want 2 (setup and refresh)", n)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+	if auth := client.Status().Auth; auth != "api-key" {
+		t.Errorf("Status().Auth = %q, want api-key", auth)
+	}
+}
+
+func TestWithCredentialProvider_OAuth2Refresh(t *testing.T) {
+	var secrets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token/" {
+			_, secret, _ := r.BasicAuth()
+			secrets = append(secrets, secret)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"access_token":"token-%s","token_type":"bearer","expires_in":3600}`, secret)
+			return
+		}
+		if r.Header.Get("Authorization") == "Bearer token-secret-0" && len(secrets) > 0 && secrets[0] == "revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer server.Close()
+
+	provider := &rotatingProvider{oauth: true}
+	client, err := New(WithCredentialProvider(provider.provide), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+
+	// The secret rotates and the token issued with the old one is revoked
+	provider.gen.Store(1)
+	secrets[0] = "revoked"
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() after rotation unexpected error: %v", err)
+	}
+	if len(secrets) != 2 || secrets[1] != "secret-1" {
+		t.Errorf("token requests used secrets %v, want the rotated secret-1 second", secrets)
+	}
+	if auth := client.Status().Auth; auth != "oauth2" {
+		t.Errorf("Status().Auth = %q, want oauth2", auth)
+	}
+}
+
+func TestWithCredentialProvider_Errors(t *testing.T) {
+	failed := errors.New("secrets manager unavailable")
+	if _, err := New(WithCredentialProvider(func(ctx context.Context) (Credentials, error) {
+		return Credentials{}, failed
+	})); !errors.Is(err, failed) {
+		t.Errorf("failing provider: error = %v, want the provider's error", err)
+	}
+	if _, err := New(WithCredentialProvider(func(ctx context.Context) (Credentials, error) {
+		return Credentials{}, nil
+	})); err == nil {
+		t.Error("empty credentials: expected error")
+	}
+	if _, err := New(WithCredentialProvider(func(ctx context.Context) (Credentials, error) {
+		return Credentials{APIKey: "key", ClientID: "id", ClientSecret: "secret"}, nil
+	})); !errors.Is(err, ErrMultipleAuthMethods) {
+		t.Errorf("mixed credentials: error = %v, want ErrMultipleAuthMethods", err)
+	}
+	provider := &rotatingProvider{}
+	if _, err := New(WithCredentialProvider(provider.provide), WithAPIKey("key")); !errors.Is(err, ErrMultipleAuthMethods) {
+		t.Errorf("provider with API key: error = %v, want ErrMultipleAuthMethods", err)
+	}
+	if _, err := New(WithCredentialProvider(nil)); err == nil {
+		t.Error("nil provider: expected error")
+	}
+}
@@ -0,0 +1,133 @@
+package openplantbook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+)
+
+// debugTransport dumps requests and responses with credentials redacted
+type debugTransport struct {
+	transport http.RoundTripper
+
+	// tokenURL identifies OAuth2 token requests, whose bodies carry secrets
+	tokenURL string
+
+	// redactor scrubs credentials that appear outside headers
+	redactor *redactor
+
+	// maxBody bounds how much of a response body is buffered for the dump
+	maxBody int64
+
+	mu sync.Mutex // serializes writes to w
+	w  io.Writer
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.dumpRequest(req)
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.printf("<-- error %s %s (%s): %v\n\n", req.Method, req.URL.Redacted(), elapsed, err)
+		return nil, err
+	}
+
+	t.dumpResponse(resp, elapsed)
+	return resp, nil
+}
+
+func (t *debugTransport) dumpRequest(req *http.Request) {
+	out := req.Clone(req.Context())
+	out.Header = redactHeaders(req.Header)
+	out.Body = nil
+
+	// Only dump bodies that can be replayed, so the real request is untouched
+	withBody := false
+	if req.Body != nil && req.GetBody != nil && !t.isTokenRequest(req) {
+		if body, err := req.GetBody(); err == nil {
+			out.Body = body
+			withBody = true
+		}
+	}
+
+	dump, err := httputil.DumpRequestOut(out, withBody)
+	if err != nil {
+		t.printf("--> %s %s (dump failed: %v)\n\n", req.Method, req.URL.Redacted(), err)
+		return
+	}
+	t.printf("--> %s %s\n%s\n\n", req.Method, req.URL.Redacted(), dump)
+}
+
+func (t *debugTransport) dumpResponse(resp *http.Response, elapsed time.Duration) {
+	out := *resp
+	out.Header = redactHeaders(resp.Header)
+
+	dump, err := httputil.DumpResponse(&out, false)
+	if err != nil {
+		t.printf("<-- %s (%s, dump failed: %v)\n\n", resp.Status, elapsed, err)
+		return
+	}
+	withBody := resp.Request == nil || !t.isTokenRequest(resp.Request)
+	if withBody {
+		dump = append(dump, t.peekBody(resp)...)
+	}
+	t.printf("<-- %s (%s)\n%s\n\n", resp.Status, elapsed, dump)
+}
+
+// peekBody returns up to maxBody bytes of the response body for the dump
+// The bytes read are put back in front of the rest of the body, so the
+// client's own size limit still sees the full response.
+func (t *debugTransport) peekBody(resp *http.Response) []byte {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	if resp.ContentLength > t.maxBody {
+		return []byte(fmt.Sprintf("(body omitted: %d bytes exceeds %d byte limit)", resp.ContentLength, t.maxBody))
+	}
+
+	peeked, err := io.ReadAll(io.LimitReader(resp.Body, t.maxBody+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), resp.Body), resp.Body}
+
+	body := peeked
+	if int64(len(body)) > t.maxBody {
+		body = append(body[:t.maxBody:t.maxBody], fmt.Sprintf("\n(body truncated at %d bytes)", t.maxBody)...)
+	}
+	if err != nil {
+		body = append(body[:len(body):len(body)], fmt.Sprintf("\n(body read failed: %v)", err)...)
+	}
+	return body
+}
+
+// isTokenRequest reports whether req fetches an OAuth2 token
+func (t *debugTransport) isTokenRequest(req *http.Request) bool {
+	return t.tokenURL != "" && req.URL.String() == t.tokenURL
+}
+
+func (t *debugTransport) printf(format string, args ...interface{}) {
+	msg := t.redactor.String(fmt.Sprintf(format, args...))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.w, msg)
+}
+
+// redactHeaders returns a copy of h with sensitive values replaced
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := out[name]; ok {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
@@ -0,0 +1,190 @@
+package openplantbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWithHTTPDebug(t *testing.T) {
+	const apiKey = "super-secret-key"
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+		w.Write([]byte(`{"count":1,"next":null,"previous":null,"results":[{"pid":"test","display_pid":"Test","alias":"Test Plant","category":"Test"}]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := New(
+		WithAPIKey(apiKey),
+		WithBaseURL(server.URL),
+		WithHTTPDebug(&buf),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	results, err := client.SearchPlants(context.Background(), "monstera", nil)
+	if err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("SearchPlants() returned %d results, want 1 (body consumed by dump?)", len(results))
+	}
+
+	// Authentication still reaches the server
+	if gotAuth != "Token "+apiKey {
+		t.Errorf("server received Authorization %q, want %q", gotAuth, "Token "+apiKey)
+	}
+
+	dump := buf.String()
+	for _, want := range []string{
+		"--> GET " + server.URL + "/plant/search?alias=monstera",
+		"Authorization: " + redacted,
+		"Set-Cookie: " + redacted,
+		"<-- 200 OK",
+		`"alias":"Test Plant"`,
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("debug output missing %q:\n%s", want, dump)
+		}
+	}
+	for _, secret := range []string{apiKey, "cookie-secret"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("debug output leaks %q:\n%s", secret, dump)
+		}
+	}
+}
+
+func TestWithHTTPDebug_OAuth2(t *testing.T) {
+	const (
+		clientSecret = "client-secret-value"
+		accessToken  = "access-token-value"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token/" {
+			w.Write([]byte(`{"access_token":"` + accessToken + `","token_type":"bearer","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test","display_pid":"Test","alias":"Test Plant"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := New(
+		WithOAuth2("client-id", clientSecret),
+		WithBaseURL(server.URL),
+		WithHTTPDebug(&buf),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.GetPlantDetails(context.Background(), "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+
+	dump := buf.String()
+	if !strings.Contains(dump, "--> POST "+server.URL+"/token/") {
+		t.Errorf("debug output missing token request:\n%s", dump)
+	}
+	if !strings.Contains(dump, "Authorization: "+redacted) {
+		t.Errorf("debug output missing redacted Authorization header:\n%s", dump)
+	}
+	for _, secret := range []string{clientSecret, accessToken} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("debug output leaks %q:\n%s", secret, dump)
+		}
+	}
+}
+
+func TestWithHTTPDebug_MaxResponseSize(t *testing.T) {
+	const limit = 64
+	body := `{"results":[` + strings.Repeat(`"x",`, 1000) + `"x"]}`
+
+	for _, tt := range []struct {
+		name          string
+		contentLength bool
+		want          string
+	}{
+		{"chunked", false, "(body truncated at 64 bytes)"},
+		{"content length", true, "(body omitted:"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentLength {
+					w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+				}
+				w.Write([]byte(body[:limit]))
+				w.(http.Flusher).Flush()
+				w.Write([]byte(body[limit:]))
+			}))
+			defer server.Close()
+
+			var buf bytes.Buffer
+			client, err := New(
+				WithAPIKey("test-key"),
+				WithBaseURL(server.URL),
+				WithHTTPDebug(&buf),
+				WithMaxResponseSize(limit),
+				DisableRateLimit(),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.SearchPlants(context.Background(), "monstera", nil)
+			var tooLarge *ErrResponseTooLarge
+			if !errors.As(err, &tooLarge) {
+				t.Fatalf("SearchPlants() error = %v, want ErrResponseTooLarge", err)
+			}
+
+			dump := buf.String()
+			if !strings.Contains(dump, tt.want) {
+				t.Errorf("debug output missing %q:\n%s", tt.want, dump)
+			}
+			if strings.Count(dump, `"x",`) > limit/4 {
+				t.Errorf("debug output holds more than %d body bytes:\n%s", limit, dump)
+			}
+		})
+	}
+}
+
+func TestWithHTTPDebug_CustomHTTPClient(t *testing.T) {
+	custom := &http.Client{}
+
+	var buf bytes.Buffer
+	client, err := New(WithHTTPClient(custom), WithHTTPDebug(&buf))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if custom.Transport != nil {
+		t.Error("WithHTTPDebug modified the caller's HTTP client")
+	}
+	if _, ok := client.httpClient.Transport.(*debugTransport); !ok {
+		t.Errorf("client transport = %T, want *debugTransport", client.httpClient.Transport)
+	}
+}
+
+func TestWithHTTPDebug_Nil(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithHTTPDebug(nil)); err == nil {
+		t.Error("WithHTTPDebug(nil) expected error, got nil")
+	}
+}
@@ -0,0 +1,76 @@
+package openplantbook
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DedupeResults merges search results with the same scientific name
+//
+// Names are compared by their DisplayPID (or PID when missing), ignoring
+// case, quotes, hyphens and other punctuation, hybrid marks and "cv.", so
+// "Epipremnum aureum 'Marble Queen'" and "epipremnum aureum marble-queen"
+// are merged. The first result of each name is kept, in order; the PIDs and
+// aliases of the others are recorded in its Duplicates and MergedAliases, and
+// its empty fields are filled from them.
+func DedupeResults(results []PlantSearchResult) []PlantSearchResult {
+	out := make([]PlantSearchResult, 0, len(results))
+	index := make(map[string]int, len(results))
+
+	for _, r := range results {
+		name := r.DisplayPID
+		if name == "" {
+			name = r.PID
+		}
+		key := normalizeName(name)
+
+		i, seen := index[key]
+		if !seen || key == "" {
+			index[key] = len(out)
+			out = append(out, r)
+			continue
+		}
+
+		kept := &out[i]
+		kept.Duplicates = append(kept.Duplicates, r.PID)
+		if r.Alias != "" && !strings.EqualFold(r.Alias, kept.Alias) &&
+			!slices.ContainsFunc(kept.MergedAliases, func(a string) bool { return strings.EqualFold(a, r.Alias) }) {
+			kept.MergedAliases = append(kept.MergedAliases, r.Alias)
+		}
+		if kept.DisplayPID == "" {
+			kept.DisplayPID = r.DisplayPID
+		}
+		if kept.Alias == "" {
+			kept.Alias = r.Alias
+		}
+		if kept.Category == "" {
+			kept.Category = r.Category
+		}
+	}
+	return out
+}
+
+// dedupeIfRequested applies DedupeResults when opts asks for it
+func dedupeIfRequested(results []PlantSearchResult, opts *SearchOptions) []PlantSearchResult {
+	if opts == nil || !opts.Dedupe {
+		return results
+	}
+	return DedupeResults(results)
+}
+
+// normalizeName reduces a plant name to lowercase words
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	kept := words[:0]
+	for _, w := range words {
+		if w == "x" || w == "cv" {
+			continue
+		}
+		kept = append(kept, w)
+	}
+	return strings.Join(kept, " ")
+}
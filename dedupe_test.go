@@ -0,0 +1,80 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDedupeResults(t *testing.T) {
+	results := []PlantSearchResult{
+		{PID: "epipremnum aureum marble queen", DisplayPID: "Epipremnum aureum 'Marble Queen'", Alias: "Marble Queen Pothos"},
+		{PID: "monstera deliciosa", DisplayPID: "Monstera deliciosa", Alias: "Monstera"},
+		{PID: "epipremnum aureum marble-queen", DisplayPID: "epipremnum aureum Marble-Queen", Alias: "Devil's Ivy", Category: "Araceae"},
+		{PID: "monstera deliciosa 2", DisplayPID: "MONSTERA DELICIOSA", Alias: "monstera"},
+		{PID: "epipremnum aureum cv marble queen", Alias: "Devil's Ivy"},
+	}
+
+	got := DedupeResults(results)
+	if len(got) != 2 {
+		t.Fatalf("DedupeResults() returned %d results, want 2: %+v", len(got), got)
+	}
+
+	pothos := got[0]
+	if pothos.PID != "epipremnum aureum marble queen" {
+		t.Errorf("kept PID = %q, want the first result", pothos.PID)
+	}
+	if len(pothos.Duplicates) != 2 {
+		t.Errorf("Duplicates = %v, want 2 PIDs", pothos.Duplicates)
+	}
+	if len(pothos.MergedAliases) != 1 || pothos.MergedAliases[0] != "Devil's Ivy" {
+		t.Errorf("MergedAliases = %v, want [Devil's Ivy]", pothos.MergedAliases)
+	}
+	if pothos.Category != "Araceae" {
+		t.Errorf("Category = %q, want it filled from the duplicate", pothos.Category)
+	}
+
+	monstera := got[1]
+	if len(monstera.Duplicates) != 1 || len(monstera.MergedAliases) != 0 {
+		t.Errorf("monstera = %+v, want one duplicate and no new aliases", monstera)
+	}
+}
+
+func TestClient_SearchPlants_Dedupe(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(searchResponse{Results: []PlantSearchResult{
+			{PID: "monstera deliciosa", DisplayPID: "Monstera deliciosa"},
+			{PID: "monstera deliciosa 2", DisplayPID: "monstera Deliciosa"},
+		}})
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	raw, err := client.SearchPlants(context.Background(), "monstera", &SearchOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	if len(raw) != 2 {
+		t.Errorf("SearchPlants() returned %d results, want 2 without Dedupe", len(raw))
+	}
+
+	deduped, err := client.SearchPlants(context.Background(), "monstera", &SearchOptions{Limit: 10, Dedupe: true})
+	if err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	if len(deduped) != 1 || len(deduped[0].Duplicates) != 1 {
+		t.Errorf("SearchPlants(Dedupe) = %+v, want one merged result", deduped)
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1 (deduped search served from cache)", requests)
+	}
+}
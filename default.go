@@ -0,0 +1,58 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNoDefaultClient is returned by the package-level functions before
+// SetDefault has been called
+var ErrNoDefaultClient = errors.New("no default client (call SetDefault)")
+
+// defaultClient is the client of the package-level functions
+var defaultClient atomic.Pointer[Client]
+
+// SetDefault sets the client used by the package-level functions (Search,
+// Details), for scripts and examples that do not want to pass a client
+// around; nil unsets it
+//
+// Libraries should take a *Client rather than rely on the default. The
+// caller keeps ownership of the client and closes it when done.
+//
+//	client, err := openplantbook.New(openplantbook.WithAPIKey(apiKey))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Close()
+//	openplantbook.SetDefault(client)
+//
+//	results, err := openplantbook.Search(ctx, "monstera")
+func SetDefault(client *Client) {
+	defaultClient.Store(client)
+}
+
+// Default returns the client set with SetDefault, or nil
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+// Search searches for plants with the default client and its default
+// search options (see Client.SearchPlants)
+func Search(ctx context.Context, query string) ([]PlantSearchResult, error) {
+	client := Default()
+	if client == nil {
+		return nil, ErrNoDefaultClient
+	}
+	return client.SearchPlants(ctx, query, nil)
+}
+
+// Details looks up plant details in English with the default client (see
+// Client.GetPlantDetails)
+func Details(ctx context.Context, pid string) (*PlantDetails, error) {
+	client := Default()
+	if client == nil {
+		return nil, ErrNoDefaultClient
+	}
+	return client.GetPlantDetails(ctx, pid, nil)
+}
@@ -0,0 +1,54 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDefaultClient(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+	ctx := context.Background()
+
+	SetDefault(nil)
+	if _, err := Search(ctx, "monstera"); !errors.Is(err, ErrNoDefaultClient) {
+		t.Errorf("Search() without a default error = %v, want ErrNoDefaultClient", err)
+	}
+	if _, err := Details(ctx, "monstera-deliciosa"); !errors.Is(err, ErrNoDefaultClient) {
+		t.Errorf("Details() without a default error = %v, want ErrNoDefaultClient", err)
+	}
+
+	searchData, _ := os.ReadFile("testdata/search_response.json")
+	detailData, _ := os.ReadFile("testdata/detail_response.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, searchPath) {
+			w.Write(searchData)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	SetDefault(client)
+	if Default() != client {
+		t.Fatal("Default() did not return the client passed to SetDefault")
+	}
+
+	results, err := Search(ctx, "monstera")
+	if err != nil || len(results) != 2 {
+		t.Errorf("Search() = %d results, %v; want 2", len(results), err)
+	}
+	details, err := Details(ctx, "monstera-deliciosa")
+	if err != nil || details.PID != "monstera-deliciosa" {
+		t.Errorf("Details() = %+v, %v", details, err)
+	}
+}
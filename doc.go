@@ -94,5 +94,17 @@
 //	    openplantbook.WithRateLimit(100), // 100 requests/day
 //	)
 //
+// # Concurrency
+//
+// A Client is safe for concurrent use by multiple goroutines and should be
+// shared rather than created per request, so that all callers draw from the
+// same cache and rate limiter. The logger and rate limit behavior can be
+// changed while requests are in flight:
+//
+//	client.SetRateLimitBehavior(openplantbook.RateLimitError)
+//
+// Custom Cache implementations passed to WithCache must also be safe for
+// concurrent use.
+//
 // For more information, see: https://github.com/rmrfslashbin/openplantbook-go
 package openplantbook
@@ -0,0 +1,183 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxCandidates is the most PIDs an ErrAmbiguousName error lists
+const maxCandidates = 5
+
+var (
+	// ErrAmbiguousName is returned when a name matches several plants
+	// equally well; the error lists some of their PIDs
+	ErrAmbiguousName = errors.New("name matches several plants")
+
+	// ErrIncompleteData is returned by EnsurePlantData for a plant record
+	// without a PID or without any care thresholds
+	ErrIncompleteData = errors.New("plant record is incomplete")
+)
+
+// EnsureStep identifies the step of EnsurePlantData that failed
+type EnsureStep string
+
+const (
+	// StepResolve is resolving the name to a PID (see ResolvePID)
+	StepResolve EnsureStep = "name resolution"
+	// StepDetails is fetching the details of the PID
+	StepDetails EnsureStep = "details lookup"
+	// StepValidate is checking the details hold care data
+	StepValidate EnsureStep = "validation"
+)
+
+// EnsureError is returned by EnsurePlantData, naming the step that failed
+//
+// It unwraps to the step's error, so errors.Is(err, ErrNotFound) and
+// errors.As with *APIError work as for the individual calls.
+type EnsureError struct {
+	Step EnsureStep // The step that failed
+	Name string     // The name EnsurePlantData was called with
+	PID  string     // The resolved PID; empty if resolution failed
+	Err  error      // The step's error
+}
+
+// Error implements the error interface
+func (e *EnsureError) Error() string {
+	if e.PID != "" && e.PID != e.Name {
+		return fmt.Sprintf("%s failed for %q (pid %q): %v", e.Step, e.Name, e.PID, e.Err)
+	}
+	return fmt.Sprintf("%s failed for %q: %v", e.Step, e.Name, e.Err)
+}
+
+// Unwrap returns the step's error
+func (e *EnsureError) Unwrap() error {
+	return e.Err
+}
+
+// ResolvePID returns the PID of the plant called name
+//
+// A PID already in the local index is returned as is. Otherwise the name is
+// searched for literally (wildcards are escaped), honoring the UserPlants
+// default of WithDefaultSearchOptions. A result whose PID, scientific name
+// or alias equals the name, ignoring case and punctuation, is preferred;
+// without one, a single result is accepted. No results return ErrNotFound,
+// several equally good ones ErrAmbiguousName.
+func (c *Client) ResolvePID(ctx context.Context, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrInvalidInput("name cannot be empty")
+	}
+	if c.index.has(name) {
+		return name, nil
+	}
+
+	opts := SearchOptions{Dedupe: true}
+	if c.defaultSearch != nil {
+		opts.UserPlants = c.defaultSearch.UserPlants
+	}
+	results, err := c.SearchPlants(ctx, EscapeQuery(name), &opts)
+	if err != nil {
+		return "", err
+	}
+
+	if exact := exactMatches(results, name); len(exact) > 0 {
+		results = exact
+	}
+	switch len(results) {
+	case 0:
+		return "", ErrNotFound
+	case 1:
+		return results[0].PID, nil
+	}
+	pids := make([]string, 0, maxCandidates)
+	for _, r := range results[:min(len(results), maxCandidates)] {
+		pids = append(pids, r.PID)
+	}
+	if len(results) > maxCandidates {
+		pids = append(pids, "…")
+	}
+	return "", fmt.Errorf("%w: %s", ErrAmbiguousName, strings.Join(pids, ", "))
+}
+
+// exactMatches returns the results named name, comparing names as
+// DedupeResults does
+func exactMatches(results []PlantSearchResult, name string) []PlantSearchResult {
+	want := normalizeName(name)
+	var out []PlantSearchResult
+	for _, r := range results {
+		for _, n := range append([]string{r.PID, r.DisplayPID, r.Alias}, r.MergedAliases...) {
+			if normalizeName(n) == want {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// EnsurePlantData returns validated, normalized details of the plant called
+// name, which may be a PID, scientific name or common name
+//
+// It resolves the name (see ResolvePID), fetches the details, checks they
+// hold care data (ErrIncompleteData if the PID or every threshold range is
+// missing) and normalizes them: text fields are trimmed, a missing
+// DisplayPID is filled from the PID, and a range whose minimum exceeds its
+// maximum is swapped. Errors are *EnsureError, naming the failed step.
+//
+// The returned details are a copy; changing them does not affect the cache.
+func (c *Client) EnsurePlantData(ctx context.Context, name string) (*PlantDetails, error) {
+	pid, err := c.ResolvePID(ctx, name)
+	if err != nil {
+		return nil, &EnsureError{Step: StepResolve, Name: name, Err: err}
+	}
+	details, err := c.GetPlantDetails(ctx, pid, nil)
+	if err != nil {
+		return nil, &EnsureError{Step: StepDetails, Name: name, PID: pid, Err: err}
+	}
+	if err := validateDetails(details); err != nil {
+		return nil, &EnsureError{Step: StepValidate, Name: name, PID: pid, Err: err}
+	}
+	normalized := *details // details may be held by the local index
+	normalizeDetails(&normalized)
+	return &normalized, nil
+}
+
+// validateDetails checks d identifies a plant and has some care data
+func validateDetails(d *PlantDetails) error {
+	if strings.TrimSpace(d.PID) == "" {
+		return fmt.Errorf("%w: no pid", ErrIncompleteData)
+	}
+	if d.MinLightLux == 0 && d.MaxLightLux == 0 &&
+		d.MinTemp == 0 && d.MaxTemp == 0 &&
+		d.MinEnvHumid == 0 && d.MaxEnvHumid == 0 &&
+		d.MinSoilMoist == 0 && d.MaxSoilMoist == 0 &&
+		d.MinSoilEC == 0 && d.MaxSoilEC == 0 {
+		return fmt.Errorf("%w: no care thresholds", ErrIncompleteData)
+	}
+	return nil
+}
+
+// normalizeDetails trims text fields, fills a missing DisplayPID and puts
+// reversed threshold ranges in order
+func normalizeDetails(d *PlantDetails) {
+	for _, s := range []*string{&d.PID, &d.DisplayPID, &d.Alias, &d.Category, &d.ImageURL, &d.Language} {
+		*s = strings.TrimSpace(*s)
+	}
+	if d.DisplayPID == "" {
+		d.DisplayPID = d.ScientificName()
+	}
+	order(&d.MinLightLux, &d.MaxLightLux)
+	order(&d.MinTemp, &d.MaxTemp)
+	order(&d.MinEnvHumid, &d.MaxEnvHumid)
+	order(&d.MinSoilMoist, &d.MaxSoilMoist)
+	order(&d.MinSoilEC, &d.MaxSoilEC)
+}
+
+// order swaps *lo and *hi if *lo is larger
+func order[T int | float64](lo, hi *T) {
+	if *lo > *hi {
+		*lo, *hi = *hi, *lo
+	}
+}
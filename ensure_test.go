@@ -0,0 +1,129 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ensureServer answers searches with search and detail lookups with details
+// (404 when empty)
+func ensureServer(t *testing.T, search, details string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, searchPath):
+			w.Write([]byte(search))
+		case details == "":
+			http.Error(w, `{"detail":"Not found."}`, http.StatusNotFound)
+		default:
+			w.Write([]byte(details))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+const ensureSearch = `{"count":3,"next":null,"previous":null,"results":[
+	{"pid":"monstera deliciosa","display_pid":"Monstera deliciosa","alias":"Swiss cheese plant","category":"Araceae"},
+	{"pid":"monstera adansonii","display_pid":"Monstera adansonii","alias":"Monkey mask","category":"Araceae"},
+	{"pid":"monstera deliciosa 'thai constellation'","display_pid":"Monstera deliciosa 'Thai Constellation'","alias":"","category":"Araceae"}
+]}`
+
+func TestResolvePID(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  string
+		query   string
+		want    string
+		wantErr error
+	}{
+		{"scientific name", ensureSearch, "Monstera Deliciosa", "monstera deliciosa", nil},
+		{"alias", ensureSearch, "monkey-mask", "monstera adansonii", nil},
+		{"cultivar", ensureSearch, "monstera deliciosa thai constellation", "monstera deliciosa 'thai constellation'", nil},
+		{"ambiguous", ensureSearch, "monstera", "", ErrAmbiguousName},
+		{"single result", `{"count":1,"results":[{"pid":"ficus lyrata","display_pid":"Ficus lyrata"}]}`, "fiddle", "ficus lyrata", nil},
+		{"no results", `{"count":0,"results":[]}`, "nothing", "", ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ensureServer(t, tt.search, "")
+			got, err := client.ResolvePID(context.Background(), tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolvePID(%q) error = %v, want %v", tt.query, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolvePID(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsurePlantData(t *testing.T) {
+	details := `{"pid":"monstera deliciosa ","display_pid":"","alias":" Swiss cheese plant",
+		"min_temp":30,"max_temp":12,"min_light_lux":1500,"max_light_lux":15000}`
+	client := ensureServer(t, ensureSearch, details)
+
+	got, err := client.EnsurePlantData(context.Background(), "Swiss Cheese Plant")
+	if err != nil {
+		t.Fatalf("EnsurePlantData() unexpected error: %v", err)
+	}
+	if got.PID != "monstera deliciosa" || got.Alias != "Swiss cheese plant" {
+		t.Errorf("text fields not trimmed: pid %q, alias %q", got.PID, got.Alias)
+	}
+	if got.DisplayPID != "Monstera deliciosa" {
+		t.Errorf("DisplayPID = %q, want %q", got.DisplayPID, "Monstera deliciosa")
+	}
+	if got.MinTemp != 12 || got.MaxTemp != 30 {
+		t.Errorf("temperature range = %g-%g, want 12-30", got.MinTemp, got.MaxTemp)
+	}
+	if got.MinLightLux != 1500 || got.MaxLightLux != 15000 {
+		t.Errorf("light range = %d-%d, want 1500-15000", got.MinLightLux, got.MaxLightLux)
+	}
+}
+
+func TestEnsurePlantData_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		search   string
+		details  string
+		query    string
+		wantStep EnsureStep
+		wantPID  string
+		wantErr  error
+	}{
+		{"empty name", ensureSearch, "", " ", StepResolve, "", nil},
+		{"ambiguous", ensureSearch, "", "monstera", StepResolve, "", ErrAmbiguousName},
+		{"not found", ensureSearch, "", "monkey mask", StepDetails, "monstera adansonii", ErrNotFound},
+		{"no care data", ensureSearch, `{"pid":"monstera adansonii","display_pid":"Monstera adansonii"}`, "monkey mask", StepValidate, "monstera adansonii", ErrIncompleteData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ensureServer(t, tt.search, tt.details)
+			_, err := client.EnsurePlantData(context.Background(), tt.query)
+
+			var ensureErr *EnsureError
+			if !errors.As(err, &ensureErr) {
+				t.Fatalf("EnsurePlantData() error = %v, want *EnsureError", err)
+			}
+			if ensureErr.Step != tt.wantStep || ensureErr.PID != tt.wantPID {
+				t.Errorf("failed at %s for pid %q, want %s for %q", ensureErr.Step, ensureErr.PID, tt.wantStep, tt.wantPID)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("EnsurePlantData() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), string(tt.wantStep)) {
+				t.Errorf("error %q does not name the step", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package openplantbook
+
+import (
+	"errors"
+	"net"
+	"net/url"
+)
+
+// DefaultTokenURL is the OAuth2 token endpoint of DefaultBaseURL
+const DefaultTokenURL = DefaultBaseURL + "/token/"
+
+// Environment is an OpenPlantbook deployment: the API base URL and the
+// OAuth2 token endpoint that belongs to it
+type Environment struct {
+	BaseURL string
+
+	// TokenURL is the OAuth2 token endpoint; empty means BaseURL + "/token/"
+	TokenURL string
+}
+
+// Production is the public OpenPlantbook API
+//
+// OpenPlantbook does not publish a separate sandbox, so there is no preset
+// for one; point integration tests at a staging deployment or a local stub
+// with WithEnvironment.
+var Production = Environment{BaseURL: DefaultBaseURL, TokenURL: DefaultTokenURL}
+
+// WithEnvironment sets the base URL and OAuth2 token endpoint together, so
+// the token is never requested from a different deployment than the API
+//
+// Example:
+//
+//	openplantbook.WithEnvironment(openplantbook.Environment{
+//	    BaseURL:  os.Getenv("OPENPLANTBOOK_STAGING_URL"),
+//	    TokenURL: os.Getenv("OPENPLANTBOOK_STAGING_TOKEN_URL"),
+//	})
+func WithEnvironment(env Environment) Option {
+	return func(c *Client) error {
+		if !absoluteURL(env.BaseURL) {
+			return optionError("WithEnvironment", env.BaseURL, "base URL must be an absolute URL")
+		}
+		if env.TokenURL != "" {
+			if err := checkTokenURL(env.TokenURL); err != nil {
+				return optionError("WithEnvironment", env.TokenURL, err.Error())
+			}
+		}
+		c.baseURL = env.BaseURL
+		c.tokenEndpoint = env.TokenURL
+		return nil
+	}
+}
+
+// absoluteURL reports whether s is an absolute http(s) URL
+func absoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// checkTokenURL validates an OAuth2 token endpoint, which receives the
+// client secret: it must be absolute and use https, except on loopback
+// addresses such as a local test server
+func checkTokenURL(s string) error {
+	if !absoluteURL(s) {
+		return errors.New("token URL must be an absolute URL")
+	}
+	u, _ := url.Parse(s)
+	if u.Scheme == "https" {
+		return nil
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return nil
+	}
+	return errors.New("token URL must use https")
+}
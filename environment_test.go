@@ -0,0 +1,114 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithEnvironment(t *testing.T) {
+	var tokenRequests atomic.Int32
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"staging-token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer auth.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer staging-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer api.Close()
+
+	client, err := New(
+		WithOAuth2("client-id", "client-secret"),
+		WithEnvironment(Environment{BaseURL: api.URL, TokenURL: auth.URL + "/oauth/token"}),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.GetPlantDetails(context.Background(), "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if n := tokenRequests.Load(); n != 1 {
+		t.Errorf("token requests = %d, want 1", n)
+	}
+}
+
+func TestWithEnvironment_TokenURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, DefaultTokenURL},
+		{"production", []Option{WithEnvironment(Production)}, DefaultTokenURL},
+		{"derived", []Option{WithEnvironment(Environment{BaseURL: "https://staging.example.com/api/v1"})}, "https://staging.example.com/api/v1/token/"},
+		{"token URL kept with a later base URL", []Option{
+			WithTokenURL("https://auth.example.com/token"),
+			WithBaseURL("https://b.example.com"),
+		}, "https://auth.example.com/token"},
+		{"token URL overrides environment", []Option{
+			WithEnvironment(Environment{BaseURL: "https://a.example.com", TokenURL: "https://auth.example.com/token"}),
+			WithTokenURL("https://sso.example.com/token"),
+		}, "https://sso.example.com/token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(append([]Option{WithAPIKey("test-key")}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+			if got := client.tokenURL(); got != tt.want {
+				t.Errorf("tokenURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithEnvironment_Invalid(t *testing.T) {
+	for _, env := range []Environment{
+		{},
+		{BaseURL: "staging.example.com"},
+		{BaseURL: "https://staging.example.com", TokenURL: "/token/"},
+		{BaseURL: "https://staging.example.com", TokenURL: "http://auth.example.com/token/"},
+	} {
+		_, err := New(WithAPIKey("test-key"), WithEnvironment(env))
+		var cfgErr *ConfigError
+		if !errors.As(err, &cfgErr) {
+			t.Errorf("WithEnvironment(%+v) error = %v, want *ConfigError", env, err)
+		}
+	}
+}
+
+func TestWithTokenURL_Validation(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://auth.example.com/oauth/token/", true},
+		{"http://127.0.0.1:8080/token/", true},
+		{"http://localhost/token/", true},
+		{"http://[::1]:9000/token/", true},
+		{"http://auth.example.com/token/", false},
+		{"/token/", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		_, err := New(WithOAuth2("id", "secret"), WithTokenURL(tt.url))
+		if (err == nil) != tt.valid {
+			t.Errorf("WithTokenURL(%q) error = %v, want valid=%v", tt.url, err, tt.valid)
+		}
+	}
+}
@@ -1,9 +1,19 @@
 package openplantbook
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
+	"net"
 	"net/http"
+	"regexp"
+	"strings"
+	"syscall"
 	"time"
 )
 
@@ -11,13 +21,17 @@ import (
 var (
 	// Authentication errors
 	ErrUnauthorized        = errors.New("invalid credentials or token expired")
-	ErrMultipleAuthMethods = errors.New("multiple authentication methods provided (use only API key OR OAuth2)")
-	ErrNoAuthProvided      = errors.New("no authentication provided (use WithAPIKey or WithOAuth2)")
+	ErrMultipleAuthMethods = errors.New("multiple authentication methods provided (use only one of API key, OAuth2 or token source)")
+	ErrNoAuthProvided      = errors.New("no authentication provided (use WithAPIKey, WithOAuth2 or WithTokenSource)")
 
 	// API errors
 	ErrRateLimitExceeded = errors.New("rate limit exceeded (200 requests/day)")
 	ErrNotFound          = errors.New("plant not found")
 
+	// ErrBudgetExhausted is returned when a request would exceed the budget
+	// of its context (see WithBudget)
+	ErrBudgetExhausted = errors.New("request budget exhausted")
+
 	// Input validation
 	ErrInvalidInput = func(msg string) error { return &ValidationError{Message: msg} }
 
@@ -26,11 +40,15 @@ var (
 )
 
 // APIError represents an error response from the OpenPlantbook API
+// For statuses without a sentinel error (e.g. 5xx), Message includes the
+// error the body reports (a JSON detail, error or message field, or the
+// title of an HTML page) and the Server header, telling the API, a proxy
+// or a CDN apart; Body holds the start of the body.
 type APIError struct {
 	StatusCode int
 	Message    string
 	Endpoint   string
-	Body       string
+	Body       string // At most errorBodyLen bytes, with credentials redacted
 }
 
 // Error implements the error interface
@@ -64,15 +82,31 @@ func (e *ValidationError) Error() string {
 }
 
 // ConfigError represents a configuration error
+// Option names the failing option (e.g. "WithRateLimit") and Value holds the
+// rejected argument, when there is one worth reporting. Credentials are never
+// recorded in Value.
 type ConfigError struct {
+	Option  string
+	Value   interface{}
 	Message string
 }
 
 // Error implements the error interface
 func (e *ConfigError) Error() string {
+	switch {
+	case e.Option != "" && e.Value != nil:
+		return fmt.Sprintf("configuration error in %s(%v): %s", e.Option, e.Value, e.Message)
+	case e.Option != "":
+		return fmt.Sprintf("configuration error in %s: %s", e.Option, e.Message)
+	}
 	return fmt.Sprintf("configuration error: %s", e.Message)
 }
 
+// optionError creates a ConfigError for a rejected option argument
+func optionError(option string, value interface{}, msg string) error {
+	return &ConfigError{Option: option, Value: value, Message: msg}
+}
+
 // ErrRateLimited indicates the rate limit has been exceeded
 // This error is returned when RateLimitBehavior is set to RateLimitError
 // and a request would exceed the configured rate limit.
@@ -88,6 +122,107 @@ func (e *ErrRateLimited) Error() string {
 		e.RetryAfter.Format(time.RFC3339))
 }
 
+// RequestStage identifies where a request was when its context ended
+type RequestStage string
+
+const (
+	// StageRateLimit is the wait for the client-side rate limiter
+	StageRateLimit RequestStage = "rate limit wait"
+	// StageHTTP is the HTTP exchange, including reading the response
+	StageHTTP RequestStage = "HTTP request"
+)
+
+// ErrContextCanceled indicates a request stopped because the caller's context
+// was canceled or its deadline passed, as opposed to an API or network failure
+//
+// It unwraps to context.Canceled or context.DeadlineExceeded.
+type ErrContextCanceled struct {
+	Stage   RequestStage  // Where the request was when the context ended
+	Elapsed time.Duration // Time spent in Stage
+	Err     error         // The context's error
+}
+
+// Error implements the error interface
+func (e *ErrContextCanceled) Error() string {
+	return fmt.Sprintf("%s interrupted after %s: %v", e.Stage, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+// Unwrap returns the context's error
+func (e *ErrContextCanceled) Unwrap() error {
+	return e.Err
+}
+
+// ErrDryRun is returned in dry-run mode (see WithDryRun) in place of a
+// request that would have been sent to the API
+type ErrDryRun struct {
+	Method   string        // HTTP method
+	URL      string        // Full request URL, including query parameters
+	Endpoint EndpointClass // Quota class the request would count against
+	CacheKey string        // Cache entry the response would be stored under, if cached
+}
+
+// Error implements the error interface
+func (e *ErrDryRun) Error() string {
+	return fmt.Sprintf("dry run: %s %s not sent", e.Method, e.URL)
+}
+
+// ErrMalformedResponse indicates the API answered with a body that is not
+// the expected JSON: truncated or invalid JSON, JSON of the wrong shape, or
+// an HTML page such as a proxy's error or login page
+type ErrMalformedResponse struct {
+	Endpoint    string // Request path
+	StatusCode  int    // 0 when the body was rejected after decoding
+	ContentType string // Set when the body was rejected before decoding
+	Snippet     string // Start of the body, with credentials redacted
+	Err         error  // The decoding error, if decoding was attempted
+}
+
+// Error implements the error interface
+func (e *ErrMalformedResponse) Error() string {
+	msg := "malformed response from " + e.Endpoint
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d, %s)", e.StatusCode, e.ContentType)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	} else {
+		msg += ": unexpected content type"
+	}
+	return fmt.Sprintf("%s: %q", msg, e.Snippet)
+}
+
+// Unwrap returns the decoding error
+func (e *ErrMalformedResponse) Unwrap() error {
+	return e.Err
+}
+
+// ErrResponseTooLarge indicates a response body exceeded the configured
+// maximum size (see WithMaxResponseSize) and was discarded
+type ErrResponseTooLarge struct {
+	Endpoint string // Request path
+	Limit    int64  // Configured maximum in bytes
+	Size     int64  // Announced Content-Length, or 0 if the body was cut off while reading
+}
+
+// Error implements the error interface
+func (e *ErrResponseTooLarge) Error() string {
+	if e.Size > 0 {
+		return fmt.Sprintf("response from %s is %d bytes, over the %d byte limit", e.Endpoint, e.Size, e.Limit)
+	}
+	return fmt.Sprintf("response from %s exceeds the %d byte limit", e.Endpoint, e.Limit)
+}
+
+// malformedSnippetLen bounds ErrMalformedResponse.Snippet
+const malformedSnippetLen = 200
+
+// setDryRunCacheKey records the cache key in err if it is an ErrDryRun
+func setDryRunCacheKey(err error, key string) {
+	var dryRun *ErrDryRun
+	if errors.As(err, &dryRun) {
+		dryRun.CacheKey = key
+	}
+}
+
 // newAPIError creates an APIError from an HTTP response
 func newAPIError(resp *http.Response, endpoint string) error {
 	apiErr := &APIError{
@@ -97,7 +232,10 @@ func newAPIError(resp *http.Response, endpoint string) error {
 
 	// Parse common error cases
 	switch resp.StatusCode {
-	case http.StatusUnauthorized, http.StatusForbidden:
+	case http.StatusUnauthorized:
+		// Reads like the 403 error; errCredentialsRejected lets send re-authenticate
+		return fmt.Errorf("%w: %w", ErrUnauthorized, errCredentialsRejected)
+	case http.StatusForbidden:
 		apiErr.Message = "authentication failed"
 		return fmt.Errorf("%w: %s", ErrUnauthorized, apiErr.Message)
 	case http.StatusNotFound:
@@ -107,7 +245,169 @@ func newAPIError(resp *http.Response, endpoint string) error {
 		apiErr.Message = "rate limit exceeded"
 		return fmt.Errorf("%w: %s", ErrRateLimitExceeded, apiErr.Message)
 	default:
+		body, truncated := readErrorBody(resp.Body)
+		apiErr.Body = body
+		if truncated {
+			apiErr.Body += "…"
+		}
 		apiErr.Message = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		if server := resp.Header.Get("Server"); server != "" {
+			apiErr.Message += " from " + server
+		}
+		if reason := errorReason(resp.Header.Get("Content-Type"), body); reason != "" {
+			apiErr.Message += ": " + reason
+		}
 		return apiErr
 	}
 }
+
+// errorBodyLen bounds the body read from error responses
+const errorBodyLen = 1024
+
+// errorReasonLen bounds the reason taken from an error body
+const errorReasonLen = 200
+
+// readErrorBody reads the start of an error response body
+func readErrorBody(r io.Reader) (body string, truncated bool) {
+	data, _ := io.ReadAll(io.LimitReader(r, errorBodyLen+1))
+	if len(data) > errorBodyLen {
+		data, truncated = data[:errorBodyLen], true
+	}
+	return strings.ToValidUTF8(string(data), ""), truncated
+}
+
+// htmlTitle matches the title of an HTML error page
+var htmlTitle = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// errorReason extracts the error an error body reports: the detail, error or
+// message field of a JSON object (Django REST framework uses detail), the
+// title of an HTML page, or the first line of plain text
+func errorReason(contentType, body string) string {
+	body = strings.TrimSpace(body)
+	var reason string
+	var fields map[string]any
+	switch {
+	case body == "":
+		return ""
+	case json.Unmarshal([]byte(body), &fields) == nil:
+		for _, key := range []string{"detail", "error", "message"} {
+			if s, ok := fields[key].(string); ok && s != "" {
+				reason = s
+				break
+			}
+		}
+	case strings.HasPrefix(contentType, "text/html") || strings.HasPrefix(body, "<"):
+		if m := htmlTitle.FindStringSubmatch(body); m != nil {
+			reason = html.UnescapeString(m[1])
+		}
+	default:
+		reason, _, _ = strings.Cut(body, "\n")
+	}
+
+	reason = strings.Join(strings.Fields(reason), " ")
+	if len(reason) > errorReasonLen {
+		reason = strings.ToValidUTF8(reason[:errorReasonLen], "") + "…"
+	}
+	return reason
+}
+
+// IsRetryable reports whether a request that failed with err may succeed if
+// repeated later
+//
+// Retryable: rate limiting (429 and ErrRateLimited), server errors (5xx),
+// request timeouts (408) and transient network failures such as refused or
+// reset connections and timeouts.
+//
+// Context cancellation and expired deadlines (ErrContextCanceled) are
+// neither retryable nor permanent: the caller decided to stop, and retrying
+// with the same context cannot succeed.
+func IsRetryable(err error) bool {
+	return classify(err) == classRetryable
+}
+
+// IsPermanent reports whether err will recur no matter how often the request
+// is repeated
+//
+// Permanent: authentication and not-found errors, other 4xx responses,
+// invalid input or configuration, unknown hosts, TLS certificate failures
+// and redirects the redirect policy refuses.
+// Unrecognized errors are neither permanent nor retryable.
+func IsPermanent(err error) bool {
+	return classify(err) == classPermanent
+}
+
+type errorClass int
+
+const (
+	classUnknown errorClass = iota
+	classRetryable
+	classPermanent
+)
+
+// classify sorts err into retryable, permanent or unknown
+func classify(err error) errorClass {
+	if err == nil {
+		return classUnknown
+	}
+
+	// The caller gave up; checked first because network errors wrap these
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return classUnknown
+	}
+
+	// Sentinel errors produced by newAPIError
+	switch {
+	case errors.Is(err, ErrRateLimitExceeded):
+		return classRetryable
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrNotFound),
+		errors.Is(err, ErrNoAuthProvided), errors.Is(err, ErrMultipleAuthMethods),
+		errors.Is(err, ErrRedirectNotFollowed):
+		return classPermanent
+	}
+
+	var (
+		rateLimited *ErrRateLimited
+		apiErr      *APIError
+		validation  *ValidationError
+		config      *ConfigError
+	)
+	switch {
+	case errors.As(err, &rateLimited):
+		return classRetryable
+	case errors.As(err, &apiErr):
+		if apiErr.IsServerError() || apiErr.StatusCode == http.StatusRequestTimeout {
+			return classRetryable
+		}
+		if apiErr.IsClientError() {
+			return classPermanent
+		}
+		return classUnknown
+	case errors.As(err, &validation), errors.As(err, &config):
+		return classPermanent
+	}
+
+	// Network failures
+	var (
+		dnsErr      *net.DNSError
+		certErr     *tls.CertificateVerificationError
+		unknownCA   x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		netErr      net.Error
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsNotFound {
+			return classPermanent
+		}
+		return classRetryable
+	case errors.As(err, &certErr), errors.As(err, &unknownCA), errors.As(err, &hostnameErr):
+		return classPermanent
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return classRetryable
+	case errors.As(err, &netErr):
+		return classRetryable
+	}
+
+	return classUnknown
+}
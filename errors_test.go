@@ -1,10 +1,19 @@
 package openplantbook
 
 import (
+	"context"
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -126,12 +135,34 @@ func TestValidationError_Error(t *testing.T) {
 }
 
 func TestConfigError_Error(t *testing.T) {
-	cfgErr := &ConfigError{Message: "invalid configuration"}
-	want := "configuration error: invalid configuration"
-	got := cfgErr.Error()
+	tests := []struct {
+		name string
+		err  *ConfigError
+		want string
+	}{
+		{
+			name: "message only",
+			err:  &ConfigError{Message: "invalid configuration"},
+			want: "configuration error: invalid configuration",
+		},
+		{
+			name: "with option",
+			err:  &ConfigError{Option: "WithCache", Message: "cache cannot be nil"},
+			want: "configuration error in WithCache: cache cannot be nil",
+		},
+		{
+			name: "with option and value",
+			err:  &ConfigError{Option: "WithRateLimit", Value: 0, Message: "rate limit must be positive"},
+			want: "configuration error in WithRateLimit(0): rate limit must be positive",
+		},
+	}
 
-	if got != want {
-		t.Errorf("ConfigError.Error() = %q, want %q", got, want)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("ConfigError.Error() = %q, want %q", got, tt.want)
+			}
+		})
 	}
 }
 
@@ -202,3 +233,243 @@ func TestNewAPIError(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAPIError_AuthMessage(t *testing.T) {
+	errFor := func(status int) error {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(status)
+		resp := rec.Result()
+		defer resp.Body.Close()
+		return newAPIError(resp, "/plant/search/")
+	}
+
+	unauthorized, forbidden := errFor(http.StatusUnauthorized), errFor(http.StatusForbidden)
+	if unauthorized.Error() != forbidden.Error() {
+		t.Errorf("401 error = %q, want it to match 403 error %q", unauthorized, forbidden)
+	}
+	if !credentialsRejected(unauthorized) || credentialsRejected(forbidden) {
+		t.Error("credentialsRejected() should only match 401 errors")
+	}
+}
+
+func TestNewAPIError_Body(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      string
+		contentType string
+		body        string
+		wantMessage string
+	}{
+		{
+			name:        "django detail",
+			contentType: "application/json",
+			body:        `{"detail": "A server error occurred."}`,
+			wantMessage: "HTTP 502: A server error occurred.",
+		},
+		{
+			name:        "json error field",
+			server:      "nginx",
+			contentType: "application/json",
+			body:        `{"error": "upstream timed out", "code": 17}`,
+			wantMessage: "HTTP 502 from nginx: upstream timed out",
+		},
+		{
+			name:        "html page",
+			server:      "cloudflare",
+			contentType: "text/html; charset=UTF-8",
+			body:        "<html><head><title>open.plantbook.io | 502:\n Bad gateway</title></head><body>...</body></html>",
+			wantMessage: "HTTP 502 from cloudflare: open.plantbook.io | 502: Bad gateway",
+		},
+		{
+			name:        "plain text",
+			contentType: "text/plain",
+			body:        "upstream connect error\nreset reason: connection failure",
+			wantMessage: "HTTP 502: upstream connect error",
+		},
+		{
+			name:        "empty body",
+			wantMessage: "HTTP 502",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if tt.server != "" {
+				rec.Header().Set("Server", tt.server)
+			}
+			if tt.contentType != "" {
+				rec.Header().Set("Content-Type", tt.contentType)
+			}
+			rec.WriteHeader(http.StatusBadGateway)
+			rec.WriteString(tt.body)
+
+			var apiErr *APIError
+			if !errors.As(newAPIError(rec.Result(), "/plant/search/"), &apiErr) {
+				t.Fatal("newAPIError() did not return an *APIError")
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if apiErr.Body != tt.body {
+				t.Errorf("Body = %q, want %q", apiErr.Body, tt.body)
+			}
+		})
+	}
+}
+
+func TestNewAPIError_LongBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusInternalServerError)
+	rec.WriteString(`{"detail": "` + strings.Repeat("x", 2*errorBodyLen) + `"}`)
+
+	var apiErr *APIError
+	if !errors.As(newAPIError(rec.Result(), "/plant/search/"), &apiErr) {
+		t.Fatal("newAPIError() did not return an *APIError")
+	}
+	if !strings.HasSuffix(apiErr.Body, "…") || len(apiErr.Body) != errorBodyLen+len("…") {
+		t.Errorf("Body has length %d, want the first %d bytes and an ellipsis", len(apiErr.Body), errorBodyLen)
+	}
+	// The cut-off JSON is read as plain text
+	if len(apiErr.Message) > len("HTTP 500: ")+errorReasonLen+len("…") {
+		t.Errorf("Message has length %d, want the reason truncated", len(apiErr.Message))
+	}
+}
+
+func TestIsRetryable_IsPermanent(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+		wantPermanent bool
+	}{
+		{"nil", nil, false, false},
+		{"unknown", errors.New("something odd"), false, false},
+		{"rate limit exceeded", fmt.Errorf("search plants: %w", ErrRateLimitExceeded), true, false},
+		{"rate limited", &ErrRateLimited{RetryAfter: time.Now(), Message: "slow down"}, true, false},
+		{"server error", &APIError{StatusCode: http.StatusBadGateway}, true, false},
+		{"request timeout", &APIError{StatusCode: http.StatusRequestTimeout}, true, false},
+		{"bad request", &APIError{StatusCode: http.StatusBadRequest}, false, true},
+		{"unauthorized", fmt.Errorf("%w: authentication failed", ErrUnauthorized), false, true},
+		{"not found", fmt.Errorf("get plant details: %w", ErrNotFound), false, true},
+		{"validation", ErrInvalidInput("query cannot be empty"), false, true},
+		{"config", ErrInvalidConfig("base URL cannot be empty"), false, true},
+		{"no auth", ErrNoAuthProvided, false, true},
+		{"canceled", fmt.Errorf("HTTP request failed: %w", context.Canceled), false, false},
+		{"deadline", &url.Error{Op: "Get", URL: "x", Err: context.DeadlineExceeded}, false, false},
+		{"connection refused", &url.Error{Op: "Get", URL: "x", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}, true, false},
+		{"connection reset", &url.Error{Op: "Get", URL: "x", Err: syscall.ECONNRESET}, true, false},
+		{"unexpected EOF", fmt.Errorf("read response: %w", io.ErrUnexpectedEOF), true, false},
+		{"unknown host", &net.DNSError{Err: "no such host", Name: "nope.invalid", IsNotFound: true}, false, true},
+		{"dns timeout", &net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true}, true, false},
+		{"bad certificate", &url.Error{Op: "Get", URL: "x", Err: x509.UnknownAuthorityError{}}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.wantRetryable {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.wantRetryable)
+			}
+			if got := IsPermanent(tt.err); got != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", tt.err, got, tt.wantPermanent)
+			}
+		})
+	}
+}
+
+func TestIsRetryable_ClientErrors(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), WithCache(NewNoOpCache()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.SearchPlants(context.Background(), "monstera", nil)
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable(503 error %v) = false, want true", err)
+	}
+
+	status = http.StatusNotFound
+	_, err = client.GetPlantDetails(context.Background(), "unknown", nil)
+	if !IsPermanent(err) {
+		t.Errorf("IsPermanent(404 error %v) = false, want true", err)
+	}
+
+	// Nothing is listening once the server is closed
+	server.Close()
+	_, err = client.SearchPlants(context.Background(), "monstera", nil)
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable(connection error %v) = false, want true", err)
+	}
+}
+
+func TestErrContextCanceled(t *testing.T) {
+	t.Run("rate limit wait", func(t *testing.T) {
+		client, err := New(WithAPIKey("test-key"), WithRateLimit(1))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		// Use up the burst so the next request has to wait
+		client.rateLimiter.Allow()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err = client.SearchPlants(ctx, "monstera", nil)
+		var ctxErr *ErrContextCanceled
+		if !errors.As(err, &ctxErr) {
+			t.Fatalf("SearchPlants() error = %v, want *ErrContextCanceled", err)
+		}
+		if ctxErr.Stage != StageRateLimit {
+			t.Errorf("Stage = %q, want %q", ctxErr.Stage, StageRateLimit)
+		}
+		if ctxErr.Elapsed < 20*time.Millisecond {
+			t.Errorf("Elapsed = %v, want at least 20ms", ctxErr.Elapsed)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Error("error does not match context.Canceled")
+		}
+	})
+
+	t.Run("HTTP request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = client.GetPlantDetails(ctx, "monstera", nil)
+		var ctxErr *ErrContextCanceled
+		if !errors.As(err, &ctxErr) {
+			t.Fatalf("GetPlantDetails() error = %v, want *ErrContextCanceled", err)
+		}
+		if ctxErr.Stage != StageHTTP {
+			t.Errorf("Stage = %q, want %q", ctxErr.Stage, StageHTTP)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("error does not match context.DeadlineExceeded")
+		}
+		if IsRetryable(err) || IsPermanent(err) {
+			t.Error("context errors must be neither retryable nor permanent")
+		}
+	})
+
+	t.Run("message", func(t *testing.T) {
+		err := &ErrContextCanceled{Stage: StageHTTP, Elapsed: 1500 * time.Millisecond, Err: context.Canceled}
+		if got, want := err.Error(), "HTTP request interrupted after 1.5s: context canceled"; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	})
+}
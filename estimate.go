@@ -0,0 +1,118 @@
+package openplantbook
+
+import (
+	"time"
+)
+
+// BatchPlan lists the lookups a batch job intends to make
+type BatchPlan struct {
+	Searches []PlannedSearch
+	Details  []PlannedDetails
+}
+
+// PlannedSearch is a search in a BatchPlan
+type PlannedSearch struct {
+	Query   string
+	Options *SearchOptions
+}
+
+// PlannedDetails is a details lookup in a BatchPlan
+type PlannedDetails struct {
+	PID     string
+	Options *DetailOptions
+}
+
+// CostReport is the expected API usage of a BatchPlan
+type CostReport struct {
+	// Lookups is the number of searches and details lookups in the plan
+	Lookups int `json:"lookups"`
+
+	// Cached lookups are served from fresh cache entries, Duplicates repeat
+	// an earlier lookup in the plan and Invalid ones fail validation; none
+	// of them reach the API
+	Cached     int `json:"cached"`
+	Duplicates int `json:"duplicates"`
+	Invalid    int `json:"invalid"`
+
+	// Calls is the number of API requests expected, by endpoint class in
+	// ByEndpoint
+	Calls      int                   `json:"calls"`
+	ByEndpoint map[EndpointClass]int `json:"by_endpoint"`
+
+	// Quota is the client's quota usage when the estimate was made
+	Quota QuotaStatus `json:"quota"`
+
+	// Fits reports whether Calls fit in the remaining quota (always true
+	// when rate limiting is disabled)
+	Fits bool `json:"fits"`
+
+	// Duration is how long the calls take at the configured rate limit,
+	// ignoring response times
+	Duration time.Duration `json:"duration"`
+}
+
+// EstimateCost reports how many API requests plan would make with the
+// current cache, and whether they fit in the remaining quota
+//
+// It sends no requests. Lookups are counted once per cache key, so repeated
+// PIDs and searches differing only in Dedupe are free after the first.
+// Entries may expire between the estimate and the run; pair it with
+// WithDryRun to check a plan against the exact code path. Per-endpoint
+// limits from WithRateLimits are not reflected in Fits or Duration.
+func (c *Client) EstimateCost(plan BatchPlan) CostReport {
+	report := CostReport{
+		Lookups:    len(plan.Searches) + len(plan.Details),
+		ByEndpoint: map[EndpointClass]int{},
+		Quota:      c.Status().Quota,
+	}
+
+	seen := make(map[string]bool, report.Lookups)
+	count := func(key string, class EndpointClass) {
+		switch {
+		case key == "":
+			// Not cached (see Client.accountCacheable), so every lookup is a call
+			report.Calls++
+			report.ByEndpoint[class]++
+			return
+		case seen[key]:
+			report.Duplicates++
+		case c.cachedFresh(key):
+			report.Cached++
+		default:
+			report.Calls++
+			report.ByEndpoint[class]++
+		}
+		seen[key] = true
+	}
+	for _, s := range plan.Searches {
+		opts := c.searchOptions(s.Options)
+		query, _, err := parseQuery(s.Query, opts)
+		if err != nil || query == "" {
+			report.Invalid++
+			continue
+		}
+		count(c.searchKey(query, opts), EndpointSearch)
+	}
+	for _, d := range plan.Details {
+		if d.PID == "" {
+			report.Invalid++
+			continue
+		}
+		count(c.detailKey(d.PID, d.Options), EndpointDetails)
+	}
+
+	report.Fits = c.rateLimiter == nil || report.Calls <= report.Quota.Remaining
+	if c.rateLimiter != nil && report.Calls > 1 {
+		report.Duration = time.Duration(report.Calls-1) * (24 * time.Hour / time.Duration(c.dailyLimit))
+	}
+	return report
+}
+
+// cachedFresh reports whether key has a fresh cache entry
+func (c *Client) cachedFresh(key string) bool {
+	if !c.cacheEnabled() {
+		return false
+	}
+	_, meta, ok := c.cacheGet(key, true)
+	return ok && meta.fresh(c.clock.Now())
+}
@@ -0,0 +1,73 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateCost(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRateLimit(4))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	if _, err := client.GetPlantDetails(context.Background(), "monstera", nil); err != nil {
+		t.Fatalf("seeding cache: %v", err)
+	}
+
+	plan := BatchPlan{
+		Searches: []PlannedSearch{
+			{Query: "fern", Options: &SearchOptions{Limit: 5}},
+			{Query: "fern", Options: &SearchOptions{Limit: 5, Dedupe: true}}, // same cache entry
+			{Query: ""},
+		},
+		Details: []PlannedDetails{
+			{PID: "monstera"}, // cached
+			{PID: "ficus"},
+			{PID: "ficus"},
+			{PID: "ficus", Options: &DetailOptions{Language: "de"}},
+		},
+	}
+	report := client.EstimateCost(plan)
+
+	if report.Lookups != 7 || report.Cached != 1 || report.Duplicates != 2 || report.Invalid != 1 {
+		t.Errorf("report = %+v, want 7 lookups, 1 cached, 2 duplicates, 1 invalid", report)
+	}
+	if report.Calls != 3 || report.ByEndpoint[EndpointSearch] != 1 || report.ByEndpoint[EndpointDetails] != 2 {
+		t.Errorf("Calls = %d by %v, want 1 search and 2 details", report.Calls, report.ByEndpoint)
+	}
+	if report.Quota.Remaining != 3 || !report.Fits {
+		t.Errorf("Quota = %+v, Fits = %v, want 3 remaining and fitting", report.Quota, report.Fits)
+	}
+	if report.Duration != 12*time.Hour {
+		t.Errorf("Duration = %s, want 12h at 4 requests/day", report.Duration)
+	}
+
+	plan.Details = append(plan.Details, PlannedDetails{PID: "pothos"})
+	if report := client.EstimateCost(plan); report.Fits {
+		t.Errorf("Fits = true for %d calls with %d remaining", report.Calls, report.Quota.Remaining)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"by_endpoint":{"details":2,"search":1}`) {
+		t.Errorf("JSON = %s, want endpoint classes by name", data)
+	}
+}
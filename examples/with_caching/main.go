@@ -21,6 +21,7 @@ func main() {
 
 	// Create client with custom cache and rate limiting
 	cache := openplantbook.NewInMemoryCache()
+	defer cache.Close()
 	client, err := openplantbook.New(
 		openplantbook.WithAPIKey(apiKey),
 		openplantbook.WithCache(cache),
@@ -0,0 +1,56 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// missingTTL is how long PlantExists remembers that a PID was not found
+const missingTTL = 6 * time.Hour
+
+// PlantExists reports whether a plant with the given PID exists
+//
+// The API has no cheaper existence check than the detail endpoint, so
+// PlantExists avoids requests where it can: PIDs in the local index (from
+// earlier searches and lookups) or with cached details exist, and PIDs found
+// missing are remembered for a few hours. Otherwise it fetches the details,
+// which are cached, so a later GetPlantDetails for the PID is free.
+func (c *Client) PlantExists(ctx context.Context, pid string) (bool, error) {
+	if pid == "" {
+		return false, ErrInvalidInput("pid cannot be empty")
+	}
+	if c.index.has(pid) {
+		return true, nil
+	}
+
+	useCache := c.cacheEnabled()
+	if useCache {
+		if c.cachedFresh(c.detailKey(pid, nil)) {
+			return true, nil
+		}
+		if _, ok := c.cache.Get(c.cacheKey(missingKey(pid))); ok {
+			c.log("cache hit for missing plant", "pid", pid)
+			return false, nil
+		}
+	}
+
+	_, _, err := c.details(ctx, pid, nil, false)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrNotFound):
+		if useCache {
+			c.cache.Set(c.cacheKey(missingKey(pid)), []byte{1}, missingTTL)
+		}
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// missingKey returns the cache key recording that pid was not found
+func missingKey(pid string) string {
+	return CacheKeyFor(CacheOpMissing, map[string]string{"pid": strings.TrimSpace(pid)})
+}
@@ -0,0 +1,67 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_PlantExists(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/plant/detail/nonexistent/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	client.index.Add(PlantSearchResult{PID: "ficus lyrata", Alias: "Fiddle-leaf fig"})
+
+	ctx := context.Background()
+	tests := []struct {
+		pid       string
+		want      bool
+		wantCalls int32
+	}{
+		{"ficus lyrata", true, 0},       // known from the index
+		{"monstera", true, 1},           // fetched
+		{"nonexistent", false, 2},       // not found
+		{"nonexistent", false, 2},       // negative cache
+		{" nonexistent ", false, 2},     // same negative cache entry
+		{"monstera", true, 2},           // positive cache
+		{"monstera-deliciosa", true, 2}, // indexed from the fetched details
+	}
+	for _, tt := range tests {
+		got, err := client.PlantExists(ctx, tt.pid)
+		if err != nil {
+			t.Fatalf("PlantExists(%q) unexpected error: %v", tt.pid, err)
+		}
+		if got != tt.want {
+			t.Errorf("PlantExists(%q) = %v, want %v", tt.pid, got, tt.want)
+		}
+		if n := calls.Load(); n != tt.wantCalls {
+			t.Errorf("after PlantExists(%q): %d requests, want %d", tt.pid, n, tt.wantCalls)
+		}
+	}
+
+	var valErr *ValidationError
+	if _, err := client.PlantExists(ctx, ""); !errors.As(err, &valErr) {
+		t.Errorf("PlantExists(\"\") error = %v, want ValidationError", err)
+	}
+}
@@ -0,0 +1,266 @@
+// Package grafana generates Grafana dashboards from OpenPlantbook care
+// thresholds.
+//
+// A dashboard has one time series panel per metric the plant has thresholds
+// for, querying the plant's sensor series and shading the range the plant
+// tolerates:
+//
+//	dash := grafana.New(details, &grafana.Options{
+//	    Series: func(d *openplantbook.PlantDetails, m care.Metric) string {
+//	        return fmt.Sprintf(`soil_sensor_%s{plant="balcony-fern"}`, m)
+//	    },
+//	})
+//	err := dash.Write(os.Stdout)
+//
+// The JSON can be imported through the Grafana UI or provisioned from a file.
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// DefaultDatasourceType is the datasource plugin queried by default
+const DefaultDatasourceType = "prometheus"
+
+// schemaVersion is the Grafana dashboard schema the generated JSON follows
+const schemaVersion = 39
+
+// Options configures a dashboard
+type Options struct {
+	// Title is the dashboard title (default: the plant's name)
+	Title string
+
+	// DatasourceType is the plugin ID of the datasource the panels query
+	// (default DefaultDatasourceType); the datasource itself is chosen with
+	// the dashboard's datasource variable
+	DatasourceType string
+
+	// Series returns the query of a metric's sensor series (default:
+	// plant_<metric>{pid="<pid>"}, e.g. plant_soil_moisture{pid="ficus lyrata"})
+	Series func(d *openplantbook.PlantDetails, metric care.Metric) string
+}
+
+// Dashboard is a Grafana dashboard, ready to be written as JSON
+type Dashboard struct {
+	Title         string     `json:"title"`
+	UID           string     `json:"uid,omitempty"`
+	Tags          []string   `json:"tags"`
+	Timezone      string     `json:"timezone"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the default time range of a dashboard
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the dashboard variables
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a dashboard variable
+type Variable struct {
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+// Panel is a time series panel
+type Panel struct {
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	Type        string      `json:"type"`
+	GridPos     GridPos     `json:"gridPos"`
+	Datasource  Datasource  `json:"datasource"`
+	Targets     []Target    `json:"targets"`
+	FieldConfig FieldConfig `json:"fieldConfig"`
+}
+
+// GridPos places a panel on the dashboard grid, 24 units wide
+type GridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Datasource refers to a datasource by plugin type and UID
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// Target is a panel query
+type Target struct {
+	RefID      string     `json:"refId"`
+	Expr       string     `json:"expr"`
+	Datasource Datasource `json:"datasource"`
+}
+
+// FieldConfig sets the unit, axis range and thresholds of a panel
+type FieldConfig struct {
+	Defaults FieldDefaults `json:"defaults"`
+}
+
+// FieldDefaults are the field settings of a panel
+type FieldDefaults struct {
+	Unit       string         `json:"unit,omitempty"`
+	Min        *float64       `json:"min,omitempty"`
+	Max        *float64       `json:"max,omitempty"`
+	Thresholds Thresholds     `json:"thresholds"`
+	Custom     map[string]any `json:"custom"`
+}
+
+// Thresholds are the colored bands of a panel
+type Thresholds struct {
+	Mode  string          `json:"mode"`
+	Steps []ThresholdStep `json:"steps"`
+}
+
+// ThresholdStep starts a band at Value; the first step has no value and
+// covers everything below the next
+type ThresholdStep struct {
+	Color string   `json:"color"`
+	Value *float64 `json:"value"`
+}
+
+// grafanaUnits maps metrics to Grafana unit IDs
+var grafanaUnits = map[care.Metric]string{
+	care.MetricLight:        "lux",
+	care.MetricTemperature:  "celsius",
+	care.MetricHumidity:     "humidity",
+	care.MetricSoilMoisture: "percent",
+	care.MetricSoilEC:       "µS/cm",
+}
+
+// metricTitles are the panel titles of the metrics
+var metricTitles = map[care.Metric]string{
+	care.MetricLight:        "Light",
+	care.MetricTemperature:  "Temperature",
+	care.MetricHumidity:     "Air humidity",
+	care.MetricSoilMoisture: "Soil moisture",
+	care.MetricSoilEC:       "Soil EC",
+}
+
+// New returns a dashboard for the plant d
+// Metrics without thresholds in the record get no panel.
+func New(d *openplantbook.PlantDetails, opts *Options) *Dashboard {
+	if opts == nil {
+		opts = &Options{}
+	}
+	dsType := opts.DatasourceType
+	if dsType == "" {
+		dsType = DefaultDatasourceType
+	}
+	series := opts.Series
+	if series == nil {
+		series = defaultSeries
+	}
+	title := opts.Title
+	if title == "" {
+		title = d.FormattedName(d.Language)
+	}
+
+	ds := Datasource{Type: dsType, UID: "${datasource}"}
+	dash := &Dashboard{
+		Title:         title,
+		UID:           uid(d.PID),
+		Tags:          []string{"openplantbook", "plants"},
+		Timezone:      "browser",
+		SchemaVersion: schemaVersion,
+		Time:          TimeRange{From: "now-7d", To: "now"},
+		Templating: Templating{List: []Variable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: dsType},
+		}},
+	}
+
+	for _, m := range care.Metrics {
+		r, ok := care.RangeFor(d, m)
+		if !ok {
+			continue
+		}
+		n := len(dash.Panels)
+		dash.Panels = append(dash.Panels, Panel{
+			ID:          n + 1,
+			Title:       fmt.Sprintf("%s (%s–%s %s)", metricTitles[m], format(r.Min), format(r.Max), m.Unit()),
+			Type:        "timeseries",
+			GridPos:     GridPos{X: (n % 2) * 12, Y: (n / 2) * 8, W: 12, H: 8},
+			Datasource:  ds,
+			Targets:     []Target{{RefID: "A", Expr: series(d, m), Datasource: ds}},
+			FieldConfig: fieldConfig(m, r),
+		})
+	}
+	return dash
+}
+
+// Write writes the dashboard as indented JSON
+func (d *Dashboard) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(d)
+}
+
+// fieldConfig shades the plant's range green and values outside it red
+func fieldConfig(m care.Metric, r care.Range) FieldConfig {
+	lo, hi := r.Min, r.Max
+	defaults := FieldDefaults{
+		Unit: grafanaUnits[m],
+		Thresholds: Thresholds{
+			Mode: "absolute",
+			Steps: []ThresholdStep{
+				{Color: "red"},
+				{Color: "green", Value: &lo},
+				{Color: "red", Value: &hi},
+			},
+		},
+		Custom: map[string]any{
+			"thresholdsStyle": map[string]string{"mode": "line+area"},
+		},
+	}
+	if m == care.MetricHumidity || m == care.MetricSoilMoisture {
+		zero, hundred := 0.0, 100.0
+		defaults.Min, defaults.Max = &zero, &hundred
+	}
+	return FieldConfig{Defaults: defaults}
+}
+
+// defaultSeries names a metric's series plant_<metric>, labelled with the PID
+func defaultSeries(d *openplantbook.PlantDetails, m care.Metric) string {
+	return fmt.Sprintf("plant_%s{pid=%q}", m, d.PID)
+}
+
+// uid derives a stable dashboard UID from the PID, so regenerating a
+// dashboard replaces it on import; Grafana limits UIDs to 40 characters
+func uid(pid string) string {
+	id := "opb-" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, pid)
+	if len(id) > 40 {
+		id = id[:40]
+	}
+	return id
+}
+
+// format prints a threshold without trailing zeros
+func format(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,98 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func testPlant() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		DisplayPID:   "Monstera deliciosa",
+		MinLightLux:  1500,
+		MaxLightLux:  30000,
+		MinTemp:      12,
+		MaxTemp:      32,
+		MinSoilMoist: 15,
+		MaxSoilMoist: 60,
+	}
+}
+
+func TestNew(t *testing.T) {
+	dash := New(testPlant(), nil)
+
+	if dash.UID != "opb-monstera-deliciosa" {
+		t.Errorf("UID = %q, want opb-monstera-deliciosa", dash.UID)
+	}
+	if len(dash.Panels) != 3 {
+		t.Fatalf("got %d panels, want 3 (light, temperature, soil moisture)", len(dash.Panels))
+	}
+
+	moisture := dash.Panels[2]
+	if got := moisture.Targets[0].Expr; got != `plant_soil_moisture{pid="monstera deliciosa"}` {
+		t.Errorf("Expr = %q", got)
+	}
+	steps := moisture.FieldConfig.Defaults.Thresholds.Steps
+	if len(steps) != 3 || steps[0].Value != nil || *steps[1].Value != 15 || *steps[2].Value != 60 {
+		t.Errorf("threshold steps = %+v, want red, green from 15, red from 60", steps)
+	}
+	if moisture.Datasource.UID != "${datasource}" || moisture.Datasource.Type != DefaultDatasourceType {
+		t.Errorf("Datasource = %+v", moisture.Datasource)
+	}
+	if moisture.GridPos != (GridPos{X: 0, Y: 8, W: 12, H: 8}) {
+		t.Errorf("GridPos = %+v, want the second row", moisture.GridPos)
+	}
+}
+
+func TestNew_Options(t *testing.T) {
+	dash := New(testPlant(), &Options{
+		Title:          "Office monstera",
+		DatasourceType: "influxdb",
+		Series: func(d *openplantbook.PlantDetails, m care.Metric) string {
+			return "sensor_" + string(m)
+		},
+	})
+	if dash.Title != "Office monstera" {
+		t.Errorf("Title = %q", dash.Title)
+	}
+	if v := dash.Templating.List[0]; v.Type != "datasource" || v.Query != "influxdb" {
+		t.Errorf("datasource variable = %+v", v)
+	}
+	if got := dash.Panels[0].Targets[0].Expr; got != "sensor_light" {
+		t.Errorf("Expr = %q, want sensor_light", got)
+	}
+}
+
+func TestDashboard_Write(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New(testPlant(), nil).Write(&buf); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Write() produced invalid JSON: %v", err)
+	}
+	if doc["schemaVersion"] != float64(schemaVersion) {
+		t.Errorf("schemaVersion = %v", doc["schemaVersion"])
+	}
+	for _, want := range []string{`"mode": "line+area"`, `"value": null`, `"unit": "lux"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("dashboard JSON missing %s", want)
+		}
+	}
+}
+
+func TestUID(t *testing.T) {
+	long := uid(strings.Repeat("x", 60))
+	if len(long) != 40 {
+		t.Errorf("uid of a long pid has length %d, want 40", len(long))
+	}
+	if got := uid("Ficus 'Audrey'"); got != "opb-ficus--audrey-" {
+		t.Errorf("uid = %q", got)
+	}
+}
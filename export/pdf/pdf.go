@@ -0,0 +1,227 @@
+// Package pdf renders printable plant care cards from OpenPlantbook details.
+//
+// Each card shows the plant's names, its image, the care thresholds and a QR
+// code linking to the plant's OpenPlantbook page, one card per page:
+//
+//	details, err := client.GetPlantDetails(ctx, "monstera deliciosa", nil)
+//	...
+//	err = pdf.Write(f, []*openplantbook.PlantDetails{details}, &pdf.Options{
+//	    Image: fetchImage,
+//	})
+//
+// It is a separate module, so the core SDK does not depend on PDF and QR
+// code libraries:
+//
+//	go get github.com/rmrfslashbin/openplantbook-go/export/pdf
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/label"
+)
+
+// Page sizes for Options.PageSize
+const (
+	PageA4 = "A4"
+	PageA5 = "A5"
+	PageA6 = "A6" // default, a postcard-sized card
+)
+
+// Options configures the cards
+type Options struct {
+	// PageSize is PageA4, PageA5 or PageA6 (default)
+	PageSize string
+
+	// Image returns the bytes of the image at url (JPEG, PNG or GIF); nil,
+	// an error or another format leaves the image off the card. The package
+	// never fetches images itself.
+	Image func(url string) ([]byte, error)
+
+	// Link returns the address encoded in the QR code (default:
+	// PlantDetails.PageURL)
+	Link func(*openplantbook.PlantDetails) string
+
+	// Language is the language the card is read in, choosing between the
+	// alias and the scientific name (see PlantDetails.FormattedName)
+	Language string
+}
+
+// layout holds the measurements of a page size, in millimetres
+type layout struct {
+	margin, title, body, image, qr float64
+}
+
+var layouts = map[string]layout{
+	PageA4: {margin: 20, title: 28, body: 14, image: 90, qr: 45},
+	PageA5: {margin: 14, title: 20, body: 11, image: 60, qr: 32},
+	PageA6: {margin: 8, title: 14, body: 8, image: 42, qr: 24},
+}
+
+// Write renders one care card per plant to w
+func Write(w io.Writer, plants []*openplantbook.PlantDetails, opts *Options) error {
+	if len(plants) == 0 {
+		return errors.New("pdf: no plants to render")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	size := opts.PageSize
+	if size == "" {
+		size = PageA6
+	}
+	l, ok := layouts[size]
+	if !ok {
+		return fmt.Errorf("pdf: unknown page size %q (use A4, A5 or A6)", size)
+	}
+
+	doc := fpdf.New("P", "mm", size, "")
+	doc.SetMargins(l.margin, l.margin, l.margin)
+	doc.SetAutoPageBreak(false, l.margin)
+	doc.SetTitle("Plant care cards", true)
+	doc.SetCreator("openplantbook-go", true)
+	text := doc.UnicodeTranslatorFromDescriptor("") // core fonts are cp1252
+
+	for i, d := range plants {
+		if d == nil {
+			return fmt.Errorf("pdf: plant %d is nil", i)
+		}
+		card{doc: doc, l: l, text: text, opts: opts, n: i}.render(d)
+	}
+	if err := doc.Error(); err != nil {
+		return fmt.Errorf("pdf: %w", err)
+	}
+	return doc.Output(w)
+}
+
+// card renders one plant onto a new page
+type card struct {
+	doc  *fpdf.Fpdf
+	l    layout
+	text func(string) string
+	opts *Options
+	n    int // index of the card, naming its images
+}
+
+func (c card) render(d *openplantbook.PlantDetails) {
+	doc, l := c.doc, c.l
+	doc.AddPage()
+	pageW, pageH := doc.GetPageSize()
+	width := pageW - 2*l.margin
+	line := l.body * 0.5
+
+	// Names
+	doc.SetFont("Helvetica", "B", l.title)
+	name := d.Alias
+	if name == "" || d.FormattedName(c.opts.Language) == d.ScientificName() {
+		name = d.ScientificName()
+	}
+	doc.MultiCell(width, l.title*0.45, c.text(name), "", "L", false)
+	if name != d.ScientificName() {
+		doc.SetFont("Helvetica", "I", l.body+1)
+		doc.MultiCell(width, line, c.text(d.ScientificName()), "", "L", false)
+	}
+	if d.Category != "" {
+		doc.SetFont("Helvetica", "", l.body)
+		doc.SetTextColor(100, 100, 100)
+		doc.MultiCell(width, line, c.text(d.Category), "", "L", false)
+		doc.SetTextColor(0, 0, 0)
+	}
+	doc.Ln(line / 2)
+
+	// Image, scaled to fit a square box
+	if img := c.image(d); img != "" {
+		info := doc.GetImageInfo(img)
+		w, h := l.image, l.image
+		if iw, ih := info.Width(), info.Height(); iw > ih {
+			h = l.image * ih / iw
+		} else if ih > 0 {
+			w = l.image * iw / ih
+		}
+		doc.ImageOptions(img, l.margin+(width-w)/2, doc.GetY(), w, h, true, fpdf.ImageOptions{}, 0, "")
+		doc.Ln(line / 2)
+	}
+
+	// Thresholds
+	doc.SetFont("Helvetica", "B", l.body+1)
+	doc.CellFormat(width, line*1.4, "Care", "B", 1, "L", false, 0, "")
+	doc.Ln(line / 3)
+	labelW := width * 0.45
+	for _, row := range thresholds(d) {
+		doc.SetFont("Helvetica", "", l.body)
+		doc.CellFormat(labelW, line*1.3, c.text(row[0]), "", 0, "L", false, 0, "")
+		doc.SetFont("Helvetica", "B", l.body)
+		doc.CellFormat(width-labelW, line*1.3, c.text(row[1]), "", 1, "L", false, 0, "")
+	}
+
+	// QR code in the bottom right corner, above the link
+	link, _ := label.Payload(d, &label.Options{Link: c.opts.Link})
+	if png, err := label.EncodePNG(link, 512); err == nil {
+		name := "qr-" + strconv.Itoa(c.n)
+		doc.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		y := pageH - l.margin - l.qr
+		doc.ImageOptions(name, pageW-l.margin-l.qr, y, l.qr, l.qr, false, fpdf.ImageOptions{}, 0, link)
+	}
+	doc.SetFont("Helvetica", "", l.body-2)
+	doc.SetTextColor(100, 100, 100)
+	doc.SetXY(l.margin, pageH-l.margin-line)
+	doc.CellFormat(width-l.qr, line, "Data: OpenPlantbook", "", 0, "L", false, 0, link)
+	doc.SetTextColor(0, 0, 0)
+}
+
+// image registers the plant's image, returning its name, or "" when there
+// is none to show
+func (c card) image(d *openplantbook.PlantDetails) string {
+	if c.opts.Image == nil || d.ImageURL == "" {
+		return ""
+	}
+	data, err := c.opts.Image(d.ImageURL)
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	var kind string
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		kind = "JPG"
+	case "image/png":
+		kind = "PNG"
+	case "image/gif":
+		kind = "GIF"
+	default:
+		return ""
+	}
+
+	name := "image-" + strconv.Itoa(c.n)
+	c.doc.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: kind}, bytes.NewReader(data))
+	if !c.doc.Ok() {
+		// A corrupt image must not spoil the card
+		c.doc.ClearError()
+		return ""
+	}
+	return name
+}
+
+// thresholds returns the label and range of each care threshold, leaving
+// out those the record lacks
+func thresholds(d *openplantbook.PlantDetails) [][2]string {
+	var rows [][2]string
+	add := func(label, value string, recorded bool) {
+		if recorded {
+			rows = append(rows, [2]string{label, value})
+		}
+	}
+	add("Light", fmt.Sprintf("%d–%d lux", d.MinLightLux, d.MaxLightLux), d.MaxLightLux > 0)
+	add("Temperature", fmt.Sprintf("%.0f–%.0f °C", d.MinTemp, d.MaxTemp), d.MaxTemp != 0 || d.MinTemp != 0)
+	add("Air humidity", fmt.Sprintf("%d–%d %%", d.MinEnvHumid, d.MaxEnvHumid), d.MaxEnvHumid > 0)
+	add("Soil moisture", fmt.Sprintf("%d–%d %%", d.MinSoilMoist, d.MaxSoilMoist), d.MaxSoilMoist > 0)
+	add("Soil EC", fmt.Sprintf("%d–%d µS/cm", d.MinSoilEC, d.MaxSoilEC), d.MaxSoilEC > 0)
+	return rows
+}
@@ -0,0 +1,114 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func testPlant() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		DisplayPID:   "Monstera deliciosa",
+		Alias:        "Swiss cheese plant",
+		Category:     "Araceae",
+		MinLightLux:  1500,
+		MaxLightLux:  30000,
+		MinTemp:      12,
+		MaxTemp:      32,
+		MinEnvHumid:  40,
+		MaxEnvHumid:  80,
+		MinSoilMoist: 15,
+		MaxSoilMoist: 60,
+		MinSoilEC:    350,
+		MaxSoilEC:    2000,
+		ImageURL:     "https://example.com/monstera.png",
+	}
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	for x := 0; x < 8; x++ {
+		img.Set(x, 1, color.RGBA{G: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWrite(t *testing.T) {
+	image := testPNG(t)
+	var fetched []string
+	var links int
+	opts := &Options{
+		Image: func(url string) ([]byte, error) {
+			fetched = append(fetched, url)
+			return image, nil
+		},
+		Link: func(d *openplantbook.PlantDetails) string {
+			links++
+			return d.PageURL()
+		},
+	}
+
+	plants := []*openplantbook.PlantDetails{testPlant(), {PID: "ficus lyrata"}}
+	for _, size := range []string{"", PageA4, PageA5} {
+		var buf bytes.Buffer
+		opts.PageSize = size
+		if err := Write(&buf, plants, opts); err != nil {
+			t.Fatalf("Write(%q) unexpected error: %v", size, err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+			t.Errorf("Write(%q) did not produce a PDF", size)
+		}
+		if pages := bytes.Count(buf.Bytes(), []byte("/Type /Page\n")); pages != 2 {
+			t.Errorf("Write(%q) pages = %d, want 2", size, pages)
+		}
+	}
+	if len(fetched) != 3 || links != 6 {
+		t.Errorf("images fetched = %v, links = %d; want one image and link per card with an image URL", fetched, links)
+	}
+}
+
+func TestWrite_BadImages(t *testing.T) {
+	for name, fetch := range map[string]func(string) ([]byte, error){
+		"error":   func(string) ([]byte, error) { return nil, errors.New("timeout") },
+		"html":    func(string) ([]byte, error) { return []byte("<html>not found</html>"), nil },
+		"corrupt": func(string) ([]byte, error) { return []byte("\x89PNG\r\n\x1a\ngarbage"), nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, []*openplantbook.PlantDetails{testPlant()}, &Options{Image: fetch}); err != nil {
+				t.Errorf("Write() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWrite_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, nil, nil); err == nil {
+		t.Error("no plants: expected error")
+	}
+	if err := Write(&buf, []*openplantbook.PlantDetails{testPlant()}, &Options{PageSize: "letter"}); err == nil {
+		t.Error("unknown page size: expected error")
+	}
+	if err := Write(&buf, []*openplantbook.PlantDetails{nil}, nil); err == nil {
+		t.Error("nil plant: expected error")
+	}
+}
+
+func TestThresholds_SkipsMissing(t *testing.T) {
+	rows := thresholds(&openplantbook.PlantDetails{MinTemp: 10, MaxTemp: 25})
+	if len(rows) != 1 || rows[0][0] != "Temperature" {
+		t.Errorf("thresholds() = %v, want only temperature", rows)
+	}
+}
@@ -0,0 +1,58 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// FallbackFunc returns bundled details for a plant, e.g. from a dataset file
+// kept by cmd/plantbook-sync, reporting false when it has none for pid in
+// language
+type FallbackFunc func(pid, language string) (*PlantDetails, bool)
+
+// WithFallback serves details from fn when the API cannot answer: it is
+// unreachable or overloaded, the rate limit or quota is spent, or the
+// request budget (WithBudget) is exhausted
+//
+// The fallback is consulted after the cache, including stale entries allowed
+// by WithStaleIfError, and its details are not cached. Use
+// GetPlantDetailsWithMeta to tell them apart by ResultMeta.Fallback. Answers
+// such as 404 or 401 are returned as errors.
+//
+//	client, err := openplantbook.New(
+//	    openplantbook.WithAPIKey(key),
+//	    openplantbook.WithFallback(lookupBundled),
+//	)
+func WithFallback(fn FallbackFunc) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return optionError("WithFallback", nil, "fallback cannot be nil")
+		}
+		c.fallback = fn
+		return nil
+	}
+}
+
+// serveFallback returns the fallback's details for pid when err allows it
+func (c *Client) serveFallback(err error, pid string, opts *DetailOptions) (*PlantDetails, bool) {
+	if c.fallback == nil || !fallbackQualifies(err) {
+		return nil, false
+	}
+	d, ok := c.fallback(pid, detailLanguage(opts))
+	if !ok || d == nil {
+		return nil, false
+	}
+	details := *d
+	details.Language = detailLanguage(opts)
+	c.checkImageURL(&details)
+	return &details, true
+}
+
+// fallbackQualifies reports whether err means the API could not answer,
+// rather than answered with an error
+func fallbackQualifies(err error) bool {
+	var dnsErr *net.DNSError
+	return IsRetryable(err) || errors.As(err, &dnsErr) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrRateLimitExceeded) || errors.Is(err, ErrBudgetExhausted)
+}
@@ -0,0 +1,79 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_Fallback(t *testing.T) {
+	var status atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	var gotLanguage string
+	fallback := func(pid, language string) (*PlantDetails, bool) {
+		gotLanguage = language
+		if pid != "monstera deliciosa" {
+			return nil, false
+		}
+		return &PlantDetails{PID: pid, Alias: "Monstera", MaxTemp: 30}, true
+	}
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithFallback(fallback),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("serves fallback when API is down", func(t *testing.T) {
+		status.Store(http.StatusServiceUnavailable)
+		got, err := client.GetPlantDetailsWithMeta(ctx, "monstera deliciosa", &DetailOptions{Language: "DE"})
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		if !got.Fallback || got.FromCache || got.Details.MaxTemp != 30 {
+			t.Errorf("GetPlantDetailsWithMeta() = %+v, want fallback details", got)
+		}
+		if gotLanguage != "de" || got.Details.Language != "de" {
+			t.Errorf("language = %q, details language %q; want de", gotLanguage, got.Details.Language)
+		}
+	})
+
+	t.Run("serves fallback when budget is spent", func(t *testing.T) {
+		status.Store(http.StatusOK)
+		got, err := client.GetPlantDetailsWithMeta(WithBudget(ctx, 0), "monstera deliciosa", nil)
+		if err != nil || !got.Fallback {
+			t.Errorf("GetPlantDetailsWithMeta() = %+v, %v; want fallback details", got, err)
+		}
+	})
+
+	t.Run("unknown plant", func(t *testing.T) {
+		status.Store(http.StatusServiceUnavailable)
+		if _, err := client.GetPlantDetails(ctx, "ficus lyrata", nil); err == nil {
+			t.Error("GetPlantDetails() expected error for a plant the fallback lacks")
+		}
+	})
+
+	t.Run("returns API answers", func(t *testing.T) {
+		status.Store(http.StatusNotFound)
+		if _, err := client.GetPlantDetails(ctx, "monstera deliciosa", nil); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetPlantDetails() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestWithFallback_Nil(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithFallback(nil)); err == nil {
+		t.Error("New() expected error for nil fallback")
+	}
+}
@@ -0,0 +1,191 @@
+package openplantbook
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileCacheExt is the extension of cache entry files
+const fileCacheExt = ".entry"
+
+// FileCache implements Cache with one file per entry in a directory
+//
+// Entries survive restarts, so repeated CLI invocations and batch jobs share
+// cached responses. Expired entries are removed when read. Writes are atomic
+// (temp file and rename), so readers never see partial entries, and an
+// advisory lock on a .lock file in the directory lets several processes share
+// one cache safely, including on Windows, where a file cannot be replaced
+// while another process has it open.
+type FileCache struct {
+	dir   string
+	clock Clock
+}
+
+// fileEntryHeader is the first line of an entry file; the value follows it
+type fileEntryHeader struct {
+	Key       string    `json:"key"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewFileCache creates a file cache in dir, creating the directory if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if dir == "" {
+		return nil, ErrInvalidConfig("cache directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cache directory: %w", err)
+	}
+	return &FileCache{dir: dir, clock: realClock{}}, nil
+}
+
+// Dir returns the cache directory
+func (c *FileCache) Dir() string {
+	return c.dir
+}
+
+// Get retrieves a value from the cache
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	defer c.lock(false).unlock()
+
+	header, value, err := readFileEntry(c.path(key))
+	if err != nil || header.Key != key {
+		return nil, false
+	}
+
+	if c.clock.Now().After(header.ExpiresAt) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Set stores a value in the cache with a TTL
+// Write failures are ignored, as with any cache miss.
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	defer c.lock(true).unlock()
+	c.write(fileEntryHeader{Key: key, ExpiresAt: c.clock.Now().Add(ttl)}, value)
+}
+
+// Delete removes a value from the cache
+func (c *FileCache) Delete(key string) {
+	defer c.lock(true).unlock()
+	os.Remove(c.path(key))
+}
+
+// Len returns the number of entry files, including expired entries that
+// have not been read since they expired
+func (c *FileCache) Len() int {
+	paths, _ := filepath.Glob(filepath.Join(c.dir, "*"+fileCacheExt))
+	return len(paths)
+}
+
+// Clear removes all values from the cache
+func (c *FileCache) Clear() {
+	defer c.lock(true).unlock()
+
+	paths, _ := filepath.Glob(filepath.Join(c.dir, "*"+fileCacheExt))
+	for _, p := range paths {
+		os.Remove(p)
+	}
+}
+
+// path maps a key to its entry file
+// Keys are hashed because they contain characters that are not valid in
+// file names on every platform.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileCacheExt)
+}
+
+// lock takes the directory lock, returning nil when locks are unsupported
+// Caching is best effort, so a cache on a file system without locking (some
+// network mounts) still works, relying on atomic renames alone.
+func (c *FileCache) lock(exclusive bool) *fileLock {
+	l, err := lockFile(filepath.Join(c.dir, fileCacheLock), exclusive)
+	if err != nil {
+		return nil
+	}
+	return l
+}
+
+// write stores an entry; callers hold the exclusive lock
+func (c *FileCache) write(header fileEntryHeader, value []byte) error {
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	w := bufio.NewWriter(tmp)
+	w.Write(line)
+	w.WriteByte('\n')
+	w.Write(value)
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(header.Key))
+}
+
+// entries calls fn for every unexpired entry
+func (c *FileCache) entries(fn func(header fileEntryHeader, value []byte) error) error {
+	defer c.lock(false).unlock()
+
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*"+fileCacheExt))
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	for _, p := range paths {
+		header, value, err := readFileEntry(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // removed concurrently
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filepath.Base(p), err)
+		}
+		if now.After(header.ExpiresAt) {
+			continue
+		}
+		if err := fn(header, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readFileEntry(path string) (fileEntryHeader, []byte, error) {
+	var header fileEntryHeader
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return header, nil, err
+	}
+
+	line, value, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return header, nil, errors.New("malformed cache entry")
+	}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return header, nil, fmt.Errorf("malformed cache entry: %w", err)
+	}
+	return header, value, nil
+}
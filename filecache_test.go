@@ -0,0 +1,94 @@
+package openplantbook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCache_GetSet(t *testing.T) {
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache() unexpected error: %v", err)
+	}
+
+	key := "detail:monstera deliciosa:<nil>"
+	if _, ok := cache.Get(key); ok {
+		t.Error("Get() returned true for non-existent key")
+	}
+
+	cache.Set(key, []byte(`{"pid":"monstera deliciosa"}`), time.Hour)
+	got, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("Get() returned false for existing key")
+	}
+	if string(got) != `{"pid":"monstera deliciosa"}` {
+		t.Errorf("Get() = %q", got)
+	}
+
+	// Entries persist across instances
+	reopened, _ := NewFileCache(cache.Dir())
+	if _, ok := reopened.Get(key); !ok {
+		t.Error("entry not found after reopening the cache")
+	}
+
+	cache.Delete(key)
+	if _, ok := cache.Get(key); ok {
+		t.Error("Get() returned true after Delete()")
+	}
+}
+
+func TestFileCache_Expiration(t *testing.T) {
+	cache, _ := NewFileCache(t.TempDir())
+
+	cache.Set("short", []byte("value"), 50*time.Millisecond)
+	if _, ok := cache.Get("short"); !ok {
+		t.Fatal("Get() returned false immediately after Set()")
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if _, ok := cache.Get("short"); ok {
+		t.Error("Get() returned true after TTL expiration")
+	}
+
+	// Expired entries are removed from disk on read
+	entries, _ := filepath.Glob(filepath.Join(cache.Dir(), "*"+fileCacheExt))
+	if len(entries) != 0 {
+		t.Errorf("%d entry files left after expiry, want 0", len(entries))
+	}
+}
+
+func TestFileCache_Clear(t *testing.T) {
+	cache, _ := NewFileCache(t.TempDir())
+	cache.Set("a", []byte("1"), time.Hour)
+	cache.Set("b", []byte("2"), time.Hour)
+
+	// Unrelated files in the directory are left alone
+	other := filepath.Join(cache.Dir(), "README")
+	os.WriteFile(other, []byte("keep"), 0o600)
+
+	cache.Clear()
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Get(a) returned true after Clear()")
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("Clear() removed an unrelated file: %v", err)
+	}
+}
+
+func TestFileCache_CorruptEntry(t *testing.T) {
+	cache, _ := NewFileCache(t.TempDir())
+	cache.Set("key", []byte("value"), time.Hour)
+	os.WriteFile(cache.path("key"), []byte("garbage"), 0o600)
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Get() returned true for a corrupt entry")
+	}
+}
+
+func TestNewFileCache_EmptyDir(t *testing.T) {
+	if _, err := NewFileCache(""); err == nil {
+		t.Error("NewFileCache(\"\") expected error, got nil")
+	}
+}
@@ -0,0 +1,39 @@
+package openplantbook
+
+import "os"
+
+// fileCacheLock is the lock file guarding a FileCache directory
+const fileCacheLock = ".lock"
+
+// fileLock is an advisory lock held on an open file
+//
+// Locks coordinate every FileCache on a directory, in this process or
+// another, so concurrent CLI invocations can share a cache. They are
+// advisory: other programs touching the directory are not blocked.
+type fileLock struct {
+	f *os.File
+}
+
+// lockFile opens path, creating it if needed, and locks it
+// A shared lock admits other shared holders; an exclusive lock admits none.
+// lockFile blocks until the lock is granted.
+func lockFile(path string, exclusive bool) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFD(f, exclusive); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &fileLock{f: f}, nil
+}
+
+// unlock releases the lock; it is safe to call on a nil lock
+func (l *fileLock) unlock() {
+	if l == nil {
+		return
+	}
+	unlockFD(l.f)
+	l.f.Close()
+}
@@ -0,0 +1,17 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package openplantbook
+
+import "os"
+
+// Platforms without flock or LockFileEx (e.g. js/wasm) rely on atomic
+// renames alone
+const fileLocking = false
+
+func lockFD(f *os.File, exclusive bool) error {
+	return nil
+}
+
+func unlockFD(f *os.File) error {
+	return nil
+}
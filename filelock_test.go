@@ -0,0 +1,193 @@
+package openplantbook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockFile_Exclusive(t *testing.T) {
+	if !fileLocking {
+		t.Skip("file locks are not supported on this platform")
+	}
+	path := filepath.Join(t.TempDir(), fileCacheLock)
+
+	held, err := lockFile(path, true)
+	if err != nil {
+		t.Fatalf("lockFile() unexpected error: %v", err)
+	}
+
+	acquired := make(chan *fileLock)
+	go func() {
+		l, err := lockFile(path, false)
+		if err != nil {
+			t.Errorf("lockFile() unexpected error: %v", err)
+		}
+		acquired <- l
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("shared lock granted while an exclusive lock was held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	held.unlock()
+	select {
+	case l := <-acquired:
+		l.unlock()
+	case <-time.After(5 * time.Second):
+		t.Fatal("shared lock not granted after the exclusive lock was released")
+	}
+}
+
+func TestLockFile_Shared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileCacheLock)
+
+	a, err := lockFile(path, false)
+	if err != nil {
+		t.Fatalf("lockFile() unexpected error: %v", err)
+	}
+	defer a.unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b, err := lockFile(path, false)
+		if err != nil {
+			t.Errorf("lockFile() unexpected error: %v", err)
+		}
+		b.unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("second shared lock blocked")
+	}
+}
+
+// fileCacheValue is the value stored under key by the concurrency tests
+func fileCacheValue(key string) []byte {
+	return bytes.Repeat([]byte(key), 512)
+}
+
+// checkFileCacheEntries verifies every key written by the concurrency tests
+func checkFileCacheEntries(t *testing.T, cache *FileCache, keys int) {
+	t.Helper()
+	for i := 0; i < keys; i++ {
+		key := "key-" + strconv.Itoa(i)
+		got, ok := cache.Get(key)
+		if !ok {
+			t.Errorf("Get(%q) missing", key)
+			continue
+		}
+		if !bytes.Equal(got, fileCacheValue(key)) {
+			t.Errorf("Get(%q) returned a corrupt value (%d bytes)", key, len(got))
+		}
+	}
+
+	tmps, _ := filepath.Glob(filepath.Join(cache.Dir(), ".tmp-*"))
+	if len(tmps) != 0 {
+		t.Errorf("%d temp files left behind", len(tmps))
+	}
+}
+
+func TestFileCache_ConcurrentInstances(t *testing.T) {
+	dir := t.TempDir()
+	const workers, keys = 8, 20
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Each worker has its own instance, like separate processes
+			cache, err := NewFileCache(dir)
+			if err != nil {
+				t.Errorf("NewFileCache() unexpected error: %v", err)
+				return
+			}
+			for i := 0; i < keys; i++ {
+				key := "key-" + strconv.Itoa(i)
+				cache.Set(key, fileCacheValue(key), time.Hour)
+				if got, ok := cache.Get(key); ok && !bytes.Equal(got, fileCacheValue(key)) {
+					t.Errorf("Get(%q) returned a corrupt value", key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	cache, _ := NewFileCache(dir)
+	checkFileCacheEntries(t, cache, keys)
+}
+
+// TestFileCache_Processes shares a cache between several processes
+// It re-runs the test binary as helper processes that write to the cache.
+func TestFileCache_Processes(t *testing.T) {
+	if dir := os.Getenv("OPENPLANTBOOK_FILECACHE_HELPER"); dir != "" {
+		fileCacheHelper(dir)
+		return
+	}
+	if testing.Short() {
+		t.Skip("skipping multi-process test in short mode")
+	}
+	if !fileLocking {
+		t.Skip("file locks are not supported on this platform")
+	}
+
+	dir := t.TempDir()
+	const processes = 4
+
+	cmds := make([]*exec.Cmd, processes)
+	for i := range cmds {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestFileCache_Processes$")
+		cmd.Env = append(os.Environ(), "OPENPLANTBOOK_FILECACHE_HELPER="+dir)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("start helper: %v", err)
+		}
+		cmds[i] = cmd
+		t.Cleanup(func() {
+			if t.Failed() && stderr.Len() > 0 {
+				t.Logf("helper stderr:\n%s", stderr.String())
+			}
+		})
+	}
+	for i, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Errorf("helper %d failed: %v", i, err)
+		}
+	}
+
+	cache, _ := NewFileCache(dir)
+	checkFileCacheEntries(t, cache, fileCacheHelperKeys)
+}
+
+// fileCacheHelperKeys is the number of keys each helper process writes
+const fileCacheHelperKeys = 50
+
+func fileCacheHelper(dir string) {
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for i := 0; i < fileCacheHelperKeys; i++ {
+		key := "key-" + strconv.Itoa(i)
+		cache.Set(key, fileCacheValue(key), time.Hour)
+		if got, ok := cache.Get(key); ok && !bytes.Equal(got, fileCacheValue(key)) {
+			fmt.Fprintf(os.Stderr, "corrupt value for %s\n", key)
+			os.Exit(1)
+		}
+	}
+}
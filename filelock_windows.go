@@ -0,0 +1,29 @@
+//go:build windows
+
+package openplantbook
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// fileLocking reports whether lockFile takes real locks on this platform
+const fileLocking = true
+
+// lockRange is the byte range locked; Windows locks ranges, not files
+const lockRange = 1
+
+func lockFD(f *os.File, exclusive bool) error {
+	var flags uint32
+	if exclusive {
+		flags = windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+	ol := new(windows.Overlapped)
+	return windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, lockRange, 0, ol)
+}
+
+func unlockFD(f *os.File) error {
+	ol := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, lockRange, 0, ol)
+}
@@ -0,0 +1,91 @@
+package openplantbook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// getJSONTTL is how long GetJSON caches responses
+const getJSONTTL = 1 * time.Hour
+
+// GetJSON sends a GET request for path, relative to the base URL, with the
+// given query parameters and decodes the JSON response into out
+//
+// It is for endpoints the SDK does not model yet, and treats them like the
+// modeled ones: requests are authenticated, rate limited and budgeted
+// (against the details quota, unless path is under /plant/search), reported
+// to hooks and the audit log, and their errors are mapped to APIError,
+// ErrNotFound and the other client errors. Responses are cached for an hour
+// under a CacheOpGet key that includes the account, and served stale with
+// WithStaleIfError; clients that cannot identify their account (see
+// WithCacheNamespace) only cache them once a namespace is set. path must start with "/"; escape its segments with
+// url.PathEscape.
+//
+//	var out struct {
+//	    Count int `json:"count"`
+//	}
+//	err := client.GetJSON(ctx, "/plant/categories/", url.Values{"lang": {"de"}}, &out)
+func (c *Client) GetJSON(ctx context.Context, path string, query url.Values, out any) error {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.ContainsAny(path, "?#") {
+		return &ValidationError{Field: "path", Value: path, Message: "must be a path relative to the base URL, starting with /, without query or fragment"}
+	}
+	if out == nil {
+		return ErrInvalidInput("out cannot be nil")
+	}
+	class := endpointClassOf(path)
+
+	if !c.cacheEnabled() || !c.accountCacheable() {
+		_, err := c.fetchJSON(ctx, path, query, out)
+		return err
+	}
+
+	cacheKey := c.cacheKey(CacheKeyFor(CacheOpGet, map[string]string{
+		"path":    path,
+		"query":   query.Encode(),
+		"account": c.identity,
+	}))
+	data, meta, ok := c.cacheGet(cacheKey, c.hooks.OnCacheHit != nil)
+	if ok && meta.fresh(c.clock.Now()) && c.serializer.Unmarshal(data, out) == nil {
+		c.cacheHits.Add(1)
+		c.log("cache hit for GET", "path", path)
+		c.hooks.cacheHit(CacheHitInfo{Key: cacheKey, Endpoint: class, FetchedAt: meta.FetchedAt})
+		return nil
+	}
+
+	c.cacheMisses.Add(1)
+	raw, err := c.fetchJSON(ctx, path, query, out)
+	if err != nil {
+		setDryRunCacheKey(err, cacheKey)
+		if ok && c.serveStale(err, meta) && c.serializer.Unmarshal(data, out) == nil {
+			c.log("serving stale GET response", "path", path, "error", err)
+			c.hooks.cacheHit(CacheHitInfo{Key: cacheKey, Endpoint: class, FetchedAt: meta.FetchedAt, Stale: true})
+			return nil
+		}
+		return err
+	}
+	c.cacheSet(cacheKey, raw, out, getJSONTTL)
+	return nil
+}
+
+// fetchJSON sends a GetJSON request, bypassing the cache
+func (c *Client) fetchJSON(ctx context.Context, path string, query url.Values, out any) ([]byte, error) {
+	if err := c.waitRateLimit(ctx, endpointClassOf(path)); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	raw, err := c.doRequestRaw(ctx, req, out)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", path, err)
+	}
+	c.log("GET completed", "path", path)
+	return raw, nil
+}
@@ -0,0 +1,147 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestGetJSON(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Token test-key" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/plant/categories/":
+			w.Write([]byte(`{"count":2,"lang":"` + r.URL.Query().Get("lang") + `"}`))
+		default:
+			http.Error(w, `{"detail":"Not found."}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	type categories struct {
+		Count int    `json:"count"`
+		Lang  string `json:"lang"`
+	}
+	for i := 0; i < 2; i++ {
+		var out categories
+		if err := client.GetJSON(ctx, "/plant/categories/", url.Values{"lang": {"de"}}, &out); err != nil {
+			t.Fatalf("GetJSON() unexpected error: %v", err)
+		}
+		if out.Count != 2 || out.Lang != "de" {
+			t.Errorf("GetJSON() decoded %+v", out)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests for two identical calls, want 1 (cached)", n)
+	}
+
+	// Other query parameters are cached separately
+	var out categories
+	if err := client.GetJSON(ctx, "/plant/categories/", url.Values{"lang": {"fr"}}, &out); err != nil || out.Lang != "fr" {
+		t.Errorf("GetJSON(lang=fr) = %+v, %v", out, err)
+	}
+	if used := client.Status().Quota.Used; used != 2 {
+		t.Errorf("quota used = %d, want 2", used)
+	}
+
+	if err := client.GetJSON(ctx, "/plant/unknown/", nil, &out); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetJSON(unknown) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetJSON_WithoutIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		owner := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.Write([]byte(`{"owner":"` + owner + `"}`))
+	}))
+	defer server.Close()
+
+	// Clients authenticating with a token source share a cache but cannot
+	// tell their accounts apart in it
+	shared := NewInMemoryCache()
+	defer shared.Close()
+	newClient := func(token string) *Client {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		client, err := New(WithTokenSource(ts), WithBaseURL(server.URL), WithCache(shared), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+	alice, bob := newClient("alice"), newClient("bob")
+	defer alice.Close()
+	defer bob.Close()
+
+	for _, c := range []struct {
+		client *Client
+		owner  string
+	}{{alice, "alice"}, {bob, "bob"}} {
+		var out struct {
+			Owner string `json:"owner"`
+		}
+		if err := c.client.GetJSON(context.Background(), "/plant-instance/", nil, &out); err != nil {
+			t.Fatalf("GetJSON() unexpected error: %v", err)
+		}
+		if out.Owner != c.owner {
+			t.Errorf("GetJSON() owner = %q, want %q", out.Owner, c.owner)
+		}
+	}
+	if n := shared.Len(); n != 0 {
+		t.Errorf("cache holds %d entries, want none", n)
+	}
+}
+
+func TestGetJSON_InvalidInput(t *testing.T) {
+	client, err := New(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:1"), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var out map[string]any
+	for _, path := range []string{"", "plant/x", "//evil.example.com/x", "https://evil.example.com/x", "/plant?x=1", "/plant#x"} {
+		var validation *ValidationError
+		if err := client.GetJSON(context.Background(), path, nil, &out); !errors.As(err, &validation) {
+			t.Errorf("GetJSON(%q) error = %v, want *ValidationError", path, err)
+		}
+	}
+	if err := client.GetJSON(context.Background(), "/plant/x/", nil, nil); err == nil {
+		t.Error("GetJSON() with nil out expected error, got nil")
+	}
+}
+
+func TestGetJSON_DryRun(t *testing.T) {
+	client, err := New(WithAPIKey("test-key"), WithDryRun(), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var out map[string]any
+	err = client.GetJSON(context.Background(), "/plant/search/extra/", url.Values{"q": {"x"}}, &out)
+	var dryRun *ErrDryRun
+	if !errors.As(err, &dryRun) {
+		t.Fatalf("GetJSON() error = %v, want *ErrDryRun", err)
+	}
+	if dryRun.Endpoint != EndpointSearch || dryRun.CacheKey == "" {
+		t.Errorf("dry run = %+v, want the search class and a cache key", dryRun)
+	}
+}
@@ -0,0 +1,85 @@
+package openplantbook
+
+import (
+	"context"
+	"net/http"
+)
+
+// hedgedFetch sends req and, if no response arrives within the hedge delay,
+// a second identical request; the first successful response wins and the
+// other attempt is canceled
+//
+// The second attempt is only sent if the rate limiter has a token available
+// right away, so hedging never delays requests or exceeds the configured limit.
+// It also counts against request budgets (see WithBudget).
+func (c *Client) hedgedFetch(ctx context.Context, req *http.Request) ([]byte, error) {
+	type attempt struct {
+		n   int
+		raw []byte
+		err error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the losing attempt
+
+	results := make(chan attempt, 2)
+	launch := func(n int) {
+		go func() {
+			raw, err := c.fetch(req.Clone(ctx))
+			results <- attempt{n: n, raw: raw, err: err}
+		}()
+	}
+
+	launch(1)
+	pending := 1
+	hedge := c.clock.After(c.hedgeDelay)
+
+	var firstErr error
+	for {
+		select {
+		case <-hedge:
+			if c.allowHedge(ctx, endpointClassOf(req.URL.Path)) {
+				c.log("sending hedged request", "path", req.URL.Path, "after", c.hedgeDelay)
+				launch(2)
+				pending++
+			}
+
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				if r.n == 2 {
+					c.log("hedged request won", "path", req.URL.Path)
+				}
+				return r.raw, nil
+			}
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			if pending == 0 {
+				// Failures are not retried here; hedging only targets slow responses
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+// allowHedge reports whether an extra request is permitted now: the budgets
+// of ctx and the rate limiter for class must both allow it without waiting
+func (c *Client) allowHedge(ctx context.Context, class EndpointClass) bool {
+	if spendBudget(ctx) != nil {
+		return false
+	}
+	if limiters := c.limitersFor(class); len(limiters) > 0 {
+		now := c.clock.Now()
+		reservation, ok := reserve(limiters, now)
+		if ok && reservation.delayFrom(now) > 0 {
+			reservation.cancelAt(now)
+			ok = false
+		}
+		if !ok {
+			refundBudget(ctx)
+			return false
+		}
+	}
+	return true
+}
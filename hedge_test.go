@@ -0,0 +1,119 @@
+package openplantbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const hedgeDetail = `{"pid":"test","display_pid":"Test","alias":"Test Plant"}`
+
+// slowFirstServer stalls the first request until it is canceled and answers
+// later requests immediately
+func slowFirstServer(calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		w.Write([]byte(hedgeDetail))
+	}))
+}
+
+func TestWithHedging_SlowFirstAttempt(t *testing.T) {
+	var calls atomic.Int32
+	server := slowFirstServer(&calls)
+	defer server.Close()
+
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithHedging(20*time.Millisecond),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	details, err := client.GetPlantDetails(context.Background(), "test", nil)
+	if err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("GetPlantDetails() took %v, hedged request did not win", elapsed)
+	}
+	if details.PID != "test" {
+		t.Errorf("PID = %q, want %q", details.PID, "test")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestWithHedging_FastResponse(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(hedgeDetail))
+	}))
+	defer server.Close()
+
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithHedging(time.Second),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.GetPlantDetails(context.Background(), "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestWithHedging_RespectsRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := slowFirstServer(&calls)
+	defer server.Close()
+
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithHedging(20*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	// One token for the first attempt, none left for a hedge
+	client.rateLimiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err == nil {
+		t.Fatal("GetPlantDetails() expected timeout, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1 (hedge must not bypass the rate limit)", got)
+	}
+}
+
+func TestWithHedging_Invalid(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithHedging(0)); err == nil {
+		t.Error("WithHedging(0) expected error, got nil")
+	}
+}
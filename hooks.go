@@ -0,0 +1,98 @@
+package openplantbook
+
+import (
+	"time"
+)
+
+// Hooks are callbacks for request lifecycle events, for metrics and audit
+// trails without wrapping the HTTP client
+//
+// Any field may be nil. Hooks run synchronously on the goroutine making the
+// call, possibly on several goroutines at once, so they must be fast and
+// safe for concurrent use.
+type Hooks struct {
+	// OnRequest is called before each HTTP request is sent, including hedged
+	// second attempts
+	OnRequest func(RequestInfo)
+
+	// OnResponse is called when a request succeeds
+	OnResponse func(ResponseInfo)
+
+	// OnError is called when a request fails, with StatusCode 0 when no
+	// response was received
+	OnError func(ResponseInfo)
+
+	// OnCacheHit is called when a search, details or autocomplete lookup is
+	// answered from the cache
+	OnCacheHit func(CacheHitInfo)
+
+	// OnRateLimitWait is called after a request was held back by the rate
+	// limiter
+	OnRateLimitWait func(RateLimitWaitInfo)
+}
+
+// RequestInfo describes an outgoing API request
+type RequestInfo struct {
+	Method   string
+	URL      string // Full URL including query parameters
+	Endpoint EndpointClass
+}
+
+// ResponseInfo describes the outcome of an API request
+type ResponseInfo struct {
+	RequestInfo
+	StatusCode int
+	Duration   time.Duration // Time until the body was read or the request failed
+	Size       int           // Response body size in bytes
+	Err        error         // Set for OnError, with credentials redacted
+}
+
+// CacheHitInfo describes a lookup answered from the cache
+type CacheHitInfo struct {
+	Key      string
+	Endpoint EndpointClass
+
+	// FetchedAt is when the entry was fetched; zero for autocomplete entries
+	// and entries cached without metadata
+	FetchedAt time.Time
+
+	// Stale is set when an expired entry was served because the API failed
+	// (see WithStaleIfError)
+	Stale bool
+}
+
+// RateLimitWaitInfo describes a wait for the rate limiter
+type RateLimitWaitInfo struct {
+	Endpoint EndpointClass
+	Wait     time.Duration
+}
+
+func (h *Hooks) request(info RequestInfo) {
+	if h.OnRequest != nil {
+		h.OnRequest(info)
+	}
+}
+
+func (h *Hooks) response(info ResponseInfo) {
+	if info.Err != nil {
+		if h.OnError != nil {
+			h.OnError(info)
+		}
+		return
+	}
+	if h.OnResponse != nil {
+		h.OnResponse(info)
+	}
+}
+
+func (h *Hooks) cacheHit(info CacheHitInfo) {
+	if h.OnCacheHit != nil {
+		h.OnCacheHit(info)
+	}
+}
+
+func (h *Hooks) rateLimitWait(info RateLimitWaitInfo) {
+	if h.OnRateLimitWait != nil {
+		h.OnRateLimitWait(info)
+	}
+}
@@ -0,0 +1,74 @@
+package openplantbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestWithHooks(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/plant/detail/missing/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	var (
+		mu        sync.Mutex
+		requests  []RequestInfo
+		responses []ResponseInfo
+		failures  []ResponseInfo
+		hits      []CacheHitInfo
+		waits     []RateLimitWaitInfo
+	)
+	hooks := Hooks{
+		OnRequest:       func(i RequestInfo) { mu.Lock(); requests = append(requests, i); mu.Unlock() },
+		OnResponse:      func(i ResponseInfo) { mu.Lock(); responses = append(responses, i); mu.Unlock() },
+		OnError:         func(i ResponseInfo) { mu.Lock(); failures = append(failures, i); mu.Unlock() },
+		OnCacheHit:      func(i CacheHitInfo) { mu.Lock(); hits = append(hits, i); mu.Unlock() },
+		OnRateLimitWait: func(i RateLimitWaitInfo) { mu.Lock(); waits = append(waits, i); mu.Unlock() },
+	}
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), WithHooks(hooks))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	client.rateLimiter = rate.NewLimiter(rate.Every(20*time.Millisecond), 1)
+
+	ctx := context.Background()
+	for _, pid := range []string{"monstera", "monstera", "missing"} {
+		client.GetPlantDetails(ctx, pid, nil)
+	}
+
+	if len(requests) != 2 || requests[0].Method != "GET" || requests[0].URL != server.URL+"/plant/detail/monstera/" ||
+		requests[0].Endpoint != EndpointDetails {
+		t.Errorf("OnRequest calls = %+v, want the two uncached lookups", requests)
+	}
+	if len(responses) != 1 || responses[0].StatusCode != http.StatusOK || responses[0].Size != len(detailData) {
+		t.Errorf("OnResponse calls = %+v, want one 200 response", responses)
+	}
+	if len(failures) != 1 || failures[0].StatusCode != http.StatusNotFound || failures[0].Err == nil {
+		t.Errorf("OnError calls = %+v, want one 404", failures)
+	}
+	if len(hits) != 1 || hits[0].Key != detailCacheKey("monstera", nil) || hits[0].FetchedAt.IsZero() || hits[0].Stale {
+		t.Errorf("OnCacheHit calls = %+v, want one fresh hit for monstera", hits)
+	}
+	// The second request waited for a token
+	if len(waits) != 1 || waits[0].Endpoint != EndpointDetails || waits[0].Wait <= 0 {
+		t.Errorf("OnRateLimitWait calls = %+v, want one wait", waits)
+	}
+}
@@ -0,0 +1,73 @@
+package openplantbook
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// imagePolicy validates and rewrites image URLs (see WithImageHosts and
+// WithImageURLRewrite)
+type imagePolicy struct {
+	hosts   []string
+	rewrite func(string) string
+}
+
+// checkImageURL validates the image URL of decoded details
+// URLs must be absolute http or https URLs without credentials; http is
+// upgraded to https, and with WithImageHosts the host must be allowed.
+// Invalid URLs are cleared rather than failing the lookup, since the care
+// data is usable without an image. The rewrite of WithImageURLRewrite is
+// applied to the URLs that pass.
+func (c *Client) checkImageURL(d *PlantDetails) {
+	if d.ImageURL == "" {
+		return
+	}
+	u, err := normalizeImageURL(d.ImageURL, c.images.hosts)
+	if err != nil {
+		c.log("image url rejected", "pid", d.PID, "url", d.ImageURL, "error", err)
+		d.ImageURL = ""
+		return
+	}
+	if c.images.rewrite != nil {
+		u = c.images.rewrite(u)
+	}
+	d.ImageURL = u
+}
+
+// normalizeImageURL returns raw as an https URL, or an error if it is not
+// an acceptable image URL for the allowed hosts (any host when empty)
+func normalizeImageURL(raw string, hosts []string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+	case "http":
+		u.Scheme = "https"
+	default:
+		return "", errors.New("scheme must be https")
+	}
+	if u.Hostname() == "" {
+		return "", errors.New("missing host")
+	}
+	if u.User != nil {
+		return "", errors.New("credentials in image URL")
+	}
+	if len(hosts) > 0 && !imageHostAllowed(u.Hostname(), hosts) {
+		return "", errors.New("host not allowed")
+	}
+	return u.String(), nil
+}
+
+// imageHostAllowed reports whether host is one of hosts or a subdomain of one
+func imageHostAllowed(host string, hosts []string) bool {
+	host = strings.ToLower(host)
+	for _, h := range hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
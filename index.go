@@ -0,0 +1,305 @@
+package openplantbook
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Match weights for SearchLocal scoring
+const (
+	exactTokenScore  = 1.0
+	prefixTokenScore = 0.8
+	fuzzyTokenScore  = 0.5
+
+	// minFuzzyLen is the shortest query token matched with a typo allowance
+	minFuzzyLen = 4
+)
+
+// DefaultIndexSize is how many plants a client's local index holds unless
+// WithLocalIndex says otherwise
+const DefaultIndexSize = 5000
+
+// LocalResult is a SearchLocal match with its relevance score
+type LocalResult struct {
+	PlantSearchResult
+	Score float64 `json:"score"`
+}
+
+// Index is an in-memory full-text index over plant records
+//
+// Query tokens match indexed words exactly, as a prefix, or (for tokens of
+// four or more characters) with a single typo. Every query token must match
+// for a plant to be returned. An Index is safe for concurrent use.
+type Index struct {
+	mu     sync.RWMutex
+	limit  int
+	plants map[string]PlantSearchResult
+	tokens map[string]map[string]bool // token -> set of PIDs
+	byPID  map[string][]string        // PID -> tokens, for re-indexing
+	order  *list.List                 // PIDs, least recently added first
+	elems  map[string]*list.Element   // PID -> its element in order
+}
+
+// NewIndex creates an empty index without a size limit
+func NewIndex() *Index {
+	return NewLimitedIndex(0)
+}
+
+// NewLimitedIndex creates an empty index holding at most limit plants
+// Adding a plant beyond the limit evicts the least recently added one; a
+// limit of zero or less means no limit.
+func NewLimitedIndex(limit int) *Index {
+	return &Index{
+		limit:  limit,
+		plants: make(map[string]PlantSearchResult),
+		tokens: make(map[string]map[string]bool),
+		byPID:  make(map[string][]string),
+		order:  list.New(),
+		elems:  make(map[string]*list.Element),
+	}
+}
+
+// Add indexes plants, replacing earlier records with the same PID
+func (idx *Index) Add(plants ...PlantSearchResult) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, p := range plants {
+		if p.PID == "" {
+			continue
+		}
+		idx.remove(p.PID)
+
+		words := tokenize(p.PID + " " + p.DisplayPID + " " + p.Alias + " " + p.Category)
+		for _, w := range words {
+			if idx.tokens[w] == nil {
+				idx.tokens[w] = make(map[string]bool)
+			}
+			idx.tokens[w][p.PID] = true
+		}
+		idx.plants[p.PID] = p
+		idx.byPID[p.PID] = words
+		idx.elems[p.PID] = idx.order.PushBack(p.PID)
+	}
+
+	for idx.limit > 0 && len(idx.plants) > idx.limit {
+		idx.remove(idx.order.Front().Value.(string))
+	}
+}
+
+// Clear removes every plant from the index
+func (idx *Index) Clear() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	clear(idx.plants)
+	clear(idx.tokens)
+	clear(idx.byPID)
+	clear(idx.elems)
+	idx.order.Init()
+}
+
+// Len returns the number of indexed plants
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.plants)
+}
+
+// Search returns plants matching every token of query, best matches first
+func (idx *Index) Search(query string) []LocalResult {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var scores map[string]float64
+	for _, term := range terms {
+		termScores := make(map[string]float64)
+		for word, pids := range idx.tokens {
+			score := matchScore(term, word)
+			if score == 0 {
+				continue
+			}
+			for pid := range pids {
+				if score > termScores[pid] {
+					termScores[pid] = score
+				}
+			}
+		}
+
+		// Intersect with the plants matched by earlier terms
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for pid := range scores {
+			if s, ok := termScores[pid]; ok {
+				scores[pid] += s
+			} else {
+				delete(scores, pid)
+			}
+		}
+	}
+
+	results := make([]LocalResult, 0, len(scores))
+	for pid, score := range scores {
+		results = append(results, LocalResult{PlantSearchResult: idx.plants[pid], Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].PID < results[j].PID
+	})
+	return results
+}
+
+// pids returns the PIDs of all indexed plants in sorted order; a nil index
+// has none
+func (idx *Index) pids() []string {
+	if idx == nil {
+		return nil
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	pids := make([]string, 0, len(idx.plants))
+	for pid := range idx.plants {
+		pids = append(pids, pid)
+	}
+	sort.Strings(pids)
+	return pids
+}
+
+// has reports whether pid is indexed; a nil index has nothing
+func (idx *Index) has(pid string) bool {
+	if idx == nil {
+		return false
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	_, ok := idx.plants[pid]
+	return ok
+}
+
+// remove drops pid from the token index; callers hold the write lock
+func (idx *Index) remove(pid string) {
+	for _, w := range idx.byPID[pid] {
+		delete(idx.tokens[w], pid)
+		if len(idx.tokens[w]) == 0 {
+			delete(idx.tokens, w)
+		}
+	}
+	delete(idx.byPID, pid)
+	delete(idx.plants, pid)
+	if e, ok := idx.elems[pid]; ok {
+		idx.order.Remove(e)
+		delete(idx.elems, pid)
+	}
+}
+
+// matchScore rates how well a query term matches an indexed word
+func matchScore(term, word string) float64 {
+	switch {
+	case term == word:
+		return exactTokenScore
+	case strings.HasPrefix(word, term):
+		return prefixTokenScore
+	case len(term) >= minFuzzyLen && withinOneEdit(term, word):
+		return fuzzyTokenScore
+	}
+	return 0
+}
+
+// withinOneEdit reports whether a and b differ by at most one insertion,
+// deletion or substitution
+func withinOneEdit(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb)-len(ra) > 1 {
+		return false
+	}
+
+	i, j, edits := 0, 0, 0
+	for i < len(ra) && j < len(rb) {
+		if ra[i] == rb[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		if len(ra) == len(rb) {
+			i++ // substitution
+		}
+		j++ // insertion into the shorter string
+	}
+	return edits+(len(rb)-j)+(len(ra)-i) <= 1
+}
+
+// tokenize splits text into lowercase words
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	seen := make(map[string]bool, len(fields))
+	words := fields[:0]
+	for _, f := range fields {
+		if !seen[f] {
+			seen[f] = true
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// SearchLocal searches plants this client has already seen, without an API
+// request
+//
+// Search results and English plant details the client returns, whether
+// fetched or served from the cache, are added to an in-memory index of the
+// most recent DefaultIndexSize plants (see WithLocalIndex). User plants are
+// left out, as they belong to the account. SearchLocal tolerates partial
+// words and single typos ("monstra" finds Monstera).
+func (c *Client) SearchLocal(query string) []LocalResult {
+	if c.index == nil {
+		return nil
+	}
+	return c.index.Search(query)
+}
+
+// indexResults adds search results to the local index, unless they may
+// include the account's user plants
+func (c *Client) indexResults(results []PlantSearchResult, opts *SearchOptions) {
+	if c.index == nil || (opts != nil && opts.UserPlants) {
+		return
+	}
+	c.index.Add(results...)
+}
+
+// indexDetails adds a plant detail record to the local index
+func (c *Client) indexDetails(d *PlantDetails) {
+	// Search results carry English aliases; details in other languages
+	// would put translated aliases in front of English searches
+	if c.index == nil || d.UserPlant || !sameLanguage(d.Language, "en") {
+		return
+	}
+	c.index.Add(PlantSearchResult{
+		PID:        d.PID,
+		DisplayPID: d.DisplayPID,
+		Alias:      d.Alias,
+		Category:   d.Category,
+	})
+}
@@ -0,0 +1,210 @@
+package openplantbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testIndex() *Index {
+	idx := NewIndex()
+	idx.Add(
+		PlantSearchResult{PID: "monstera deliciosa", DisplayPID: "Monstera deliciosa", Alias: "Swiss cheese plant", Category: "Araceae"},
+		PlantSearchResult{PID: "monstera adansonii", DisplayPID: "Monstera adansonii", Alias: "Monkey mask", Category: "Araceae"},
+		PlantSearchResult{PID: "ficus lyrata", DisplayPID: "Ficus lyrata", Alias: "Fiddle-leaf fig", Category: "Moraceae"},
+	)
+	return idx
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := testIndex()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"monstera", []string{"monstera adansonii", "monstera deliciosa"}},
+		{"monstera deliciosa", []string{"monstera deliciosa"}},
+		{"Swiss cheese", []string{"monstera deliciosa"}},
+		{"fid", []string{"ficus lyrata"}},                                 // prefix
+		{"monstra", []string{"monstera adansonii", "monstera deliciosa"}}, // one typo
+		{"fiddle-leaf", []string{"ficus lyrata"}},
+		{"araceae monkey", []string{"monstera adansonii"}},
+		{"cactus", nil},
+		{"mon fig", nil}, // every term must match the same plant
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := idx.Search(tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i, pid := range tt.want {
+				if got[i].PID != pid {
+					t.Errorf("Search(%q)[%d] = %q, want %q", tt.query, i, got[i].PID, pid)
+				}
+			}
+		})
+	}
+}
+
+func TestIndex_Ranking(t *testing.T) {
+	idx := testIndex()
+	idx.Add(PlantSearchResult{PID: "monsteroid", Alias: "Not a monstera"})
+
+	got := idx.Search("monstera")
+	if len(got) != 3 {
+		t.Fatalf("Search() returned %d results, want 3", len(got))
+	}
+	if got[0].Score != exactTokenScore {
+		t.Errorf("best score = %v, want exact match %v", got[0].Score, exactTokenScore)
+	}
+}
+
+func TestIndex_AddReplaces(t *testing.T) {
+	idx := testIndex()
+	idx.Add(PlantSearchResult{PID: "ficus lyrata", Alias: "Banjo fig"})
+
+	if idx.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", idx.Len())
+	}
+	if got := idx.Search("fiddle"); len(got) != 0 {
+		t.Errorf("Search(fiddle) = %v, want stale alias removed", got)
+	}
+	if got := idx.Search("banjo"); len(got) != 1 {
+		t.Errorf("Search(banjo) = %v, want 1 result", got)
+	}
+}
+
+func TestIndex_Limit(t *testing.T) {
+	idx := NewLimitedIndex(2)
+	idx.Add(PlantSearchResult{PID: "monstera deliciosa"}, PlantSearchResult{PID: "ficus lyrata"})
+	idx.Add(PlantSearchResult{PID: "monstera deliciosa", Alias: "Swiss cheese plant"}) // seen again
+	idx.Add(PlantSearchResult{PID: "aloe vera"})
+
+	if idx.Len() != 2 || idx.has("ficus lyrata") || !idx.has("monstera deliciosa") || !idx.has("aloe vera") {
+		t.Errorf("index holds %v, want the two most recently added plants", idx.pids())
+	}
+	if got := idx.Search("ficus"); len(got) != 0 {
+		t.Errorf("Search() found evicted plant: %v", got)
+	}
+
+	idx.Clear()
+	if idx.Len() != 0 || len(idx.Search("monstera")) != 0 {
+		t.Errorf("index holds %v after Clear()", idx.pids())
+	}
+	idx.Add(PlantSearchResult{PID: "aloe vera"})
+	if idx.Len() != 1 {
+		t.Errorf("Len() after re-adding = %d, want 1", idx.Len())
+	}
+}
+
+func TestWithinOneEdit(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"monstera", "monstera", true},
+		{"monstra", "monstera", true},
+		{"monstera", "monstero", true},
+		{"monsteras", "monstera", true},
+		{"monstr", "monstera", false},
+		{"ficus", "fucas", false},
+	}
+	for _, tt := range tests {
+		if got := withinOneEdit(tt.a, tt.b); got != tt.want {
+			t.Errorf("withinOneEdit(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClient_SearchLocal(t *testing.T) {
+	searchData, err := os.ReadFile("testdata/search_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write(searchData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if got := client.SearchLocal("monstera"); len(got) != 0 {
+		t.Errorf("SearchLocal() before any search = %v, want none", got)
+	}
+
+	results, err := client.SearchPlants(context.Background(), "monstera", nil)
+	if err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+
+	local := client.SearchLocal("monstra")
+	if len(local) != len(results) {
+		t.Errorf("SearchLocal() returned %d results, want %d", len(local), len(results))
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1", calls)
+	}
+
+	// A shared index is searchable from another client
+	other, err := New(WithAPIKey("test-key"), WithIndex(client.index))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if got := other.SearchLocal("monstera"); len(got) != len(results) {
+		t.Errorf("SearchLocal() on shared index returned %d results, want %d", len(got), len(results))
+	}
+}
+
+func TestClient_SearchLocalUserPlants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/plant/detail/") {
+			w.Write([]byte(`{"pid":"ficus lyrata","alias":"My fig","user_plant":true}`))
+			return
+		}
+		w.Write([]byte(`{"count":1,"results":[{"pid":"ficus lyrata","alias":"My fig"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := client.SearchPlants(ctx, "fig", &SearchOptions{UserPlants: true}); err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	if _, err := client.GetPlantDetails(ctx, "ficus lyrata", &DetailOptions{UserPlants: true}); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if got := client.SearchLocal("fig"); len(got) != 0 {
+		t.Errorf("SearchLocal() = %v, want user plants left out of the index", got)
+	}
+
+	// A disabled index indexes nothing
+	client, err = New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), WithLocalIndex(0))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.SearchPlants(ctx, "fig", nil); err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	if got := client.SearchLocal("fig"); len(got) != 0 {
+		t.Errorf("SearchLocal() with the index disabled = %v, want none", got)
+	}
+	if _, err := New(WithAPIKey("test-key"), WithLocalIndex(-1)); err == nil {
+		t.Error("WithLocalIndex(-1) expected error, got nil")
+	}
+}
@@ -0,0 +1,356 @@
+// Package ingest receives sensor readings over HTTP, for gateways and
+// microcontrollers (ESP32, ESPHome, Tasmota) that can POST JSON but not run
+// the care engine themselves.
+//
+// Handler returns an http.Handler that checks each batch of readings against
+// the plant's thresholds, stores it, and optionally notifies and forwards it.
+// It can be mounted in any mux:
+//
+//	store := ingest.NewJSONLStore("readings.jsonl")
+//	receiver := ingest.Handler(client, store)
+//	receiver.Token = os.Getenv("INGEST_TOKEN")
+//	receiver.Notifier = notify.NewDispatcher(&notify.Ntfy{Topic: "my-plants"})
+//	receiver.Alerter = &care.Alerter{}
+//	receiver.AlertRules = plants.AlertRules // a *collection.Collection
+//	http.Handle("/readings", receiver)
+//
+// Devices POST a JSON Payload:
+//
+//	{"pid": "monstera deliciosa", "sensor": "esp32-livingroom",
+//	 "readings": [{"metric": "soil_moisture", "value": 34}, {"metric": "temperature", "value": 21.5}]}
+//
+// or a raw report converted by a sensors profile:
+//
+//	{"pid": "monstera deliciosa", "profile": "miflora", "report": {"temperature": 215, "moisture": 34}}
+package ingest
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/notify"
+	"github.com/rmrfslashbin/openplantbook-go/sensors"
+)
+
+// DefaultMaxBodySize is the request body limit when Receiver.MaxBodySize is zero
+const DefaultMaxBodySize = 64 << 10
+
+// DefaultLookupTTL is how long a receiver reuses a plant's details, or the
+// answer that it does not exist, when Receiver.LookupTTL is zero
+const DefaultLookupTTL = time.Hour
+
+// maxLookups bounds the plants a receiver remembers; all are forgotten when
+// it is reached
+const maxLookups = 1000
+
+// Payload is the request body devices POST
+type Payload struct {
+	PID    string `json:"pid"`
+	Sensor string `json:"sensor,omitempty"`
+
+	// Time is when the readings were taken; readings without a time of
+	// their own get it, or the time they were received if it is zero
+	Time time.Time `json:"time,omitempty"`
+
+	Readings []care.Reading `json:"readings,omitempty"`
+
+	// Profile names a sensors profile (see sensors.Lookup) that converts
+	// Report, the device's raw fields, into readings
+	Profile string             `json:"profile,omitempty"`
+	Report  map[string]float64 `json:"report,omitempty"`
+}
+
+// Record is an accepted batch of readings, as stored and forwarded
+type Record struct {
+	PID        string           `json:"pid"`
+	Sensor     string           `json:"sensor,omitempty"`
+	Received   time.Time        `json:"received"`
+	Readings   []care.Reading   `json:"readings"`
+	Violations []care.Violation `json:"violations,omitempty"`
+}
+
+// Response is the JSON body of a successful request
+type Response struct {
+	PID        string           `json:"pid"`
+	Accepted   int              `json:"accepted"`
+	Violations []care.Violation `json:"violations,omitempty"`
+	Forwarded  bool             `json:"forwarded,omitempty"`
+
+	// Warnings reports notification and forwarding failures; the readings
+	// were stored regardless
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Store persists accepted records
+type Store interface {
+	Append(ctx context.Context, r Record) error
+}
+
+// Forwarder sends accepted records on, such as to OpenPlantbook
+//
+// The SDK does not yet expose OpenPlantbook's sensor data upload endpoints,
+// so callers provide an implementation. Once the client supports them it
+// will satisfy this interface directly.
+type Forwarder interface {
+	Forward(ctx context.Context, r Record) error
+}
+
+// ForwarderFunc adapts a function to the Forwarder interface
+type ForwarderFunc func(ctx context.Context, r Record) error
+
+// Forward calls f(ctx, r)
+func (f ForwarderFunc) Forward(ctx context.Context, r Record) error {
+	return f(ctx, r)
+}
+
+// Receiver is the ingest handler; set its fields before serving
+type Receiver struct {
+	// Token must be sent by devices as "Authorization: Bearer <token>"
+	// While it is empty every request is refused, unless AllowAnonymous is
+	// set: each accepted request may cost an API request, so an open
+	// receiver lets anyone who can reach it spend the quota.
+	Token string
+
+	// AllowAnonymous accepts requests without a token, e.g. on a trusted
+	// network behind a proxy that authenticates devices
+	AllowAnonymous bool
+
+	// Evaluator adjusts thresholds, e.g. for winter dormancy; the zero value
+	// uses the raw thresholds
+	Evaluator care.Evaluator
+
+	// Notifier, if set, is sent the violations of each batch
+	Notifier *notify.Dispatcher
+
+	// Alerter, if set, debounces notifications: each batch is passed
+	// through it with the plant's AlertRules, and only the alerts it raises
+	// or clears are sent to Notifier. Set its Evaluator like Evaluator.
+	Alerter *care.Alerter
+
+	// AlertRules returns the alert rules of a plant for Alerter, e.g.
+	// collection.Collection.AlertRules; without it every metric alerts on
+	// the first reading out of range and clears on the first back in it
+	AlertRules func(pid string) care.AlertRules
+
+	// Forwarder, if set, is sent each stored record
+	Forwarder Forwarder
+
+	// MaxBodySize limits request bodies (DefaultMaxBodySize if zero)
+	MaxBodySize int64
+
+	// LookupTTL is how long a plant's details, or the answer that it does
+	// not exist, are reused for later requests (DefaultLookupTTL if zero)
+	LookupTTL time.Duration
+
+	client *openplantbook.Client
+	store  Store
+
+	mu      sync.Mutex
+	lookups map[string]lookup
+}
+
+// lookup is a remembered details lookup
+type lookup struct {
+	details *openplantbook.PlantDetails
+	err     error
+	expires time.Time
+}
+
+// Handler returns a receiver checking readings with client's plant details
+// and appending them to store
+// client may be nil to store readings without checking them.
+func Handler(client *openplantbook.Client, store Store) *Receiver {
+	return &Receiver{client: client, store: store}
+}
+
+// ServeHTTP implements http.Handler
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+	if rc.Token == "" && !rc.AllowAnonymous {
+		writeError(w, http.StatusServiceUnavailable, "receiver has no token configured")
+		return
+	}
+	if rc.Token != "" && !rc.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="ingest"`)
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+
+	limit := rc.MaxBodySize
+	if limit == 0 {
+		limit = DefaultMaxBodySize
+	}
+	var p Payload
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	if err := dec.Decode(&p); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("body exceeds %d bytes", limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	received := time.Now()
+	readings, err := p.readings(received)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	record := Record{PID: p.PID, Sensor: p.Sensor, Received: received, Readings: readings}
+	var details *openplantbook.PlantDetails
+	if rc.client != nil {
+		details, err = rc.plantDetails(ctx, p.PID)
+		switch {
+		case errors.Is(err, openplantbook.ErrNotFound):
+			writeError(w, http.StatusNotFound, fmt.Sprintf("unknown plant %q", p.PID))
+			return
+		case err != nil:
+			writeError(w, http.StatusBadGateway, "look up plant: "+err.Error())
+			return
+		}
+		record.PID = details.PID
+		record.Violations = rc.Evaluator.Evaluate(details, readings...)
+	}
+
+	if err := rc.store.Append(ctx, record); err != nil {
+		writeError(w, http.StatusInternalServerError, "store readings: "+err.Error())
+		return
+	}
+
+	resp := Response{PID: record.PID, Accepted: len(readings), Violations: record.Violations}
+	if rc.Notifier != nil {
+		if err := rc.notify(ctx, details, record); err != nil {
+			resp.Warnings = append(resp.Warnings, "notify: "+err.Error())
+		}
+	}
+	if rc.Forwarder != nil {
+		if err := rc.Forwarder.Forward(ctx, record); err != nil {
+			resp.Warnings = append(resp.Warnings, "forward: "+err.Error())
+		} else {
+			resp.Forwarded = true
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// plantDetails returns the details of pid, reusing recent lookups so that
+// frequent reports, or reports for unknown plants, cost no further requests
+func (rc *Receiver) plantDetails(ctx context.Context, pid string) (*openplantbook.PlantDetails, error) {
+	now := time.Now()
+	rc.mu.Lock()
+	l, ok := rc.lookups[pid]
+	rc.mu.Unlock()
+	if ok && now.Before(l.expires) {
+		return l.details, l.err
+	}
+
+	details, err := rc.client.GetPlantDetails(ctx, pid, nil)
+	if err != nil && !errors.Is(err, openplantbook.ErrNotFound) {
+		// Transient failures are retried by the next request
+		return nil, err
+	}
+	ttl := rc.LookupTTL
+	if ttl == 0 {
+		ttl = DefaultLookupTTL
+	}
+	rc.mu.Lock()
+	if rc.lookups == nil || len(rc.lookups) >= maxLookups {
+		rc.lookups = make(map[string]lookup)
+	}
+	rc.lookups[pid] = lookup{details: details, err: err, expires: now.Add(ttl)}
+	rc.mu.Unlock()
+	return details, err
+}
+
+// notify sends the record's violations to Notifier, or with an Alerter,
+// the alerts its readings raise or clear
+func (rc *Receiver) notify(ctx context.Context, details *openplantbook.PlantDetails, record Record) error {
+	if rc.Alerter == nil || details == nil {
+		return rc.Notifier.Dispatch(ctx, record.Violations)
+	}
+	var rules care.AlertRules
+	if rc.AlertRules != nil {
+		rules = rc.AlertRules(record.PID)
+	}
+	// The Alerter expects readings in time order
+	readings := slices.Clone(record.Readings)
+	slices.SortStableFunc(readings, func(a, b care.Reading) int { return a.Time.Compare(b.Time) })
+	return rc.Notifier.Alert(ctx, rc.Alerter.Observe(details, rules, readings...))
+}
+
+// authorized reports whether the request carries the bearer token
+func (rc *Receiver) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(rc.Token)) == 1
+}
+
+// readings validates the payload and returns its readings, converting a
+// profile report and filling in times
+func (p *Payload) readings(received time.Time) ([]care.Reading, error) {
+	p.PID = strings.TrimSpace(p.PID)
+	if p.PID == "" {
+		return nil, errors.New("pid is required")
+	}
+	at := p.Time
+	if at.IsZero() {
+		at = received
+	}
+
+	readings := slices.Clone(p.Readings)
+	if p.Profile != "" || len(p.Report) > 0 {
+		profile, ok := sensors.Lookup(p.Profile)
+		if !ok {
+			return nil, fmt.Errorf("unknown profile %q", p.Profile)
+		}
+		readings = append(readings, profile.Readings(p.Report, at)...)
+	}
+	if len(readings) == 0 {
+		return nil, errors.New("no readings")
+	}
+
+	for i := range readings {
+		rd := &readings[i]
+		if !slices.Contains(care.Metrics, rd.Metric) {
+			return nil, fmt.Errorf("reading %d: unknown metric %q", i, rd.Metric)
+		}
+		if math.IsNaN(rd.Value) || math.IsInf(rd.Value, 0) {
+			return nil, fmt.Errorf("reading %d: invalid value", i)
+		}
+		if rd.Time.IsZero() {
+			rd.Time = at
+		}
+		if rd.Sensor == "" {
+			rd.Sensor = p.Sensor
+		}
+	}
+	return readings, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,255 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+	"github.com/rmrfslashbin/openplantbook-go/notify"
+)
+
+func testClient(t *testing.T) *openplantbook.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/plant/detail/"), "/")
+		if pid != "monstera deliciosa" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(openplantbook.PlantDetails{
+			PID: pid, Alias: "Monstera", MinTemp: 15, MaxTemp: 30, MinSoilMoist: 15, MaxSoilMoist: 60,
+		})
+	}))
+	t.Cleanup(server.Close)
+	client, err := openplantbook.New(
+		openplantbook.WithAPIKey("test-key"),
+		openplantbook.WithBaseURL(server.URL),
+		openplantbook.DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func post(h http.Handler, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/readings", strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReceiver(t *testing.T) {
+	store := &MemoryStore{}
+	receiver := Handler(testClient(t), store)
+	receiver.AllowAnonymous = true
+
+	var notified []care.Violation
+	receiver.Notifier = notify.NewDispatcher(notify.SinkFunc(func(ctx context.Context, n notify.Notification) error {
+		notified = append(notified, n.Violations...)
+		return nil
+	}))
+	var forwarded []Record
+	receiver.Forwarder = ForwarderFunc(func(ctx context.Context, r Record) error {
+		forwarded = append(forwarded, r)
+		return nil
+	})
+
+	rec := post(receiver, `{"pid": "monstera deliciosa", "sensor": "esp32", "time": "2025-06-01T12:00:00Z",
+		"readings": [{"metric": "soil_moisture", "value": 9}, {"metric": "temperature", "value": 22}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp Response
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Accepted != 2 || len(resp.Violations) != 1 || !resp.Forwarded || len(resp.Warnings) != 0 {
+		t.Errorf("response = %+v", resp)
+	}
+
+	records := store.Records()
+	if len(records) != 1 {
+		t.Fatalf("stored %d records, want 1", len(records))
+	}
+	r := records[0]
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	if r.PID != "monstera deliciosa" || r.Readings[0].Sensor != "esp32" || !r.Readings[0].Time.Equal(at) {
+		t.Errorf("stored record = %+v", r)
+	}
+	if len(notified) != 1 || notified[0].Reading.Metric != care.MetricSoilMoisture {
+		t.Errorf("notified %v, want the soil moisture violation", notified)
+	}
+	if len(forwarded) != 1 {
+		t.Errorf("forwarded %d records, want 1", len(forwarded))
+	}
+
+	// A forwarding failure is reported, but the readings are kept
+	receiver.Forwarder = ForwarderFunc(func(ctx context.Context, r Record) error {
+		return errors.New("upstream down")
+	})
+	rec = post(receiver, `{"pid": "monstera deliciosa", "profile": "miflora", "report": {"temperature": 215, "moisture": 34}}`)
+	resp = Response{}
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if rec.Code != http.StatusOK || resp.Accepted != 2 || resp.Forwarded || len(resp.Warnings) != 1 {
+		t.Errorf("status %d, response %+v", rec.Code, resp)
+	}
+	if got := store.Records()[1].Readings[0]; got.Metric != care.MetricTemperature || got.Value != 21.5 {
+		t.Errorf("profile reading = %+v, want 21.5 °C", got)
+	}
+}
+
+func TestReceiver_Alerter(t *testing.T) {
+	receiver := Handler(testClient(t), &MemoryStore{})
+	receiver.AllowAnonymous = true
+	var sent []notify.Notification
+	receiver.Notifier = notify.NewDispatcher(notify.SinkFunc(func(ctx context.Context, n notify.Notification) error {
+		sent = append(sent, n)
+		return nil
+	}))
+	receiver.Alerter = &care.Alerter{}
+	receiver.AlertRules = func(pid string) care.AlertRules {
+		return care.AlertRules{care.MetricSoilMoisture: {Hysteresis: 10}}
+	}
+
+	// Repeated violations and readings within the hysteresis notify once
+	// when the alert is raised and once when it clears
+	for i, value := range []float64{9, 12, 20, 10, 30} {
+		body := fmt.Sprintf(`{"pid": "monstera deliciosa", "time": "2025-06-01T%02d:00:00Z", "readings": [{"metric": "soil_moisture", "value": %g}]}`, i, value)
+		if rec := post(receiver, body); rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+		}
+	}
+	if len(sent) != 2 || len(sent[0].Violations) != 1 || len(sent[1].Resolved) != 1 {
+		t.Errorf("sent %+v, want the alert raised once and cleared once", sent)
+	}
+}
+
+func TestReceiver_Errors(t *testing.T) {
+	store := &MemoryStore{}
+	receiver := Handler(testClient(t), store)
+	receiver.Token = "secret"
+	receiver.MaxBodySize = 256
+	auth := []string{"Authorization", "Bearer secret"}
+
+	tests := []struct {
+		name   string
+		body   string
+		header []string
+		want   int
+	}{
+		{"no token", `{"pid": "monstera deliciosa", "readings": [{"metric": "temperature", "value": 22}]}`, nil, http.StatusUnauthorized},
+		{"wrong token", `{}`, []string{"Authorization", "Bearer guess"}, http.StatusUnauthorized},
+		{"invalid JSON", `{"pid":`, auth, http.StatusBadRequest},
+		{"no pid", `{"readings": [{"metric": "temperature", "value": 22}]}`, auth, http.StatusBadRequest},
+		{"no readings", `{"pid": "monstera deliciosa"}`, auth, http.StatusBadRequest},
+		{"unknown metric", `{"pid": "monstera deliciosa", "readings": [{"metric": "co2", "value": 400}]}`, auth, http.StatusBadRequest},
+		{"unknown profile", `{"pid": "monstera deliciosa", "profile": "acme", "report": {"t": 1}}`, auth, http.StatusBadRequest},
+		{"unknown plant", `{"pid": "no such plant", "readings": [{"metric": "temperature", "value": 22}]}`, auth, http.StatusNotFound},
+		{"too large", `{"pid": "` + strings.Repeat("x", 300) + `"}`, auth, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(receiver, tt.body, tt.header...)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), `"error"`) {
+				t.Errorf("body %s has no error", rec.Body)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readings", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET status = %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+	if n := len(store.Records()); n != 0 {
+		t.Errorf("stored %d records from rejected requests", n)
+	}
+}
+
+func TestReceiver_NoToken(t *testing.T) {
+	store := &MemoryStore{}
+	rec := post(Handler(nil, store), `{"pid": "anything", "readings": [{"metric": "temperature", "value": 22}]}`)
+	if rec.Code != http.StatusServiceUnavailable || len(store.Records()) != 0 {
+		t.Errorf("status %d, %d records; want every request refused without a token", rec.Code, len(store.Records()))
+	}
+}
+
+func TestReceiver_Lookups(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !strings.Contains(r.URL.Path, "monstera") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"pid": "monstera deliciosa", "min_temp": 15, "max_temp": 30}`))
+	}))
+	defer server.Close()
+	client, err := openplantbook.New(openplantbook.WithAPIKey("test-key"), openplantbook.WithBaseURL(server.URL),
+		openplantbook.DisableCache(), openplantbook.DisableRateLimit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	receiver := Handler(client, &MemoryStore{})
+	receiver.Token = "secret"
+	for _, pid := range []string{"monstera deliciosa", "monstera deliciosa", "no such plant", "no such plant"} {
+		post(receiver, `{"pid": "`+pid+`", "readings": [{"metric": "temperature", "value": 22}]}`, "Authorization", "Bearer secret")
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d API requests, want 2 (one per plant)", n)
+	}
+}
+
+func TestReceiver_NoClient(t *testing.T) {
+	store := &MemoryStore{}
+	receiver := Handler(nil, store)
+	receiver.AllowAnonymous = true
+	rec := post(receiver, `{"pid": "anything", "readings": [{"metric": "temperature", "value": 99}]}`)
+	if rec.Code != http.StatusOK || len(store.Records()) != 1 || store.Records()[0].Violations != nil {
+		t.Errorf("status %d, records %+v", rec.Code, store.Records())
+	}
+}
+
+func TestJSONLStore(t *testing.T) {
+	store := NewJSONLStore(filepath.Join(t.TempDir(), "data", "readings.jsonl"))
+	if records, err := store.Load(); err != nil || records != nil {
+		t.Fatalf("Load() of a missing file = %v, %v", records, err)
+	}
+
+	ctx := context.Background()
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, v := range []float64{20, 21} {
+		r := Record{PID: "monstera deliciosa", Received: at, Readings: []care.Reading{{Metric: care.MetricTemperature, Value: v, Time: at}}}
+		if err := store.Append(ctx, r); err != nil {
+			t.Fatalf("Append() unexpected error: %v", err)
+		}
+	}
+
+	records, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(records) != 2 || records[1].Readings[0].Value != 21 || !records[0].Received.Equal(at) {
+		t.Errorf("Load() = %+v", records)
+	}
+}
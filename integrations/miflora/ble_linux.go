@@ -0,0 +1,154 @@
+//go:build linux
+
+package miflora
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"tinygo.org/x/bluetooth"
+)
+
+var (
+	// mu serializes reads: the adapter runs one scan at a time
+	mu sync.Mutex
+
+	enableOnce sync.Once
+	enableErr  error
+)
+
+// Read scans for the sensor with the given address, connects to it and
+// returns its current measurement, battery level and firmware version
+//
+// It uses the system's default Bluetooth adapter through BlueZ, and waits
+// until the sensor is found and read or ctx is done. Reads are serialized.
+// A connection attempt in progress when ctx is done completes in the
+// background, and the sensor is then disconnected.
+func Read(ctx context.Context, address string) (Measurement, error) {
+	mac, err := bluetooth.ParseMAC(address)
+	if err != nil {
+		return Measurement{}, fmt.Errorf("miflora: invalid address %q: %w", address, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	adapter := bluetooth.DefaultAdapter
+	enableOnce.Do(func() { enableErr = adapter.Enable() })
+	if enableErr != nil {
+		return Measurement{}, fmt.Errorf("miflora: enable Bluetooth adapter: %w", enableErr)
+	}
+
+	addr, err := scan(ctx, adapter, mac)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	type result struct {
+		m   Measurement
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		m, err := readDevice(adapter, addr)
+		done <- result{m, err}
+	}()
+	select {
+	case res := <-done:
+		return res.m, res.err
+	case <-ctx.Done():
+		return Measurement{}, ctx.Err()
+	}
+}
+
+// scan waits until the sensor advertises
+func scan(ctx context.Context, adapter *bluetooth.Adapter, mac bluetooth.MAC) (bluetooth.Address, error) {
+	found := make(chan bluetooth.Address, 1)
+	scanErr := make(chan error, 1)
+	go func() {
+		scanErr <- adapter.Scan(func(a *bluetooth.Adapter, r bluetooth.ScanResult) {
+			if r.Address.MAC != mac {
+				return
+			}
+			select {
+			case found <- r.Address:
+				a.StopScan()
+			default:
+			}
+		})
+	}()
+
+	select {
+	case addr := <-found:
+		<-scanErr
+		return addr, nil
+	case err := <-scanErr:
+		if err == nil {
+			err = fmt.Errorf("scan stopped before %s was found", mac)
+		}
+		return bluetooth.Address{}, fmt.Errorf("miflora: scan: %w", err)
+	case <-ctx.Done():
+		adapter.StopScan()
+		<-scanErr
+		return bluetooth.Address{}, fmt.Errorf("miflora: %s not found: %w", mac, ctx.Err())
+	}
+}
+
+// readDevice connects to the sensor and reads its characteristics
+func readDevice(adapter *bluetooth.Adapter, addr bluetooth.Address) (Measurement, error) {
+	device, err := adapter.Connect(addr, bluetooth.ConnectionParams{})
+	if err != nil {
+		return Measurement{}, fmt.Errorf("miflora: connect to %s: %w", addr.MAC, err)
+	}
+	defer device.Disconnect()
+
+	uuids, err := parseUUIDs(ServiceUUID, ModeUUID, DataUUID, FirmwareUUID)
+	if err != nil {
+		return Measurement{}, err
+	}
+	services, err := device.DiscoverServices(uuids[:1])
+	if err != nil {
+		return Measurement{}, fmt.Errorf("miflora: discover services: %w", err)
+	}
+	chars, err := services[0].DiscoverCharacteristics(uuids[1:])
+	if err != nil {
+		return Measurement{}, fmt.Errorf("miflora: discover characteristics: %w", err)
+	}
+	mode, data, firmware := chars[0], chars[1], chars[2]
+
+	buf := make([]byte, 32)
+	n, err := firmware.Read(buf)
+	if err != nil {
+		return Measurement{}, fmt.Errorf("miflora: read firmware: %w", err)
+	}
+	battery, version, err := DecodeFirmware(buf[:n])
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	if _, err := mode.WriteWithoutResponse(RealTimeMode); err != nil {
+		return Measurement{}, fmt.Errorf("miflora: enable real-time mode: %w", err)
+	}
+	if n, err = data.Read(buf); err != nil {
+		return Measurement{}, fmt.Errorf("miflora: read data: %w", err)
+	}
+	m, err := Decode(buf[:n])
+	if err != nil {
+		return Measurement{}, err
+	}
+	m.Battery, m.Firmware = battery, version
+	return m, nil
+}
+
+func parseUUIDs(ss ...string) ([]bluetooth.UUID, error) {
+	uuids := make([]bluetooth.UUID, len(ss))
+	for i, s := range ss {
+		u, err := bluetooth.ParseUUID(s)
+		if err != nil {
+			return nil, fmt.Errorf("miflora: parse UUID %s: %w", s, err)
+		}
+		uuids[i] = u
+	}
+	return uuids, nil
+}
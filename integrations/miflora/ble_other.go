@@ -0,0 +1,10 @@
+//go:build !linux
+
+package miflora
+
+import "context"
+
+// Read returns ErrUnsupported: reading sensors requires Linux with BlueZ
+func Read(ctx context.Context, address string) (Measurement, error) {
+	return Measurement{}, ErrUnsupported
+}
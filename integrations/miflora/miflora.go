@@ -0,0 +1,97 @@
+// Package miflora reads Xiaomi Mi Flora (Flower Care) plant sensors over
+// Bluetooth Low Energy and converts their measurements to care readings.
+//
+// Reading a sensor requires Linux with BlueZ; on other platforms Read returns
+// ErrUnsupported. Decoding works everywhere, for measurements received some
+// other way (such as from an ESPHome or Home Assistant BLE proxy):
+//
+//	m, err := miflora.Read(ctx, "C4:7C:8D:6A:12:34")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	violations := care.Evaluate(details, m.Readings(time.Now())...)
+package miflora
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// GATT service and characteristics of the sensor
+const (
+	// ServiceUUID is the data service
+	ServiceUUID = "00001204-0000-1000-8000-00805f9b34fb"
+	// ModeUUID switches the sensor to real-time data mode
+	ModeUUID = "00001a00-0000-1000-8000-00805f9b34fb"
+	// DataUUID holds the real-time measurement (see Decode)
+	DataUUID = "00001a01-0000-1000-8000-00805f9b34fb"
+	// FirmwareUUID holds the battery level and firmware version (see
+	// DecodeFirmware)
+	FirmwareUUID = "00001a02-0000-1000-8000-00805f9b34fb"
+)
+
+// RealTimeMode is written to the mode characteristic before reading data;
+// firmware 2.6.6 and later return placeholder data without it
+var RealTimeMode = []byte{0xa0, 0x1f}
+
+// ErrUnsupported is returned by Read on platforms without BLE support
+var ErrUnsupported = errors.New("miflora: Bluetooth reading is only supported on Linux")
+
+// Measurement is one reading of every sensor on the device
+type Measurement struct {
+	Temperature  float64 `json:"temperature"`  // °C
+	Light        uint32  `json:"light"`        // lux
+	Moisture     uint8   `json:"moisture"`     // Soil moisture in %
+	Conductivity uint16  `json:"conductivity"` // Soil EC in µS/cm
+	Battery      uint8   `json:"battery"`      // In %; zero if not read
+	Firmware     string  `json:"firmware"`     // Empty if not read
+}
+
+// Decode parses the 16-byte value of the data characteristic
+func Decode(data []byte) (Measurement, error) {
+	if len(data) < 10 {
+		return Measurement{}, fmt.Errorf("miflora: data is %d bytes, want 16", len(data))
+	}
+	// aa bb cc dd ee ff 99 88 77 66 ...: placeholder returned when the
+	// sensor is not in real-time mode
+	if data[0] == 0xaa && data[1] == 0xbb && data[2] == 0xcc {
+		return Measurement{}, errors.New("miflora: sensor returned placeholder data (real-time mode not enabled)")
+	}
+	return Measurement{
+		Temperature:  float64(int16(binary.LittleEndian.Uint16(data[0:2]))) / 10,
+		Light:        binary.LittleEndian.Uint32(data[3:7]),
+		Moisture:     data[7],
+		Conductivity: binary.LittleEndian.Uint16(data[8:10]),
+	}, nil
+}
+
+// DecodeFirmware parses the value of the firmware characteristic: the
+// battery level in percent followed by the firmware version
+func DecodeFirmware(data []byte) (battery uint8, version string, err error) {
+	if len(data) < 3 {
+		return 0, "", fmt.Errorf("miflora: firmware data is %d bytes, want 7", len(data))
+	}
+	version = string(data[2:])
+	for i, b := range data[2:] {
+		if b == 0 {
+			version = string(data[2 : 2+i])
+			break
+		}
+	}
+	return data[0], version, nil
+}
+
+// Readings returns the measurement as care readings taken at the given time,
+// in the units Plantbook thresholds use
+func (m Measurement) Readings(at time.Time) []care.Reading {
+	return []care.Reading{
+		{Metric: care.MetricLight, Value: float64(m.Light), Time: at},
+		{Metric: care.MetricTemperature, Value: m.Temperature, Time: at},
+		{Metric: care.MetricSoilMoisture, Value: float64(m.Moisture), Time: at},
+		{Metric: care.MetricSoilEC, Value: float64(m.Conductivity), Time: at},
+	}
+}
@@ -0,0 +1,78 @@
+package miflora
+
+import (
+	"testing"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func TestDecode(t *testing.T) {
+	// 23.1 °C, 1234 lux, 34 %, 420 µS/cm
+	data := []byte{0xe7, 0x00, 0x00, 0xd2, 0x04, 0x00, 0x00, 0x22, 0xa4, 0x01, 0x02, 0x3c, 0x00, 0x00, 0x00, 0x00}
+	m, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() unexpected error: %v", err)
+	}
+	want := Measurement{Temperature: 23.1, Light: 1234, Moisture: 34, Conductivity: 420}
+	if m != want {
+		t.Errorf("Decode() = %+v, want %+v", m, want)
+	}
+
+	// Below zero
+	if m, _ := Decode([]byte{0xe2, 0xff, 0, 0, 0, 0, 0, 0, 0, 0}); m.Temperature != -3 {
+		t.Errorf("Decode() temperature = %v, want -3", m.Temperature)
+	}
+
+	placeholder := []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x99, 0x88, 0x77, 0x66, 0, 0, 0, 0, 0, 0}
+	if _, err := Decode(placeholder); err == nil {
+		t.Error("Decode(placeholder) expected error, got nil")
+	}
+	if _, err := Decode(data[:4]); err == nil {
+		t.Error("Decode(short) expected error, got nil")
+	}
+}
+
+func TestDecodeFirmware(t *testing.T) {
+	battery, version, err := DecodeFirmware([]byte{0x63, 0x13, '3', '.', '2', '.', '1'})
+	if err != nil || battery != 99 || version != "3.2.1" {
+		t.Errorf("DecodeFirmware() = %d, %q, %v; want 99, 3.2.1", battery, version, err)
+	}
+	if _, version, _ := DecodeFirmware([]byte{0x50, 0x13, '2', '.', '7', 0, 0}); version != "2.7" {
+		t.Errorf("DecodeFirmware() version = %q, want 2.7", version)
+	}
+	if _, _, err := DecodeFirmware([]byte{0x63}); err == nil {
+		t.Error("DecodeFirmware(short) expected error, got nil")
+	}
+}
+
+func TestMeasurement_Readings(t *testing.T) {
+	details := &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		MinLightLux:  2500,
+		MaxLightLux:  20000,
+		MinTemp:      15,
+		MaxTemp:      30,
+		MinSoilMoist: 15,
+		MaxSoilMoist: 60,
+		MinSoilEC:    350,
+		MaxSoilEC:    2000,
+	}
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	m := Measurement{Temperature: 23.1, Light: 1234, Moisture: 34, Conductivity: 200}
+
+	readings := m.Readings(at)
+	if len(readings) != len(care.Metrics)-1 {
+		t.Fatalf("Readings() = %d readings, want %d", len(readings), len(care.Metrics)-1)
+	}
+	violations := care.Evaluate(details, readings...)
+	if len(violations) != 2 {
+		t.Fatalf("Evaluate() = %v, want light and soil EC too low", violations)
+	}
+	for _, v := range violations {
+		if v.Status != care.StatusLow || !v.Reading.Time.Equal(at) {
+			t.Errorf("violation = %v", v)
+		}
+	}
+}
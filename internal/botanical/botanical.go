@@ -0,0 +1,169 @@
+// Package botanical parses and formats scientific plant names.
+//
+// It backs the public taxonomy package and name formatting in the root
+// package, which taxonomy itself imports.
+package botanical
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Name is a parsed scientific plant name
+type Name struct {
+	Genus string `json:"genus"`
+
+	// Species is the specific epithet ("deliciosa"); empty for genus-only
+	// names and unspecified species ("sp.")
+	Species string `json:"species,omitempty"`
+
+	// Rank and Infraspecific hold a variety, subspecies or form, e.g.
+	// "var." and "laurentii"
+	Rank          string `json:"rank,omitempty"`
+	Infraspecific string `json:"infraspecific,omitempty"`
+
+	// Cultivar is the cultivar name without quotes ("Marble Queen")
+	Cultivar string `json:"cultivar,omitempty"`
+
+	// Hybrid is true for names marked as hybrids
+	Hybrid bool `json:"hybrid,omitempty"`
+}
+
+// ranks maps infraspecific rank spellings to their canonical abbreviation
+var ranks = map[string]string{
+	"var.": "var.", "var": "var.",
+	"subsp.": "subsp.", "subsp": "subsp.", "ssp.": "subsp.", "ssp": "subsp.",
+	"f.": "f.", "forma": "f.",
+}
+
+// Parse splits a scientific name into its parts
+// Unparseable input yields a Name with only Genus set to the first word.
+func Parse(name string) Name {
+	var n Name
+
+	name, n.Cultivar = cutCultivar(name)
+
+	words := strings.Fields(name)
+	for len(words) > 0 && isHybridMark(words[0]) {
+		n.Hybrid = true
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return n
+	}
+
+	genus := words[0]
+	if g, ok := strings.CutPrefix(genus, "×"); ok && g != "" {
+		n.Hybrid, genus = true, g
+	}
+	n.Genus = capitalize(genus)
+	words = words[1:]
+
+	if len(words) > 0 && isHybridMark(words[0]) {
+		n.Hybrid = true
+		words = words[1:]
+	}
+
+	if len(words) > 0 {
+		switch w := words[0]; {
+		case strings.EqualFold(w, "sp.") || strings.EqualFold(w, "spp.") || strings.EqualFold(w, "sp"):
+			words = words[1:]
+		case w == "cv." || w == "cv":
+			// Cultivar follows directly after the genus
+		case !startsUpper(w):
+			n.Species = strings.ToLower(strings.TrimPrefix(w, "×"))
+			n.Hybrid = n.Hybrid || strings.HasPrefix(w, "×")
+			words = words[1:]
+		}
+	}
+
+	if len(words) >= 2 {
+		if rank, ok := ranks[strings.ToLower(words[0])]; ok {
+			n.Rank, n.Infraspecific = rank, strings.ToLower(words[1])
+			words = words[2:]
+		}
+	}
+
+	if len(words) > 0 && (words[0] == "cv." || words[0] == "cv") {
+		words = words[1:]
+	}
+	if len(words) > 0 && n.Cultivar == "" {
+		n.Cultivar = titleCase(strings.Join(words, " "))
+	}
+	return n
+}
+
+// Binomial returns "Genus species", or just the genus when the species is
+// unknown
+func (n Name) Binomial() string {
+	if n.Species == "" {
+		return n.Genus
+	}
+	if n.Hybrid {
+		return n.Genus + " × " + n.Species
+	}
+	return n.Genus + " " + n.Species
+}
+
+// String formats the name in botanical style, e.g.
+// "Sansevieria trifasciata var. laurentii" or "Epipremnum aureum 'Marble Queen'"
+func (n Name) String() string {
+	s := n.Binomial()
+	if n.Rank != "" {
+		s += " " + n.Rank + " " + n.Infraspecific
+	}
+	if n.Cultivar != "" {
+		s += " '" + n.Cultivar + "'"
+	}
+	return s
+}
+
+// cutCultivar removes a quoted cultivar name from s
+func cutCultivar(s string) (rest, cultivar string) {
+	for _, q := range [][2]string{{"'", "'"}, {"‘", "’"}, {`"`, `"`}, {"“", "”"}} {
+		start := strings.Index(s, q[0])
+		if start < 0 {
+			continue
+		}
+		end := strings.Index(s[start+len(q[0]):], q[1])
+		if end < 0 {
+			continue
+		}
+		cultivar = strings.TrimSpace(s[start+len(q[0]) : start+len(q[0])+end])
+		rest = s[:start] + " " + s[start+len(q[0])+end+len(q[1]):]
+		return rest, cultivar
+	}
+	return s, ""
+}
+
+func isHybridMark(w string) bool {
+	return w == "×" || w == "x" || w == "X"
+}
+
+func startsUpper(w string) bool {
+	r, _ := utf8.DecodeRuneInString(w)
+	return unicode.IsUpper(r)
+}
+
+// capitalize uppercases the first letter and lowercases the rest
+func capitalize(w string) string {
+	r, size := utf8.DecodeRuneInString(w)
+	if r == utf8.RuneError {
+		return w
+	}
+	return string(unicode.ToUpper(r)) + strings.ToLower(w[size:])
+}
+
+// titleCase capitalizes each word of an all-lowercase string and leaves
+// other strings as written
+func titleCase(s string) string {
+	if s != strings.ToLower(s) {
+		return s
+	}
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
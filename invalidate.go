@@ -0,0 +1,166 @@
+package openplantbook
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InvalidationReason says why cached details stopped being current
+type InvalidationReason int
+
+const (
+	// InvalidationExpired means cached details passed their TTL
+	InvalidationExpired InvalidationReason = iota
+	// InvalidationChanged means details fetched to refresh an expired entry
+	// differ from the cached ones
+	InvalidationChanged
+)
+
+// String returns the reason name
+func (r InvalidationReason) String() string {
+	switch r {
+	case InvalidationExpired:
+		return "expired"
+	case InvalidationChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("InvalidationReason(%d)", int(r))
+	}
+}
+
+// Invalidation reports cached details that data derived from them (care
+// schedules, Home Assistant configuration, rendered labels) may no longer
+// match (see WithOnInvalidate)
+type Invalidation struct {
+	Reason   InvalidationReason
+	PID      string
+	Language string // Normalized requested language; empty means English
+	Key      string // Cache key of the details
+
+	// ExpiresAt is when the details expired (InvalidationExpired)
+	ExpiresAt time.Time
+
+	// Old and New are the cached and refreshed details (InvalidationChanged)
+	Old, New *PlantDetails
+}
+
+// WithOnInvalidate calls fn when cached plant details expire or change, so
+// applications can drop artifacts computed from them
+//
+// Expiry is reported when the TTL of details the client fetched or served
+// from the cache passes, whether or not they are requested again; fn then
+// runs in a background goroutine stopped by Close. Entries cached by other
+// processes are only watched once this client has served them. A change is
+// reported when a refresh differs from the expired entry, as for
+// WithOnUpdate, synchronously in the goroutine that called GetPlantDetails;
+// expired details are kept for 30 days to compare against. fn must be safe
+// for concurrent use and must not call Close. Nothing is reported when
+// caching is disabled.
+func WithOnInvalidate(fn func(Invalidation)) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return optionError("WithOnInvalidate", nil, "invalidation callback cannot be nil")
+		}
+		c.onInvalidate = fn
+		return nil
+	}
+}
+
+// expiryWatch reports tracked cache entries as they expire
+type expiryWatch struct {
+	clock  Clock
+	notify func(Invalidation)
+
+	mu      sync.Mutex
+	pending map[string]Invalidation // by cache key
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newExpiryWatch starts watching; close stops it
+func newExpiryWatch(clock Clock, notify func(Invalidation)) *expiryWatch {
+	w := &expiryWatch{
+		clock:   clock,
+		notify:  notify,
+		pending: make(map[string]Invalidation),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// track reports the entry under key when it expires at expiresAt, replacing
+// any earlier deadline of the entry
+func (w *expiryWatch) track(key, pid, lang string, expiresAt time.Time) {
+	w.mu.Lock()
+	w.pending[key] = Invalidation{Reason: InvalidationExpired, PID: pid, Language: lang, Key: key, ExpiresAt: expiresAt}
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// close stops the watch and waits for a running callback to return
+func (w *expiryWatch) close() error {
+	close(w.stop)
+	<-w.done
+	return nil
+}
+
+func (w *expiryWatch) run() {
+	defer close(w.done)
+	for {
+		due, next := w.due()
+		for _, inv := range due {
+			w.notify(inv)
+		}
+
+		var timer <-chan time.Time
+		if !next.IsZero() {
+			timer = w.clock.After(next.Sub(w.clock.Now()))
+		}
+		select {
+		case <-timer:
+		case <-w.wake:
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// due removes and returns the expired entries, and returns the earliest
+// deadline of the others (zero if there are none)
+func (w *expiryWatch) due() ([]Invalidation, time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	var (
+		due  []Invalidation
+		next time.Time
+	)
+	for key, inv := range w.pending {
+		if !now.Before(inv.ExpiresAt) {
+			due = append(due, inv)
+			delete(w.pending, key)
+		} else if next.IsZero() || inv.ExpiresAt.Before(next) {
+			next = inv.ExpiresAt
+		}
+	}
+	return due, next
+}
+
+// watchExpiry tracks cached details for WithOnInvalidate
+func (c *Client) watchExpiry(key, pid string, opts *DetailOptions, expiresAt time.Time) {
+	if c.expiries == nil || expiresAt.IsZero() {
+		return
+	}
+	c.expiries.track(key, pid, detailLanguage(opts), expiresAt)
+}
@@ -0,0 +1,97 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/clocktest"
+)
+
+func TestWithOnInvalidate(t *testing.T) {
+	var maxTemp atomic.Int64
+	maxTemp.Store(30)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(PlantDetails{PID: "monstera", MinTemp: 15, MaxTemp: float64(maxTemp.Load())})
+	}))
+	defer server.Close()
+
+	clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	events := make(chan Invalidation, 4)
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		DisableRateLimit(),
+		WithOnInvalidate(func(inv Invalidation) { events <- inv }),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	lookup := func() {
+		t.Helper()
+		if _, err := client.GetPlantDetails(ctx, "monstera", &DetailOptions{Language: "DE"}); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+	next := func() Invalidation {
+		t.Helper()
+		select {
+		case inv := <-events:
+			return inv
+		case <-time.After(5 * time.Second):
+			t.Fatal("no invalidation reported")
+			return Invalidation{}
+		}
+	}
+
+	lookup()
+	clock.BlockUntil(1) // the watch is waiting for the entry to expire
+	clock.Advance(detailsTTL - time.Minute)
+	select {
+	case inv := <-events:
+		t.Fatalf("invalidation %+v reported before the TTL passed", inv)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+	inv := next()
+	if inv.Reason != InvalidationExpired || inv.PID != "monstera" || inv.Language != "de" ||
+		inv.Key != detailCacheKey("monstera", &DetailOptions{Language: "de"}) || inv.Old != nil {
+		t.Errorf("expiry reported as %+v", inv)
+	}
+
+	// A refresh with changed data reports both versions, and watches the new
+	// entry's expiry
+	maxTemp.Store(28)
+	lookup()
+	inv = next()
+	if inv.Reason != InvalidationChanged || inv.Old.MaxTemp != 30 || inv.New.MaxTemp != 28 {
+		t.Errorf("change reported as %+v", inv)
+	}
+	clock.BlockUntil(1)
+	clock.Advance(detailsTTL)
+	if inv := next(); inv.Reason != InvalidationExpired {
+		t.Errorf("second expiry reported as %v", inv.Reason)
+	}
+
+	// An unchanged refresh reports nothing
+	lookup()
+	select {
+	case inv := <-events:
+		t.Errorf("unchanged refresh reported %+v", inv)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestWithOnInvalidate_Nil(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithOnInvalidate(nil)); err == nil {
+		t.Error("WithOnInvalidate(nil) expected error, got nil")
+	}
+}
@@ -0,0 +1,146 @@
+// Package label produces plant tag contents from OpenPlantbook details: QR
+// codes carrying a link to the plant's page or its care thresholds, and
+// short text labels.
+//
+//	png, err := label.QRCode(details, &label.Options{Payload: label.PayloadJSON})
+//
+// It is a separate module, so the core SDK does not depend on a QR code
+// library:
+//
+//	go get github.com/rmrfslashbin/openplantbook-go/label
+package label
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// Payloads for Options.Payload
+const (
+	PayloadLink = "link" // the plant's page (default)
+	PayloadJSON = "json" // the care thresholds, readable without a network
+)
+
+// DefaultSize is the default width and height of QR codes, in pixels
+const DefaultSize = 256
+
+// Options configures a QR code
+type Options struct {
+	// Payload is PayloadLink (default) or PayloadJSON
+	Payload string
+
+	// Size is the width and height of the PNG in pixels (default DefaultSize)
+	Size int
+
+	// Link returns the address of PayloadLink (default:
+	// PlantDetails.PageURL), e.g. a deep link into an app
+	Link func(*openplantbook.PlantDetails) string
+}
+
+// Thresholds is the JSON payload: the plant and its care ranges as
+// [min, max] pairs, with short keys to keep the QR code small
+// Ranges the record lacks are left out.
+type Thresholds struct {
+	PID      string      `json:"pid"`
+	Name     string      `json:"name,omitempty"`
+	Light    *[2]int     `json:"lux,omitempty"`
+	Temp     *[2]float64 `json:"temp,omitempty"`
+	Humidity *[2]int     `json:"humid,omitempty"`
+	Moisture *[2]int     `json:"moist,omitempty"`
+	EC       *[2]int     `json:"ec,omitempty"`
+}
+
+// NewThresholds returns the care thresholds of d
+func NewThresholds(d *openplantbook.PlantDetails) Thresholds {
+	t := Thresholds{PID: d.PID, Name: d.Alias}
+	if d.MaxLightLux > 0 {
+		t.Light = &[2]int{d.MinLightLux, d.MaxLightLux}
+	}
+	if d.MinTemp != 0 || d.MaxTemp != 0 {
+		t.Temp = &[2]float64{d.MinTemp, d.MaxTemp}
+	}
+	if d.MaxEnvHumid > 0 {
+		t.Humidity = &[2]int{d.MinEnvHumid, d.MaxEnvHumid}
+	}
+	if d.MaxSoilMoist > 0 {
+		t.Moisture = &[2]int{d.MinSoilMoist, d.MaxSoilMoist}
+	}
+	if d.MaxSoilEC > 0 {
+		t.EC = &[2]int{d.MinSoilEC, d.MaxSoilEC}
+	}
+	return t
+}
+
+// Payload returns the text a QR code for d encodes
+func Payload(d *openplantbook.PlantDetails, opts *Options) (string, error) {
+	if d == nil || d.PID == "" {
+		return "", fmt.Errorf("label: plant has no pid")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	switch opts.Payload {
+	case "", PayloadLink:
+		if opts.Link != nil {
+			return opts.Link(d), nil
+		}
+		return d.PageURL(), nil
+	case PayloadJSON:
+		data, err := json.Marshal(NewThresholds(d))
+		return string(data), err
+	default:
+		return "", fmt.Errorf("label: unknown payload %q (use link or json)", opts.Payload)
+	}
+}
+
+// QRCode returns a PNG QR code for d
+func QRCode(d *openplantbook.PlantDetails, opts *Options) ([]byte, error) {
+	payload, err := Payload(d, opts)
+	if err != nil {
+		return nil, err
+	}
+	size := DefaultSize
+	if opts != nil && opts.Size != 0 {
+		size = opts.Size
+	}
+	return EncodePNG(payload, size)
+}
+
+// EncodePNG returns a PNG QR code of size by size pixels encoding text
+func EncodePNG(text string, size int) ([]byte, error) {
+	if size < 21 {
+		// A QR code has at least 21 modules per side
+		return nil, fmt.Errorf("label: size %d too small", size)
+	}
+	return qrcode.Encode(text, qrcode.Medium, size)
+}
+
+// Text returns a short plain-text label for d: its name and one care range
+// per line, for printers without graphics
+func Text(d *openplantbook.PlantDetails) string {
+	var b strings.Builder
+	b.WriteString(d.FormattedName(""))
+	b.WriteByte('\n')
+	t := NewThresholds(d)
+	if t.Light != nil {
+		fmt.Fprintf(&b, "Light     %d-%d lux\n", t.Light[0], t.Light[1])
+	}
+	if t.Temp != nil {
+		fmt.Fprintf(&b, "Temp      %.0f-%.0f °C\n", t.Temp[0], t.Temp[1])
+	}
+	if t.Humidity != nil {
+		fmt.Fprintf(&b, "Humidity  %d-%d %%\n", t.Humidity[0], t.Humidity[1])
+	}
+	if t.Moisture != nil {
+		fmt.Fprintf(&b, "Soil      %d-%d %%\n", t.Moisture[0], t.Moisture[1])
+	}
+	if t.EC != nil {
+		fmt.Fprintf(&b, "EC        %d-%d µS/cm\n", t.EC[0], t.EC[1])
+	}
+	return b.String()
+}
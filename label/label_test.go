@@ -0,0 +1,90 @@
+package label
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"strings"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func testPlant() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		DisplayPID:   "Monstera deliciosa",
+		Alias:        "Swiss cheese plant",
+		MinLightLux:  1500,
+		MaxLightLux:  30000,
+		MinTemp:      12,
+		MaxTemp:      32,
+		MinEnvHumid:  40,
+		MaxEnvHumid:  80,
+		MinSoilMoist: 15,
+		MaxSoilMoist: 60,
+	}
+}
+
+func TestPayload(t *testing.T) {
+	d := testPlant()
+
+	link, err := Payload(d, nil)
+	if err != nil || link != d.PageURL() {
+		t.Errorf("Payload(link) = %q, %v; want %q", link, err, d.PageURL())
+	}
+
+	deep, _ := Payload(d, &Options{Link: func(d *openplantbook.PlantDetails) string { return "myapp://plant/" + d.PID }})
+	if deep != "myapp://plant/monstera deliciosa" {
+		t.Errorf("Payload(custom link) = %q", deep)
+	}
+
+	data, err := Payload(d, &Options{Payload: PayloadJSON})
+	if err != nil {
+		t.Fatalf("Payload(json) unexpected error: %v", err)
+	}
+	var got Thresholds
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("Payload(json) = %q is not JSON: %v", data, err)
+	}
+	if got.PID != d.PID || got.Temp == nil || got.Temp[1] != 32 || got.EC != nil {
+		t.Errorf("Payload(json) = %s, want thresholds without the unrecorded EC", data)
+	}
+
+	if _, err := Payload(d, &Options{Payload: "vcard"}); err == nil {
+		t.Error("unknown payload: expected error")
+	}
+	if _, err := Payload(&openplantbook.PlantDetails{}, nil); err == nil {
+		t.Error("plant without pid: expected error")
+	}
+}
+
+func TestQRCode(t *testing.T) {
+	data, err := QRCode(testPlant(), &Options{Payload: PayloadJSON, Size: 300})
+	if err != nil {
+		t.Fatalf("QRCode() unexpected error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("QRCode() did not return a PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 300 || b.Dy() != 300 {
+		t.Errorf("QRCode() size = %dx%d, want 300x300", b.Dx(), b.Dy())
+	}
+
+	if _, err := QRCode(testPlant(), &Options{Size: 10}); err == nil {
+		t.Error("tiny size: expected error")
+	}
+}
+
+func TestText(t *testing.T) {
+	text := Text(testPlant())
+	for _, want := range []string{"Swiss cheese plant (Monstera deliciosa)", "1500-30000 lux", "12-32 °C", "15-60 %"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "EC") {
+		t.Errorf("Text() shows unrecorded EC:\n%s", text)
+	}
+}
@@ -0,0 +1,95 @@
+package openplantbook
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// languageChain returns the languages details are requested in, in order:
+// Language (if set), then Languages, without repeats
+// Languages are kept as the caller wrote them, as for a single language;
+// only repeats are found by their normalized form. The API default ("")
+// counts as English.
+func (o *DetailOptions) languageChain() []string {
+	if o == nil || len(o.Languages) == 0 {
+		return nil
+	}
+	langs := o.Languages
+	if o.Language != "" {
+		langs = append([]string{o.Language}, langs...)
+	}
+	var chain, seen []string
+	for _, lang := range langs {
+		if lang = strings.TrimSpace(lang); lang == "" {
+			lang = "en"
+		}
+		if key := normalizeLanguage(lang); !slices.Contains(seen, key) {
+			seen = append(seen, key)
+			chain = append(chain, lang)
+		}
+	}
+	return chain
+}
+
+// needsTranslation reports whether d lacks localized text another language
+// could fill in
+func needsTranslation(d *PlantDetails) bool {
+	return d.Alias == "" || d.Category == ""
+}
+
+// detailsInLanguages looks details up in each language of chain until the
+// localized fields are filled in
+// The first language supplies the record; later ones only fill in a blank
+// Alias or Category. Each language is fetched and cached like a lookup with
+// DetailOptions.Language set to it, so a chain ending in English reuses
+// cached English details. Failures after the first language are ignored.
+func (c *Client) detailsInLanguages(ctx context.Context, pid string, opts *DetailOptions, chain []string, withMeta bool) (*PlantDetails, ResultMeta, error) {
+	var (
+		merged *PlantDetails
+		meta   ResultMeta
+	)
+	for i, lang := range chain {
+		o := *opts
+		o.Language, o.Languages = lang, nil
+		d, m, err := c.details(ctx, pid, &o, withMeta)
+		if i == 0 {
+			if err != nil {
+				return nil, ResultMeta{}, err
+			}
+			copied := *d // d may be held by the local index
+			merged, meta = &copied, m
+		} else {
+			if err != nil {
+				c.log("fallback language failed", "pid", pid, "language", lang, "error", err)
+				continue
+			}
+			if merged.Alias == "" && d.Alias != "" {
+				merged.Alias, merged.Language = d.Alias, d.Language
+			}
+			if merged.Category == "" {
+				merged.Category = d.Category
+			}
+			meta = mergeMeta(meta, m)
+		}
+		if !needsTranslation(merged) {
+			break
+		}
+	}
+	return merged, meta, nil
+}
+
+// mergeMeta describes a result assembled from two lookups: it is only from
+// the cache if both were, and as old and as degraded as the worse of them
+func mergeMeta(a, b ResultMeta) ResultMeta {
+	merged := ResultMeta{
+		FromCache: a.FromCache && b.FromCache,
+		FetchedAt: a.FetchedAt,
+		Stale:     a.Stale || b.Stale,
+		Fallback:  a.Fallback || b.Fallback,
+	}
+	if merged.FetchedAt.IsZero() || (!b.FetchedAt.IsZero() && b.FetchedAt.Before(merged.FetchedAt)) {
+		merged.FetchedAt = b.FetchedAt
+	}
+	return merged
+}
@@ -0,0 +1,142 @@
+package openplantbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestDetailOptions_LanguageChain(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *DetailOptions
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"language only", &DetailOptions{Language: "de"}, nil},
+		{"languages", &DetailOptions{Languages: []string{"de", "en"}}, []string{"de", "en"}},
+		{"language first", &DetailOptions{Language: "fr", Languages: []string{"de", "en"}}, []string{"fr", "de", "en"}},
+		{"deduplicated as written", &DetailOptions{Language: "pt_BR", Languages: []string{"pt-br", "", "EN"}}, []string{"pt_BR", "en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.languageChain(); !slices.Equal(got, tt.want) {
+				t.Errorf("languageChain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// localizedServer serves a monstera whose alias and category are only
+// recorded in the given languages, recording the languages requested
+func localizedServer(t *testing.T, aliases map[string]string) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := r.URL.Query().Get("lang")
+		mu.Lock()
+		requested = append(requested, lang)
+		mu.Unlock()
+		category := ""
+		if lang == "en" || lang == "" {
+			category = "Araceae"
+		}
+		fmt.Fprintf(w, `{"pid":"monstera deliciosa","display_pid":"Monstera deliciosa","alias":%q,"category":%q,"max_temp":32}`,
+			aliases[lang], category)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(requested)
+	}
+}
+
+func TestGetPlantDetails_Languages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fills blanks from the next language", func(t *testing.T) {
+		server, requested := localizedServer(t, map[string]string{"en": "Swiss cheese plant"})
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		opts := &DetailOptions{Languages: []string{"de", "en"}}
+		result, err := client.GetPlantDetailsWithMeta(ctx, "monstera deliciosa", opts)
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		d := result.Details
+		if d.Alias != "Swiss cheese plant" || d.Language != "en" || d.Category != "Araceae" || d.MaxTemp != 32 {
+			t.Errorf("details = %+v, want the English alias and category", d)
+		}
+		if result.FromCache {
+			t.Error("FromCache = true for fetched details")
+		}
+		if got := requested(); !slices.Equal(got, []string{"de", "en"}) {
+			t.Errorf("requested languages %q, want de then en", got)
+		}
+
+		// Both links of the chain are cached
+		result, err = client.GetPlantDetailsWithMeta(ctx, "monstera deliciosa", opts)
+		if err != nil || !result.FromCache || result.Details.Alias != "Swiss cheese plant" {
+			t.Errorf("second lookup = %+v, %v; want the merged details from the cache", result, err)
+		}
+		if got := requested(); len(got) != 2 {
+			t.Errorf("second lookup sent requests: %q", got)
+		}
+
+		// The cached German record was not modified by the merge
+		de, err := client.GetPlantDetails(ctx, "monstera deliciosa", &DetailOptions{Language: "de"})
+		if err != nil || de.Alias != "" || de.Language != "de" {
+			t.Errorf("German details = %+v, %v; want the record without an alias", de, err)
+		}
+	})
+
+	t.Run("stops at the first complete language", func(t *testing.T) {
+		server, requested := localizedServer(t, map[string]string{"en": "Swiss cheese plant", "de": "Fensterblatt"})
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		d, err := client.GetPlantDetails(ctx, "monstera deliciosa", &DetailOptions{Language: "de", Languages: []string{"en"}})
+		if err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+		if d.Alias != "Fensterblatt" || d.Language != "de" || d.Category != "Araceae" {
+			t.Errorf("details = %+v, want the German alias and the English category", d)
+		}
+		if got := requested(); !slices.Equal(got, []string{"de", "en"}) {
+			t.Errorf("requested languages %q, want de then en (for the category)", got)
+		}
+	})
+
+	t.Run("sends languages as written", func(t *testing.T) {
+		server, requested := localizedServer(t, map[string]string{"pt_BR": "Costela-de-adão"})
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		d, err := client.GetPlantDetails(ctx, "monstera deliciosa", &DetailOptions{Languages: []string{"pt_BR", "pt-br", "en"}})
+		if err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+		if d.Alias != "Costela-de-adão" || d.Language != "pt-br" {
+			t.Errorf("details = %+v, want the Brazilian Portuguese alias", d)
+		}
+		if got := requested(); !slices.Equal(got, []string{"pt_BR", "en"}) {
+			t.Errorf("requested languages %q, want pt_BR as written, then en", got)
+		}
+	})
+}
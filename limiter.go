@@ -0,0 +1,173 @@
+package openplantbook
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter is a daily request budget that can be shared between clients
+//
+// OpenPlantbook accounts requests per account, so an API-key client for
+// reads and an OAuth2 client for writes in the same process draw from one
+// quota. Give both the same Limiter with WithSharedRateLimiter to pace them
+// together. A Limiter is safe for concurrent use.
+type Limiter struct {
+	rl       *rate.Limiter
+	perDay   int
+	requests requestLog
+
+	mu    sync.Mutex
+	clock Clock // of the clients sharing the limiter (see WithClock)
+}
+
+// NewLimiter creates a limiter allowing requestsPerDay requests, evenly
+// spaced over the day
+func NewLimiter(requestsPerDay int) (*Limiter, error) {
+	if requestsPerDay <= 0 {
+		return nil, &ConfigError{Option: "NewLimiter", Value: requestsPerDay, Message: "rate limit must be positive"}
+	}
+	return &Limiter{
+		rl:     rate.NewLimiter(rate.Every(24*time.Hour/time.Duration(requestsPerDay)), 1),
+		perDay: requestsPerDay,
+		clock:  realClock{},
+	}, nil
+}
+
+// Limit returns the configured requests per day
+func (l *Limiter) Limit() int {
+	return l.perDay
+}
+
+// Used returns the number of requests sent in the last 24 hours by all
+// clients sharing the limiter
+// The 24 hours are told by the clients' clock when they were created with
+// WithClock, so clients sharing a limiter should share a clock.
+func (l *Limiter) Used() int {
+	l.mu.Lock()
+	clock := l.clock
+	l.mu.Unlock()
+	return l.requests.count(clock.Now())
+}
+
+// setClock makes the limiter tell the time by clock
+func (l *Limiter) setClock(clock Clock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clock = clock
+}
+
+// Remaining returns Limit minus Used, never negative
+func (l *Limiter) Remaining() int {
+	return max(l.perDay-l.Used(), 0)
+}
+
+// EndpointClass groups API endpoints that share a quota
+type EndpointClass int
+
+const (
+	// EndpointSearch covers plant search and autocomplete
+	EndpointSearch EndpointClass = iota
+	// EndpointDetails covers plant detail lookups
+	EndpointDetails
+)
+
+// String returns the class name
+func (e EndpointClass) String() string {
+	switch e {
+	case EndpointSearch:
+		return "search"
+	case EndpointDetails:
+		return "details"
+	default:
+		return fmt.Sprintf("EndpointClass(%d)", int(e))
+	}
+}
+
+// MarshalText encodes the class by name, e.g. as a JSON object key
+func (e EndpointClass) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText decodes a class name written by MarshalText
+func (e *EndpointClass) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "search":
+		*e = EndpointSearch
+	case "details":
+		*e = EndpointDetails
+	default:
+		return fmt.Errorf("unknown endpoint class %q", text)
+	}
+	return nil
+}
+
+// endpointClassOf returns the class of an API path, with or without the
+// base URL's path (e.g. /api/v1)
+func endpointClassOf(path string) EndpointClass {
+	if strings.Contains(path, searchPath) && !strings.Contains(path, detailsPath) {
+		return EndpointSearch
+	}
+	return EndpointDetails
+}
+
+// limitersFor returns the limiters pacing requests of the given class: its
+// own from WithRateLimits, if any, then the client-wide or shared limiter,
+// unless rate limiting is disabled
+func (c *Client) limitersFor(class EndpointClass) []*rate.Limiter {
+	var limiters []*rate.Limiter
+	if l, ok := c.classLimiters[class]; ok {
+		limiters = append(limiters, l)
+	}
+	if c.rateLimiter != nil {
+		limiters = append(limiters, c.rateLimiter)
+	}
+	return limiters
+}
+
+// reservation holds a token of each limiter pacing a request
+type reservation []*rate.Reservation
+
+// reserve takes a token from each limiter at now, reporting false, and
+// taking none, if any of them can never allow the request
+func reserve(limiters []*rate.Limiter, now time.Time) (reservation, bool) {
+	r := make(reservation, 0, len(limiters))
+	for _, l := range limiters {
+		res := l.ReserveN(now, 1)
+		if !res.OK() {
+			r.cancelAt(now)
+			return nil, false
+		}
+		r = append(r, res)
+	}
+	return r, true
+}
+
+// delayFrom returns how long after now every limiter allows the request
+func (r reservation) delayFrom(now time.Time) time.Duration {
+	var delay time.Duration
+	for _, res := range r {
+		delay = max(delay, res.DelayFrom(now))
+	}
+	return delay
+}
+
+// cancelAt returns the tokens to their limiters
+func (r reservation) cancelAt(now time.Time) {
+	for _, res := range r {
+		res.CancelAt(now)
+	}
+}
+
+// classLimit returns the daily quota WithRateLimits gave class, or 0
+func (c *Client) classLimit(class EndpointClass) int {
+	l, ok := c.classLimiters[class]
+	if !ok {
+		return 0
+	}
+	return int(math.Round(float64(l.Limit()) * (24 * time.Hour).Seconds()))
+}
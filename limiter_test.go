@@ -0,0 +1,160 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestWithSharedRateLimiter(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	limiter, err := NewLimiter(DefaultRateLimit)
+	if err != nil {
+		t.Fatalf("NewLimiter() unexpected error: %v", err)
+	}
+
+	newClient := func(auth Option) *Client {
+		t.Helper()
+		c, err := New(auth, WithBaseURL(server.URL), WithSharedRateLimiter(limiter),
+			WithRateLimitBehavior(RateLimitError), DisableCache())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		t.Cleanup(func() { c.Close() })
+		return c
+	}
+	reader := newClient(WithAPIKey("test-key"))
+	other := newClient(WithAPIKey("other-key"))
+
+	ctx := context.Background()
+	if _, err := reader.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("first request unexpected error: %v", err)
+	}
+
+	// The budget's only token was spent by the other client
+	var rateErr *ErrRateLimited
+	if _, err := other.GetPlantDetails(ctx, "monstera", nil); !errors.As(err, &rateErr) {
+		t.Errorf("second client error = %v, want ErrRateLimited", err)
+	}
+
+	if got := limiter.Used(); got != 1 {
+		t.Errorf("Limiter.Used() = %d, want 1", got)
+	}
+	if got := other.Status().Quota; got.Used != 1 || got.Remaining != DefaultRateLimit-1 {
+		t.Errorf("other client quota = %+v, want the shared usage", got)
+	}
+}
+
+func TestNewLimiter_Invalid(t *testing.T) {
+	var cfgErr *ConfigError
+	if _, err := NewLimiter(0); !errors.As(err, &cfgErr) {
+		t.Errorf("NewLimiter(0) error = %v, want ConfigError", err)
+	}
+	if _, err := New(WithAPIKey("test-key"), WithSharedRateLimiter(nil)); err == nil {
+		t.Error("New() with nil limiter expected error, got nil")
+	}
+}
+
+func TestWithRateLimits(t *testing.T) {
+	searchData, err := os.ReadFile("testdata/search_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/plant/search" {
+			w.Write(searchData)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableCache(),
+		WithRateLimitBehavior(RateLimitError), DisableRateLimit(),
+		WithRateLimits(map[EndpointClass]int{EndpointDetails: 1}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("first details request unexpected error: %v", err)
+	}
+	var rateErr *ErrRateLimited
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); !errors.As(err, &rateErr) {
+		t.Errorf("second details request error = %v, want ErrRateLimited", err)
+	}
+
+	// Search has no quota of its own, and the client-wide limit is disabled
+	if _, err := client.SearchPlants(ctx, "monstera", nil); err != nil {
+		t.Errorf("search unexpected error: %v", err)
+	}
+}
+
+func TestWithRateLimits_SharedLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pid":"monstera"}`))
+	}))
+	defer server.Close()
+
+	limiter, _ := NewLimiter(1)
+	newClient := func(opts ...Option) *Client {
+		opts = append([]Option{WithAPIKey("test-key"), WithBaseURL(server.URL), DisableCache(),
+			WithRateLimitBehavior(RateLimitError), WithSharedRateLimiter(limiter)}, opts...)
+		client, err := New(opts...)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client
+	}
+	other := newClient()
+	defer other.Close()
+	client := newClient(WithRateLimits(map[EndpointClass]int{EndpointSearch: 100, EndpointDetails: 100}))
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := other.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+
+	// The class quota has room, but the shared daily budget is spent
+	var rateErr *ErrRateLimited
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); !errors.As(err, &rateErr) {
+		t.Errorf("GetPlantDetails() with a class quota error = %v, want ErrRateLimited", err)
+	}
+	if q := client.Status().Quota; q.Limit != 1 || q.Used != 1 || q.Remaining != 0 {
+		t.Errorf("Status().Quota = %+v, want the shared limit of 1, all used", q)
+	}
+}
+
+func TestWithRateLimits_Invalid(t *testing.T) {
+	tests := map[string]map[EndpointClass]int{
+		"empty":         {},
+		"zero limit":    {EndpointSearch: 0},
+		"unknown class": {EndpointClass(99): 10},
+	}
+	for name, limits := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfgErr *ConfigError
+			if _, err := New(WithAPIKey("test-key"), WithRateLimits(limits)); !errors.As(err, &cfgErr) {
+				t.Errorf("New() error = %v, want ConfigError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package openplantbook
+
+import "log/slog"
+
+// badKey is the key of values without one, as in log/slog
+const badKey = "!BADKEY"
+
+// LogFunc adapts a function to the Logger interface
+// The function receives the key/value arguments validated as attributes
+// (see LogAttrs), which suits loggers taking typed fields, e.g. logrus:
+//
+//	openplantbook.WithLogger(openplantbook.LogFunc(func(level slog.Level, msg string, attrs []slog.Attr) {
+//	    fields := logrus.Fields{}
+//	    for _, a := range attrs {
+//	        fields[a.Key] = a.Value.Any()
+//	    }
+//	    logrus.WithFields(fields).Debug(msg)
+//	}))
+type LogFunc func(level slog.Level, msg string, attrs []slog.Attr)
+
+// Debug implements Logger
+func (f LogFunc) Debug(msg string, args ...interface{}) { f(slog.LevelDebug, msg, LogAttrs(args)) }
+
+// Info implements Logger
+func (f LogFunc) Info(msg string, args ...interface{}) { f(slog.LevelInfo, msg, LogAttrs(args)) }
+
+// Warn implements Logger
+func (f LogFunc) Warn(msg string, args ...interface{}) { f(slog.LevelWarn, msg, LogAttrs(args)) }
+
+// Error implements Logger
+func (f LogFunc) Error(msg string, args ...interface{}) { f(slog.LevelError, msg, LogAttrs(args)) }
+
+// SugaredLogger is the key/value interface of zap's *zap.SugaredLogger
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// FromSugared adapts a zap SugaredLogger to the Logger interface
+//
+//	openplantbook.WithLogger(openplantbook.FromSugared(zapLogger.Sugar()))
+func FromSugared(l SugaredLogger) Logger {
+	return sugaredLogger{l}
+}
+
+type sugaredLogger struct {
+	l SugaredLogger
+}
+
+func (s sugaredLogger) Debug(msg string, args ...interface{}) { s.l.Debugw(msg, logPairs(args)...) }
+func (s sugaredLogger) Info(msg string, args ...interface{})  { s.l.Infow(msg, logPairs(args)...) }
+func (s sugaredLogger) Warn(msg string, args ...interface{})  { s.l.Warnw(msg, logPairs(args)...) }
+func (s sugaredLogger) Error(msg string, args ...interface{}) { s.l.Errorw(msg, logPairs(args)...) }
+
+// LogAttrs validates logger key/value arguments
+// It follows log/slog: an argument is either a slog.Attr or a string key
+// followed by its value. A value in key position, or a key without a value,
+// is kept under the key "!BADKEY" rather than shifting the pairs after it.
+// Groups are flattened into dotted keys ("group.key").
+func LogAttrs(args []interface{}) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(args)/2+1)
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case slog.Attr:
+			attrs = appendAttr(attrs, "", arg)
+		case string:
+			if i+1 == len(args) {
+				attrs = append(attrs, slog.Any(badKey, arg))
+				break
+			}
+			attrs = appendAttr(attrs, "", slog.Any(arg, args[i+1]))
+			i++
+		default:
+			attrs = append(attrs, slog.Any(badKey, arg))
+		}
+	}
+	return attrs
+}
+
+// appendAttr appends a, flattening groups into prefixed keys
+func appendAttr(attrs []slog.Attr, prefix string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		if a.Key == "" {
+			a.Key = badKey
+		}
+		a.Key = prefix + a.Key
+		return append(attrs, a)
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, member := range a.Value.Group() {
+		attrs = appendAttr(attrs, prefix, member)
+	}
+	return attrs
+}
+
+// logPairs turns arguments into alternating string keys and values
+func logPairs(args []interface{}) []interface{} {
+	attrs := LogAttrs(args)
+	pairs := make([]interface{}, 0, 2*len(attrs))
+	for _, a := range attrs {
+		pairs = append(pairs, a.Key, a.Value.Any())
+	}
+	return pairs
+}
@@ -0,0 +1,104 @@
+package openplantbook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogAttrs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []interface{}
+		want string
+	}{
+		{"pairs", []interface{}{"pid", "abc", "results", 3}, "pid=abc results=3"},
+		{"dangling key", []interface{}{"pid", "abc", "error"}, "pid=abc !BADKEY=error"},
+		{"value in key position", []interface{}{42, "pid", "abc"}, "!BADKEY=42 pid=abc"},
+		{"attr", []interface{}{slog.Int("results", 3), "pid", "abc"}, "results=3 pid=abc"},
+		{"group", []interface{}{slog.Group("req", "path", "/x", slog.Int("status", 200))}, "req.path=/x req.status=200"},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []string
+			for _, a := range LogAttrs(tt.args) {
+				parts = append(parts, fmt.Sprintf("%s=%v", a.Key, a.Value.Any()))
+			}
+			if got := strings.Join(parts, " "); got != tt.want {
+				t.Errorf("LogAttrs(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+// sugared records the calls of a zap-style logger
+type sugared struct {
+	calls []string
+}
+
+func (s *sugared) record(level, msg string, kv []interface{}) {
+	s.calls = append(s.calls, fmt.Sprintf("%s %s %v", level, msg, kv))
+}
+
+func (s *sugared) Debugw(msg string, kv ...interface{}) { s.record("debug", msg, kv) }
+func (s *sugared) Infow(msg string, kv ...interface{})  { s.record("info", msg, kv) }
+func (s *sugared) Warnw(msg string, kv ...interface{})  { s.record("warn", msg, kv) }
+func (s *sugared) Errorw(msg string, kv ...interface{}) { s.record("error", msg, kv) }
+
+func TestFromSugared(t *testing.T) {
+	s := &sugared{}
+	logger := FromSugared(s)
+	logger.Warn("odd", "pid", "abc", "dangling")
+	logger.Error("attr", slog.String("pid", "abc"))
+
+	want := []string{"warn odd [pid abc !BADKEY dangling]", "error attr [pid abc]"}
+	if fmt.Sprint(s.calls) != fmt.Sprint(want) {
+		t.Errorf("calls = %q, want %q", s.calls, want)
+	}
+}
+
+func TestLogFunc(t *testing.T) {
+	var gotLevel slog.Level
+	var gotAttrs []slog.Attr
+	logger := LogFunc(func(level slog.Level, msg string, attrs []slog.Attr) {
+		gotLevel, gotAttrs = level, attrs
+	})
+
+	logger.Info("search completed", "query", "monstera", 7)
+	if gotLevel != slog.LevelInfo {
+		t.Errorf("level = %v, want INFO", gotLevel)
+	}
+	if len(gotAttrs) != 2 || gotAttrs[0].Key != "query" || gotAttrs[1].Key != badKey {
+		t.Errorf("attrs = %v, want query and !BADKEY", gotAttrs)
+	}
+}
+
+func TestClientLog_ValidatesArgs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := New(WithAPIKey("api-key-0123456789"), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	buf.Reset()
+
+	client.log("request failed",
+		slog.Group("auth", "token", "tok-123456"),
+		"error", errors.New("bad key api-key-0123456789"),
+		"dangling")
+
+	out := buf.String()
+	for _, want := range []string{"auth.token=[REDACTED]", `error="bad key [REDACTED]"`, "!BADKEY=dangling"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "tok-123456") || strings.Contains(out, "api-key-0123456789") {
+		t.Errorf("log leaks a secret:\n%s", out)
+	}
+}
@@ -0,0 +1,117 @@
+package openplantbook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MatchMode selects how search results must match the query
+//
+// The API matches the query as a case-insensitive substring of a plant's
+// PID, scientific name or alias, and has no wildcard syntax; the stricter
+// modes filter its results on the client.
+type MatchMode int
+
+const (
+	// MatchContains returns everything the API finds (default)
+	MatchContains MatchMode = iota
+	// MatchPrefix keeps results whose PID, scientific name or alias starts
+	// with the query
+	MatchPrefix
+	// MatchExact keeps results whose PID, scientific name or alias equals
+	// the query
+	MatchExact
+)
+
+// String returns the mode name
+func (m MatchMode) String() string {
+	switch m {
+	case MatchContains:
+		return "contains"
+	case MatchPrefix:
+		return "prefix"
+	case MatchExact:
+		return "exact"
+	default:
+		return fmt.Sprintf("MatchMode(%d)", int(m))
+	}
+}
+
+// MarshalText encodes the mode by name
+func (m MatchMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes a mode name
+func (m *MatchMode) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "contains", "":
+		*m = MatchContains
+	case "prefix":
+		*m = MatchPrefix
+	case "exact":
+		*m = MatchExact
+	default:
+		return fmt.Errorf("unknown match mode %q (want contains, prefix or exact)", text)
+	}
+	return nil
+}
+
+// EscapeQuery escapes the characters with a meaning in search queries
+// (`*` and `\`), so user input is searched for literally
+func EscapeQuery(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`).Replace(s)
+}
+
+// parseQuery unescapes a search query and resolves its match mode
+// A trailing unescaped `*` requests MatchPrefix; wildcards elsewhere are
+// rejected since the API cannot honor them.
+func parseQuery(query string, opts *SearchOptions) (string, MatchMode, error) {
+	mode := MatchContains
+	if opts != nil {
+		mode = opts.Match
+	}
+	if mode < MatchContains || mode > MatchExact {
+		return "", mode, &ValidationError{Field: "Match", Value: mode, Message: "unknown match mode"}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(query); i++ {
+		switch ch := query[i]; {
+		case ch == '\\' && i+1 < len(query):
+			i++
+			b.WriteByte(query[i])
+		case ch == '*' && i == len(query)-1:
+			if mode == MatchExact {
+				return "", mode, &ValidationError{Field: "query", Value: query, Message: "wildcard conflicts with exact matching"}
+			}
+			mode = MatchPrefix
+		case ch == '*':
+			return "", mode, &ValidationError{Field: "query", Value: query,
+				Message: "wildcards are only supported at the end of a query (escape literal * with EscapeQuery)"}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String(), mode, nil
+}
+
+// filterMatches keeps the results matching query under mode
+func filterMatches(results []PlantSearchResult, query string, mode MatchMode) []PlantSearchResult {
+	if mode == MatchContains {
+		return results
+	}
+
+	query = strings.ToLower(query)
+	out := make([]PlantSearchResult, 0, len(results))
+	for _, r := range results {
+		for _, name := range []string{r.PID, r.DisplayPID, r.Alias} {
+			name = strings.ToLower(name)
+			if (mode == MatchExact && name == query) || (mode == MatchPrefix && strings.HasPrefix(name, query)) {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query     string
+		mode      MatchMode
+		wantQuery string
+		wantMode  MatchMode
+		wantErr   bool
+	}{
+		{"monstera", MatchContains, "monstera", MatchContains, false},
+		{"mons*", MatchContains, "mons", MatchPrefix, false},
+		{"mons*", MatchPrefix, "mons", MatchPrefix, false},
+		{"mons*", MatchExact, "", MatchExact, true},
+		{"mo*ns", MatchContains, "", MatchContains, true},
+		{`a\*b\\`, MatchContains, `a*b\`, MatchContains, false},
+		{EscapeQuery(`star* \ fern`), MatchExact, `star* \ fern`, MatchExact, false},
+		{"fern", MatchMode(7), "", MatchMode(7), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, mode, err := parseQuery(tt.query, &SearchOptions{Match: tt.mode})
+			var valErr *ValidationError
+			if tt.wantErr {
+				if !errors.As(err, &valErr) {
+					t.Errorf("parseQuery(%q) error = %v, want ValidationError", tt.query, err)
+				}
+				return
+			}
+			if err != nil || query != tt.wantQuery || mode != tt.wantMode {
+				t.Errorf("parseQuery(%q) = %q, %v, %v, want %q, %v", tt.query, query, mode, err, tt.wantQuery, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestClient_SearchPlants_Match(t *testing.T) {
+	var gotAlias string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAlias = r.URL.Query().Get("alias")
+		w.Write([]byte(`{"count":3,"next":null,"previous":null,"results":[
+			{"pid":"ficus lyrata","display_pid":"Ficus lyrata","alias":"Fiddle-leaf fig"},
+			{"pid":"ficus","display_pid":"Ficus","alias":"Fig"},
+			{"pid":"schefflera","display_pid":"Schefflera","alias":"Umbrella tree (not a ficus)"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		query string
+		opts  *SearchOptions
+		want  []string
+	}{
+		{"ficus", nil, []string{"ficus lyrata", "ficus", "schefflera"}},
+		{"ficus*", nil, []string{"ficus lyrata", "ficus"}},
+		{"FICUS", &SearchOptions{Match: MatchExact}, []string{"ficus"}},
+		{"fig", &SearchOptions{Match: MatchPrefix}, []string{"ficus"}},
+	}
+	for _, tt := range tests {
+		results, err := client.SearchPlants(context.Background(), tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("SearchPlants(%q) unexpected error: %v", tt.query, err)
+		}
+		if gotAlias != "ficus" && gotAlias != "FICUS" && gotAlias != "fig" {
+			t.Errorf("API query = %q, want the query without wildcard", gotAlias)
+		}
+		var pids []string
+		for _, r := range results {
+			pids = append(pids, r.PID)
+		}
+		if len(pids) != len(tt.want) {
+			t.Errorf("SearchPlants(%q, %+v) = %v, want %v", tt.query, tt.opts, pids, tt.want)
+			continue
+		}
+		for i := range pids {
+			if pids[i] != tt.want[i] {
+				t.Errorf("SearchPlants(%q, %+v) = %v, want %v", tt.query, tt.opts, pids, tt.want)
+				break
+			}
+		}
+	}
+}
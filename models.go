@@ -1,11 +1,18 @@
 package openplantbook
 
+import "time"
+
 // PlantSearchResult represents a single plant in search results
 type PlantSearchResult struct {
 	PID        string `json:"pid"`
 	DisplayPID string `json:"display_pid"`
 	Alias      string `json:"alias"`
 	Category   string `json:"category"`
+
+	// Duplicates and MergedAliases are only set with SearchOptions.Dedupe:
+	// the PIDs merged into this result and their aliases
+	Duplicates    []string `json:"duplicates,omitempty"`
+	MergedAliases []string `json:"merged_aliases,omitempty"`
 }
 
 // searchResponse wraps the paginated API response
@@ -33,6 +40,46 @@ type PlantDetails struct {
 	MinSoilEC    int     `json:"min_soil_ec"`
 	ImageURL     string  `json:"image_url"`
 	Category     string  `json:"category"`
+
+	// UserPlant is true when the API reports the record as a user-contributed
+	// plant rather than one from the verified database; its data may be
+	// incomplete or unreviewed. Only records requested with
+	// DetailOptions.UserPlants can be user plants.
+	UserPlant bool `json:"user_plant,omitempty"`
+
+	// Language is the language Alias was requested in (DetailOptions.Language);
+	// empty means the API default, English
+	Language string `json:"language,omitempty"`
+}
+
+// ResultMeta describes where a result came from and how old it is
+type ResultMeta struct {
+	// FromCache is true when the result was served from the cache
+	FromCache bool
+
+	// FetchedAt is when the data was retrieved from the API; it is zero for
+	// cache entries written without metadata (e.g. by an older client)
+	FetchedAt time.Time
+
+	// Stale is true when expired cached data was returned because the API
+	// could not be reached (see WithStaleIfError)
+	Stale bool
+
+	// Fallback is true when the details came from WithFallback because the
+	// API could not answer
+	Fallback bool
+}
+
+// SearchResult is a search response with its provenance
+type SearchResult struct {
+	Results []PlantSearchResult
+	ResultMeta
+}
+
+// DetailsResult is plant details with their provenance
+type DetailsResult struct {
+	Details *PlantDetails
+	ResultMeta
 }
 
 // SearchOptions configures plant search behavior
@@ -42,10 +89,29 @@ type SearchOptions struct {
 
 	// UserPlants includes user-contributed plants in results
 	UserPlants bool
+
+	// Dedupe merges results with the same scientific name, ignoring case,
+	// quotes and punctuation, into the first (most relevant) one
+	Dedupe bool
+
+	// Match narrows results to prefix or exact matches of the query; a
+	// trailing * in the query selects MatchPrefix. Filtering happens after
+	// Limit is applied by the API.
+	Match MatchMode
 }
 
 // DetailOptions configures plant detail retrieval
 type DetailOptions struct {
 	// Language is the ISO 639-1 language code (e.g., "en", "de", "es")
 	Language string
+
+	// Languages are tried after Language, in order, when the record lacks a
+	// localized Alias or Category (e.g. []string{"de", "en"}); the first
+	// language supplies the record and later ones fill in the blanks.
+	// PlantDetails.Language reports the language the Alias came from.
+	Languages []string
+
+	// UserPlants also looks the PID up among the account's user-contributed
+	// plants; check PlantDetails.UserPlant to warn about unverified data
+	UserPlants bool
 }
@@ -0,0 +1,18 @@
+module github.com/rmrfslashbin/openplantbook-go/msgpack
+
+go 1.24.0
+
+require (
+	github.com/rmrfslashbin/openplantbook-go v0.0.0-00010101000000-000000000000
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	golang.org/x/oauth2 v0.27.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/time v0.14.0 // indirect
+)
+
+replace github.com/rmrfslashbin/openplantbook-go => ..
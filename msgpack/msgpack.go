@@ -0,0 +1,45 @@
+// Package msgpack provides a MessagePack cache serializer for the
+// OpenPlantbook client.
+//
+// It is a separate module (go get
+// github.com/rmrfslashbin/openplantbook-go/msgpack), so the core SDK does
+// not depend on a MessagePack implementation:
+//
+//	client, err := openplantbook.New(
+//	    openplantbook.WithAPIKey("key"),
+//	    openplantbook.WithSerializer(msgpack.Serializer{}),
+//	)
+package msgpack
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+// Serializer encodes cache entries as MessagePack
+// Struct fields use their JSON names, so entries stay readable with generic
+// MessagePack tooling.
+type Serializer struct{}
+
+var _ openplantbook.Serializer = Serializer{}
+
+// Marshal encodes v as MessagePack
+func (Serializer) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes MessagePack data into v
+func (Serializer) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
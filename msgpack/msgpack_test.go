@@ -0,0 +1,38 @@
+package msgpack
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func TestSerializer_RoundTrip(t *testing.T) {
+	want := openplantbook.PlantDetails{
+		PID:         "monstera deliciosa",
+		DisplayPID:  "Monstera deliciosa",
+		MaxTemp:     30.5,
+		MinTemp:     12,
+		MaxEnvHumid: 80,
+		ImageURL:    "https://example.com/monstera.jpg",
+	}
+
+	data, err := Serializer{}.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+
+	jsonData, _ := json.Marshal(want)
+	if len(data) >= len(jsonData) {
+		t.Errorf("msgpack entry is %d bytes, want smaller than JSON (%d bytes)", len(data), len(jsonData))
+	}
+
+	var got openplantbook.PlantDetails
+	if err := (Serializer{}).Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package openplantbook
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/rmrfslashbin/openplantbook-go/internal/botanical"
+)
+
+// ScientificName returns the plant's scientific name in botanical style:
+// genus capitalized, species epithet lowercase and cultivar in single
+// quotes, e.g. "Epipremnum aureum 'Marble Queen'"
+// It is parsed from DisplayPID, falling back to PID.
+func (d *PlantDetails) ScientificName() string {
+	name := d.DisplayPID
+	if name == "" {
+		name = d.PID
+	}
+	return botanical.Parse(name).String()
+}
+
+// PageURL returns the address of the plant's page on the OpenPlantbook
+// website, e.g. for links and QR codes on printed labels
+func (d *PlantDetails) PageURL() string {
+	return "https://open.plantbook.io/plant/detail/" + url.PathEscape(d.PID) + "/"
+}
+
+// ScientificName returns the result's scientific name formatted like
+// PlantDetails.ScientificName
+func (r PlantSearchResult) ScientificName() string {
+	name := r.DisplayPID
+	if name == "" {
+		name = r.PID
+	}
+	return botanical.Parse(name).String()
+}
+
+// FormattedName returns a name for display to a user reading lang (an ISO
+// 639-1 code): "Alias (Scientific name)" when the alias is in lang, and the
+// scientific name otherwise
+//
+// The alias is in the language the details were requested in (Language),
+// English by default. An empty lang accepts the alias in any language.
+func (d *PlantDetails) FormattedName(lang string) string {
+	scientific := d.ScientificName()
+	if d.Alias == "" || !sameLanguage(d.Language, lang) || strings.EqualFold(d.Alias, scientific) {
+		return scientific
+	}
+	return d.Alias + " (" + scientific + ")"
+}
+
+// sameLanguage reports whether the alias language have matches want,
+// treating empty have as English and empty want as any language
+func sameLanguage(have, want string) bool {
+	if want == "" {
+		return true
+	}
+	if have == "" {
+		have = "en"
+	}
+	// Compare the primary subtag, so "pt" matches "pt-BR"
+	have, _, _ = strings.Cut(have, "-")
+	want, _, _ = strings.Cut(want, "-")
+	return strings.EqualFold(have, want)
+}
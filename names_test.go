@@ -0,0 +1,42 @@
+package openplantbook
+
+import "testing"
+
+func TestPlantDetails_FormattedName(t *testing.T) {
+	tests := []struct {
+		name    string
+		details PlantDetails
+		lang    string
+		want    string
+	}{
+		{"English alias", PlantDetails{PID: "monstera deliciosa", DisplayPID: "Monstera deliciosa", Alias: "Swiss cheese plant"},
+			"en", "Swiss cheese plant (Monstera deliciosa)"},
+		{"any language", PlantDetails{DisplayPID: "Monstera deliciosa", Alias: "Fensterblatt", Language: "de"},
+			"", "Fensterblatt (Monstera deliciosa)"},
+		{"localized alias", PlantDetails{DisplayPID: "Monstera deliciosa", Alias: "Fensterblatt", Language: "de"},
+			"de-AT", "Fensterblatt (Monstera deliciosa)"},
+		{"alias in another language", PlantDetails{DisplayPID: "Monstera deliciosa", Alias: "Swiss cheese plant"},
+			"de", "Monstera deliciosa"},
+		{"capitalization and cultivar", PlantDetails{PID: "epipremnum aureum marble queen", DisplayPID: "EPIPREMNUM aureum 'Marble Queen'"},
+			"en", "Epipremnum aureum 'Marble Queen'"},
+		{"cultivar from PID", PlantDetails{PID: "ficus elastica cv. tineke"},
+			"en", "Ficus elastica 'Tineke'"},
+		{"alias equal to name", PlantDetails{DisplayPID: "Ficus", Alias: "ficus"},
+			"en", "Ficus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.details.FormattedName(tt.lang); got != tt.want {
+				t.Errorf("FormattedName(%q) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlantSearchResult_ScientificName(t *testing.T) {
+	r := PlantSearchResult{PID: "sansevieria trifasciata var laurentii"}
+	if got, want := r.ScientificName(), "Sansevieria trifasciata var. laurentii"; got != want {
+		t.Errorf("ScientificName() = %q, want %q", got, want)
+	}
+}
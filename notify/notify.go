@@ -0,0 +1,166 @@
+// Package notify dispatches care threshold violations to notification sinks.
+//
+// A Dispatcher fans a Notification out to any number of sinks (webhook,
+// ntfy, Pushover, SMTP email or a custom Sink implementation):
+//
+//	d := notify.NewDispatcher(
+//	    &notify.Ntfy{Topic: "my-plants"},
+//	    &notify.Webhook{URL: "https://example.com/hooks/plants"},
+//	)
+//	err := d.Evaluate(ctx, details, care.Reading{Metric: care.MetricSoilMoisture, Value: 9})
+//
+// To avoid alerts flapping on readings near a threshold, pass them through
+// a care.Alerter with the plant's rules and send the result with Alert:
+//
+//	alerts := alerter.Observe(details, plant.Alerts, readings...)
+//	err := d.Alert(ctx, alerts)
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// Notification is a message delivered to sinks
+type Notification struct {
+	Title      string           `json:"title"`
+	Message    string           `json:"message"`
+	Time       time.Time        `json:"time"`
+	Violations []care.Violation `json:"violations"`
+
+	// Resolved holds the violations whose alerts have cleared, each with
+	// the reading that cleared it
+	Resolved []care.Violation `json:"resolved,omitempty"`
+}
+
+// Sink delivers notifications to an external service
+type Sink interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, n Notification) error
+
+// Notify calls f(ctx, n)
+func (f SinkFunc) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
+
+// Dispatcher sends notifications to all configured sinks
+type Dispatcher struct {
+	// Evaluator adjusts thresholds used by Evaluate, e.g. for winter
+	// dormancy; the zero value uses the raw thresholds
+	Evaluator care.Evaluator
+
+	sinks []Sink
+}
+
+// NewDispatcher creates a dispatcher delivering to the given sinks
+func NewDispatcher(sinks ...Sink) *Dispatcher {
+	return &Dispatcher{sinks: sinks}
+}
+
+// Send delivers a notification to every sink
+// A failing sink does not prevent delivery to the others; all sink errors
+// are joined into the returned error.
+func (d *Dispatcher) Send(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, sink := range d.sinks {
+		if err := sink.Notify(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("notify %T: %w", sink, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dispatch builds a notification from violations and sends it
+// Nothing is sent when violations is empty.
+func (d *Dispatcher) Dispatch(ctx context.Context, violations []care.Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return d.Send(ctx, NewNotification(violations))
+}
+
+// Evaluate checks readings against the plant's thresholds and dispatches any violations
+func (d *Dispatcher) Evaluate(ctx context.Context, details *openplantbook.PlantDetails, readings ...care.Reading) error {
+	return d.Dispatch(ctx, d.Evaluator.Evaluate(details, readings...))
+}
+
+// Alert builds a notification from raised and resolved alerts and sends it
+// Nothing is sent when alerts is empty.
+func (d *Dispatcher) Alert(ctx context.Context, alerts []care.Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	return d.Send(ctx, NewAlertNotification(alerts))
+}
+
+// NewNotification summarizes violations into a single notification
+func NewNotification(violations []care.Violation) Notification {
+	n := Notification{
+		Time:       time.Now(),
+		Violations: violations,
+	}
+
+	plants := make(map[string]bool)
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		plants[v.Plant] = true
+		lines = append(lines, v.String())
+	}
+
+	if len(plants) == 1 {
+		n.Title = fmt.Sprintf("%s needs attention", violations[0].Plant)
+	} else {
+		n.Title = fmt.Sprintf("%d plants need attention", len(plants))
+	}
+	n.Message = strings.Join(lines, "\n")
+
+	return n
+}
+
+// NewAlertNotification summarizes alerts into a single notification
+// Raised alerts become Violations and cleared ones Resolved; the title asks
+// for attention if any alert was raised.
+func NewAlertNotification(alerts []care.Alert) Notification {
+	var raised, resolved []care.Violation
+	for _, a := range alerts {
+		if a.Resolved {
+			resolved = append(resolved, a.Violation)
+		} else {
+			raised = append(raised, a.Violation)
+		}
+	}
+	if len(raised) > 0 {
+		n := NewNotification(raised)
+		n.Resolved = resolved
+		for _, a := range alerts {
+			if a.Resolved {
+				n.Message += "\n" + a.String()
+			}
+		}
+		return n
+	}
+
+	n := Notification{Time: time.Now(), Resolved: resolved}
+	plants := make(map[string]bool)
+	lines := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		plants[a.Violation.Plant] = true
+		lines = append(lines, a.String())
+	}
+	if len(plants) == 1 {
+		n.Title = fmt.Sprintf("%s is back in range", resolved[0].Plant)
+	} else {
+		n.Title = fmt.Sprintf("%d plants are back in range", len(plants))
+	}
+	n.Message = strings.Join(lines, "\n")
+	return n
+}
@@ -0,0 +1,181 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func testDetails() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		Alias:        "Monstera",
+		MaxSoilMoist: 60,
+		MinSoilMoist: 15,
+		MaxTemp:      30,
+		MinTemp:      15,
+	}
+}
+
+func TestDispatcher_Evaluate(t *testing.T) {
+	var got []Notification
+	d := NewDispatcher(SinkFunc(func(ctx context.Context, n Notification) error {
+		got = append(got, n)
+		return nil
+	}))
+
+	// In-range readings send nothing
+	err := d.Evaluate(context.Background(), testDetails(), care.Reading{Metric: care.MetricTemperature, Value: 20})
+	if err != nil {
+		t.Fatalf("Evaluate() unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("Evaluate() sent %d notifications for in-range reading, want 0", len(got))
+	}
+
+	err = d.Evaluate(context.Background(), testDetails(),
+		care.Reading{Metric: care.MetricSoilMoisture, Value: 9},
+		care.Reading{Metric: care.MetricTemperature, Value: 35},
+	)
+	if err != nil {
+		t.Fatalf("Evaluate() unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Evaluate() sent %d notifications, want 1", len(got))
+	}
+	if got[0].Title != "Monstera needs attention" {
+		t.Errorf("notification title = %q", got[0].Title)
+	}
+	if len(got[0].Violations) != 2 {
+		t.Errorf("notification has %d violations, want 2", len(got[0].Violations))
+	}
+}
+
+func TestDispatcher_Alert(t *testing.T) {
+	var got []Notification
+	d := NewDispatcher(SinkFunc(func(ctx context.Context, n Notification) error {
+		got = append(got, n)
+		return nil
+	}))
+
+	var a care.Alerter
+	dry := care.Reading{Metric: care.MetricSoilMoisture, Value: 9}
+	if err := d.Alert(context.Background(), a.Observe(testDetails(), nil, dry)); err != nil {
+		t.Fatalf("Alert() unexpected error: %v", err)
+	}
+	if err := d.Alert(context.Background(), a.Observe(testDetails(), nil, dry)); err != nil {
+		t.Fatalf("Alert() unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Monstera needs attention" || len(got[0].Violations) != 1 {
+		t.Fatalf("Alert() sent %+v, want one notification for the raised alert", got)
+	}
+
+	watered := care.Reading{Metric: care.MetricSoilMoisture, Value: 30}
+	if err := d.Alert(context.Background(), a.Observe(testDetails(), nil, watered)); err != nil {
+		t.Fatalf("Alert() unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[1].Title != "Monstera is back in range" || len(got[1].Resolved) != 1 || len(got[1].Violations) != 0 {
+		t.Errorf("Alert() sent %+v, want a resolution notification", got[1:])
+	}
+	if !strings.Contains(got[1].Message, "soil_moisture is back in range") {
+		t.Errorf("resolution message = %q", got[1].Message)
+	}
+}
+
+func TestDispatcher_SendJoinsErrors(t *testing.T) {
+	calls := 0
+	failing := SinkFunc(func(ctx context.Context, n Notification) error {
+		calls++
+		return errors.New("boom")
+	})
+	ok := SinkFunc(func(ctx context.Context, n Notification) error {
+		calls++
+		return nil
+	})
+
+	err := NewDispatcher(failing, ok).Send(context.Background(), Notification{Title: "t"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Send() error = %v, want joined sink error", err)
+	}
+	if calls != 2 {
+		t.Errorf("Send() called %d sinks, want 2", calls)
+	}
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			t.Errorf("missing custom header")
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	sink := &Webhook{URL: server.URL, Headers: map[string]string{"X-Token": "secret"}}
+	if err := sink.Notify(context.Background(), Notification{Title: "hello"}); err != nil {
+		t.Fatalf("Notify() unexpected error: %v", err)
+	}
+	if received.Title != "hello" {
+		t.Errorf("webhook received title %q, want %q", received.Title, "hello")
+	}
+}
+
+func TestNtfy_Notify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/plants" {
+			t.Errorf("path = %q, want /plants", r.URL.Path)
+		}
+		if r.Header.Get("Title") != "title" || r.Header.Get("Authorization") != "Bearer tk" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "message" {
+			t.Errorf("body = %q, want %q", body, "message")
+		}
+	}))
+	defer server.Close()
+
+	sink := &Ntfy{Server: server.URL, Topic: "plants", Token: "tk"}
+	if err := sink.Notify(context.Background(), Notification{Title: "title", Message: "message"}); err != nil {
+		t.Fatalf("Notify() unexpected error: %v", err)
+	}
+}
+
+func TestPushover_Notify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("token") != "app" || r.Form.Get("user") != "usr" || r.Form.Get("message") != "m" {
+			t.Errorf("unexpected form: %v", r.Form)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":0}`))
+	}))
+	defer server.Close()
+
+	sink := &Pushover{Token: "app", User: "usr", Endpoint: server.URL}
+	err := sink.Notify(context.Background(), Notification{Title: "t", Message: "m"})
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Notify() error = %v, want status 400 error", err)
+	}
+}
+
+func TestSMTP_Message(t *testing.T) {
+	sink := &SMTP{From: "plants@example.com", To: []string{"me@example.com"}}
+	msg := string(sink.message(Notification{Title: "a\r\nBcc: evil@example.com", Message: "line1\nline2"}))
+
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Error("message allows header injection through title")
+	}
+	if !strings.Contains(msg, "line1\r\nline2") {
+		t.Error("message body does not use CRLF line endings")
+	}
+}
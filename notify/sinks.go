@@ -0,0 +1,190 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultNtfyServer is the public ntfy.sh server
+	DefaultNtfyServer = "https://ntfy.sh"
+
+	// DefaultPushoverEndpoint is the Pushover message API endpoint
+	DefaultPushoverEndpoint = "https://api.pushover.net/1/messages.json"
+)
+
+// defaultHTTPClient is used by sinks without a custom HTTP client
+var defaultHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// Webhook POSTs notifications as JSON to a URL
+type Webhook struct {
+	URL        string
+	Headers    map[string]string
+	HTTPClient *http.Client
+}
+
+// Notify implements Sink
+func (w *Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+
+	return do(w.HTTPClient, req)
+}
+
+// Ntfy publishes notifications to an ntfy topic (https://ntfy.sh)
+type Ntfy struct {
+	// Server is the ntfy server URL (default: DefaultNtfyServer)
+	Server string
+	Topic  string
+
+	// Token is an optional access token for protected topics
+	Token string
+
+	// Priority is an optional ntfy priority (1-5)
+	Priority   int
+	HTTPClient *http.Client
+}
+
+// Notify implements Sink
+func (s *Ntfy) Notify(ctx context.Context, n Notification) error {
+	if s.Topic == "" {
+		return fmt.Errorf("ntfy topic cannot be empty")
+	}
+	server := s.Server
+	if server == "" {
+		server = DefaultNtfyServer
+	}
+
+	endpoint := strings.TrimRight(server, "/") + "/" + url.PathEscape(s.Topic)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(n.Message))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Title", n.Title)
+	req.Header.Set("Tags", "potted_plant")
+	if s.Priority > 0 {
+		req.Header.Set("Priority", fmt.Sprint(s.Priority))
+	}
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	return do(s.HTTPClient, req)
+}
+
+// Pushover sends notifications through the Pushover API (https://pushover.net)
+type Pushover struct {
+	// Token is the application API token
+	Token string
+	// User is the user or group key
+	User string
+
+	// Endpoint overrides the API endpoint (default: DefaultPushoverEndpoint)
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// Notify implements Sink
+func (p *Pushover) Notify(ctx context.Context, n Notification) error {
+	if p.Token == "" || p.User == "" {
+		return fmt.Errorf("pushover token and user cannot be empty")
+	}
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultPushoverEndpoint
+	}
+
+	form := url.Values{
+		"token":   {p.Token},
+		"user":    {p.User},
+		"title":   {n.Title},
+		"message": {n.Message},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return do(p.HTTPClient, req)
+}
+
+// SMTP sends notifications as plain-text email
+type SMTP struct {
+	// Addr is the SMTP server address in host:port form
+	Addr string
+	// Auth is optional SMTP authentication (e.g., smtp.PlainAuth)
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// Notify implements Sink
+// The context is not honored by net/smtp once the connection is established.
+func (s *SMTP) Notify(ctx context.Context, n Notification) error {
+	if len(s.To) == 0 {
+		return fmt.Errorf("smtp recipients cannot be empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, s.message(n))
+}
+
+// message renders an RFC 5322 message for the notification
+func (s *SMTP) message(n Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(n.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.Time.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(n.Message, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// sanitizeHeader prevents header injection through notification titles
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+// do executes req and treats any non-2xx status as an error
+func do(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
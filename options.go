@@ -1,9 +1,13 @@
 package openplantbook
 
 import (
+	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/time/rate"
 )
 
@@ -15,7 +19,7 @@ type Option func(*Client) error
 func WithAPIKey(apiKey string) Option {
 	return func(c *Client) error {
 		if apiKey == "" {
-			return ErrInvalidConfig("API key cannot be empty")
+			return optionError("WithAPIKey", nil, "API key cannot be empty")
 		}
 		c.apiKey = apiKey
 		return nil
@@ -27,7 +31,7 @@ func WithAPIKey(apiKey string) Option {
 func WithOAuth2(clientID, clientSecret string) Option {
 	return func(c *Client) error {
 		if clientID == "" || clientSecret == "" {
-			return ErrInvalidConfig("client_id and client_secret cannot be empty")
+			return optionError("WithOAuth2", nil, "client_id and client_secret cannot be empty")
 		}
 		c.clientID = clientID
 		c.clientSecret = clientSecret
@@ -35,47 +39,574 @@ func WithOAuth2(clientID, clientSecret string) Option {
 	}
 }
 
+// WithOAuth2Scopes requests the given scopes with the OAuth2 client
+// credentials of WithOAuth2 (default: none, the account's full access)
+func WithOAuth2Scopes(scopes ...string) Option {
+	return func(c *Client) error {
+		if len(scopes) == 0 || slices.Contains(scopes, "") {
+			return optionError("WithOAuth2Scopes", scopes, "scopes cannot be empty")
+		}
+		c.scopes = scopes
+		return nil
+	}
+}
+
+// WithTokenSource authenticates with OAuth2 tokens from ts instead of
+// client credentials, e.g. pre-fetched tokens or a delegated auth service
+// Tokens are reused until they expire. It is an authentication method of its
+// own, so it cannot be combined with WithAPIKey or WithOAuth2; the client's
+// transport options, debugging and redaction still apply.
+func WithTokenSource(ts oauth2.TokenSource) Option {
+	return func(c *Client) error {
+		if ts == nil {
+			return optionError("WithTokenSource", nil, "token source cannot be nil")
+		}
+		c.tokenSource = ts
+		return nil
+	}
+}
+
+// WithReauth sets fn to supply new credentials when the API rejects the
+// client's current ones, e.g. to rotate an API key from a secrets store
+// A request that fails with 401 (or whose OAuth2 token request is refused)
+// is sent once more after re-authenticating; if it fails again the error
+// wraps ErrUnauthorized as before. Without a hook the client still retries
+// once, fetching a new OAuth2 token in case the old one was revoked.
+// Concurrent rejected requests share one call of fn. Not available with
+// WithHTTPClient, whose authentication the client does not manage.
+func WithReauth(fn ReauthFunc) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return optionError("WithReauth", nil, "reauth function cannot be nil")
+		}
+		c.reauth = fn
+		return nil
+	}
+}
+
 // WithBaseURL sets a custom base URL (useful for testing)
 func WithBaseURL(url string) Option {
 	return func(c *Client) error {
 		if url == "" {
-			return ErrInvalidConfig("base URL cannot be empty")
+			return optionError("WithBaseURL", nil, "base URL cannot be empty")
 		}
 		c.baseURL = url
 		return nil
 	}
 }
 
+// WithTokenURL sets the OAuth2 token endpoint, for setups where the token is
+// issued by a different host or path than the API (default: the base URL
+// plus "/token/")
+// The URL must be absolute, and https unless it points at a loopback
+// address, since the client secret is sent to it.
+func WithTokenURL(tokenURL string) Option {
+	return func(c *Client) error {
+		if err := checkTokenURL(tokenURL); err != nil {
+			return optionError("WithTokenURL", tokenURL, err.Error())
+		}
+		c.tokenEndpoint = tokenURL
+		return nil
+	}
+}
+
 // WithHTTPClient allows providing a custom HTTP client
 // NOTE: This bypasses authentication configuration
 func WithHTTPClient(httpClient *http.Client) Option {
 	return func(c *Client) error {
 		if httpClient == nil {
-			return ErrInvalidConfig("HTTP client cannot be nil")
+			return optionError("WithHTTPClient", nil, "HTTP client cannot be nil")
 		}
 		c.httpClient = httpClient
 		return nil
 	}
 }
 
+// WithMaxIdleConns sets how many idle keep-alive connections are kept open
+// The default transport keeps only two per host, which forces high-throughput
+// batch jobs to open (and leave in TIME_WAIT) a new connection per request.
+// Cannot be combined with WithHTTPClient.
+func WithMaxIdleConns(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return optionError("WithMaxIdleConns", n, "max idle connections must be positive")
+		}
+		c.transport.set = true
+		c.transport.maxIdleConns = n
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle keep-alive connection is kept open
+// Cannot be combined with WithHTTPClient.
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		if d <= 0 {
+			return optionError("WithIdleConnTimeout", d, "idle connection timeout must be positive")
+		}
+		c.transport.set = true
+		c.transport.idleConnTimeout = d
+		return nil
+	}
+}
+
+// DisableHTTP2 restricts the client to HTTP/1.1
+// Useful behind proxies with broken HTTP/2 support. Cannot be combined with
+// WithHTTPClient.
+func DisableHTTP2() Option {
+	return func(c *Client) error {
+		c.transport.set = true
+		c.transport.disableHTTP2 = true
+		return nil
+	}
+}
+
+// WithTransport sets the transport requests are sent with, beneath
+// authentication
+// Unlike WithHTTPClient, the client still adds credentials, so a custom
+// transport (a fetch-based one under GOOS=js, a test double, or a
+// company-wide proxy transport) does not need to know about them. Cannot be
+// combined with WithHTTPClient or the connection pooling options, which
+// configure the default transport.
+//
+// Example, in a browser:
+//
+//	client, _ := openplantbook.New(
+//	    openplantbook.WithAPIKey(apiKey),
+//	    openplantbook.WithTransport(fetchTransport),
+//	)
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) error {
+		if rt == nil {
+			return optionError("WithTransport", nil, "transport cannot be nil")
+		}
+		c.transport.set = true
+		c.transport.base = rt
+		return nil
+	}
+}
+
+// WithHedging sends a second, identical request when the first has not
+// responded within delay, returning whichever succeeds first and canceling
+// the other
+//
+// Hedging trades an occasional extra request for lower tail latency, which
+// suits interactive use such as autocomplete. The extra request is only sent
+// when the rate limiter allows it immediately.
+func WithHedging(delay time.Duration) Option {
+	return func(c *Client) error {
+		if delay <= 0 {
+			return optionError("WithHedging", delay, "hedging delay must be positive")
+		}
+		c.hedgeDelay = delay
+		return nil
+	}
+}
+
+// WithStaleIfError serves expired cache entries, up to maxStale past their
+// TTL, when the API cannot be reached
+//
+// Network and DNS failures, timeouts, 5xx responses and rate limiting fall
+// back to the stale entry; answers such as 404 are returned as errors. Use
+// GetPlantDetailsWithMeta or SearchPlantsWithMeta to tell stale results
+// apart. Entries stay in the cache for their TTL plus maxStale.
+func WithStaleIfError(maxStale time.Duration) Option {
+	return func(c *Client) error {
+		if maxStale <= 0 {
+			return optionError("WithStaleIfError", maxStale, "max staleness must be positive")
+		}
+		c.staleIfError = maxStale
+		return nil
+	}
+}
+
+// updateRetention is how long expired details are kept for WithOnUpdate
+const updateRetention = 30 * 24 * time.Hour
+
+// WithOnUpdate calls fn when details fetched to refresh an expired cache
+// entry differ from the cached ones, e.g. after upstream thresholds change
+//
+// fn runs synchronously in the goroutine that called GetPlantDetails, after
+// the new details are cached. To have something to compare against, expired
+// responses are kept in the cache for 30 days past their TTL (they are still
+// only returned stale within WithStaleIfError's bound). Nothing is reported
+// when caching is disabled or a plant is fetched for the first time.
+func WithOnUpdate(fn func(pid string, old, new *PlantDetails)) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return optionError("WithOnUpdate", nil, "update callback cannot be nil")
+		}
+		c.onUpdate = fn
+		return nil
+	}
+}
+
+// WithImageHosts only accepts PlantDetails.ImageURL values on the given
+// hosts or their subdomains; other image URLs are cleared
+// Image URLs are always required to be absolute http(s) URLs, and http is
+// upgraded to https.
+func WithImageHosts(hosts ...string) Option {
+	return func(c *Client) error {
+		if len(hosts) == 0 {
+			return optionError("WithImageHosts", hosts, "at least one host required")
+		}
+		allowed := make([]string, 0, len(hosts))
+		for _, h := range hosts {
+			h = strings.ToLower(strings.TrimSpace(h))
+			if h == "" || strings.ContainsAny(h, "/:") {
+				return optionError("WithImageHosts", hosts, "hosts must be bare host names")
+			}
+			allowed = append(allowed, h)
+		}
+		c.images.hosts = allowed
+		return nil
+	}
+}
+
+// WithImageURLRewrite passes every valid PlantDetails.ImageURL through fn,
+// e.g. to route image fetches through a CDN or egress proxy
+// fn sees the URL after validation (see WithImageHosts) and applies to
+// cached details too, so changing it needs no cache flush.
+//
+// Example:
+//
+//	openplantbook.WithImageURLRewrite(func(u string) string {
+//	    return "https://cdn.example.com/fetch?url=" + url.QueryEscape(u)
+//	})
+func WithImageURLRewrite(fn func(string) string) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return optionError("WithImageURLRewrite", nil, "rewrite function cannot be nil")
+		}
+		c.images.rewrite = fn
+		return nil
+	}
+}
+
+// WithClock sets the clock used for rate limiting, cache freshness, quota
+// accounting and the hedging and autocomplete delays (default: the time
+// package)
+// With a fake clock such as clocktest.Clock, tests advance time instead of
+// sleeping. The default in-memory cache uses the clock too; caches passed to
+// WithCache keep their own time for expiry. A Limiter shared with
+// WithSharedRateLimiter counts usage by the clock too, so clients sharing it
+// should share a clock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) error {
+		if clock == nil {
+			return optionError("WithClock", nil, "clock cannot be nil")
+		}
+		c.clock = clock
+		return nil
+	}
+}
+
+// WithJSONCodec decodes API responses with codec instead of encoding/json,
+// e.g. a faster library for bulk workloads
+// The default JSONSerializer decodes cached responses with it too. Bodies
+// are still checked with encoding/json's validator before decoding, so
+// malformed responses are reported the same way.
+//
+// Example:
+//
+//	openplantbook.WithJSONCodec(jsoniter.ConfigCompatibleWithStandardLibrary)
+func WithJSONCodec(codec Codec) Option {
+	return func(c *Client) error {
+		if codec == nil {
+			return optionError("WithJSONCodec", nil, "codec cannot be nil")
+		}
+		c.codec = codec
+		return nil
+	}
+}
+
+// WithBatchConcurrency sets how many requests batch APIs such as
+// GetPlantDetailsBatch send at once (default 1)
+// Requests still wait on the rate limiter, so this only speeds up batches
+// when the limit allows more than one request at a time, e.g. with a higher
+// WithRateLimit or DisableRateLimit.
+func WithBatchConcurrency(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return optionError("WithBatchConcurrency", n, "batch concurrency must be positive")
+		}
+		c.batchConcurrency = n
+		return nil
+	}
+}
+
+// WithHTTPDebug writes a dump of every HTTP request and response to w
+// Authorization and other credential headers are redacted. Intended for
+// troubleshooting; dumps include response bodies up to WithMaxResponseSize.
+//
+// Example:
+//
+//	client, _ := openplantbook.New(
+//	    openplantbook.WithAPIKey(apiKey),
+//	    openplantbook.WithHTTPDebug(os.Stderr),
+//	)
+func WithHTTPDebug(w io.Writer) Option {
+	return func(c *Client) error {
+		if w == nil {
+			return optionError("WithHTTPDebug", nil, "debug writer cannot be nil")
+		}
+		c.debugWriter = w
+		return nil
+	}
+}
+
+// WithAuditLog appends a JSON line to w for every API request: time, method,
+// endpoint class, path, query parameters, status, duration, response size,
+// error and the quota usage after the request
+// Credentials are never logged. Cache hits are not requests and are not
+// logged. Open files with os.O_APPEND so the log survives restarts.
+//
+// Example:
+//
+//	f, _ := os.OpenFile("audit.ndjson", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+//	client, _ := openplantbook.New(
+//	    openplantbook.WithAPIKey(apiKey),
+//	    openplantbook.WithAuditLog(f),
+//	)
+func WithAuditLog(w io.Writer) Option {
+	return func(c *Client) error {
+		if w == nil {
+			return optionError("WithAuditLog", nil, "audit writer cannot be nil")
+		}
+		c.auditLog = &auditLog{w: w}
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits how many bytes of a response body the client
+// reads (default DefaultMaxResponseSize)
+// Larger responses fail with ErrResponseTooLarge, which protects small
+// devices when a custom base URL or proxy returns a pathological body.
+func WithMaxResponseSize(bytes int64) Option {
+	return func(c *Client) error {
+		if bytes <= 0 {
+			return optionError("WithMaxResponseSize", bytes, "maximum response size must be positive")
+		}
+		c.maxResponseSize = bytes
+		return nil
+	}
+}
+
 // WithCache sets a custom cache implementation
+// The caller keeps ownership: Client.Close leaves the cache open, so it can be
+// shared between clients. Use WithOwnedCache to hand it over instead.
 func WithCache(cache Cache) Option {
 	return func(c *Client) error {
 		if cache == nil {
-			return ErrInvalidConfig("cache cannot be nil")
+			return optionError("WithCache", nil, "cache cannot be nil")
 		}
 		c.cache = cache
 		return nil
 	}
 }
 
+// WithOwnedCache sets a cache that the client takes ownership of
+// Client.Close closes the cache (if it has a Close method), as does New when
+// it fails, so short-lived clients and tests don't leave cleanup goroutines
+// behind.
+func WithOwnedCache(cache Cache) Option {
+	return func(c *Client) error {
+		if cache == nil {
+			return optionError("WithOwnedCache", nil, "cache cannot be nil")
+		}
+		c.cache = cache
+		c.onClose(func() error { return closeCache(cache) })
+		return nil
+	}
+}
+
+// DisableCache turns off response caching
+// Every call reaches the API (subject to rate limiting), and no cache keys or
+// entries are built. Equivalent to WithCache(NewNoOpCache()).
+func DisableCache() Option {
+	return func(c *Client) error {
+		c.cache = NewNoOpCache()
+		return nil
+	}
+}
+
+// WithCacheNamespace prefixes the client's cache keys with ns, so clients
+// sharing a cache (e.g. Redis) keep their entries apart
+// Use it when clients with different base URLs or accounts share one cache.
+// Searches and details including user plants, and GetJSON responses, are
+// kept per account even without a namespace. With WithTokenSource or
+// WithHTTPClient the client cannot identify the account, so those responses
+// are only cached once a namespace is set.
+func WithCacheNamespace(ns string) Option {
+	return func(c *Client) error {
+		if ns == "" || strings.ContainsAny(ns, "/ \t\n") {
+			return optionError("WithCacheNamespace", ns, "namespace must be non-empty without slashes or whitespace")
+		}
+		c.cacheNamespace = ns
+		return nil
+	}
+}
+
+// WithSerializer sets how responses are encoded in the cache
+// The default JSONSerializer stores raw response bodies; the msgpack
+// subpackage produces smaller entries for memory-constrained devices.
+func WithSerializer(s Serializer) Option {
+	return func(c *Client) error {
+		if s == nil {
+			return optionError("WithSerializer", nil, "serializer cannot be nil")
+		}
+		c.serializer = s
+		return nil
+	}
+}
+
+// WithDefaultSearchOptions sets the options of searches made with nil
+// options
+// Searches passing their own options use those alone; fields are not merged.
+//
+//	openplantbook.WithDefaultSearchOptions(openplantbook.SearchOptions{Limit: 25, UserPlants: true})
+func WithDefaultSearchOptions(opts SearchOptions) Option {
+	return func(c *Client) error {
+		if opts.Limit < 0 {
+			return optionError("WithDefaultSearchOptions", opts.Limit, "limit cannot be negative")
+		}
+		if opts.Match < MatchContains || opts.Match > MatchExact {
+			return optionError("WithDefaultSearchOptions", opts.Match, "unknown match mode")
+		}
+		c.defaultSearch = &opts
+		return nil
+	}
+}
+
+// WithIndex sets the index used by SearchLocal
+// Pass a pre-populated index (for example built from a snapshot) to search
+// plants the client has not seen yet, or share one index between clients.
+func WithIndex(idx *Index) Option {
+	return func(c *Client) error {
+		if idx == nil {
+			return optionError("WithIndex", nil, "index cannot be nil")
+		}
+		c.index = idx
+		return nil
+	}
+}
+
+// WithLocalIndex sets how many plants the index behind SearchLocal holds
+// (DefaultIndexSize by default), evicting the least recently seen beyond it
+// Zero disables the index: nothing is indexed and SearchLocal finds nothing.
+func WithLocalIndex(maxPlants int) Option {
+	return func(c *Client) error {
+		switch {
+		case maxPlants < 0:
+			return optionError("WithLocalIndex", maxPlants, "size cannot be negative")
+		case maxPlants == 0:
+			c.index = nil
+		default:
+			c.index = NewLimitedIndex(maxPlants)
+		}
+		return nil
+	}
+}
+
 // WithRateLimit sets a custom rate limiter (requests per day)
 func WithRateLimit(requestsPerDay int) Option {
 	return func(c *Client) error {
 		if requestsPerDay <= 0 {
-			return ErrInvalidConfig("rate limit must be positive")
+			return optionError("WithRateLimit", requestsPerDay, "rate limit must be positive")
 		}
 		c.rateLimiter = rate.NewLimiter(rate.Every(24*time.Hour/time.Duration(requestsPerDay)), 1)
+		c.dailyLimit = requestsPerDay
+		c.sharedLimiter = nil
+		return nil
+	}
+}
+
+// WithRateLimits gives endpoint classes their own daily quota (requests per
+// day), so a batch of detail lookups cannot starve interactive searches and
+// vice versa
+// Class quotas apply in addition to the client-wide or shared limit (see
+// WithSharedRateLimiter): a request waits until both allow it. Combine with
+// DisableRateLimit, placed first, to pace classes by their quotas alone.
+//
+// Example:
+//
+//	client, _ := openplantbook.New(
+//	    openplantbook.WithAPIKey(apiKey),
+//	    openplantbook.WithRateLimits(map[openplantbook.EndpointClass]int{
+//	        openplantbook.EndpointSearch:  50,
+//	        openplantbook.EndpointDetails: 150,
+//	    }),
+//	)
+func WithRateLimits(limits map[EndpointClass]int) Option {
+	return func(c *Client) error {
+		if len(limits) == 0 {
+			return optionError("WithRateLimits", limits, "at least one endpoint class is required")
+		}
+		classLimiters := make(map[EndpointClass]*rate.Limiter, len(limits))
+		for class, requestsPerDay := range limits {
+			if class != EndpointSearch && class != EndpointDetails {
+				return optionError("WithRateLimits", class, "unknown endpoint class")
+			}
+			if requestsPerDay <= 0 {
+				return optionError("WithRateLimits", requestsPerDay, "rate limit for "+class.String()+" must be positive")
+			}
+			classLimiters[class] = rate.NewLimiter(rate.Every(24*time.Hour/time.Duration(requestsPerDay)), 1)
+		}
+		c.classLimiters = classLimiters
+		return nil
+	}
+}
+
+// WithSharedRateLimiter paces the client with a limiter that other clients
+// may share, so they draw from one daily budget
+//
+// Example:
+//
+//	limiter, _ := openplantbook.NewLimiter(openplantbook.DefaultRateLimit)
+//	reader, _ := openplantbook.New(openplantbook.WithAPIKey(key), openplantbook.WithSharedRateLimiter(limiter))
+//	writer, _ := openplantbook.New(openplantbook.WithOAuth2(id, secret), openplantbook.WithSharedRateLimiter(limiter))
+func WithSharedRateLimiter(l *Limiter) Option {
+	return func(c *Client) error {
+		if l == nil {
+			return optionError("WithSharedRateLimiter", nil, "limiter cannot be nil")
+		}
+		c.rateLimiter = l.rl
+		c.dailyLimit = l.perDay
+		c.sharedLimiter = l
+		return nil
+	}
+}
+
+// WithDryRun makes the client report API requests instead of sending them
+//
+// Methods still validate their input and serve fresh cache entries; a call
+// that would reach the API logs the request and returns an *ErrDryRun
+// describing it, without consuming rate limit tokens or request budget.
+// Count the ErrDryRun results of a planned batch job to estimate its quota
+// cost against the current cache.
+func WithDryRun() Option {
+	return func(c *Client) error {
+		c.dryRun = true
+		return nil
+	}
+}
+
+// WithHooks sets callbacks for request lifecycle events
+//
+// Example:
+//
+//	client, _ := openplantbook.New(
+//	    openplantbook.WithAPIKey(apiKey),
+//	    openplantbook.WithHooks(openplantbook.Hooks{
+//	        OnResponse: func(r openplantbook.ResponseInfo) {
+//	            requestDuration.Observe(r.Duration.Seconds())
+//	        },
+//	        OnCacheHit: func(openplantbook.CacheHitInfo) { cacheHits.Inc() },
+//	    }),
+//	)
+func WithHooks(hooks Hooks) Option {
+	return func(c *Client) error {
+		c.hooks = hooks
 		return nil
 	}
 }
@@ -92,6 +623,8 @@ func WithLogger(logger Logger) Option {
 func DisableRateLimit() Option {
 	return func(c *Client) error {
 		c.rateLimiter = nil
+		c.sharedLimiter = nil
+		c.classLimiters = nil
 		return nil
 	}
 }
@@ -115,7 +648,9 @@ func WithRateLimitBehavior(behavior RateLimitBehavior) Option {
 }
 
 // Logger is the interface for optional logging injection
-// Implemented by slog.Logger, logrus, zap, etc.
+// Implemented by *slog.Logger; FromSugared adapts zap and LogFunc adapts
+// other loggers. The client passes args as alternating string keys and
+// values, validated and redacted (see LogAttrs).
 type Logger interface {
 	Debug(msg string, args ...interface{})
 	Info(msg string, args ...interface{})
@@ -0,0 +1,69 @@
+// Package parallel runs work over a bounded pool of goroutines.
+//
+// It is what the client's batch APIs use internally, and is exported for
+// custom batch flows. Calls made through an openplantbook.Client inside the
+// work function still wait on the client's rate limiter, so the pool bounds
+// concurrency while the limiter bounds request rate; with the default daily
+// limit a single worker is as fast as many. Prefer this over starting one
+// goroutine per item, which with RateLimitError behavior turns most of the
+// batch into rate-limit errors.
+package parallel
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ForEach calls fn for every item using at most workers goroutines
+// (workers < 1 means 1)
+//
+// The first error cancels the context passed to the remaining calls, no
+// further items are started, and that error is returned once running calls
+// finish. A canceled ctx stops the loop the same way.
+func ForEach[T any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(workers, 1))
+
+	for _, item := range items {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return fn(gctx, item)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	// Items may have been skipped if ctx ended between calls
+	return ctx.Err()
+}
+
+// Map calls fn for every item like ForEach and returns the results in the
+// order of items
+// On error, the partial results are discarded.
+func Map[T, R any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	err := ForEach(ctx, indexes, workers, func(ctx context.Context, i int) error {
+		r, err := fn(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		results[i] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,78 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach_BoundsWorkers(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	items := make([]int, 20)
+
+	err := ForEach(context.Background(), items, 4, func(ctx context.Context, _ int) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach() unexpected error: %v", err)
+	}
+	if p := peak.Load(); p > 4 {
+		t.Errorf("peak workers = %d, want at most 4", p)
+	}
+}
+
+func TestForEach_StopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+
+	err := ForEach(context.Background(), []int{1, 2, 3, 4, 5}, 1, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		if i == 2 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("ForEach() error = %v, want boom", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("fn called %d times, want 2", n)
+	}
+}
+
+func TestForEach_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ForEach(ctx, []int{1, 2}, 2, func(ctx context.Context, i int) error {
+		t.Error("fn called with a canceled context")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ForEach() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestMap_PreservesOrder(t *testing.T) {
+	got, err := Map(context.Background(), []int{3, 1, 2}, 3, func(ctx context.Context, i int) (int, error) {
+		time.Sleep(time.Duration(i) * time.Millisecond)
+		return i * 10, nil
+	})
+	if err != nil {
+		t.Fatalf("Map() unexpected error: %v", err)
+	}
+	if len(got) != 3 || got[0] != 30 || got[1] != 10 || got[2] != 20 {
+		t.Errorf("Map() = %v, want [30 10 20]", got)
+	}
+}
@@ -0,0 +1,138 @@
+package openplantbook
+
+import (
+	"context"
+	"fmt"
+)
+
+// ItemError is the failure of one item of a batch operation
+type ItemError struct {
+	// Key identifies the item, e.g. its PID
+	Key string
+	Err error
+}
+
+// Error implements the error interface
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError reports the items of a batch operation that failed while
+// the rest succeeded
+//
+// Batch methods returning it also return the results of the items that
+// succeeded, so one missing plant among fifty does not discard the other
+// forty-nine. errors.Is and errors.As look through every failure:
+//
+//	details, err := client.GetPlantDetailsAll(ctx, pids, nil)
+//	var partial *openplantbook.PartialError
+//	if errors.As(err, &partial) {
+//	    retry, failed := partial.Split()
+//	    ...
+//	}
+type PartialError struct {
+	// Failures lists the failed items in input order
+	Failures []*ItemError
+
+	// Total is the number of items in the operation
+	Total int
+}
+
+// Error implements the error interface
+func (e *PartialError) Error() string {
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("1 of %d items failed: %v", e.Total, e.Failures[0])
+	}
+	return fmt.Sprintf("%d of %d items failed, first: %v", len(e.Failures), e.Total, e.Failures[0])
+}
+
+// Unwrap returns the failures, for errors.Is and errors.As
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Keys returns the keys of the failed items
+func (e *PartialError) Keys() []string {
+	keys := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		keys[i] = f.Key
+	}
+	return keys
+}
+
+// Split separates failures that may succeed if retried later (see
+// IsRetryable) from the rest
+func (e *PartialError) Split() (retryable, other []*ItemError) {
+	for _, f := range e.Failures {
+		if IsRetryable(f.Err) {
+			retryable = append(retryable, f)
+		} else {
+			other = append(other, f)
+		}
+	}
+	return retryable, other
+}
+
+// SplitRetryable separates errs into those that may succeed if retried
+// later (see IsRetryable) and the rest, keeping their order
+// It suits operations that collect one error per item, e.g. from
+// BatchDetailsResult.Err.
+func SplitRetryable(errs []error) (retryable, other []error) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if IsRetryable(err) {
+			retryable = append(retryable, err)
+		} else {
+			other = append(other, err)
+		}
+	}
+	return retryable, other
+}
+
+// GetPlantDetailsAll fetches details for each PID, returning them in the
+// order of pids
+//
+// It is GetPlantDetailsBatch collecting the results. When some PIDs fail,
+// their entries are nil and the error is a *PartialError listing them; the
+// other details are still returned. When ctx ends, its error is returned
+// with the details fetched so far.
+func (c *Client) GetPlantDetailsAll(ctx context.Context, pids []string, opts *DetailOptions) ([]*PlantDetails, error) {
+	// Results may arrive out of order; a PID listed twice fills its slots in turn
+	slots := make(map[string][]int, len(pids))
+	for i, pid := range pids {
+		slots[pid] = append(slots[pid], i)
+	}
+	details := make([]*PlantDetails, len(pids))
+	failed := make([]error, len(pids))
+	err := c.GetPlantDetailsBatch(ctx, pids, opts, func(r BatchDetailsResult) error {
+		i := slots[r.PID][0]
+		slots[r.PID] = slots[r.PID][1:]
+		details[i], failed[i] = r.Details, r.Err
+		return nil
+	})
+	if err != nil {
+		return details, err
+	}
+
+	partial := &PartialError{Total: len(pids)}
+	for i, err := range failed {
+		if err != nil {
+			partial.Failures = append(partial.Failures, &ItemError{Key: pids[i], Err: err})
+		}
+	}
+	if len(partial.Failures) > 0 {
+		return details, partial
+	}
+	return details, nil
+}
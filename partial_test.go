@@ -0,0 +1,86 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_GetPlantDetailsAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.Contains(r.URL.Path, "flaky"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			pid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/plant/detail/"), "/")
+			fmt.Fprintf(w, `{"pid":%q}`, pid)
+		}
+	}))
+	defer server.Close()
+
+	for _, concurrency := range []int{1, 4} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(),
+				WithBatchConcurrency(concurrency))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			pids := []string{"monstera", "missing", "ficus", "flaky", "monstera"}
+			details, err := client.GetPlantDetailsAll(context.Background(), pids, nil)
+
+			var partial *PartialError
+			if !errors.As(err, &partial) {
+				t.Fatalf("GetPlantDetailsAll() error = %v, want *PartialError", err)
+			}
+			if partial.Total != 5 || strings.Join(partial.Keys(), ",") != "missing,flaky" {
+				t.Errorf("PartialError = %v, want missing and flaky of 5", partial)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				t.Error("errors.Is(err, ErrNotFound) = false")
+			}
+			for i, want := range []string{"monstera", "", "ficus", "", "monstera"} {
+				switch {
+				case want == "" && details[i] != nil:
+					t.Errorf("details[%d] = %+v, want nil", i, details[i])
+				case want != "" && (details[i] == nil || details[i].PID != want):
+					t.Errorf("details[%d] = %+v, want %s", i, details[i], want)
+				}
+			}
+
+			retry, other := partial.Split()
+			if len(retry) != 1 || retry[0].Key != "flaky" || len(other) != 1 || other[0].Key != "missing" {
+				t.Errorf("Split() = %v, %v", retry, other)
+			}
+		})
+	}
+
+	t.Run("no failures", func(t *testing.T) {
+		client, _ := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		defer client.Close()
+		details, err := client.GetPlantDetailsAll(context.Background(), []string{"ficus"}, nil)
+		if err != nil || len(details) != 1 || details[0].PID != "ficus" {
+			t.Errorf("GetPlantDetailsAll() = %v, %v", details, err)
+		}
+	})
+}
+
+func TestSplitRetryable(t *testing.T) {
+	errs := []error{
+		fmt.Errorf("get plant details: %w", ErrNotFound),
+		nil,
+		&APIError{StatusCode: http.StatusBadGateway},
+		ErrRateLimitExceeded,
+	}
+	retry, other := SplitRetryable(errs)
+	if len(retry) != 2 || len(other) != 1 || !errors.Is(other[0], ErrNotFound) {
+		t.Errorf("SplitRetryable() = %v, %v", retry, other)
+	}
+}
@@ -0,0 +1,72 @@
+package openplantbook
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// API paths, in the form the API serves them without a redirect
+// The details path ends with a slash, as Django's APPEND_SLASH expects.
+const (
+	searchPath  = "/plant/search"
+	detailsPath = "/plant/detail/"
+)
+
+// detailPath returns the details path of pid
+// The PID is escaped as a single path segment, so spaces, non-ASCII letters
+// and reserved characters such as '/', '?', '#' and '%' reach the API as part
+// of the PID.
+func detailPath(pid string) string {
+	return detailsPath + url.PathEscape(pid) + "/"
+}
+
+// slashRedirects remembers, per endpoint class, whether the server
+// redirected a request to add or remove the trailing slash of its path
+//
+// The HTTP client follows such a redirect, but it costs a second request
+// against the quota; once one is seen, later requests use the path the
+// server redirected to.
+type slashRedirects struct {
+	mu    sync.RWMutex
+	slash map[EndpointClass]bool // true: add a trailing slash, false: remove it
+}
+
+// apply returns path with the trailing slash the server asked for
+func (s *slashRedirects) apply(path string) string {
+	s.mu.RLock()
+	slash, ok := s.slash[endpointClassOf(path)]
+	s.mu.RUnlock()
+	switch {
+	case !ok:
+		return path
+	case slash && !strings.HasSuffix(path, "/"):
+		return path + "/"
+	case !slash:
+		return strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// observe records a redirect from the requested path to the final one,
+// if they differ only in the trailing slash
+func (s *slashRedirects) observe(requested, final string) {
+	var slash bool
+	switch final {
+	case requested + "/":
+		slash = true
+	case strings.TrimSuffix(requested, "/"):
+		if final == requested {
+			return
+		}
+	default:
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.slash == nil {
+		s.slash = make(map[EndpointClass]bool)
+	}
+	s.slash[endpointClassOf(requested)] = slash
+}
@@ -0,0 +1,206 @@
+package openplantbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// slashServer serves the API under /api/v1 either with trailing slashes,
+// redirecting paths without one (Django's APPEND_SLASH), or without them,
+// redirecting paths with one
+func slashServer(t *testing.T, slash bool) (*httptest.Server, *[]string) {
+	t.Helper()
+	searchData, err := os.ReadFile("testdata/search_response.json")
+	if err != nil {
+		t.Fatalf("failed to load test fixture: %v", err)
+	}
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to load test fixture: %v", err)
+	}
+
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		if hasSlash := strings.HasSuffix(r.URL.Path, "/"); hasSlash != slash {
+			target := strings.TrimSuffix(r.URL.Path, "/")
+			if slash {
+				target += "/"
+			}
+			u := *r.URL
+			u.Path = target
+			http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+			return
+		}
+		if strings.Contains(r.URL.Path, searchPath) {
+			w.Write(searchData)
+			return
+		}
+		w.Write(detailData)
+	}))
+	t.Cleanup(server.Close)
+	return server, &paths
+}
+
+func TestTrailingSlashRedirects(t *testing.T) {
+	tests := []struct {
+		name  string
+		slash bool
+		want  []string
+	}{
+		{
+			name:  "server appends slashes",
+			slash: true,
+			want: []string{
+				"/api/v1/plant/search", "/api/v1/plant/search/", // redirected once
+				"/api/v1/plant/search/",
+				"/api/v1/plant/detail/monstera/",
+				"/api/v1/plant/detail/ficus/",
+			},
+		},
+		{
+			name:  "server strips slashes",
+			slash: false,
+			want: []string{
+				"/api/v1/plant/search",
+				"/api/v1/plant/search",
+				"/api/v1/plant/detail/monstera/", "/api/v1/plant/detail/monstera", // redirected once
+				"/api/v1/plant/detail/ficus",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, paths := slashServer(t, tt.slash)
+			client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL+"/api/v1"), DisableRateLimit(), DisableCache())
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			ctx := context.Background()
+			for _, query := range []string{"monstera", "ficus"} {
+				if _, err := client.SearchPlants(ctx, query, nil); err != nil {
+					t.Fatalf("SearchPlants(%q) unexpected error: %v", query, err)
+				}
+			}
+			for _, pid := range []string{"monstera", "ficus"} {
+				if _, err := client.GetPlantDetails(ctx, pid, nil); err != nil {
+					t.Fatalf("GetPlantDetails(%q) unexpected error: %v", pid, err)
+				}
+			}
+
+			if strings.Join(*paths, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("requested paths:\n%s\nwant:\n%s", strings.Join(*paths, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestEndpointClassOf(t *testing.T) {
+	tests := []struct {
+		path string
+		want EndpointClass
+	}{
+		{"/plant/search", EndpointSearch},
+		{"/api/v1/plant/search/", EndpointSearch},
+		{"/api/v1/plant/detail/monstera/", EndpointDetails},
+		{"/api/v1/plant/detail/x/plant/search/", EndpointDetails},
+	}
+	for _, tt := range tests {
+		if got := endpointClassOf(tt.path); got != tt.want {
+			t.Errorf("endpointClassOf(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDetailPath_Escaping(t *testing.T) {
+	pids := []string{
+		"alocasia amazonica × sanderiana",
+		"cattleya 'chocolate drop'",
+		"aglaonema 'silver bay' / 'maria'",
+		"mystery?plant#1",
+		"100% cotton+wool & friends",
+		"ficus;benjamina",
+		"蘭",
+	}
+
+	var gotPath, gotEscaped atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath.Store(r.URL.Path)
+		gotEscaped.Store(r.URL.EscapedPath())
+		fmt.Fprintf(w, `{"pid":%q,"display_pid":"x"}`, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, detailsPath), "/"))
+	}))
+	defer server.Close()
+
+	// A trailing slash on the base URL must not double the path separator
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL+"/"), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for _, pid := range pids {
+		details, err := client.GetPlantDetails(context.Background(), pid, nil)
+		if err != nil {
+			t.Errorf("GetPlantDetails(%q) unexpected error: %v", pid, err)
+			continue
+		}
+		if want := detailsPath + pid + "/"; gotPath.Load() != want {
+			t.Errorf("GetPlantDetails(%q) requested path %q, want %q", pid, gotPath.Load(), want)
+		}
+		if want := detailsPath + url.PathEscape(pid) + "/"; gotEscaped.Load() != want {
+			t.Errorf("GetPlantDetails(%q) sent %q, want %q", pid, gotEscaped.Load(), want)
+		}
+		if details.PID != pid {
+			t.Errorf("GetPlantDetails(%q) PID = %q", pid, details.PID)
+		}
+	}
+}
+
+func TestSearchQuery_Escaping(t *testing.T) {
+	queries := []string{
+		"alocasia × sanderiana",
+		"rock & roll=yes",
+		"cattleya 'chocolate drop'",
+		"50% shade+sun #1?",
+		"fougère",
+	}
+
+	var got atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Store(r.URL.Query())
+		w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for _, query := range queries {
+		if _, err := client.SearchPlants(context.Background(), query, &SearchOptions{Limit: 5}); err != nil {
+			t.Errorf("SearchPlants(%q) unexpected error: %v", query, err)
+			continue
+		}
+		params := got.Load().(url.Values)
+		if params.Get("alias") != query || params.Get("limit") != "5" || len(params) != 2 {
+			t.Errorf("SearchPlants(%q) sent %v", query, params)
+		}
+	}
+}
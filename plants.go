@@ -1,176 +1,339 @@
 package openplantbook
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
-	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// detailsTTL is how long plant details are cached
+const detailsTTL = 24 * time.Hour
+
 // SearchPlants searches for plants by alias/common name
+// Nil opts use the client's defaults (see WithDefaultSearchOptions).
 func (c *Client) SearchPlants(ctx context.Context, query string, opts *SearchOptions) ([]PlantSearchResult, error) {
-	if query == "" {
-		return nil, ErrInvalidInput("query cannot be empty")
+	opts = c.searchOptions(opts)
+	query, mode, err := parseQuery(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	response, _, err := c.search(ctx, query, opts, false)
+	if err != nil {
+		return nil, err
+	}
+	return dedupeIfRequested(filterMatches(response.Results, query, mode), opts), nil
+}
+
+// SearchPlantsWithMeta is SearchPlants, also reporting whether the results
+// came from the cache, when they were fetched, and whether they are stale
+func (c *Client) SearchPlantsWithMeta(ctx context.Context, query string, opts *SearchOptions) (*SearchResult, error) {
+	opts = c.searchOptions(opts)
+	query, mode, err := parseQuery(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	response, meta, err := c.search(ctx, query, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	results := dedupeIfRequested(filterMatches(response.Results, query, mode), opts)
+	return &SearchResult{Results: results, ResultMeta: meta}, nil
+}
+
+// searchOptions returns opts, or the client's defaults when opts is nil
+func (c *Client) searchOptions(opts *SearchOptions) *SearchOptions {
+	if opts == nil {
+		return c.defaultSearch
+	}
+	return opts
+}
+
+// search performs a plant search and returns the full paginated response
+func (c *Client) search(ctx context.Context, query string, opts *SearchOptions, withMeta bool) (*searchResponse, ResultMeta, error) {
+	if normalizeQuery(query) == "" {
+		return nil, ResultMeta{}, ErrInvalidInput("query cannot be empty")
+	}
+
+	var cacheKey string
+	if c.cacheEnabled() {
+		cacheKey = c.searchKey(query, opts)
+	}
+	if cacheKey == "" {
+		response, _, err := c.fetchSearch(ctx, query, opts)
+		return response, ResultMeta{FetchedAt: c.clock.Now()}, err
 	}
 
 	// Check cache first
-	cacheKey := fmt.Sprintf("search:%s:%v", query, opts)
-	if cached, ok := c.cache.Get(cacheKey); ok {
-		var results []PlantSearchResult
-		if err := json.Unmarshal(cached, &results); err == nil {
-			c.log("cache hit for search", "query", query)
-			return results, nil
+	var cached searchResponse
+	data, meta, ok := c.cacheGet(cacheKey, withMeta || c.hooks.OnCacheHit != nil)
+	if ok && c.serializer.Unmarshal(data, &cached) != nil {
+		ok = false
+	}
+	if ok && meta.fresh(c.clock.Now()) {
+		c.cacheHits.Add(1)
+		c.log("cache hit for search", "query", query)
+		c.hooks.cacheHit(CacheHitInfo{Key: cacheKey, Endpoint: EndpointSearch, FetchedAt: meta.FetchedAt})
+		c.indexResults(cached.Results, opts)
+		return &cached, ResultMeta{FromCache: true, FetchedAt: meta.FetchedAt}, nil
+	}
+
+	c.cacheMisses.Add(1)
+	response, raw, err := c.fetchSearch(ctx, query, opts)
+	if err != nil {
+		setDryRunCacheKey(err, cacheKey)
+		if ok && c.serveStale(err, meta) {
+			c.log("serving stale search results", "query", query, "error", err)
+			c.hooks.cacheHit(CacheHitInfo{Key: cacheKey, Endpoint: EndpointSearch, FetchedAt: meta.FetchedAt, Stale: true})
+			c.indexResults(cached.Results, opts)
+			return &cached, ResultMeta{FromCache: true, FetchedAt: meta.FetchedAt, Stale: true}, nil
 		}
+		return nil, ResultMeta{}, err
 	}
 
-	// Handle rate limiting based on configured behavior
-	if c.rateLimiter != nil {
-		if c.rateLimitBehavior == RateLimitError {
-			// Check if we can proceed without waiting
-			reservation := c.rateLimiter.Reserve()
-			if !reservation.OK() {
-				return nil, &ErrRateLimited{
-					RetryAfter: time.Now().Add(24 * time.Hour),
-					Message:    "rate limiter exhausted",
-				}
-			}
+	// Cache results (1 hour TTL)
+	fetchedAt := c.cacheSet(cacheKey, raw, response, 1*time.Hour)
 
-			delay := reservation.Delay()
-			if delay > 0 {
-				// Cancel the reservation and return error
-				reservation.Cancel()
-				return nil, &ErrRateLimited{
-					RetryAfter: time.Now().Add(delay),
-					Message:    "rate limit exceeded, please retry later",
-				}
-			}
-			// If delay is 0, reservation is consumed and we can proceed
-		} else {
-			// Default behavior: wait for rate limiter
-			if err := c.rateLimiter.Wait(ctx); err != nil {
-				return nil, fmt.Errorf("rate limit wait: %w", err)
-			}
-		}
+	return response, ResultMeta{FetchedAt: fetchedAt}, nil
+}
+
+// fetchSearch queries the search endpoint, bypassing the cache
+func (c *Client) fetchSearch(ctx context.Context, query string, opts *SearchOptions) (*searchResponse, []byte, error) {
+	// Handle rate limiting based on configured behavior
+	if err := c.waitRateLimit(ctx, EndpointSearch); err != nil {
+		return nil, nil, err
 	}
 
 	// Build request
-	req, err := c.newRequest(ctx, "GET", "/plant/search", nil)
+	req, err := c.newRequest(ctx, "GET", searchPath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 
-	// Add query parameters
+	// Add query parameters; the cache key is built from the same ones
 	q := req.URL.Query()
-	q.Set("alias", query)
-
-	if opts != nil {
-		if opts.Limit > 0 {
-			q.Set("limit", strconv.Itoa(opts.Limit))
-		}
-		if opts.UserPlants {
-			q.Set("userplant", "user")
-		}
+	for name, value := range searchParams(query, opts) {
+		q.Set(name, value)
 	}
 	req.URL.RawQuery = q.Encode()
 
 	// Execute request
 	var response searchResponse
-	if err := c.doRequest(ctx, req, &response); err != nil {
-		return nil, fmt.Errorf("search plants: %w", err)
+	raw, err := c.doRequestRaw(ctx, req, &response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("search plants: %w", err)
 	}
 
 	c.log("search completed", "query", query, "results", len(response.Results))
+	c.indexResults(response.Results, opts)
 
-	// Cache results (1 hour TTL)
-	if data, err := json.Marshal(response.Results); err == nil {
-		c.cache.Set(cacheKey, data, 1*time.Hour)
-	}
-
-	return response.Results, nil
+	return &response, raw, nil
 }
 
 // GetPlantDetails retrieves detailed plant care information
 func (c *Client) GetPlantDetails(ctx context.Context, pid string, opts *DetailOptions) (*PlantDetails, error) {
-	if pid == "" {
-		return nil, ErrInvalidInput("pid cannot be empty")
+	details, _, err := c.details(ctx, pid, opts, false)
+	return details, err
+}
+
+// GetPlantDetailsWithMeta is GetPlantDetails, also reporting whether the
+// details came from the cache, when they were fetched, and whether they are
+// stale
+//
+// Use FetchedAt to show how current the data is, e.g. "as of 3 days ago".
+func (c *Client) GetPlantDetailsWithMeta(ctx context.Context, pid string, opts *DetailOptions) (*DetailsResult, error) {
+	details, meta, err := c.details(ctx, pid, opts, true)
+	if err != nil {
+		return nil, err
 	}
+	return &DetailsResult{Details: details, ResultMeta: meta}, nil
+}
 
-	// Check cache first
-	cacheKey := fmt.Sprintf("detail:%s:%v", pid, opts)
-	if cached, ok := c.cache.Get(cacheKey); ok {
-		var details PlantDetails
-		if err := json.Unmarshal(cached, &details); err == nil {
-			c.log("cache hit for details", "pid", pid)
-			return &details, nil
-		}
+// details retrieves plant details along with their provenance
+func (c *Client) details(ctx context.Context, pid string, opts *DetailOptions, withMeta bool) (*PlantDetails, ResultMeta, error) {
+	if strings.TrimSpace(pid) == "" {
+		return nil, ResultMeta{}, ErrInvalidInput("pid cannot be empty")
+	}
+	if chain := opts.languageChain(); len(chain) > 1 {
+		return c.detailsInLanguages(ctx, pid, opts, chain, withMeta)
 	}
 
-	// Handle rate limiting based on configured behavior
-	if c.rateLimiter != nil {
-		if c.rateLimitBehavior == RateLimitError {
-			// Check if we can proceed without waiting
-			reservation := c.rateLimiter.Reserve()
-			if !reservation.OK() {
-				return nil, &ErrRateLimited{
-					RetryAfter: time.Now().Add(24 * time.Hour),
-					Message:    "rate limiter exhausted",
+	// Check cache first
+	useCache := c.cacheEnabled()
+	var (
+		cacheKey  string
+		cached    PlantDetails
+		meta      cacheMeta
+		haveStale bool
+	)
+	if useCache {
+		cacheKey = c.detailKey(pid, opts)
+		useCache = cacheKey != ""
+	}
+	if useCache {
+		var (
+			data []byte
+			ok   bool
+		)
+		if data, meta, ok = c.cacheGet(cacheKey, withMeta || c.hooks.OnCacheHit != nil || c.expiries != nil); ok {
+			if err := c.serializer.Unmarshal(data, &cached); err == nil {
+				cached.Language = detailLanguage(opts)
+				c.checkImageURL(&cached)
+				if meta.fresh(c.clock.Now()) {
+					c.cacheHits.Add(1)
+					c.log("cache hit for details", "pid", pid)
+					c.hooks.cacheHit(CacheHitInfo{Key: cacheKey, Endpoint: EndpointDetails, FetchedAt: meta.FetchedAt})
+					c.indexDetails(&cached)
+					c.watchExpiry(cacheKey, pid, opts, meta.ExpiresAt)
+					return &cached, ResultMeta{FromCache: true, FetchedAt: meta.FetchedAt}, nil
 				}
+				haveStale = true
 			}
+		}
+		c.cacheMisses.Add(1)
+	}
 
-			delay := reservation.Delay()
-			if delay > 0 {
-				// Cancel the reservation and return error
-				reservation.Cancel()
-				return nil, &ErrRateLimited{
-					RetryAfter: time.Now().Add(delay),
-					Message:    "rate limit exceeded, please retry later",
-				}
-			}
-			// If delay is 0, reservation is consumed and we can proceed
-		} else {
-			// Default behavior: wait for rate limiter
-			if err := c.rateLimiter.Wait(ctx); err != nil {
-				return nil, fmt.Errorf("rate limit wait: %w", err)
-			}
+	details, raw, err := c.fetchDetails(ctx, pid, opts)
+	if err != nil {
+		setDryRunCacheKey(err, cacheKey)
+		if haveStale && c.serveStale(err, meta) {
+			c.log("serving stale details", "pid", pid, "error", err)
+			c.hooks.cacheHit(CacheHitInfo{Key: cacheKey, Endpoint: EndpointDetails, FetchedAt: meta.FetchedAt, Stale: true})
+			c.indexDetails(&cached)
+			return &cached, ResultMeta{FromCache: true, FetchedAt: meta.FetchedAt, Stale: true}, nil
+		}
+		if fb, ok := c.serveFallback(err, pid, opts); ok {
+			c.log("serving fallback details", "pid", pid, "error", err)
+			c.indexDetails(fb)
+			return fb, ResultMeta{Fallback: true}, nil
 		}
+		return nil, ResultMeta{}, err
 	}
 
-	// Build request
-	path := fmt.Sprintf("/plant/detail/%s", pid)
+	// Cache results (24 hours TTL)
+	fetchedAt := c.clock.Now()
+	if useCache {
+		fetchedAt = c.cacheSet(cacheKey, raw, details, detailsTTL)
+		c.watchExpiry(cacheKey, pid, opts, fetchedAt.Add(detailsTTL))
+	}
+	if haveStale && cached != *details {
+		if c.onUpdate != nil {
+			c.onUpdate(pid, &cached, details)
+		}
+		if c.onInvalidate != nil {
+			c.onInvalidate(Invalidation{
+				Reason: InvalidationChanged, PID: pid, Language: detailLanguage(opts), Key: cacheKey,
+				Old: &cached, New: details,
+			})
+		}
+	}
+
+	return details, ResultMeta{FetchedAt: fetchedAt}, nil
+}
+
+// fetchDetails queries the detail endpoint, bypassing the cache
+func (c *Client) fetchDetails(ctx context.Context, pid string, opts *DetailOptions) (*PlantDetails, []byte, error) {
+	// Handle rate limiting based on configured behavior
+	if err := c.waitRateLimit(ctx, EndpointDetails); err != nil {
+		return nil, nil, err
+	}
+
+	// Build request from the caller's parameters; the cache key is built from
+	// their normalized form
+	params := detailParams(pid, opts)
+	path := detailPath(params["pid"])
 	req, err := c.newRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 
 	// Add query parameters
-	if opts != nil && opts.Language != "" {
-		q := req.URL.Query()
-		q.Set("lang", opts.Language)
-		req.URL.RawQuery = q.Encode()
+	q := req.URL.Query()
+	for _, name := range []string{"lang", "userplant"} {
+		if v := params[name]; v != "" {
+			q.Set(name, v)
+		}
 	}
+	req.URL.RawQuery = q.Encode()
 
 	// Execute request
 	var details PlantDetails
-	if err := c.doRequest(ctx, req, &details); err != nil {
-		return nil, fmt.Errorf("get plant details: %w", err)
+	raw, err := c.doRequestRaw(ctx, req, &details)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get plant details: %w", err)
+	}
+	if details.PID == "" {
+		return nil, nil, fmt.Errorf("get plant details: %w", c.malformed(path, 0, "", raw, errors.New("missing pid")))
 	}
+	details.Language = detailLanguage(opts)
+	c.checkImageURL(&details)
 
 	c.log("details retrieved", "pid", pid)
+	c.indexDetails(&details)
 
-	// Cache results (24 hours TTL)
-	if data, err := json.Marshal(details); err == nil {
-		c.cache.Set(cacheKey, data, 24*time.Hour)
+	return &details, raw, nil
+}
+
+// detailLanguage returns the normalized language requested by opts
+// It is the language of both the cache key and PlantDetails.Language, so a
+// cached record always reports the language it was fetched in.
+func detailLanguage(opts *DetailOptions) string {
+	return normalizeLanguage(requestLanguage(opts))
+}
+
+// requestLanguage returns the language requested by opts as the caller
+// wrote it, without surrounding whitespace
+func requestLanguage(opts *DetailOptions) string {
+	if opts == nil {
+		return ""
 	}
+	if opts.Language == "" && len(opts.Languages) > 0 {
+		return strings.TrimSpace(opts.Languages[0])
+	}
+	return strings.TrimSpace(opts.Language)
+}
 
-	return &details, nil
+// cacheEnabled reports whether responses are cached
+// With a NoOpCache, callers skip building cache keys and encoding entries.
+func (c *Client) cacheEnabled() bool {
+	_, noop := c.cache.(*NoOpCache)
+	return !noop
+}
+
+// cacheSet stores a decoded response using the configured serializer and
+// returns the fetch time recorded in its metadata
+// With the default JSON serializer the raw body is stored as received. With
+// WithStaleIfError or WithOnUpdate, the entry is kept past its TTL (see
+// retention), and the metadata records when it goes stale.
+func (c *Client) cacheSet(key string, raw []byte, v any, ttl time.Duration) time.Time {
+	now := c.clock.Now()
+
+	data := raw
+	if _, ok := c.serializer.(JSONSerializer); !ok {
+		var err error
+		if data, err = c.serializer.Marshal(v); err != nil {
+			c.log("cache encode failed", "key", key, "error", err)
+			return now
+		}
+	}
+
+	meta := cacheMeta{FetchedAt: now, ExpiresAt: now.Add(ttl)}
+	ttl += c.retention()
+	c.cache.Set(key, data, ttl)
+	c.cache.Set(metaKey(key), meta.encode(), ttl)
+	return now
 }
 
 // newRequest creates a new HTTP request with the base URL
 func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
-	url := c.baseURL + path
+	url := c.baseURL + c.redirects.apply(path)
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
@@ -189,21 +352,196 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 
 // doRequest executes an HTTP request and decodes the JSON response
 func (c *Client) doRequest(ctx context.Context, req *http.Request, result interface{}) error {
+	_, err := c.doRequestRaw(ctx, req, result)
+	return err
+}
+
+// doRequestRaw executes an HTTP request, decodes the JSON response and
+// returns the raw body
+// The body is only returned once it has decoded successfully, so callers
+// can cache it knowing it is valid.
+func (c *Client) doRequestRaw(ctx context.Context, req *http.Request, result interface{}) ([]byte, error) {
+	start := c.clock.Now()
+
+	if c.dryRun {
+		c.log("dry run: request not sent", "method", req.Method, "url", req.URL.String())
+		return nil, &ErrDryRun{Method: req.Method, URL: req.URL.String(), Endpoint: endpointClassOf(req.URL.Path)}
+	}
+
+	raw, err := c.send(ctx, req)
+	if err == nil {
+		if err = c.decodeJSON(raw, result); err != nil {
+			// The body is valid JSON (see exchange) but not of the expected shape
+			raw, err = nil, c.malformed(req.URL.Path, 0, "", raw, err)
+		}
+	}
+
+	if err != nil && ctx.Err() != nil {
+		// The caller's context ended; report that rather than the transport error
+		return nil, &ErrContextCanceled{Stage: StageHTTP, Elapsed: c.clock.Now().Sub(start), Err: ctx.Err()}
+	}
+	return raw, c.redactor.Error(err)
+}
+
+// send performs the HTTP exchange for req, hedged if enabled
+// When the credentials are rejected, it re-authenticates (see WithReauth)
+// and sends req once more.
+func (c *Client) send(ctx context.Context, req *http.Request) ([]byte, error) {
+	var gen uint64
+	if c.auth != nil {
+		gen = c.auth.generation()
+	}
+	raw, err := c.sendOnce(ctx, req)
+	if c.auth == nil || !credentialsRejected(err) || (req.Body != nil && req.GetBody == nil) {
+		return raw, err
+	}
+
+	if reauthErr := c.reauthenticate(ctx, gen); reauthErr != nil {
+		return nil, fmt.Errorf("%w (%v)", err, reauthErr)
+	}
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	// The retry is another request, paced and budgeted like the first
+	if waitErr := c.waitRateLimit(ctx, endpointClassOf(req.URL.Path)); waitErr != nil {
+		return nil, err
+	}
+	return c.sendOnce(ctx, retry)
+}
+
+// sendOnce sends req once, or twice if hedged
+func (c *Client) sendOnce(ctx context.Context, req *http.Request) ([]byte, error) {
+	if c.hedgeDelay > 0 && req.Method == http.MethodGet {
+		return c.hedgedFetch(ctx, req)
+	}
+	return c.fetch(req)
+}
+
+// fetch performs a single HTTP exchange and returns the response body
+func (c *Client) fetch(req *http.Request) ([]byte, error) {
+	now := c.clock.Now()
+	c.requests.add(now)
+	if c.sharedLimiter != nil {
+		c.sharedLimiter.requests.add(now)
+	}
+
+	info := RequestInfo{
+		Method:   req.Method,
+		URL:      c.redactor.String(req.URL.String()),
+		Endpoint: endpointClassOf(req.URL.Path),
+	}
+	c.hooks.request(info)
+
+	raw, status, err := c.exchange(req)
+	result := ResponseInfo{
+		RequestInfo: info,
+		StatusCode:  status,
+		Duration:    c.clock.Now().Sub(now),
+		Size:        len(raw),
+		Err:         c.redactor.Error(err),
+	}
+	c.hooks.response(result)
+	c.audit(now, result, req.URL.Path, req.URL.Query())
+	return raw, err
+}
+
+// exchange sends req and reads the response body, also returning the status
+// code (0 if no response was received)
+func (c *Client) exchange(req *http.Request) ([]byte, int, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("HTTP request failed: %w", err)
+		return nil, 0, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	c.redirects.observe(req.URL.Path, resp.Request.URL.Path)
 
 	// Check for HTTP errors
 	if resp.StatusCode >= 400 {
-		return newAPIError(resp, req.URL.Path)
+		err := newAPIError(resp, req.URL.Path)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			// Servers may echo credentials back in error bodies
+			apiErr.Message = c.redactor.String(apiErr.Message)
+			apiErr.Body = c.redactor.String(apiErr.Body)
+		}
+		return nil, resp.StatusCode, err
+	}
+
+	// Read the body into a pooled buffer, reading one byte past the limit to
+	// detect oversized bodies without a Content-Length
+	if resp.ContentLength > c.maxResponseSize {
+		return nil, resp.StatusCode, &ErrResponseTooLarge{Endpoint: req.URL.Path, Limit: c.maxResponseSize, Size: resp.ContentLength}
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if resp.ContentLength > 0 {
+		buf.Grow(int(resp.ContentLength))
+	}
+	if _, err := buf.ReadFrom(io.LimitReader(resp.Body, c.maxResponseSize+1)); err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("read response: %w", err)
+	}
+	if int64(buf.Len()) > c.maxResponseSize {
+		return nil, resp.StatusCode, &ErrResponseTooLarge{Endpoint: req.URL.Path, Limit: c.maxResponseSize}
 	}
 
-	// Decode JSON response
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("decode response: %w", err)
+	if err := c.checkBody(req.URL.Path, resp.StatusCode, resp.Header.Get("Content-Type"), buf.Bytes()); err != nil {
+		return nil, resp.StatusCode, err
 	}
 
+	// Copy out of the pooled buffer before it is reused
+	return bytes.Clone(buf.Bytes()), resp.StatusCode, nil
+}
+
+// checkBody rejects error pages served with a success status and bodies
+// that are not valid JSON, e.g. because they were cut short
+// Other content types are accepted as long as the body is JSON.
+func (c *Client) checkBody(endpoint string, status int, contentType string, body []byte) error {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "text/html" {
+		return c.malformed(endpoint, status, contentType, body, nil)
+	}
+	if !json.Valid(body) {
+		err := json.Unmarshal(body, new(json.RawMessage))
+		return c.malformed(endpoint, status, contentType, body, err)
+	}
 	return nil
 }
+
+// malformed creates an ErrMalformedResponse with a redacted snippet of body
+func (c *Client) malformed(endpoint string, status int, contentType string, body []byte, err error) error {
+	// Redaction may lengthen the snippet, so it is truncated again after
+	snippet := c.redactor.String(string(body[:min(len(body), malformedSnippetLen)]))
+	snippet = strings.ToValidUTF8(snippet[:min(len(snippet), malformedSnippetLen)], "")
+	return &ErrMalformedResponse{
+		Endpoint:    endpoint,
+		StatusCode:  status,
+		ContentType: contentType,
+		Snippet:     snippet,
+		Err:         err,
+	}
+}
+
+// maxPooledBuffer caps the size of buffers returned to the pool so one
+// unusually large response does not pin memory for the life of the process
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
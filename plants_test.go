@@ -1,14 +1,24 @@
 package openplantbook
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/time/rate"
+
+	"github.com/rmrfslashbin/openplantbook-go/clocktest"
 )
 
 // mockSearchHandler creates a test server handler for search tests
@@ -188,6 +198,48 @@ func TestClient_SearchPlants_Caching(t *testing.T) {
 	}
 }
 
+func TestWithDefaultSearchOptions(t *testing.T) {
+	var query atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query.Store(r.URL.RawQuery)
+		w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithDefaultSearchOptions(SearchOptions{Limit: 25, UserPlants: true}),
+		DisableCache(),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	if _, err := client.SearchPlants(ctx, "fern", nil); err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	if got := query.Load().(string); !strings.Contains(got, "limit=25") || !strings.Contains(got, "userplant=user") {
+		t.Errorf("nil options sent %q, want the defaults", got)
+	}
+
+	if _, err := client.SearchPlantsWithMeta(ctx, "fern", &SearchOptions{Limit: 5}); err != nil {
+		t.Fatalf("SearchPlantsWithMeta() unexpected error: %v", err)
+	}
+	if got := query.Load().(string); !strings.Contains(got, "limit=5") || strings.Contains(got, "userplant") {
+		t.Errorf("explicit options sent %q, want them alone", got)
+	}
+
+	for _, opts := range []SearchOptions{{Limit: -1}, {Match: MatchMode(7)}} {
+		if _, err := New(WithAPIKey("test-key"), WithDefaultSearchOptions(opts)); err == nil {
+			t.Errorf("WithDefaultSearchOptions(%+v): expected error", opts)
+		}
+	}
+}
+
 func TestClient_GetPlantDetails(t *testing.T) {
 	// Load test fixture
 	detailData, err := os.ReadFile("testdata/detail_response.json")
@@ -247,7 +299,7 @@ func TestClient_GetPlantDetails(t *testing.T) {
 				}
 
 				if tt.pid != "" {
-					expectedPath := "/plant/detail/" + tt.pid
+					expectedPath := "/plant/detail/" + tt.pid + "/"
 					if r.URL.Path != expectedPath {
 						t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
 					}
@@ -342,30 +394,24 @@ func TestClient_GetPlantDetails_Caching(t *testing.T) {
 }
 
 func TestClient_RateLimiting(t *testing.T) {
-	// Skip this test in short mode as it involves timing delays
-	if testing.Short() {
-		t.Skip("skipping rate limiting test in short mode")
-	}
-
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
 	}))
 	defer server.Close()
 
-	// Create client with custom rate limiter for faster testing
-	// We'll manually set a very restrictive rate for testing
+	clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
 	client, err := New(
 		WithAPIKey("test-key"),
 		WithBaseURL(server.URL),
 		WithCache(NewNoOpCache()), // Disable cache to test rate limiting
+		WithClock(clock),
 	)
 	if err != nil {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	// Manually override with very restrictive limiter for testing
-	// 1 request per 100ms
+	// Very restrictive limiter for testing: 1 request per 100ms
 	client.rateLimiter = rate.NewLimiter(rate.Every(100*time.Millisecond), 1)
 
 	// First request should succeed immediately
@@ -374,20 +420,30 @@ func TestClient_RateLimiting(t *testing.T) {
 		t.Fatalf("first request failed: %v", err)
 	}
 
-	// Second request should be delayed by ~100ms
-	start := time.Now()
-	_, err = client.SearchPlants(context.Background(), "test2", nil)
-	if err != nil {
-		t.Fatalf("second request failed: %v", err)
+	// Second request waits until the clock has moved on by 100ms
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.SearchPlants(context.Background(), "test2", nil)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(50 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("second request sent before the rate limit allowed it")
+	case <-time.After(10 * time.Millisecond):
 	}
-	elapsed := time.Since(start)
 
-	// Should have waited ~100ms
-	if elapsed < 50*time.Millisecond {
-		t.Errorf("expected rate limiting delay of ~100ms, got %v", elapsed)
+	clock.Advance(50 * time.Millisecond)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("second request failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second request still waiting after the rate limit interval")
 	}
-
-	t.Logf("rate limiting delay: %v", elapsed)
 }
 
 func TestClient_ContextCancellation(t *testing.T) {
@@ -418,3 +474,529 @@ func TestClient_ContextCancellation(t *testing.T) {
 		t.Error("expected context cancellation error, got nil")
 	}
 }
+
+// roundTripFunc serves canned responses without a network round trip
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newBenchClient returns a client whose transport always responds with body
+func newBenchClient(b *testing.B, body []byte, cache Cache) *Client {
+	b.Helper()
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	})
+
+	client, err := New(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithCache(cache),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		b.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func readTestdata(b *testing.B, name string) []byte {
+	b.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		b.Fatalf("failed to read %s: %v", name, err)
+	}
+	return data
+}
+
+func BenchmarkSearchPlants(b *testing.B) {
+	client := newBenchClient(b, readTestdata(b, "search_response.json"), NewNoOpCache())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.SearchPlants(ctx, "monstera", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchPlants_CacheMiss(b *testing.B) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	client := newBenchClient(b, readTestdata(b, "search_response.json"), cache)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		cache.Clear()
+		if _, err := client.SearchPlants(ctx, "monstera", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchPlants_CacheHit(b *testing.B) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	client := newBenchClient(b, readTestdata(b, "search_response.json"), cache)
+	ctx := context.Background()
+	if _, err := client.SearchPlants(ctx, "monstera", nil); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.SearchPlants(ctx, "monstera", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPlantDetails(b *testing.B) {
+	client := newBenchClient(b, readTestdata(b, "detail_response.json"), NewNoOpCache())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPlantDetails_CacheMiss(b *testing.B) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	client := newBenchClient(b, readTestdata(b, "detail_response.json"), cache)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		cache.Clear()
+		if _, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPlantDetails_CacheHit(b *testing.B) {
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	client := newBenchClient(b, readTestdata(b, "detail_response.json"), cache)
+	ctx := context.Background()
+	if _, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestClient_CachesRawResponse(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	want, err := client.GetPlantDetails(context.Background(), "monstera-deliciosa", nil)
+	if err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+
+	cached, ok := client.cache.Get(detailCacheKey("monstera-deliciosa", nil))
+	if !ok {
+		t.Fatal("response was not cached")
+	}
+	if !bytes.Equal(cached, detailData) {
+		t.Errorf("cached bytes differ from the response body:\n got: %s\nwant: %s", cached, detailData)
+	}
+
+	// The cached copy decodes to the same result
+	got, err := client.GetPlantDetails(context.Background(), "monstera-deliciosa", nil)
+	if err != nil {
+		t.Fatalf("cached GetPlantDetails() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cached details = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_InvalidResponseNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count": "not a number"`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.SearchPlants(context.Background(), "monstera", nil); err == nil {
+		t.Fatal("SearchPlants() expected decode error, got nil")
+	}
+	if _, ok := client.cache.Get(searchCacheKey("monstera", nil)); ok {
+		t.Error("invalid response was cached")
+	}
+}
+
+func TestClient_MalformedResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		path        string
+		wantErr     bool
+		wantSnippet string
+	}{
+		{"HTML error page", "text/html; charset=utf-8", "<html><body>Bad Gateway</body></html>", "/plant/detail/x/", true, "<html>"},
+		{"truncated JSON", "application/json", `{"pid":"x","display_pid":"X`, "/plant/detail/x/", true, `{"pid"`},
+		{"wrong shape", "application/json", `{"pid":42}`, "/plant/detail/x/", true, `{"pid":42}`},
+		{"missing pid", "application/json", `{}`, "/plant/detail/x/", true, `{}`},
+		{"JSON with wrong content type", "text/plain", `{"pid":"x"}`, "/plant/detail/x/", false, ""},
+		{"HTML search page", "text/html", "<!DOCTYPE html>", "/plant/search", true, "<!DOCTYPE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if tt.path == "/plant/search" {
+				_, err = client.SearchPlants(context.Background(), "x", nil)
+			} else {
+				_, err = client.GetPlantDetails(context.Background(), "x", nil)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var malformed *ErrMalformedResponse
+			if !errors.As(err, &malformed) {
+				t.Fatalf("error = %v, want ErrMalformedResponse", err)
+			}
+			if malformed.Endpoint != tt.path || !strings.HasPrefix(malformed.Snippet, tt.wantSnippet) {
+				t.Errorf("ErrMalformedResponse = %+v", malformed)
+			}
+		})
+	}
+}
+
+func TestWithMaxResponseSize(t *testing.T) {
+	body := `{"pid":"x","display_pid":"X","alias":"` + strings.Repeat("a", 100) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lang") == "chunked" {
+			// No Content-Length: the limit applies while reading
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		limit   int64
+		lang    string
+		wantErr bool
+	}{
+		{"under limit", int64(len(body)), "", false},
+		{"announced length over limit", int64(len(body) - 1), "", true},
+		{"streamed body over limit", int64(len(body) - 1), "chunked", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), WithMaxResponseSize(tt.limit),
+				DisableRateLimit(), DisableCache())
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.GetPlantDetails(context.Background(), "x", &DetailOptions{Language: tt.lang})
+			var tooLarge *ErrResponseTooLarge
+			if got := errors.As(err, &tooLarge); got != tt.wantErr {
+				t.Fatalf("error = %v, want ErrResponseTooLarge: %v", err, tt.wantErr)
+			}
+			if tt.wantErr && tooLarge.Limit != tt.limit {
+				t.Errorf("Limit = %d, want %d", tooLarge.Limit, tt.limit)
+			}
+		})
+	}
+
+	if _, err := New(WithAPIKey("test-key"), WithMaxResponseSize(0)); err == nil {
+		t.Error("New() with zero maximum response size expected error, got nil")
+	}
+}
+
+func FuzzCheckBody(f *testing.F) {
+	for _, seed := range []string{
+		`{"pid":"monstera","display_pid":"Monstera","max_temp":35}`,
+		`{"count":1,"next":null,"results":[{"pid":"x"}]}`,
+		`{"pid":"x"`,
+		"<html>502</html>",
+		"\xff\xfe{",
+		"null",
+	} {
+		f.Add([]byte(seed), "application/json")
+	}
+	f.Add([]byte("<html>"), "text/html")
+
+	client, err := New(WithAPIKey("test-key"))
+	if err != nil {
+		f.Fatalf("failed to create client: %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte, contentType string) {
+		err := client.checkBody("/plant/detail/x", http.StatusOK, contentType, body)
+		if err == nil {
+			// Accepted bodies must decode or fail with a plain decoding error
+			var details PlantDetails
+			_ = json.Unmarshal(body, &details)
+			var search searchResponse
+			_ = json.Unmarshal(body, &search)
+			return
+		}
+
+		var malformed *ErrMalformedResponse
+		if !errors.As(err, &malformed) {
+			t.Fatalf("checkBody() error = %v, want ErrMalformedResponse", err)
+		}
+		if len(malformed.Snippet) > malformedSnippetLen || !utf8.ValidString(malformed.Snippet) {
+			t.Errorf("Snippet = %q, want at most %d bytes of valid UTF-8", malformed.Snippet, malformedSnippetLen)
+		}
+		_ = malformed.Error()
+	})
+}
+
+// countingSerializer counts Marshal calls
+type countingSerializer struct {
+	GobSerializer
+	marshals int
+}
+
+func (s *countingSerializer) Marshal(v any) ([]byte, error) {
+	s.marshals++
+	return s.GobSerializer.Marshal(v)
+}
+
+func TestClient_DisableCache(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	serializer := &countingSerializer{}
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithSerializer(serializer),
+		DisableCache(),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPlantDetails(context.Background(), "monstera-deliciosa", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("API called %d times, want 2", calls)
+	}
+	if serializer.marshals != 0 {
+		t.Errorf("serializer.Marshal called %d times with caching disabled, want 0", serializer.marshals)
+	}
+}
+
+func TestClient_ResultMeta(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	searchData, err := os.ReadFile("testdata/search_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/plant/search" {
+			w.Write(searchData)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	t.Run("details", func(t *testing.T) {
+		before := time.Now()
+		first, err := client.GetPlantDetailsWithMeta(ctx, "monstera-deliciosa", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		if first.FromCache || first.Stale {
+			t.Errorf("first call meta = %+v, want fresh from API", first.ResultMeta)
+		}
+		if first.FetchedAt.Before(before) {
+			t.Errorf("FetchedAt = %v, want after %v", first.FetchedAt, before)
+		}
+
+		second, err := client.GetPlantDetailsWithMeta(ctx, "monstera-deliciosa", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		if !second.FromCache || second.Stale {
+			t.Errorf("second call meta = %+v, want fresh from cache", second.ResultMeta)
+		}
+		if !second.FetchedAt.Equal(first.FetchedAt) {
+			t.Errorf("cached FetchedAt = %v, want %v", second.FetchedAt, first.FetchedAt)
+		}
+		if second.Details.PID != first.Details.PID {
+			t.Errorf("cached PID = %q, want %q", second.Details.PID, first.Details.PID)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		first, err := client.SearchPlantsWithMeta(ctx, "monstera", nil)
+		if err != nil {
+			t.Fatalf("SearchPlantsWithMeta() unexpected error: %v", err)
+		}
+		second, err := client.SearchPlantsWithMeta(ctx, "monstera", nil)
+		if err != nil {
+			t.Fatalf("SearchPlantsWithMeta() unexpected error: %v", err)
+		}
+		if first.FromCache || !second.FromCache {
+			t.Errorf("FromCache = %v, %v; want false, true", first.FromCache, second.FromCache)
+		}
+		if !second.FetchedAt.Equal(first.FetchedAt) {
+			t.Errorf("cached FetchedAt = %v, want %v", second.FetchedAt, first.FetchedAt)
+		}
+		if len(second.Results) != len(first.Results) {
+			t.Errorf("cached results = %d, want %d", len(second.Results), len(first.Results))
+		}
+	})
+
+	t.Run("entry without metadata", func(t *testing.T) {
+		key := detailCacheKey("ficus", nil)
+		client.cache.Set(key, detailData, time.Hour)
+
+		got, err := client.GetPlantDetailsWithMeta(ctx, "ficus", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		if !got.FromCache || !got.FetchedAt.IsZero() {
+			t.Errorf("meta = %+v, want from cache with unknown FetchedAt", got.ResultMeta)
+		}
+	})
+}
+
+func TestWithDryRun(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	cache := NewInMemoryCache()
+	seed, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), WithCache(cache), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer seed.Close()
+	if _, err := seed.GetPlantDetails(context.Background(), "monstera", nil); err != nil {
+		t.Fatalf("seeding cache: %v", err)
+	}
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), WithCache(cache), WithDryRun(),
+		WithRateLimit(1), WithRateLimitBehavior(RateLimitError))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := WithBudget(context.Background(), 1)
+
+	// Cached data is still served
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Errorf("cached details unexpected error: %v", err)
+	}
+
+	// Misses are reported, repeatedly, without consuming tokens or budget
+	for range 2 {
+		var dryRun *ErrDryRun
+		_, err := client.GetPlantDetails(ctx, "ficus", &DetailOptions{Language: "de"})
+		if !errors.As(err, &dryRun) {
+			t.Fatalf("GetPlantDetails() error = %v, want ErrDryRun", err)
+		}
+		if dryRun.Method != "GET" || dryRun.URL != server.URL+"/plant/detail/ficus/?lang=de" {
+			t.Errorf("ErrDryRun request = %s %s", dryRun.Method, dryRun.URL)
+		}
+		if dryRun.Endpoint != EndpointDetails || dryRun.CacheKey != "detail?lang=de&pid=ficus" {
+			t.Errorf("ErrDryRun = %+v, want details endpoint and cache key", dryRun)
+		}
+	}
+	if remaining, _ := BudgetRemaining(ctx); remaining != 1 {
+		t.Errorf("BudgetRemaining() = %d, want 1", remaining)
+	}
+
+	// Input is still validated
+	var valErr *ValidationError
+	if _, err := client.SearchPlants(ctx, "", nil); !errors.As(err, &valErr) {
+		t.Errorf("SearchPlants(\"\") error = %v, want ValidationError", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("server received %d requests, want only the seeding one", calls)
+	}
+}
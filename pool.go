@@ -0,0 +1,134 @@
+package openplantbook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned by ClientPool.Client after the pool was closed
+var ErrPoolClosed = errors.New("client pool closed")
+
+// ClientPool manages one Client per set of credentials, for services whose
+// users bring their own OpenPlantbook keys
+//
+// Each tenant's client is created on first use with the pool's options and
+// the tenant's credentials. OpenPlantbook accounts quota per account, so
+// every client gets its own rate limiter, usage accounting and (unless the
+// options share one) in-memory cache. A ClientPool is safe for concurrent
+// use.
+type ClientPool struct {
+	opts []Option
+
+	mu      sync.Mutex
+	clients map[string]*Client
+	closed  bool
+}
+
+// NewClientPool creates a pool whose clients are configured with opts
+// opts must not set credentials; they come from the Credentials passed to
+// Client. Options holding state, such as WithSharedRateLimiter or WithCache,
+// are shared by every tenant; WithOwnedCache cannot be used, since the first
+// client closed would close the cache.
+func NewClientPool(opts ...Option) *ClientPool {
+	return &ClientPool{opts: opts, clients: make(map[string]*Client)}
+}
+
+// Client returns the client of the tenant with creds, creating it on first
+// use
+// creds holds either an APIKey or a ClientID and ClientSecret. Rotated
+// credentials get a new client; Remove the old one when it is no longer
+// needed.
+func (p *ClientPool) Client(creds Credentials) (*Client, error) {
+	key, err := poolKey(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	if client, ok := p.clients[key]; ok {
+		return client, nil
+	}
+
+	opts := append(p.opts[:len(p.opts):len(p.opts)], credentialOption(creds))
+	client, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = client
+	return client, nil
+}
+
+// Remove closes and forgets the client of the tenant with creds
+// Callers must have finished using it.
+func (p *ClientPool) Remove(creds Credentials) error {
+	key, err := poolKey(creds)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	client, ok := p.clients[key]
+	delete(p.clients, key)
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return client.Close()
+}
+
+// Len returns the number of clients in the pool
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// Close closes every client in the pool
+// Later calls to Client return ErrPoolClosed.
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	clients := p.clients
+	p.clients = make(map[string]*Client)
+	p.closed = true
+	p.mu.Unlock()
+
+	var errs []error
+	for _, client := range clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// poolKey identifies a tenant by a hash of its credentials, so secrets are
+// not kept as map keys
+func poolKey(creds Credentials) (string, error) {
+	var id string
+	switch {
+	case creds.APIKey != "" && (creds.ClientID != "" || creds.ClientSecret != ""):
+		return "", ErrMultipleAuthMethods
+	case creds.APIKey != "":
+		id = "api-key\x00" + creds.APIKey
+	case creds.ClientID != "" && creds.ClientSecret != "":
+		id = "oauth2\x00" + creds.ClientID + "\x00" + creds.ClientSecret
+	default:
+		return "", ErrNoAuthProvided
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// credentialOption returns the option authenticating with creds
+func credentialOption(creds Credentials) Option {
+	if creds.APIKey != "" {
+		return WithAPIKey(creds.APIKey)
+	}
+	return WithOAuth2(creds.ClientID, creds.ClientSecret)
+}
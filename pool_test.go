@@ -0,0 +1,83 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("Authorization")]++
+		mu.Unlock()
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer server.Close()
+
+	pool := NewClientPool(WithBaseURL(server.URL), DisableRateLimit())
+	defer pool.Close()
+
+	alice := Credentials{APIKey: "alice-key"}
+	bob := Credentials{APIKey: "bob-key"}
+
+	a1, err := pool.Client(alice)
+	if err != nil {
+		t.Fatalf("Client() unexpected error: %v", err)
+	}
+	a2, _ := pool.Client(alice)
+	b, _ := pool.Client(bob)
+	if a1 != a2 {
+		t.Error("same credentials returned different clients")
+	}
+	if a1 == b {
+		t.Error("different credentials shared a client")
+	}
+	if n := pool.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+
+	// Caches are per tenant, so each tenant's request reaches the API
+	ctx := context.Background()
+	for _, c := range []*Client{a1, a2, b} {
+		if _, err := c.GetPlantDetails(ctx, "test", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+	if seen["Token alice-key"] != 1 || seen["Token bob-key"] != 1 {
+		t.Errorf("requests per credential = %v, want one each", seen)
+	}
+	if used := b.Status().Quota.Used; used != 1 {
+		t.Errorf("bob's usage = %d, want 1 (quota is per tenant)", used)
+	}
+
+	if err := pool.Remove(alice); err != nil {
+		t.Fatalf("Remove() unexpected error: %v", err)
+	}
+	if n := pool.Len(); n != 1 {
+		t.Errorf("Len() after Remove = %d, want 1", n)
+	}
+}
+
+func TestClientPool_Errors(t *testing.T) {
+	pool := NewClientPool()
+
+	if _, err := pool.Client(Credentials{}); !errors.Is(err, ErrNoAuthProvided) {
+		t.Errorf("empty credentials: error = %v, want ErrNoAuthProvided", err)
+	}
+	if _, err := pool.Client(Credentials{APIKey: "key", ClientID: "id"}); !errors.Is(err, ErrMultipleAuthMethods) {
+		t.Errorf("mixed credentials: error = %v, want ErrMultipleAuthMethods", err)
+	}
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+	if _, err := pool.Client(Credentials{APIKey: "key"}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("after Close: error = %v, want ErrPoolClosed", err)
+	}
+}
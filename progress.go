@@ -0,0 +1,110 @@
+package openplantbook
+
+import (
+	"context"
+	"sync"
+)
+
+// Operations reported in ProgressEvent.Operation
+const (
+	ProgressDetailsBatch = "details batch" // GetPlantDetailsBatch
+	ProgressRecommend    = "recommend"     // RecommendPlants detail lookups
+)
+
+// Progress receives progress updates from operations that work through many
+// plants, such as GetPlantDetailsBatch
+//
+// Attach one to a context with WithProgress. Update is called once when an
+// operation starts and again as each item finishes; calls for one operation
+// are never concurrent. Update runs on the operation's goroutine, so it
+// should return quickly.
+type Progress interface {
+	Update(ProgressEvent)
+}
+
+// ProgressFunc adapts a function to Progress
+//
+// Example, forwarding events to a channel:
+//
+//	events := make(chan openplantbook.ProgressEvent, 16)
+//	ctx = openplantbook.WithProgress(ctx, openplantbook.ProgressFunc(func(e openplantbook.ProgressEvent) {
+//	    events <- e
+//	}))
+type ProgressFunc func(ProgressEvent)
+
+// Update calls f(e)
+func (f ProgressFunc) Update(e ProgressEvent) { f(e) }
+
+// ProgressEvent reports how far an operation has got
+type ProgressEvent struct {
+	// Operation names the operation, e.g. ProgressDetailsBatch
+	Operation string
+
+	// Total is the number of items the operation will process
+	Total int
+
+	// Completed counts finished items, including failures
+	Completed int
+
+	// Failed counts items that finished with an error
+	Failed int
+
+	// Item is the item that just finished and Err its error; both are
+	// empty in the event that starts an operation
+	Item string
+	Err  error
+}
+
+// Done reports whether every item has finished
+func (e ProgressEvent) Done() bool {
+	return e.Completed >= e.Total
+}
+
+// progressKey is the context key for Progress
+type progressKey struct{}
+
+// WithProgress returns a context whose operations report their progress to p
+//
+// Only the operations documented to report progress use it; single requests
+// do not.
+func WithProgress(ctx context.Context, p Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+// ProgressTracker counts the items of one operation, reporting each change
+// to the Progress of its context
+// A nil tracker, returned when the context has no Progress, ignores calls.
+type ProgressTracker struct {
+	mu    sync.Mutex
+	p     Progress
+	event ProgressEvent
+}
+
+// TrackProgress starts reporting the progress of an operation over total
+// items to the Progress of ctx, returning nil if ctx has none
+// Services can use it to report their own jobs alongside the library's.
+func TrackProgress(ctx context.Context, operation string, total int) *ProgressTracker {
+	p, _ := ctx.Value(progressKey{}).(Progress)
+	if p == nil {
+		return nil
+	}
+	t := &ProgressTracker{p: p, event: ProgressEvent{Operation: operation, Total: total}}
+	p.Update(t.event)
+	return t
+}
+
+// Finish records that item finished, failing with err if non-nil
+func (t *ProgressTracker) Finish(item string, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.event.Completed++
+	if err != nil {
+		t.event.Failed++
+	}
+	t.event.Item, t.event.Err = item, err
+	t.p.Update(t.event)
+}
@@ -0,0 +1,81 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTrackProgress(t *testing.T) {
+	if tr := TrackProgress(context.Background(), "job", 2); tr != nil {
+		t.Error("TrackProgress() without a Progress returned a tracker")
+	}
+	var nilTracker *ProgressTracker
+	nilTracker.Finish("a", nil) // must not panic
+
+	var events []ProgressEvent
+	ctx := WithProgress(context.Background(), ProgressFunc(func(e ProgressEvent) {
+		events = append(events, e)
+	}))
+
+	tr := TrackProgress(ctx, "job", 2)
+	tr.Finish("a", nil)
+	tr.Finish("b", errors.New("boom"))
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	if e := events[0]; e.Operation != "job" || e.Total != 2 || e.Completed != 0 || e.Item != "" {
+		t.Errorf("start event = %+v", e)
+	}
+	last := events[2]
+	if last.Completed != 2 || last.Failed != 1 || last.Item != "b" || last.Err == nil {
+		t.Errorf("last event = %+v, want 2 completed, 1 failed on b", last)
+	}
+	if events[1].Done() || !last.Done() {
+		t.Error("Done() reported before every item finished")
+	}
+}
+
+func TestClient_GetPlantDetailsBatch_Progress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"pid": "x"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit(), WithBatchConcurrency(2))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var events []ProgressEvent
+	ctx := WithProgress(context.Background(), ProgressFunc(func(e ProgressEvent) {
+		events = append(events, e) // never called concurrently
+	}))
+
+	pids := []string{"a", "missing", "b", "c"}
+	err = client.GetPlantDetailsBatch(ctx, pids, nil, func(BatchDetailsResult) error { return nil })
+	if err != nil {
+		t.Fatalf("GetPlantDetailsBatch() unexpected error: %v", err)
+	}
+
+	if len(events) != len(pids)+1 {
+		t.Fatalf("got %d events, want %d", len(events), len(pids)+1)
+	}
+	for i, e := range events {
+		if e.Operation != ProgressDetailsBatch || e.Total != len(pids) || e.Completed != i {
+			t.Errorf("event %d = %+v", i, e)
+		}
+	}
+	if last := events[len(events)-1]; last.Failed != 1 || !last.Done() {
+		t.Errorf("last event = %+v, want done with 1 failure", last)
+	}
+}
@@ -0,0 +1,24 @@
+package openplantbook
+
+// Provenance describes where a plant record comes from
+// The API reports no contributor or edit history, so it is derived from
+// whether the record is a user-contributed plant.
+type Provenance struct {
+	// UserContributed is true for user-contributed plants, whose data is
+	// unverified, and false for records of the verified database
+	UserContributed bool
+}
+
+// Provenance returns where the record comes from
+func (d *PlantDetails) Provenance() Provenance {
+	return Provenance{UserContributed: d.UserPlant}
+}
+
+// String describes the provenance for display: "community data" or
+// "verified data"
+func (p Provenance) String() string {
+	if p.UserContributed {
+		return "community data"
+	}
+	return "verified data"
+}
@@ -0,0 +1,19 @@
+package openplantbook
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPlantDetails_Provenance(t *testing.T) {
+	var d PlantDetails
+	if err := json.Unmarshal([]byte(`{"pid":"ficus lyrata","user_plant":true}`), &d); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if got := d.Provenance(); !got.UserContributed || got.String() != "community data" {
+		t.Errorf("Provenance() = %q, want community data", got)
+	}
+	if got := (&PlantDetails{}).Provenance().String(); got != "verified data" {
+		t.Errorf("Provenance() = %q, want verified data", got)
+	}
+}
@@ -0,0 +1,95 @@
+package openplantbook
+
+import "fmt"
+
+// Quality score weights; they add up to 100
+const (
+	qualityRangePresent   = 10 // per threshold range with data
+	qualityRangePlausible = 5  // per range that is ordered and within bounds
+	qualityImage          = 10
+	qualityName           = 5 // each of display_pid, alias and category
+)
+
+// Quality is the result of PlantDetails.QualityScore
+type Quality struct {
+	// Score ranges from 0 (empty record) to 100 (complete and plausible)
+	Score int `json:"score"`
+
+	// Issues lists what lowered the score
+	Issues []QualityIssue `json:"issues,omitempty"`
+}
+
+// QualityIssue is a missing or implausible field of a plant record
+type QualityIssue struct {
+	Field   string `json:"field"`
+	Problem string `json:"problem"`
+}
+
+// String describes the issue
+func (i QualityIssue) String() string {
+	return i.Field + ": " + i.Problem
+}
+
+// qualityRange is a threshold pair and the values it can plausibly take
+type qualityRange struct {
+	field    string
+	min, max float64
+	lo, hi   float64
+}
+
+// QualityScore rates how complete and plausible the record is
+//
+// Each of the five threshold ranges (light, temperature, humidity, soil
+// moisture, soil EC) counts when it has data, and counts fully when its
+// minimum does not exceed its maximum and both lie within physically
+// plausible bounds. An image, scientific name, common name and category
+// make up the rest. Applications can prefer higher-scoring records and flag
+// low-scoring ones for review or upstream contribution.
+func (d *PlantDetails) QualityScore() Quality {
+	var q Quality
+	issue := func(field, format string, args ...any) {
+		q.Issues = append(q.Issues, QualityIssue{Field: field, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	ranges := []qualityRange{
+		{"light_lux", float64(d.MinLightLux), float64(d.MaxLightLux), 0, 200000},
+		{"temp", d.MinTemp, d.MaxTemp, -50, 60},
+		{"env_humid", float64(d.MinEnvHumid), float64(d.MaxEnvHumid), 0, 100},
+		{"soil_moist", float64(d.MinSoilMoist), float64(d.MaxSoilMoist), 0, 100},
+		{"soil_ec", float64(d.MinSoilEC), float64(d.MaxSoilEC), 0, 10000},
+	}
+	for _, r := range ranges {
+		switch {
+		case r.min == 0 && r.max == 0:
+			issue(r.field, "missing")
+			continue
+		case r.min > r.max:
+			issue(r.field, "minimum %g exceeds maximum %g", r.min, r.max)
+		case r.min < r.lo || r.max > r.hi:
+			issue(r.field, "%g-%g is outside the plausible range %g-%g", r.min, r.max, r.lo, r.hi)
+		default:
+			q.Score += qualityRangePlausible
+		}
+		q.Score += qualityRangePresent
+	}
+
+	if d.ImageURL != "" {
+		q.Score += qualityImage
+	} else {
+		issue("image_url", "missing")
+	}
+
+	for _, f := range []struct{ field, value string }{
+		{"display_pid", d.DisplayPID},
+		{"alias", d.Alias},
+		{"category", d.Category},
+	} {
+		if f.value != "" {
+			q.Score += qualityName
+		} else {
+			issue(f.field, "missing")
+		}
+	}
+
+	return q
+}
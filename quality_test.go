@@ -0,0 +1,61 @@
+package openplantbook
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestPlantDetails_QualityScore(t *testing.T) {
+	data, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	var complete PlantDetails
+	if err := json.Unmarshal(data, &complete); err != nil {
+		t.Fatalf("failed to decode test data: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(d *PlantDetails)
+		wantScore  int
+		wantIssues []string
+	}{
+		{"complete record", func(d *PlantDetails) {}, 100, nil},
+		{"missing EC and image", func(d *PlantDetails) {
+			d.MinSoilEC, d.MaxSoilEC = 0, 0
+			d.ImageURL = ""
+		}, 75, []string{"soil_ec: missing", "image_url: missing"}},
+		{"inverted humidity", func(d *PlantDetails) {
+			d.MinEnvHumid, d.MaxEnvHumid = 80, 40
+		}, 95, []string{"env_humid: minimum 80 exceeds maximum 40"}},
+		{"implausible temperature", func(d *PlantDetails) {
+			d.MaxTemp = 300
+		}, 95, []string{"temp: 15-300 is outside the plausible range -50-60"}},
+		{"empty record", func(d *PlantDetails) { *d = PlantDetails{PID: "x"} }, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := complete
+			tt.modify(&d)
+
+			q := d.QualityScore()
+			if q.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d (issues: %v)", q.Score, tt.wantScore, q.Issues)
+			}
+			if tt.wantIssues == nil {
+				return
+			}
+			if len(q.Issues) != len(tt.wantIssues) {
+				t.Fatalf("Issues = %v, want %v", q.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := q.Issues[i].String(); got != want {
+					t.Errorf("issue %d = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
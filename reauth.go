@@ -0,0 +1,149 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// errCredentialsRejected marks 401 responses, the only failures that
+// re-authentication can fix (403 means the credentials lack access)
+var errCredentialsRejected = errors.New("authentication failed")
+
+// Credentials are replacement credentials returned by a ReauthFunc
+// Set the fields of the client's authentication method: APIKey for
+// WithAPIKey, ClientSecret (and ClientID, if it changed too) for WithOAuth2.
+// Empty fields keep their current value.
+type Credentials struct {
+	APIKey       string
+	ClientID     string
+	ClientSecret string
+}
+
+// ReauthFunc returns fresh credentials after the API rejected the client's
+// current ones (see WithReauth)
+type ReauthFunc func(ctx context.Context) (Credentials, error)
+
+// authState holds the credentials in use, which re-authentication replaces
+// It is nil when the client was given its own HTTP client.
+type authState struct {
+	mu  sync.Mutex
+	gen uint64 // incremented by every re-authentication
+
+	apiKey *apiKeyTransport          // API key authentication
+	tokens *tokenCache               // OAuth2 and token source authentication
+	config *clientcredentials.Config // OAuth2 client credentials
+	ctx    context.Context           // carries the token endpoint's HTTP client
+}
+
+// generation identifies the credentials a request is sent with
+func (a *authState) generation() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.gen
+}
+
+// tokenCache reuses tokens until they expire or are dropped by reset
+type tokenCache struct {
+	mu     sync.Mutex
+	source func() oauth2.TokenSource
+	ts     oauth2.TokenSource
+}
+
+// Token implements oauth2.TokenSource
+func (t *tokenCache) Token() (*oauth2.Token, error) {
+	t.mu.Lock()
+	if t.ts == nil {
+		t.ts = oauth2.ReuseTokenSource(nil, t.source())
+	}
+	ts := t.ts
+	t.mu.Unlock()
+	return ts.Token()
+}
+
+// reset drops the cached token, so the next request fetches a new one
+// from source, or from the current source if source is nil.
+func (t *tokenCache) reset(source func() oauth2.TokenSource) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if source != nil {
+		t.source = source
+	}
+	t.ts = nil
+}
+
+// clientCredentialsSource returns a function fetching tokens with config
+func clientCredentialsSource(ctx context.Context, config *clientcredentials.Config) func() oauth2.TokenSource {
+	return func() oauth2.TokenSource { return config.TokenSource(ctx) }
+}
+
+// credentialsRejected reports whether err means the API or the OAuth2 token
+// endpoint turned the client's credentials down
+func credentialsRejected(err error) bool {
+	if errors.Is(err, errCredentialsRejected) {
+		return true
+	}
+	var retrieve *oauth2.RetrieveError
+	if errors.As(err, &retrieve) {
+		return retrieve.ErrorCode == "invalid_client" ||
+			(retrieve.Response != nil && retrieve.Response.StatusCode == http.StatusUnauthorized)
+	}
+	return false
+}
+
+// reauthenticate replaces the credentials that were in use at generation
+// gen: with the WithReauth hook's credentials if one is set, otherwise by
+// dropping cached OAuth2 tokens
+// Concurrent requests rejected with the same credentials share one
+// re-authentication.
+func (c *Client) reauthenticate(ctx context.Context, gen uint64) error {
+	a := c.auth
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.gen != gen {
+		return nil // another request already replaced them
+	}
+
+	var creds Credentials
+	if c.reauth != nil {
+		var err error
+		if creds, err = c.reauth(ctx); err != nil {
+			return fmt.Errorf("re-authentication failed: %w", err)
+		}
+	}
+
+	switch {
+	case a.apiKey != nil:
+		if creds.APIKey != "" {
+			a.apiKey.setKey(creds.APIKey)
+		}
+	case a.config != nil:
+		if creds.ClientID != "" || creds.ClientSecret != "" {
+			config := *a.config
+			if creds.ClientID != "" {
+				config.ClientID = creds.ClientID
+			}
+			if creds.ClientSecret != "" {
+				config.ClientSecret = creds.ClientSecret
+			}
+			a.config = &config
+		}
+		a.tokens.reset(clientCredentialsSource(a.ctx, a.config))
+	case a.tokens != nil:
+		a.tokens.reset(nil)
+	}
+	clientID := creds.ClientID
+	if a.config != nil {
+		clientID = a.config.ClientID
+	}
+	c.redactor.addCredentials(creds.APIKey, clientID, creds.ClientSecret)
+
+	a.gen++
+	c.log("credentials rejected; re-authenticated", "generation", a.gen)
+	return nil
+}
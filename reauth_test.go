@@ -0,0 +1,221 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// keyServer accepts only requests authenticated with apiKey, counting all
+func keyServer(apiKey string, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Token "+apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+}
+
+func TestWithReauth_RotatesAPIKey(t *testing.T) {
+	var requests, calls atomic.Int32
+	server := keyServer("new-key-5678", &requests)
+	defer server.Close()
+
+	client, err := New(
+		WithAPIKey("old-key-1234"),
+		WithBaseURL(server.URL),
+		WithReauth(func(ctx context.Context) (Credentials, error) {
+			calls.Add(1)
+			return Credentials{APIKey: "new-key-5678"}, nil
+		}),
+		DisableRateLimit(),
+		DisableCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPlantDetails(context.Background(), "test", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("reauth calls = %d, want 1", n)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("requests = %d, want 3 (rejected, retried, reused key)", n)
+	}
+}
+
+func TestReauth_SingleRetry(t *testing.T) {
+	var requests atomic.Int32
+	server := keyServer("other-key-0000", &requests)
+	defer server.Close()
+
+	client, err := New(WithAPIKey("bad-key-1234"), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.GetPlantDetails(context.Background(), "test", nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("error = %v, want ErrUnauthorized", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("requests = %d, want 2 (one retry)", n)
+	}
+}
+
+func TestReauth_ForbiddenNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("key-1234"), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.GetPlantDetails(context.Background(), "test", nil); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("error = %v, want ErrUnauthorized", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+}
+
+func TestReauth_HookError(t *testing.T) {
+	var requests atomic.Int32
+	server := keyServer("other-key-0000", &requests)
+	defer server.Close()
+
+	client, err := New(
+		WithAPIKey("bad-key-1234"),
+		WithBaseURL(server.URL),
+		WithReauth(func(ctx context.Context) (Credentials, error) {
+			return Credentials{}, errors.New("vault sealed")
+		}),
+		DisableRateLimit(),
+		DisableCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.GetPlantDetails(context.Background(), "test", nil)
+	if !errors.Is(err, ErrUnauthorized) || !strings.Contains(err.Error(), "vault sealed") {
+		t.Errorf("error = %v, want ErrUnauthorized mentioning the hook error", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1 (no retry without new credentials)", n)
+	}
+}
+
+func TestReauth_RevokedOAuth2Token(t *testing.T) {
+	var tokens atomic.Int32
+	var revoked atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token/" {
+			n := tokens.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, n)
+			return
+		}
+		if r.Header.Get("Authorization") == "Bearer token-1" && revoked.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithOAuth2("id", "secret"), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	revoked.Store(true)
+	if _, err := client.GetPlantDetails(ctx, "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() after revocation unexpected error: %v", err)
+	}
+	if n := tokens.Load(); n != 2 {
+		t.Errorf("token requests = %d, want 2", n)
+	}
+}
+
+func TestReauth_ConcurrentShareOneCall(t *testing.T) {
+	var requests, calls atomic.Int32
+	server := keyServer("new-key-5678", &requests)
+	defer server.Close()
+
+	release := make(chan struct{})
+	client, err := New(
+		WithAPIKey("old-key-1234"),
+		WithBaseURL(server.URL),
+		WithReauth(func(ctx context.Context) (Credentials, error) {
+			calls.Add(1)
+			<-release
+			return Credentials{APIKey: "new-key-5678"}, nil
+		}),
+		DisableRateLimit(),
+		DisableCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Every request is rejected before the first re-authentication finishes
+	gen := client.auth.generation()
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.reauthenticate(context.Background(), gen)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("reauthenticate() unexpected error: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("reauth calls = %d, want 1", n)
+	}
+}
+
+func TestWithReauth_Validation(t *testing.T) {
+	if _, err := New(WithAPIKey("key"), WithReauth(nil)); err == nil {
+		t.Error("nil reauth function: expected error")
+	}
+	hook := func(ctx context.Context) (Credentials, error) { return Credentials{}, nil }
+	if _, err := New(WithHTTPClient(http.DefaultClient), WithReauth(hook)); err == nil {
+		t.Error("reauth with a custom HTTP client: expected error")
+	}
+}
@@ -0,0 +1,188 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// ConditionRange is a measured [Min, Max] interval
+// The zero value means the condition was not measured.
+type ConditionRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// set reports whether the range was given
+func (r ConditionRange) set() bool {
+	return r.Min != 0 || r.Max != 0
+}
+
+// Conditions describes the environment a plant would live in, typically the
+// lowest and highest values measured in a room over a few days
+type Conditions struct {
+	// LuxRange is illuminance in lux
+	LuxRange ConditionRange `json:"lux"`
+	// TempRange is air temperature in °C
+	TempRange ConditionRange `json:"temp"`
+	// HumidityRange is relative air humidity in %
+	HumidityRange ConditionRange `json:"humidity"`
+}
+
+// RecommendOptions configures RecommendPlants
+//
+// Candidates are gathered from Queries and PIDs. When neither is given, the
+// plants in the client's local index (see SearchLocal and WithIndex) are
+// used, so a client that has seen many plants, or was given a pre-populated
+// index, can recommend without searching.
+type RecommendOptions struct {
+	// Queries are searched to find candidate plants (e.g. "fern", "palm")
+	Queries []string
+
+	// PIDs are additional candidate plants
+	PIDs []string
+
+	// Limit is the maximum number of recommendations (0 = no limit)
+	Limit int
+
+	// MaxLookups caps the number of candidates whose details are fetched
+	// (0 = no cap). Cached details count too, but cost no API request.
+	MaxLookups int
+
+	// Language is passed to GetPlantDetails
+	Language string
+}
+
+// Recommendation is a plant whose care ranges fit the given conditions
+type Recommendation struct {
+	Details *PlantDetails `json:"details"`
+
+	// Fit ranges from 0 (the conditions reach the edge of the plant's range)
+	// to 1 (the conditions sit in the middle of every range)
+	Fit float64 `json:"fit"`
+}
+
+// RecommendPlants finds plants whose care ranges contain the given conditions
+//
+// Each candidate's details are fetched (through the cache) and kept when,
+// for every measured condition, the measured range lies within the plant's.
+// Plants without data for a measured condition are left out. The best
+// fitting plants are returned first.
+//
+// Candidate details that cannot be found are skipped; other errors, such as
+// rate limiting or a canceled context, end the search. The lookups report
+// progress (see WithProgress) as ProgressRecommend.
+func (c *Client) RecommendPlants(ctx context.Context, cond Conditions, opts *RecommendOptions) ([]Recommendation, error) {
+	if !cond.LuxRange.set() && !cond.TempRange.set() && !cond.HumidityRange.set() {
+		return nil, ErrInvalidInput("at least one condition is required")
+	}
+	for _, r := range []ConditionRange{cond.LuxRange, cond.TempRange, cond.HumidityRange} {
+		if r.Min > r.Max {
+			return nil, ErrInvalidInput("condition minimum exceeds maximum")
+		}
+	}
+	if opts == nil {
+		opts = &RecommendOptions{}
+	}
+
+	pids, err := c.recommendCandidates(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxLookups > 0 && len(pids) > opts.MaxLookups {
+		pids = pids[:opts.MaxLookups]
+	}
+
+	var recs []Recommendation
+	detailOpts := &DetailOptions{Language: opts.Language}
+	progress := TrackProgress(ctx, ProgressRecommend, len(pids))
+	for _, pid := range pids {
+		details, err := c.GetPlantDetails(ctx, pid, detailOpts)
+		if errors.Is(err, ErrNotFound) {
+			progress.Finish(pid, nil)
+			continue
+		}
+		progress.Finish(pid, err)
+		if err != nil {
+			return nil, err
+		}
+		if fit, ok := conditionFit(details, cond); ok {
+			recs = append(recs, Recommendation{Details: details, Fit: fit})
+		}
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].Fit > recs[j].Fit })
+	if opts.Limit > 0 && len(recs) > opts.Limit {
+		recs = recs[:opts.Limit]
+	}
+	return recs, nil
+}
+
+// recommendCandidates returns the de-duplicated candidate PIDs in the order
+// they were found
+func (c *Client) recommendCandidates(ctx context.Context, opts *RecommendOptions) ([]string, error) {
+	var pids []string
+	seen := make(map[string]bool)
+	add := func(pid string) {
+		if pid != "" && !seen[pid] {
+			seen[pid] = true
+			pids = append(pids, pid)
+		}
+	}
+
+	for _, query := range opts.Queries {
+		results, err := c.SearchPlants(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			add(r.PID)
+		}
+	}
+	for _, pid := range opts.PIDs {
+		add(pid)
+	}
+
+	if len(opts.Queries) == 0 && len(opts.PIDs) == 0 {
+		for _, pid := range c.index.pids() {
+			add(pid)
+		}
+	}
+	return pids, nil
+}
+
+// conditionFit reports whether every measured condition lies within the
+// plant's range, and how much headroom the tightest one leaves
+func conditionFit(d *PlantDetails, cond Conditions) (float64, bool) {
+	checks := []struct {
+		measured ConditionRange
+		plant    ConditionRange
+	}{
+		{cond.LuxRange, ConditionRange{Min: float64(d.MinLightLux), Max: float64(d.MaxLightLux)}},
+		{cond.TempRange, ConditionRange{Min: d.MinTemp, Max: d.MaxTemp}},
+		{cond.HumidityRange, ConditionRange{Min: float64(d.MinEnvHumid), Max: float64(d.MaxEnvHumid)}},
+	}
+
+	fit := 1.0
+	for _, check := range checks {
+		if !check.measured.set() {
+			continue
+		}
+		p := check.plant
+		if !p.set() || p.Min > p.Max {
+			return 0, false // no data
+		}
+		if check.measured.Min < p.Min || check.measured.Max > p.Max {
+			return 0, false
+		}
+
+		// Headroom on the tighter side, relative to half the plant's range
+		if width := p.Max - p.Min; width > 0 {
+			margin := min(check.measured.Min-p.Min, p.Max-check.measured.Max)
+			fit = min(fit, margin/(width/2))
+		} else {
+			fit = 0
+		}
+	}
+	return fit, true
+}
@@ -0,0 +1,116 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_RecommendPlants(t *testing.T) {
+	plants := map[string]PlantDetails{
+		"monstera deliciosa": {
+			PID: "monstera deliciosa", MinLightLux: 2500, MaxLightLux: 20000,
+			MinTemp: 15, MaxTemp: 30, MinEnvHumid: 40, MaxEnvHumid: 80,
+		},
+		"nephrolepis exaltata": {
+			PID: "nephrolepis exaltata", MinLightLux: 1000, MaxLightLux: 10000,
+			MinTemp: 16, MaxTemp: 27, MinEnvHumid: 60, MaxEnvHumid: 90,
+		},
+		"echinocactus grusonii": {
+			PID: "echinocactus grusonii", MinLightLux: 15000, MaxLightLux: 80000,
+			MinTemp: 10, MaxTemp: 35, MinEnvHumid: 10, MaxEnvHumid: 30,
+		},
+		"unknown plant": {PID: "unknown plant"},
+	}
+
+	var searches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/plant/search" {
+			searches++
+			json.NewEncoder(w).Encode(searchResponse{Results: []PlantSearchResult{
+				{PID: "monstera deliciosa"}, {PID: "nephrolepis exaltata"}, {PID: "echinocactus grusonii"},
+			}})
+			return
+		}
+		pid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/plant/detail/"), "/")
+		p, ok := plants[pid]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(p)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	room := Conditions{
+		LuxRange:      ConditionRange{Min: 3000, Max: 8000},
+		TempRange:     ConditionRange{Min: 18, Max: 24},
+		HumidityRange: ConditionRange{Min: 45, Max: 65},
+	}
+
+	t.Run("filters and ranks candidates", func(t *testing.T) {
+		recs, err := client.RecommendPlants(context.Background(), room, &RecommendOptions{
+			Queries: []string{"houseplant"},
+			PIDs:    []string{"monstera deliciosa", "missing", "unknown plant"},
+		})
+		if err != nil {
+			t.Fatalf("RecommendPlants() unexpected error: %v", err)
+		}
+		if len(recs) != 1 || recs[0].Details.PID != "monstera deliciosa" {
+			t.Fatalf("RecommendPlants() = %+v, want only monstera (fern needs more humidity)", recs)
+		}
+		if recs[0].Fit <= 0 || recs[0].Fit > 1 {
+			t.Errorf("Fit = %v, want within (0, 1]", recs[0].Fit)
+		}
+	})
+
+	t.Run("ranks by headroom", func(t *testing.T) {
+		dim := Conditions{TempRange: ConditionRange{Min: 18, Max: 24}}
+		recs, err := client.RecommendPlants(context.Background(), dim, &RecommendOptions{
+			PIDs: []string{"nephrolepis exaltata", "echinocactus grusonii", "monstera deliciosa"},
+		})
+		if err != nil {
+			t.Fatalf("RecommendPlants() unexpected error: %v", err)
+		}
+		var got []string
+		for _, r := range recs {
+			got = append(got, r.Details.PID)
+		}
+		want := []string{"echinocactus grusonii", "monstera deliciosa", "nephrolepis exaltata"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("RecommendPlants() order = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("uses the local index without options", func(t *testing.T) {
+		before := searches
+		recs, err := client.RecommendPlants(context.Background(), room, nil)
+		if err != nil {
+			t.Fatalf("RecommendPlants() unexpected error: %v", err)
+		}
+		if searches != before {
+			t.Errorf("RecommendPlants() searched %d times, want 0", searches-before)
+		}
+		if len(recs) != 1 {
+			t.Errorf("RecommendPlants() returned %d plants, want 1", len(recs))
+		}
+	})
+
+	t.Run("requires a condition", func(t *testing.T) {
+		_, err := client.RecommendPlants(context.Background(), Conditions{}, nil)
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Errorf("RecommendPlants() error = %v, want ValidationError", err)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package openplantbook
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// redacted replaces sensitive values in logs, errors and debug output
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are never written to debug output
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// sensitiveKeys are log keys whose values are always redacted
+var sensitiveKeys = []string{
+	"api_key",
+	"apikey",
+	"authorization",
+	"client_secret",
+	"password",
+	"secret",
+	"token",
+}
+
+// minSecretLen avoids redacting short values that would match ordinary text
+const minSecretLen = 4
+
+// redactor scrubs the client's credentials from strings, log args and errors
+type redactor struct {
+	mu      sync.RWMutex // secrets grow when credentials are replaced (see WithReauth)
+	secrets []string
+}
+
+// newRedactor returns a redactor for the client's credentials
+// The OAuth2 basic auth encoding of the client credentials is included, since
+// servers may echo the Authorization header back in error bodies.
+func newRedactor(apiKey, clientID, clientSecret string) *redactor {
+	r := &redactor{}
+	r.addCredentials(apiKey, clientID, clientSecret)
+	return r
+}
+
+// addCredentials adds the secrets of a set of credentials
+func (r *redactor) addCredentials(apiKey, clientID, clientSecret string) {
+	r.add(apiKey)
+	r.add(clientSecret)
+	if clientID != "" && clientSecret != "" {
+		basic := url.QueryEscape(clientID) + ":" + url.QueryEscape(clientSecret)
+		r.add(base64.StdEncoding.EncodeToString([]byte(basic)))
+	}
+}
+
+func (r *redactor) add(secret string) {
+	if len(secret) < minSecretLen {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !slices.Contains(r.secrets, secret) {
+		r.secrets = append(r.secrets, secret)
+	}
+}
+
+// String replaces every known secret in s
+func (r *redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// Args validates and redacts logger key/value arguments (see LogAttrs)
+// Values of sensitive keys are replaced outright; other string and error
+// values are scrubbed of known secrets. The result alternates string keys
+// and values.
+func (r *redactor) Args(args []interface{}) []interface{} {
+	attrs := LogAttrs(args)
+	out := make([]interface{}, 0, 2*len(attrs))
+	for _, a := range attrs {
+		out = append(out, a.Key, r.value(a.Key, a.Value.Any()))
+	}
+	return out
+}
+
+// value redacts the logged value of key
+func (r *redactor) value(key string, v interface{}) interface{} {
+	if isSensitiveKey(key) {
+		return redacted
+	}
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case error:
+		return r.Error(v)
+	case fmt.Stringer:
+		return r.String(v.String())
+	}
+	return v
+}
+
+// Error returns err with known secrets removed from its message
+// The original error remains reachable through errors.Is and errors.As.
+func (r *redactor) Error(err error) error {
+	if err == nil || r == nil {
+		return err
+	}
+	msg := err.Error()
+	if clean := r.String(msg); clean != msg {
+		return &redactedError{err: err, msg: clean}
+	}
+	return err
+}
+
+// redactedError hides credentials in an error message
+type redactedError struct {
+	err error
+	msg string
+}
+
+// Error implements the error interface
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the original error
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package openplantbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger keeps every formatted log line
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, msg string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf("%s %s %v", level, msg, args))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args...) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record("INFO", msg, args...) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record("WARN", msg, args...) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args...) }
+
+// assertNoSecrets fails if any of the secrets appears in text
+func assertNoSecrets(t *testing.T, where, text string, secrets ...string) {
+	t.Helper()
+	for _, secret := range secrets {
+		if strings.Contains(text, secret) {
+			t.Errorf("%s leaks %q:\n%s", where, secret, text)
+		}
+	}
+}
+
+// echoServer reflects request credentials back in every response body
+func echoServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"detail":"bad request","auth":%q,"form":%q}`, r.Header.Get("Authorization"), body)
+	}))
+}
+
+func TestRedaction_APIKey(t *testing.T) {
+	const apiKey = "api-key-0123456789"
+
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := echoServer(status)
+			defer server.Close()
+
+			logger := &recordingLogger{}
+			var debug bytes.Buffer
+			client, err := New(
+				WithAPIKey(apiKey),
+				WithBaseURL(server.URL),
+				WithLogger(logger),
+				WithHTTPDebug(&debug),
+				DisableRateLimit(),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.GetPlantDetails(context.Background(), "test", nil)
+			if err == nil {
+				t.Fatal("GetPlantDetails() expected error, got nil")
+			}
+
+			assertNoSecrets(t, "error", err.Error(), apiKey)
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				if !strings.Contains(apiErr.Body, "bad request") {
+					t.Errorf("APIError.Body = %q, want the response body", apiErr.Body)
+				}
+				assertNoSecrets(t, "APIError.Body", apiErr.Body, apiKey)
+			}
+			assertNoSecrets(t, "log", strings.Join(logger.lines, "\n"), apiKey)
+			assertNoSecrets(t, "debug output", debug.String(), apiKey)
+		})
+	}
+}
+
+func TestRedaction_OAuth2TokenFailure(t *testing.T) {
+	const (
+		clientID     = "client-id"
+		clientSecret = "client-secret-0123456789"
+	)
+
+	server := echoServer(http.StatusUnauthorized)
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	var debug bytes.Buffer
+	client, err := New(
+		WithOAuth2(clientID, clientSecret),
+		WithBaseURL(server.URL),
+		WithLogger(logger),
+		WithHTTPDebug(&debug),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.SearchPlants(context.Background(), "monstera", nil)
+	if err == nil {
+		t.Fatal("SearchPlants() expected token error, got nil")
+	}
+
+	// The server echoes the basic auth header, so its encoding must be hidden too
+	basic := newRedactor("", clientID, clientSecret).secrets[1]
+	assertNoSecrets(t, "error", err.Error(), clientSecret, basic)
+	assertNoSecrets(t, "log", strings.Join(logger.lines, "\n"), clientSecret, basic)
+	assertNoSecrets(t, "debug output", debug.String(), clientSecret, basic)
+}
+
+func TestRedaction_ClientFormatting(t *testing.T) {
+	const (
+		apiKey       = "api-key-0123456789"
+		clientSecret = "client-secret-0123456789"
+	)
+
+	apiClient, _ := New(WithAPIKey(apiKey))
+	oauthClient, _ := New(WithOAuth2("client-id", clientSecret))
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assertNoSecrets(t, format, fmt.Sprintf(format, apiClient), apiKey)
+		assertNoSecrets(t, format, fmt.Sprintf(format, oauthClient), clientSecret)
+	}
+
+	if got, want := apiClient.String(), `openplantbook.Client{baseURL: "`+DefaultBaseURL+`", auth: api-key}`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRedactor_Args(t *testing.T) {
+	r := newRedactor("secret-value", "", "")
+
+	got := r.Args([]interface{}{
+		"token", "opaque",
+		"url", "https://example.com/?key=secret-value",
+		"error", errors.New("bad key secret-value"),
+		"count", 3,
+	})
+
+	want := []interface{}{
+		"token", redacted,
+		"url", "https://example.com/?key=" + redacted,
+		"error", "bad key " + redacted,
+		"count", 3,
+	}
+	for i := range want {
+		if fmt.Sprint(got[i]) != fmt.Sprint(want[i]) {
+			t.Errorf("arg %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRedactor_ErrorKeepsChain(t *testing.T) {
+	r := newRedactor("secret-value", "", "")
+	err := r.Error(fmt.Errorf("request with secret-value: %w", ErrUnauthorized))
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("redacted error no longer matches ErrUnauthorized")
+	}
+	if strings.Contains(err.Error(), "secret-value") {
+		t.Errorf("Error() = %q, still contains the secret", err)
+	}
+}
@@ -0,0 +1,96 @@
+package openplantbook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxRedirects is the number of redirects followed per request by
+// default
+const DefaultMaxRedirects = 5
+
+// ErrRedirectNotFollowed is returned when a response redirects somewhere the
+// redirect policy does not allow (see WithRedirectPolicy)
+var ErrRedirectNotFollowed = errors.New("redirect not followed")
+
+// RedirectPolicy controls which redirects the client follows
+//
+// Whatever the policy, credentials are only sent to the host of the base URL
+// (and the OAuth2 token URL for token requests): a redirect to another host
+// is followed, if at all, without them.
+type RedirectPolicy struct {
+	// MaxRedirects is the most redirects followed per request; 0 means
+	// DefaultMaxRedirects and a negative value follows none
+	MaxRedirects int
+
+	// CrossHost follows redirects to a host other than the one the request
+	// was sent to; by default they fail with ErrRedirectNotFollowed
+	CrossHost bool
+}
+
+// WithRedirectPolicy sets which redirects the client follows
+// Without it the client follows up to DefaultMaxRedirects redirects on the
+// same host. With WithHTTPClient the policy replaces the custom client's
+// CheckRedirect (on a copy; the caller's client is not modified), but does not
+// remove credentials the custom client adds itself.
+//
+// Example, for a proxy that redirects to a CDN:
+//
+//	client, _ := openplantbook.New(
+//	    openplantbook.WithAPIKey(apiKey),
+//	    openplantbook.WithBaseURL("https://plants-proxy.example.com/api/v1"),
+//	    openplantbook.WithRedirectPolicy(openplantbook.RedirectPolicy{CrossHost: true}),
+//	)
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(c *Client) error {
+		c.redirectPolicy = &policy
+		return nil
+	}
+}
+
+// checkRedirect implements http.Client.CheckRedirect for the policy
+func (p RedirectPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	limit := p.MaxRedirects
+	if limit == 0 {
+		limit = DefaultMaxRedirects
+	}
+	if len(via) > limit {
+		if limit < 0 {
+			return fmt.Errorf("%w: redirects are disabled", ErrRedirectNotFollowed)
+		}
+		return fmt.Errorf("%w: stopped after %d redirects", ErrRedirectNotFollowed, limit)
+	}
+	if from := via[len(via)-1].URL; !p.CrossHost && !sameHost(from, req.URL) {
+		return fmt.Errorf("%w: %s redirected to another host (%s)", ErrRedirectNotFollowed, from.Host, req.URL.Host)
+	}
+	return nil
+}
+
+// sameHost reports whether a and b address the same host and port
+func sameHost(a, b *url.URL) bool {
+	return strings.EqualFold(a.Host, b.Host)
+}
+
+// hostGuard sends requests to the API host through the authenticating
+// transport, and requests to any other host (reached by a redirect) through
+// the base transport with credentials removed
+type hostGuard struct {
+	host string
+	auth http.RoundTripper
+	base http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (g *hostGuard) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.EqualFold(req.URL.Host, g.host) {
+		return g.auth.RoundTrip(req)
+	}
+	if req.Header.Get("Authorization") != "" {
+		req = req.Clone(req.Context())
+		req.Header.Del("Authorization")
+	}
+	return g.base.RoundTrip(req)
+}
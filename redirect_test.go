@@ -0,0 +1,139 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+)
+
+// redirectingServer redirects every request to target, keeping the path
+func redirectingServer(t *testing.T, target string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target+r.URL.RequestURI(), http.StatusFound)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// otherHost serves details, recording the Authorization header it receives
+func otherHost(t *testing.T, auth *atomic.Value, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to load test fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		auth.Store(r.Header.Get("Authorization"))
+		w.Write(detailData)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRedirectPolicy_CrossHost(t *testing.T) {
+	var auth atomic.Value
+	var calls atomic.Int32
+	other := otherHost(t, &auth, &calls)
+	api := redirectingServer(t, other.URL)
+
+	t.Run("refused by default", func(t *testing.T) {
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(api.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		_, err = client.GetPlantDetails(context.Background(), "monstera", nil)
+		if !errors.Is(err, ErrRedirectNotFollowed) {
+			t.Fatalf("GetPlantDetails() error = %v, want ErrRedirectNotFollowed", err)
+		}
+		if !IsPermanent(err) {
+			t.Error("IsPermanent() = false for a refused redirect")
+		}
+		if calls.Load() != 0 {
+			t.Errorf("other host received %d requests, want none", calls.Load())
+		}
+	})
+
+	t.Run("followed without credentials", func(t *testing.T) {
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(api.URL), DisableRateLimit(),
+			WithRedirectPolicy(RedirectPolicy{CrossHost: true}))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.GetPlantDetails(context.Background(), "monstera", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("other host received %d requests, want 1", calls.Load())
+		}
+		if got := auth.Load(); got != "" {
+			t.Errorf("other host received Authorization %q, want none", got)
+		}
+	})
+}
+
+func TestRedirectPolicy_MaxRedirects(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Redirect(w, r, r.URL.Path+"x", http.StatusFound) // never ends
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		policy    *RedirectPolicy
+		wantCalls int32
+	}{
+		{"default", nil, DefaultMaxRedirects + 1},
+		{"two", &RedirectPolicy{MaxRedirects: 2}, 3},
+		{"disabled", &RedirectPolicy{MaxRedirects: -1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+			opts := []Option{WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit()}
+			if tt.policy != nil {
+				opts = append(opts, WithRedirectPolicy(*tt.policy))
+			}
+			client, err := New(opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			_, err = client.GetPlantDetails(context.Background(), "monstera", nil)
+			if !errors.Is(err, ErrRedirectNotFollowed) {
+				t.Fatalf("GetPlantDetails() error = %v, want ErrRedirectNotFollowed", err)
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("server received %d requests, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithRedirectPolicy_CustomHTTPClient(t *testing.T) {
+	custom := &http.Client{}
+	client, err := New(WithHTTPClient(custom), WithRedirectPolicy(RedirectPolicy{MaxRedirects: -1}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if custom.CheckRedirect != nil {
+		t.Error("WithRedirectPolicy modified the caller's HTTP client")
+	}
+	if client.httpClient.CheckRedirect == nil {
+		t.Error("client's HTTP client has no CheckRedirect")
+	}
+}
@@ -0,0 +1,111 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// ToICS writes the calendar as an iCalendar (RFC 5545) document
+//
+// Each recommendation becomes a single recurring event with a reminder, so
+// the calendar stays small and imports cleanly into Google Calendar,
+// Apple Calendar and Outlook.
+func (c *Calendar) ToICS(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(icsTimeFormat)
+
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//openplantbook-go//schedule "+openplantbook.Version+"//EN")
+	writeICSLine(bw, "CALSCALE:GREGORIAN")
+	writeICSLine(bw, "METHOD:PUBLISH")
+	writeICSLine(bw, "X-WR-CALNAME:Plant care")
+
+	for _, rec := range c.Recommendations {
+		if rec.Interval <= 0 {
+			continue
+		}
+
+		writeICSLine(bw, "BEGIN:VEVENT")
+		writeICSLine(bw, "UID:"+icsUID(rec))
+		writeICSLine(bw, "DTSTAMP:"+stamp)
+		writeICSLine(bw, "DTSTART:"+c.Start.UTC().Format(icsTimeFormat))
+		writeICSLine(bw, "DURATION:PT15M")
+		rule, every := icsRecurrence(rec.Interval)
+		writeICSLine(bw, "RRULE:"+rule)
+		writeICSLine(bw, "SUMMARY:"+escapeICSText("Water "+rec.Name))
+		writeICSLine(bw, "DESCRIPTION:"+escapeICSText(fmt.Sprintf(
+			"Water %s (%s) every %s.", rec.Name, rec.PID, every)))
+		writeICSLine(bw, "BEGIN:VALARM")
+		writeICSLine(bw, "ACTION:DISPLAY")
+		writeICSLine(bw, "DESCRIPTION:"+escapeICSText("Water "+rec.Name))
+		writeICSLine(bw, "TRIGGER:PT0M")
+		writeICSLine(bw, "END:VALARM")
+		writeICSLine(bw, "END:VEVENT")
+	}
+
+	writeICSLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// icsRecurrence returns the RRULE value and display text for an interval
+//
+// Whole days recur daily; anything else recurs hourly, rounded to at least
+// one hour, since RFC 5545 requires a positive INTERVAL.
+func icsRecurrence(interval time.Duration) (rule, every string) {
+	if interval >= 24*time.Hour && interval%(24*time.Hour) == 0 {
+		days := int(interval / (24 * time.Hour))
+		return fmt.Sprintf("FREQ=DAILY;INTERVAL=%d", days), fmt.Sprintf("%d day(s)", days)
+	}
+	hours := max(int(interval.Round(time.Hour)/time.Hour), 1)
+	return fmt.Sprintf("FREQ=HOURLY;INTERVAL=%d", hours), fmt.Sprintf("%d hour(s)", hours)
+}
+
+// icsUID builds a stable event UID so re-imports update existing events
+func icsUID(rec Recommendation) string {
+	id := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '@' {
+			return '-'
+		}
+		return r
+	}, strings.ToLower(rec.PID+"-"+rec.Name))
+	return id + "@openplantbook-go"
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 section 3.3.11
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeICSLine writes a content line folded at 75 octets with CRLF endings
+func writeICSLine(w *bufio.Writer, line string) {
+	// Continuation lines start with a space, leaving one octet less for content
+	limit := 75
+	for len(line) > limit {
+		// Never split a multi-byte UTF-8 sequence
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
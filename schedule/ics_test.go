@@ -0,0 +1,79 @@
+package schedule
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestCalendar_ToICS(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	cal := NewCalendar(start,
+		Recommendation{PID: "monstera deliciosa", Name: "Monstera, living room", Interval: 9 * 24 * time.Hour},
+		Recommendation{PID: "skipped", Name: "No interval"},
+	)
+
+	var buf bytes.Buffer
+	if err := cal.ToICS(&buf); err != nil {
+		t.Fatalf("ToICS() unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20250301T090000Z\r\n",
+		"RRULE:FREQ=DAILY;INTERVAL=9\r\n",
+		`SUMMARY:Water Monstera\, living room` + "\r\n",
+		"UID:monstera-deliciosa-monstera,-living-room@openplantbook-go\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ToICS() output missing %q", want)
+		}
+	}
+
+	if got := strings.Count(out, "BEGIN:VEVENT"); got != 1 {
+		t.Errorf("ToICS() wrote %d events, want 1", got)
+	}
+}
+
+func TestICSRecurrence(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     string
+	}{
+		{9 * 24 * time.Hour, "FREQ=DAILY;INTERVAL=9"},
+		{36 * time.Hour, "FREQ=HOURLY;INTERVAL=36"},
+		{12 * time.Hour, "FREQ=HOURLY;INTERVAL=12"},
+		{10 * time.Minute, "FREQ=HOURLY;INTERVAL=1"},
+		{0, "FREQ=HOURLY;INTERVAL=1"},
+	}
+	for _, tt := range tests {
+		if got, _ := icsRecurrence(tt.interval); got != tt.want {
+			t.Errorf("icsRecurrence(%v) = %q, want %q", tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestWriteICSLine_Folding(t *testing.T) {
+	var buf bytes.Buffer
+	cal := NewCalendar(time.Now(), Recommendation{
+		PID:      "x",
+		Name:     strings.Repeat("é", 100),
+		Interval: 24 * time.Hour,
+	})
+	if err := cal.ToICS(&buf); err != nil {
+		t.Fatalf("ToICS() unexpected error: %v", err)
+	}
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets (%d): %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a UTF-8 sequence: %q", line)
+		}
+	}
+}
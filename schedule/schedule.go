@@ -0,0 +1,268 @@
+// Package schedule derives watering recommendations from OpenPlantbook
+// soil moisture thresholds.
+//
+// The model is a deliberately simple heuristic: the soil is assumed to dry
+// from the plant's maximum to its minimum soil moisture at a daily rate that
+// depends on pot size, season, temperature, humidity and whether the plant
+// is kept indoors. The resulting interval is a starting point that users
+// should adjust to their own observations.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+const (
+	// DefaultPotDiameterCM is the pot diameter assumed when none is provided
+	DefaultPotDiameterCM = 15.0
+
+	// MinInterval is the shortest interval ever recommended
+	MinInterval = 24 * time.Hour
+
+	// MaxInterval is the longest interval ever recommended
+	MaxInterval = 30 * 24 * time.Hour
+
+	// baseDailyLoss is the soil moisture (percentage points) lost per day by
+	// a 15cm pot indoors at 20°C and 50% humidity in spring
+	baseDailyLoss = 5.0
+)
+
+// Season is the time of year used to scale water demand
+type Season int
+
+const (
+	// Spring is the default growing season
+	Spring Season = iota
+	// Summer increases water demand
+	Summer
+	// Autumn decreases water demand
+	Autumn
+	// Winter is the dormant season with the lowest water demand
+	Winter
+)
+
+// Seasons lists all seasons in calendar order
+var Seasons = []Season{Spring, Summer, Autumn, Winter}
+
+// String returns the lowercase season name
+func (s Season) String() string {
+	switch s {
+	case Spring:
+		return "spring"
+	case Summer:
+		return "summer"
+	case Autumn:
+		return "autumn"
+	case Winter:
+		return "winter"
+	default:
+		return fmt.Sprintf("Season(%d)", int(s))
+	}
+}
+
+// MarshalText encodes the season as its name
+func (s Season) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a season name, case-insensitively; "fall" is
+// accepted for autumn
+func (s *Season) UnmarshalText(text []byte) error {
+	name := string(text)
+	for _, candidate := range Seasons {
+		if strings.EqualFold(name, candidate.String()) {
+			*s = candidate
+			return nil
+		}
+	}
+	if strings.EqualFold(name, "fall") {
+		*s = Autumn
+		return nil
+	}
+	return fmt.Errorf("unknown season %q (want spring, summer, autumn or winter)", name)
+}
+
+// factor returns the relative water demand for the season
+func (s Season) factor() float64 {
+	switch s {
+	case Summer:
+		return 1.3
+	case Autumn:
+		return 0.8
+	case Winter:
+		return 0.6
+	default:
+		return 1.0
+	}
+}
+
+// SeasonFor returns the season for a date in the given hemisphere
+func SeasonFor(t time.Time, southern bool) Season {
+	month := t.Month()
+	if southern {
+		month = (month+5)%12 + 1
+	}
+
+	switch month {
+	case time.March, time.April, time.May:
+		return Spring
+	case time.June, time.July, time.August:
+		return Summer
+	case time.September, time.October, time.November:
+		return Autumn
+	default:
+		return Winter
+	}
+}
+
+// Conditions describes where and how a plant is kept
+type Conditions struct {
+	// PotDiameterCM is the pot diameter in centimeters (0 = DefaultPotDiameterCM)
+	PotDiameterCM float64
+
+	// Season scales water demand (default: Spring)
+	Season Season
+
+	// Outdoor indicates the plant is kept outside (wind and sun dry soil faster)
+	Outdoor bool
+
+	// TempC is the typical ambient temperature (0 = midpoint of the plant's range)
+	TempC float64
+
+	// Humidity is the typical relative humidity in percent (0 = midpoint of the plant's range)
+	Humidity float64
+}
+
+// Recommendation is a suggested watering interval for a single plant
+type Recommendation struct {
+	PID      string
+	Name     string
+	Interval time.Duration
+
+	// DailyLoss is the estimated soil moisture loss in percentage points per day
+	DailyLoss float64
+}
+
+// Days returns the interval in whole days
+func (r Recommendation) Days() int {
+	return int(r.Interval / (24 * time.Hour))
+}
+
+// Recommend computes a watering interval from a plant's soil moisture range
+func Recommend(details *openplantbook.PlantDetails, cond Conditions) (Recommendation, error) {
+	if details == nil {
+		return Recommendation{}, errors.New("plant details cannot be nil")
+	}
+	if details.MaxSoilMoist <= details.MinSoilMoist {
+		return Recommendation{}, fmt.Errorf("plant %q has no usable soil moisture range (%d-%d)",
+			details.PID, details.MinSoilMoist, details.MaxSoilMoist)
+	}
+	if cond.PotDiameterCM < 0 {
+		return Recommendation{}, fmt.Errorf("pot diameter cannot be negative: %v", cond.PotDiameterCM)
+	}
+
+	pot := cond.PotDiameterCM
+	if pot == 0 {
+		pot = DefaultPotDiameterCM
+	}
+	temp := cond.TempC
+	if temp == 0 {
+		temp = (details.MinTemp + details.MaxTemp) / 2
+	}
+	humidity := cond.Humidity
+	if humidity == 0 {
+		humidity = float64(details.MinEnvHumid+details.MaxEnvHumid) / 2
+	}
+
+	// Larger pots hold more water relative to their evaporating surface
+	potFactor := clamp(DefaultPotDiameterCM/pot, 0.4, 2.5)
+	tempFactor := clamp(1+0.05*(temp-20), 0.5, 2.0)
+	humidityFactor := clamp(1+0.01*(50-humidity), 0.6, 1.5)
+	placementFactor := 1.0
+	if cond.Outdoor {
+		placementFactor = 1.5
+	}
+
+	loss := baseDailyLoss * potFactor * tempFactor * humidityFactor * placementFactor * cond.Season.factor()
+	days := math.Round(float64(details.MaxSoilMoist-details.MinSoilMoist) / loss)
+	interval := time.Duration(days) * 24 * time.Hour
+	if interval < MinInterval {
+		interval = MinInterval
+	}
+	if interval > MaxInterval {
+		interval = MaxInterval
+	}
+
+	name := details.Alias
+	if name == "" {
+		name = details.DisplayPID
+	}
+
+	return Recommendation{
+		PID:       details.PID,
+		Name:      name,
+		Interval:  interval,
+		DailyLoss: loss,
+	}, nil
+}
+
+// Task is a single scheduled watering
+type Task struct {
+	PID  string
+	Name string
+	Due  time.Time
+}
+
+// Calendar is a set of watering schedules anchored at a start time
+type Calendar struct {
+	Start           time.Time
+	Recommendations []Recommendation
+}
+
+// NewCalendar creates a calendar whose first watering is due at start
+func NewCalendar(start time.Time, recs ...Recommendation) *Calendar {
+	return &Calendar{
+		Start:           start,
+		Recommendations: recs,
+	}
+}
+
+// Tasks returns all watering tasks due in [from, to), ordered by due time
+func (c *Calendar) Tasks(from, to time.Time) []Task {
+	var tasks []Task
+	for _, rec := range c.Recommendations {
+		if rec.Interval <= 0 {
+			continue
+		}
+
+		due := c.Start
+		if due.Before(from) {
+			// Skip ahead to the first occurrence at or after from
+			steps := from.Sub(due) / rec.Interval
+			due = due.Add(steps * rec.Interval)
+			if due.Before(from) {
+				due = due.Add(rec.Interval)
+			}
+		}
+
+		for ; due.Before(to); due = due.Add(rec.Interval) {
+			tasks = append(tasks, Task{PID: rec.PID, Name: rec.Name, Due: due})
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Due.Before(tasks[j].Due)
+	})
+	return tasks
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,133 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func testDetails() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		DisplayPID:   "Monstera deliciosa",
+		Alias:        "Monstera",
+		MaxTemp:      30,
+		MinTemp:      15,
+		MaxEnvHumid:  80,
+		MinEnvHumid:  40,
+		MaxSoilMoist: 60,
+		MinSoilMoist: 15,
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		name     string
+		cond     Conditions
+		wantDays int
+	}{
+		{
+			name:     "defaults",
+			cond:     Conditions{TempC: 20, Humidity: 50},
+			wantDays: 9,
+		},
+		{
+			name:     "summer outdoors",
+			cond:     Conditions{TempC: 20, Humidity: 50, Season: Summer, Outdoor: true},
+			wantDays: 5,
+		},
+		{
+			name:     "winter large pot",
+			cond:     Conditions{TempC: 20, Humidity: 50, Season: Winter, PotDiameterCM: 30},
+			wantDays: 30,
+		},
+		{
+			name:     "tiny pot in heat",
+			cond:     Conditions{TempC: 35, Humidity: 20, PotDiameterCM: 5},
+			wantDays: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := Recommend(testDetails(), tt.cond)
+			if err != nil {
+				t.Fatalf("Recommend() unexpected error: %v", err)
+			}
+			if rec.Days() != tt.wantDays {
+				t.Errorf("Recommend() days = %d, want %d", rec.Days(), tt.wantDays)
+			}
+			if rec.Name != "Monstera" {
+				t.Errorf("Recommend() name = %q, want %q", rec.Name, "Monstera")
+			}
+		})
+	}
+}
+
+func TestRecommend_Invalid(t *testing.T) {
+	if _, err := Recommend(nil, Conditions{}); err == nil {
+		t.Error("Recommend(nil) expected error, got nil")
+	}
+
+	details := testDetails()
+	details.MaxSoilMoist = details.MinSoilMoist
+	if _, err := Recommend(details, Conditions{}); err == nil {
+		t.Error("Recommend() with empty moisture range expected error, got nil")
+	}
+
+	if _, err := Recommend(testDetails(), Conditions{PotDiameterCM: -1}); err == nil {
+		t.Error("Recommend() with negative pot size expected error, got nil")
+	}
+}
+
+func TestSeasonFor(t *testing.T) {
+	july := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if got := SeasonFor(july, false); got != Summer {
+		t.Errorf("SeasonFor(July, north) = %v, want %v", got, Summer)
+	}
+	if got := SeasonFor(july, true); got != Winter {
+		t.Errorf("SeasonFor(July, south) = %v, want %v", got, Winter)
+	}
+}
+
+func TestSeason_Text(t *testing.T) {
+	text, err := Winter.MarshalText()
+	if err != nil || string(text) != "winter" {
+		t.Fatalf("MarshalText() = %q, %v, want winter", text, err)
+	}
+
+	var s Season
+	if err := s.UnmarshalText([]byte("Fall")); err != nil || s != Autumn {
+		t.Errorf("UnmarshalText(Fall) = %v, %v, want autumn", s, err)
+	}
+	if err := s.UnmarshalText([]byte("monsoon")); err == nil {
+		t.Error("UnmarshalText(monsoon) expected error, got nil")
+	}
+}
+
+func TestCalendar_Tasks(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+	cal := NewCalendar(start,
+		Recommendation{PID: "a", Name: "A", Interval: 3 * day},
+		Recommendation{PID: "b", Name: "B", Interval: 5 * day},
+	)
+
+	tasks := cal.Tasks(start, start.Add(10*day))
+	// a: days 0,3,6,9 - b: days 0,5
+	if len(tasks) != 6 {
+		t.Fatalf("Tasks() returned %d tasks, want 6", len(tasks))
+	}
+	for i := 1; i < len(tasks); i++ {
+		if tasks[i].Due.Before(tasks[i-1].Due) {
+			t.Errorf("Tasks() not sorted at index %d", i)
+		}
+	}
+
+	// Windows starting after the calendar start skip ahead
+	later := cal.Tasks(start.Add(4*day), start.Add(6*day))
+	if len(later) != 1 || later[0].PID != "b" {
+		t.Errorf("Tasks() in later window = %+v, want single task for b", later)
+	}
+}
@@ -0,0 +1,166 @@
+// Package sensors converts raw readings from popular plant sensors to the
+// units Plantbook thresholds use, so the care package compares like with
+// like.
+//
+// A Profile maps a device's field names to metrics and converts their raw
+// scales, such as tenths of a degree or degrees Fahrenheit:
+//
+//	readings := sensors.MiFlora.Readings(map[string]float64{
+//	    "temperature":  231, // 23.1 °C
+//	    "moisture":     34,
+//	    "conductivity": 420,
+//	}, time.Now())
+//	violations := care.Evaluate(details, readings...)
+package sensors
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// Conversion turns a raw field value into a metric
+// The raw value is scaled by Factor, then shifted by Offset. A zero Factor
+// means 1, so Conversion{Metric: m} passes values through unchanged.
+type Conversion struct {
+	Metric care.Metric `json:"metric"`
+	Factor float64     `json:"factor,omitempty"`
+	Offset float64     `json:"offset,omitempty"`
+}
+
+// Apply converts a raw value
+func (c Conversion) Apply(raw float64) float64 {
+	if c.Factor != 0 {
+		raw *= c.Factor
+	}
+	return raw + c.Offset
+}
+
+// Profile describes how a device reports its readings
+type Profile struct {
+	Name string `json:"name"`
+
+	// Fields maps the device's field names to conversions. Field names are
+	// matched ignoring case and a trailing channel number, so "soilmoisture"
+	// also matches "soilmoisture3" from a gateway with several probes.
+	Fields map[string]Conversion `json:"fields"`
+}
+
+// fahrenheit converts °F to °C
+var fahrenheit = Conversion{Metric: care.MetricTemperature, Factor: 5.0 / 9, Offset: -160.0 / 9}
+
+// MiFlora is the Xiaomi Mi Flora (Flower Care) sensor, as read from its
+// Bluetooth real-time data: temperature in tenths of a degree Celsius, light
+// in lux, soil moisture in percent and conductivity ("fertility") in µS/cm.
+// Plantbook thresholds were collected with these sensors, so no other
+// correction is applied.
+var MiFlora = &Profile{
+	Name: "Mi Flora",
+	Fields: map[string]Conversion{
+		"temperature":  {Metric: care.MetricTemperature, Factor: 0.1},
+		"light":        {Metric: care.MetricLight},
+		"illuminance":  {Metric: care.MetricLight},
+		"moisture":     {Metric: care.MetricSoilMoisture},
+		"conductivity": {Metric: care.MetricSoilEC},
+		"fertility":    {Metric: care.MetricSoilEC},
+	},
+}
+
+// EcowittWH51 is the Ecowitt WH51 soil moisture probe, as uploaded by an
+// Ecowitt gateway: soil moisture in percent per channel ("soilmoisture1"
+// to "soilmoisture8"), alongside the gateway's outdoor and indoor
+// temperature in °F and humidity in percent
+var EcowittWH51 = &Profile{
+	Name: "Ecowitt WH51",
+	Fields: map[string]Conversion{
+		"soilmoisture": {Metric: care.MetricSoilMoisture},
+		"tempf":        fahrenheit,
+		"tempinf":      fahrenheit,
+		"humidity":     {Metric: care.MetricHumidity},
+		"humidityin":   {Metric: care.MetricHumidity},
+	},
+}
+
+// Tuya is a Tuya plant monitor (soil sensor) reporting standard data
+// points: temperature ("temp_current") in tenths of a degree Celsius and
+// soil moisture ("humidity") in percent. Devices set to Fahrenheit report
+// "temp_current_f" in tenths of a degree Fahrenheit.
+var Tuya = &Profile{
+	Name: "Tuya",
+	Fields: map[string]Conversion{
+		"temp_current":   {Metric: care.MetricTemperature, Factor: 0.1},
+		"temp_current_f": {Metric: care.MetricTemperature, Factor: 0.1 * 5 / 9, Offset: -160.0 / 9},
+		"humidity":       {Metric: care.MetricSoilMoisture},
+	},
+}
+
+// Profiles lists the built-in profiles
+var Profiles = []*Profile{MiFlora, EcowittWH51, Tuya}
+
+// Lookup finds a built-in profile by name, ignoring case, spaces, hyphens
+// and underscores ("miflora", "Mi-Flora", "ecowitt-wh51" and "tuya" all
+// match)
+func Lookup(name string) (*Profile, bool) {
+	key := fold(name)
+	for _, p := range Profiles {
+		if fold(p.Name) == key {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// fold lowercases s and drops separators
+func fold(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+// conversion finds the conversion of a field
+func (p *Profile) conversion(field string) (Conversion, bool) {
+	field = strings.ToLower(field)
+	if c, ok := p.Fields[field]; ok {
+		return c, true
+	}
+	c, ok := p.Fields[strings.TrimRight(field, "0123456789")]
+	return c, ok
+}
+
+// Convert converts one raw field value; ok is false when the profile does
+// not know the field
+func (p *Profile) Convert(field string, raw float64, at time.Time) (reading care.Reading, ok bool) {
+	c, ok := p.conversion(field)
+	if !ok {
+		return care.Reading{}, false
+	}
+	return care.Reading{Metric: c.Metric, Value: c.Apply(raw), Time: at}, true
+}
+
+// Readings converts a device report, skipping fields the profile does not
+// know
+// Readings are ordered as care.Metrics, and by field name within a metric.
+func (p *Profile) Readings(report map[string]float64, at time.Time) []care.Reading {
+	fields := make([]string, 0, len(report))
+	for field := range report {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	var readings []care.Reading
+	for _, field := range fields {
+		if r, ok := p.Convert(field, report[field], at); ok {
+			readings = append(readings, r)
+		}
+	}
+	slices.SortStableFunc(readings, func(a, b care.Reading) int {
+		return slices.Index(care.Metrics, a.Metric) - slices.Index(care.Metrics, b.Metric)
+	})
+	return readings
+}
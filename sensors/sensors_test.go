@@ -0,0 +1,72 @@
+package sensors
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func TestProfile_Readings(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		profile *Profile
+		report  map[string]float64
+		want    []care.Reading
+	}{
+		{MiFlora, map[string]float64{"temperature": 231, "moisture": 34, "conductivity": 420, "light": 5000, "battery": 99}, []care.Reading{
+			{Metric: care.MetricLight, Value: 5000, Time: at},
+			{Metric: care.MetricTemperature, Value: 23.1, Time: at},
+			{Metric: care.MetricSoilMoisture, Value: 34, Time: at},
+			{Metric: care.MetricSoilEC, Value: 420, Time: at},
+		}},
+		{EcowittWH51, map[string]float64{"soilmoisture1": 41, "SoilMoisture2": 12, "tempf": 68, "soilad1": 230}, []care.Reading{
+			{Metric: care.MetricTemperature, Value: 20, Time: at},
+			{Metric: care.MetricSoilMoisture, Value: 12, Time: at},
+			{Metric: care.MetricSoilMoisture, Value: 41, Time: at},
+		}},
+		{Tuya, map[string]float64{"temp_current": 215, "humidity": 27}, []care.Reading{
+			{Metric: care.MetricTemperature, Value: 21.5, Time: at},
+			{Metric: care.MetricSoilMoisture, Value: 27, Time: at},
+		}},
+		{Tuya, map[string]float64{"temp_current_f": 770}, []care.Reading{
+			{Metric: care.MetricTemperature, Value: 25, Time: at},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile.Name, func(t *testing.T) {
+			got := tt.profile.Readings(tt.report, at)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Readings() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Metric != tt.want[i].Metric || math.Abs(got[i].Value-tt.want[i].Value) > 1e-9 || !got[i].Time.Equal(at) {
+					t.Errorf("reading %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProfile_Convert(t *testing.T) {
+	if _, ok := MiFlora.Convert("battery", 99, time.Now()); ok {
+		t.Error("Convert(battery) ok = true, want false")
+	}
+	custom := &Profile{Name: "probe", Fields: map[string]Conversion{"ec": {Metric: care.MetricSoilEC, Factor: 1000}}}
+	if r, ok := custom.Convert("EC", 1.25, time.Now()); !ok || r.Value != 1250 {
+		t.Errorf("Convert(EC, 1.25) = %+v, %v; want 1250 µS/cm", r, ok)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for name, want := range map[string]*Profile{"miflora": MiFlora, "Mi-Flora": MiFlora, "ecowitt_wh51": EcowittWH51, "TUYA": Tuya} {
+		if got, ok := Lookup(name); !ok || got != want {
+			t.Errorf("Lookup(%q) = %v, %v; want %s", name, got, ok, want.Name)
+		}
+	}
+	if _, ok := Lookup("unknown"); ok {
+		t.Error("Lookup(unknown) ok = true")
+	}
+}
@@ -0,0 +1,60 @@
+package openplantbook
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Serializer encodes cached API responses
+//
+// Changing the serializer of a persistent cache is safe: entries that fail
+// to decode are treated as cache misses and fetched again.
+type Serializer interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONSerializer stores responses as JSON (default)
+// Entries are the raw response body, so no re-encoding is needed on a miss.
+type JSONSerializer struct {
+	// Codec encodes and decodes entries; nil means encoding/json. The
+	// default serializer uses the codec of WithJSONCodec.
+	Codec Codec
+}
+
+// Marshal encodes v as JSON
+func (s JSONSerializer) Marshal(v any) ([]byte, error) {
+	if s.Codec != nil {
+		return s.Codec.Marshal(v)
+	}
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into v
+func (s JSONSerializer) Unmarshal(data []byte, v any) error {
+	if s.Codec != nil {
+		return s.Codec.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// GobSerializer stores responses with encoding/gob
+// Each entry carries its own type description, so gob only pays off for
+// large snapshots; for individual responses the msgpack subpackage is both
+// smaller and faster.
+type GobSerializer struct{}
+
+// Marshal encodes v with gob
+func (GobSerializer) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes gob data into v
+func (GobSerializer) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,110 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSerializers_RoundTrip(t *testing.T) {
+	want := searchResponse{
+		Count:   1,
+		Results: []PlantSearchResult{{PID: "monstera deliciosa", DisplayPID: "Monstera deliciosa", Alias: "Monstera"}},
+	}
+
+	for name, s := range map[string]Serializer{"json": JSONSerializer{}, "gob": GobSerializer{}} {
+		t.Run(name, func(t *testing.T) {
+			data, err := s.Marshal(want)
+			if err != nil {
+				t.Fatalf("Marshal() unexpected error: %v", err)
+			}
+			var got searchResponse
+			if err := s.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestClient_WithSerializer(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	cache := NewInMemoryCache()
+	defer cache.Close()
+
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithCache(cache),
+		WithSerializer(GobSerializer{}),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	want, err := client.GetPlantDetails(context.Background(), "monstera-deliciosa", nil)
+	if err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+
+	key := detailCacheKey("monstera-deliciosa", nil)
+	cached, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("response was not cached")
+	}
+	if json.Valid(cached) {
+		t.Error("cache entry is JSON, want gob")
+	}
+
+	got, err := client.GetPlantDetails(context.Background(), "monstera-deliciosa", nil)
+	if err != nil {
+		t.Fatalf("cached GetPlantDetails() unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1", calls)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cached details = %+v, want %+v", got, want)
+	}
+
+	// A client with a different serializer treats the entry as a miss
+	jsonClient, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithCache(cache),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := jsonClient.GetPlantDetails(context.Background(), "monstera-deliciosa", nil); err != nil {
+		t.Fatalf("GetPlantDetails() with JSON serializer unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("API called %d times after serializer change, want 2", calls)
+	}
+}
+
+func TestWithSerializer_Nil(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithSerializer(nil)); err == nil {
+		t.Error("WithSerializer(nil) expected error, got nil")
+	}
+}
@@ -0,0 +1,347 @@
+// Package simulate generates synthetic sensor readings for a plant, so
+// automations built on the care and notify packages can be tested end to end
+// without hardware.
+//
+// Each metric follows a simple model: light rises and falls with the sun and
+// is zero at night, temperature peaks in the afternoon, air humidity moves
+// opposite to temperature, soil moisture dries out steadily between
+// waterings, and soil EC rises as the soil dries. A Level per metric places
+// the readings within the plant's range or below or above it:
+//
+//	readings, err := simulate.Generate(details, simulate.Config{
+//	    Start:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local),
+//	    Levels: map[care.Metric]simulate.Level{care.MetricSoilMoisture: simulate.Low},
+//	})
+//	violations := care.Evaluate(details, readings...)
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+// Defaults for zero Config fields
+const (
+	DefaultDuration = 24 * time.Hour
+	DefaultInterval = 15 * time.Minute
+	DefaultSunrise  = 6 * time.Hour
+	DefaultSunset   = 20 * time.Hour
+)
+
+const (
+	// margin keeps Within readings this fraction of the range width inside
+	// the bounds, and Low and High readings at least this far outside
+	margin = 0.1
+	// excess is how far, as a fraction of the range width, Low and High
+	// readings reach beyond the bounds
+	excess = 0.5
+	// warmestHour is when temperature peaks (and humidity bottoms out)
+	warmestHour = 15
+)
+
+// ErrNoThresholds is returned when the plant has no threshold data for any
+// of the requested metrics
+var ErrNoThresholds = errors.New("plant has no thresholds for the requested metrics")
+
+// Level places a metric's readings relative to the plant's range
+type Level int
+
+const (
+	// Within keeps readings inside the range (default)
+	Within Level = iota
+	// Low keeps readings below the minimum; soil moisture starts just below
+	// the minimum and keeps drying without being watered
+	Low
+	// High keeps readings above the maximum
+	High
+)
+
+// String returns the level name
+func (l Level) String() string {
+	switch l {
+	case Within:
+		return "within"
+	case Low:
+		return "low"
+	case High:
+		return "high"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// MarshalText encodes the level by name
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a level name
+func (l *Level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "within", "":
+		*l = Within
+	case "low":
+		*l = Low
+	case "high":
+		*l = High
+	default:
+		return fmt.Errorf("unknown level %q (want within, low or high)", text)
+	}
+	return nil
+}
+
+// Config describes a simulation
+type Config struct {
+	// Start is the time of the first reading; its location sets the time of
+	// day for the light and temperature curves
+	Start time.Time
+
+	// Duration is how long readings are generated for (DefaultDuration if
+	// zero); Interval is the time between them (DefaultInterval if zero)
+	Duration time.Duration
+	Interval time.Duration
+
+	// Metrics are the metrics to simulate; nil means care.Metrics. Metrics
+	// the plant has no thresholds for are skipped.
+	Metrics []care.Metric
+
+	// Levels places each metric's readings; metrics not listed are Within
+	Levels map[care.Metric]Level
+
+	// Sunrise and Sunset are the times of day light starts and stops, as
+	// offsets from midnight (DefaultSunrise and DefaultSunset if zero)
+	Sunrise time.Duration
+	Sunset  time.Duration
+
+	// DaylightOnly leaves out light readings between sunset and sunrise,
+	// which are zero and so fall below any minimum
+	DaylightOnly bool
+
+	// DryingPerDay is how many soil moisture points are lost per day; zero
+	// dries the soil from the plant's maximum to its minimum in three days
+	DryingPerDay float64
+
+	// Noise adds random variation of up to this fraction of each range's
+	// width; readings still stay on the side of the range their Level sets
+	Noise float64
+
+	// Seed makes the noise reproducible
+	Seed uint64
+
+	// Sensor is set on every reading
+	Sensor string
+}
+
+// Generate returns simulated readings for the plant, ordered by time and,
+// at each time, in the order of Config.Metrics
+func Generate(details *openplantbook.PlantDetails, cfg Config) ([]care.Reading, error) {
+	if err := cfg.setDefaults(); err != nil {
+		return nil, err
+	}
+
+	type series struct {
+		metric care.Metric
+		r      care.Range
+		level  Level
+	}
+	var all []series
+	for _, m := range cfg.Metrics {
+		r, ok := care.RangeFor(details, m)
+		if !ok {
+			continue
+		}
+		level := cfg.Levels[m]
+		if level < Within || level > High {
+			return nil, fmt.Errorf("simulate: unknown level %v for %s", level, m)
+		}
+		all = append(all, series{m, r, level})
+	}
+	if len(all) == 0 {
+		return nil, ErrNoThresholds
+	}
+
+	sim := newSimulation(details, cfg)
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	steps := int(cfg.Duration / cfg.Interval)
+	readings := make([]care.Reading, 0, steps*len(all))
+	for i := 0; i < steps; i++ {
+		at := cfg.Start.Add(time.Duration(i) * cfg.Interval)
+		for _, s := range all {
+			dark := s.metric == care.MetricLight && sim.daylight(at) == 0
+			if dark && cfg.DaylightOnly {
+				continue
+			}
+			v := sim.value(s.metric, s.r, s.level, at)
+			if cfg.Noise > 0 && !dark {
+				v += (rng.Float64()*2 - 1) * cfg.Noise * s.r.Width()
+			}
+			if !dark {
+				v = bound(s.r, s.level, v)
+			}
+			readings = append(readings, care.Reading{
+				Metric: s.metric,
+				Value:  physical(s.metric, v),
+				Time:   at,
+				Sensor: cfg.Sensor,
+			})
+		}
+	}
+	return readings, nil
+}
+
+// setDefaults fills in zero fields and rejects invalid ones
+func (cfg *Config) setDefaults() error {
+	if cfg.Duration == 0 {
+		cfg.Duration = DefaultDuration
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Sunrise == 0 {
+		cfg.Sunrise = DefaultSunrise
+	}
+	if cfg.Sunset == 0 {
+		cfg.Sunset = DefaultSunset
+	}
+	if cfg.Metrics == nil {
+		cfg.Metrics = care.Metrics
+	}
+	switch {
+	case cfg.Duration < 0 || cfg.Interval < 0:
+		return errors.New("simulate: duration and interval must be positive")
+	case cfg.Sunrise >= cfg.Sunset || cfg.Sunset > 24*time.Hour:
+		return errors.New("simulate: sunrise must come before sunset on the same day")
+	case cfg.DryingPerDay < 0 || cfg.Noise < 0:
+		return errors.New("simulate: drying rate and noise cannot be negative")
+	}
+	return nil
+}
+
+// simulation holds what the models share
+type simulation struct {
+	cfg Config
+
+	// perDay is the soil moisture lost per day, and cycle the time between
+	// waterings of soil kept Within its range
+	perDay float64
+	cycle  time.Duration
+}
+
+// defaultDryingDays is how many days the soil takes to dry from the
+// plant's maximum moisture to its minimum when DryingPerDay is zero
+const defaultDryingDays = 3
+
+func newSimulation(details *openplantbook.PlantDetails, cfg Config) *simulation {
+	sim := &simulation{cfg: cfg, cycle: time.Duration((1 - 2*margin) * defaultDryingDays * float64(24*time.Hour))}
+	if r, ok := care.RangeFor(details, care.MetricSoilMoisture); ok && r.Width() > 0 {
+		sim.perDay = r.Width() / defaultDryingDays
+		if cfg.DryingPerDay > 0 {
+			sim.perDay = cfg.DryingPerDay
+		}
+		sim.cycle = time.Duration((1 - 2*margin) * r.Width() / sim.perDay * float64(24*time.Hour))
+	}
+	return sim
+}
+
+// value returns the noiseless reading of a metric at a time
+func (sim *simulation) value(m care.Metric, r care.Range, level Level, at time.Time) float64 {
+	switch m {
+	case care.MetricLight:
+		sun := sim.daylight(at)
+		if sun == 0 {
+			return 0
+		}
+		return scale(r, level, sun)
+	case care.MetricTemperature:
+		return scale(r, level, warmth(at))
+	case care.MetricHumidity:
+		return scale(r, level, 1-warmth(at))
+	case care.MetricSoilMoisture:
+		switch level {
+		case Low:
+			// Never watered: drying on past the minimum
+			return r.Min - margin*r.Width() - sim.perDay*at.Sub(sim.cfg.Start).Hours()/24
+		case High:
+			return scale(r, High, 0.5) // waterlogged
+		}
+		return scale(r, Within, 1-sim.dryness(at))
+	case care.MetricSoilEC:
+		// Salts concentrate as the soil dries: EC is lowest just after
+		// watering and highest before the next one
+		return scale(r, level, sim.dryness(at))
+	}
+	return scale(r, level, 0.5)
+}
+
+// dryness returns how far soil kept Within its range has dried since it
+// was last watered, from 0 (just watered) towards 1
+func (sim *simulation) dryness(at time.Time) float64 {
+	elapsed := at.Sub(sim.cfg.Start)
+	return float64(elapsed%sim.cycle) / float64(sim.cycle)
+}
+
+// daylight returns the sun's strength in [0, 1]: a half sine wave between
+// sunrise and sunset, and zero at night
+func (sim *simulation) daylight(at time.Time) float64 {
+	midnight := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	since := at.Sub(midnight)
+	if since <= sim.cfg.Sunrise || since >= sim.cfg.Sunset {
+		return 0
+	}
+	return math.Sin(math.Pi * float64(since-sim.cfg.Sunrise) / float64(sim.cfg.Sunset-sim.cfg.Sunrise))
+}
+
+// warmth returns a daily cycle in [0, 1], peaking at warmestHour
+func warmth(at time.Time) float64 {
+	hour := float64(at.Hour()) + float64(at.Minute())/60
+	return (1 + math.Cos(2*math.Pi*(hour-warmestHour)/24)) / 2
+}
+
+// scale maps a shape value in [0, 1] into the band of level
+func scale(r care.Range, level Level, shape float64) float64 {
+	w := r.Width()
+	var lo, hi float64
+	switch level {
+	case Low:
+		lo, hi = r.Min-excess*w, r.Min-margin*w
+	case High:
+		lo, hi = r.Max+margin*w, r.Max+excess*w
+	default:
+		lo, hi = r.Min+margin*w, r.Max-margin*w
+	}
+	return lo + shape*(hi-lo)
+}
+
+// bound keeps a noisy value on the side of the range its level asks for
+func bound(r care.Range, level Level, v float64) float64 {
+	switch level {
+	case Low:
+		return math.Min(v, r.Min-margin*r.Width())
+	case High:
+		return math.Max(v, r.Max+margin*r.Width())
+	}
+	return clamp(v, r.Min, r.Max)
+}
+
+// physical keeps a value within what a sensor can report
+func physical(m care.Metric, v float64) float64 {
+	switch m {
+	case care.MetricTemperature:
+		return v
+	case care.MetricHumidity, care.MetricSoilMoisture:
+		return clamp(v, 0, 100)
+	default:
+		return max(v, 0)
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,202 @@
+package simulate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/care"
+)
+
+func testDetails() *openplantbook.PlantDetails {
+	return &openplantbook.PlantDetails{
+		PID:          "monstera deliciosa",
+		MaxLightLux:  20000,
+		MinLightLux:  2500,
+		MaxTemp:      30,
+		MinTemp:      15,
+		MaxEnvHumid:  80,
+		MinEnvHumid:  40,
+		MaxSoilMoist: 60,
+		MinSoilMoist: 15,
+		MaxSoilEC:    2000,
+		MinSoilEC:    350,
+	}
+}
+
+var start = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func byMetric(readings []care.Reading, m care.Metric) []care.Reading {
+	var out []care.Reading
+	for _, r := range readings {
+		if r.Metric == m {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestGenerate_Within(t *testing.T) {
+	readings, err := Generate(testDetails(), Config{
+		Start:        start,
+		Duration:     7 * 24 * time.Hour,
+		DaylightOnly: true,
+		Noise:        0.2,
+		Seed:         1,
+		Sensor:       "sim",
+	})
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	if v := care.Evaluate(testDetails(), readings...); len(v) != 0 {
+		t.Errorf("Evaluate() = %d violations, want none; first: %v", len(v), v[0])
+	}
+	if readings[0].Sensor != "sim" || !readings[0].Time.Equal(start) {
+		t.Errorf("first reading = %+v", readings[0])
+	}
+
+	// Soil moisture dries between waterings: it falls, then jumps back up
+	moisture := byMetric(readings, care.MetricSoilMoisture)
+	var rises int
+	for i := 1; i < len(moisture); i++ {
+		if moisture[i].Value > moisture[i-1].Value+5 {
+			rises++
+		}
+	}
+	if rises < 2 {
+		t.Errorf("soil moisture watered %d times in a week, want at least 2", rises)
+	}
+}
+
+func TestGenerate_Defaults(t *testing.T) {
+	readings, err := Generate(testDetails(), Config{Start: start, Metrics: []care.Metric{care.MetricTemperature}})
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	if len(readings) != 96 {
+		t.Errorf("len(readings) = %d, want 96", len(readings))
+	}
+	if got := readings[1].Time.Sub(readings[0].Time); got != DefaultInterval {
+		t.Errorf("interval = %v, want %v", got, DefaultInterval)
+	}
+}
+
+func TestGenerate_Light(t *testing.T) {
+	readings, err := Generate(testDetails(), Config{Start: start, Metrics: []care.Metric{care.MetricLight}})
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	var peak care.Reading
+	for _, r := range readings {
+		hour := r.Time.Hour()
+		if (hour < 6 || hour >= 20) && r.Value != 0 {
+			t.Errorf("light at %s = %v, want 0 at night", r.Time.Format("15:04"), r.Value)
+		}
+		if r.Value > peak.Value {
+			peak = r
+		}
+	}
+	if hour := peak.Time.Hour(); hour < 12 || hour > 13 {
+		t.Errorf("light peaked at %s, want around 13:00", peak.Time.Format("15:04"))
+	}
+
+	// Without DaylightOnly the dark readings fall below the minimum
+	if v := care.Evaluate(testDetails(), readings...); len(v) == 0 {
+		t.Error("Evaluate() found no violations in night-time light readings")
+	}
+}
+
+func TestGenerate_Levels(t *testing.T) {
+	tests := []struct {
+		metric care.Metric
+		level  Level
+		want   care.Status
+	}{
+		{care.MetricSoilMoisture, Low, care.StatusLow},
+		{care.MetricSoilMoisture, High, care.StatusHigh},
+		{care.MetricTemperature, High, care.StatusHigh},
+		{care.MetricHumidity, Low, care.StatusLow},
+		{care.MetricSoilEC, High, care.StatusHigh},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metric)+"/"+tt.level.String(), func(t *testing.T) {
+			readings, err := Generate(testDetails(), Config{
+				Start:   start,
+				Metrics: []care.Metric{tt.metric},
+				Levels:  map[care.Metric]Level{tt.metric: tt.level},
+				Noise:   0.3,
+			})
+			if err != nil {
+				t.Fatalf("Generate() unexpected error: %v", err)
+			}
+			violations := care.Evaluate(testDetails(), readings...)
+			if len(violations) != len(readings) {
+				t.Fatalf("%d of %d readings violate the range", len(violations), len(readings))
+			}
+			for _, v := range violations {
+				if v.Status != tt.want {
+					t.Fatalf("violation status = %v, want %v", v.Status, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Drying(t *testing.T) {
+	readings, err := Generate(testDetails(), Config{
+		Start:        start,
+		Metrics:      []care.Metric{care.MetricSoilMoisture},
+		Levels:       map[care.Metric]Level{care.MetricSoilMoisture: Low},
+		DryingPerDay: 4,
+	})
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	first, last := readings[0].Value, readings[len(readings)-1].Value
+	if first >= 15 || first-last < 3.5 || first-last > 4 {
+		t.Errorf("soil moisture went from %v to %v, want drying from below 15 by about 4", first, last)
+	}
+}
+
+func TestGenerate_Seed(t *testing.T) {
+	cfg := Config{Start: start, Noise: 0.1, Seed: 42}
+	a, _ := Generate(testDetails(), cfg)
+	b, _ := Generate(testDetails(), cfg)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("reading %d differs between runs with the same seed: %+v, %+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := Generate(&openplantbook.PlantDetails{PID: "unknown"}, Config{Start: start}); !errors.Is(err, ErrNoThresholds) {
+		t.Errorf("Generate() without thresholds error = %v, want ErrNoThresholds", err)
+	}
+	invalid := []Config{
+		{Interval: -time.Minute},
+		{Sunrise: 20 * time.Hour, Sunset: 6 * time.Hour},
+		{Noise: -1},
+		{Levels: map[care.Metric]Level{care.MetricLight: Level(7)}},
+	}
+	for _, cfg := range invalid {
+		if _, err := Generate(testDetails(), cfg); err == nil {
+			t.Errorf("Generate(%+v) expected error, got nil", cfg)
+		}
+	}
+}
+
+func TestLevel_Text(t *testing.T) {
+	for _, l := range []Level{Within, Low, High} {
+		text, _ := l.MarshalText()
+		var got Level
+		if err := got.UnmarshalText(text); err != nil || got != l {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want %v", text, got, err, l)
+		}
+	}
+	var l Level
+	if err := l.UnmarshalText([]byte("soggy")); err == nil {
+		t.Error("UnmarshalText(soggy) expected error, got nil")
+	}
+}
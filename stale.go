@@ -0,0 +1,88 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"time"
+)
+
+// cacheMeta records when a cached response was fetched and when it expires
+// It is stored beside the response under metaKey, so the response itself
+// stays in its serializer's format.
+type cacheMeta struct {
+	FetchedAt time.Time
+	ExpiresAt time.Time
+}
+
+// fresh reports whether the entry is within its TTL at now; entries without
+// metadata are assumed fresh
+func (m cacheMeta) fresh(now time.Time) bool {
+	return m.ExpiresAt.IsZero() || now.Before(m.ExpiresAt)
+}
+
+func metaKey(key string) string {
+	return "meta:" + key
+}
+
+// encode packs the timestamps into 16 bytes
+func (m cacheMeta) encode() []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], uint64(m.FetchedAt.UnixNano()))
+	binary.BigEndian.PutUint64(b[8:], uint64(m.ExpiresAt.UnixNano()))
+	return b
+}
+
+func decodeCacheMeta(b []byte) (cacheMeta, bool) {
+	if len(b) != 16 {
+		return cacheMeta{}, false
+	}
+	return cacheMeta{
+		FetchedAt: time.Unix(0, int64(binary.BigEndian.Uint64(b[:8]))),
+		ExpiresAt: time.Unix(0, int64(binary.BigEndian.Uint64(b[8:]))),
+	}, true
+}
+
+// retention returns how long entries are kept past their TTL: long enough
+// to serve them stale (WithStaleIfError) or to compare them with a refreshed
+// response (WithOnUpdate)
+func (c *Client) retention() time.Duration {
+	r := c.staleIfError
+	if c.onUpdate != nil || c.onInvalidate != nil {
+		r = max(r, updateRetention)
+	}
+	return r
+}
+
+// cacheGet looks up a cached response and, when withMeta is set or entries
+// are retained past their TTL, its metadata
+// With retention, callers must check meta.fresh.
+func (c *Client) cacheGet(key string, withMeta bool) ([]byte, cacheMeta, bool) {
+	data, ok := c.cache.Get(key)
+	if !ok || (!withMeta && c.retention() <= 0) {
+		return data, cacheMeta{}, ok
+	}
+
+	var meta cacheMeta
+	if raw, found := c.cache.Get(metaKey(key)); found {
+		meta, _ = decodeCacheMeta(raw)
+	}
+	return data, meta, true
+}
+
+// serveStale reports whether err allows falling back to a stale entry with
+// the given metadata
+// Failures that suggest the API is unreachable or overloaded qualify,
+// including DNS failures and deadlines that pass while waiting on a dead
+// network. A 404 or validation error is a real answer and is returned as is,
+// and a canceled context means the caller no longer wants a result. Entries
+// retained longer for WithOnUpdate are not served once past the staleness
+// bound.
+func (c *Client) serveStale(err error, meta cacheMeta) bool {
+	if c.staleIfError <= 0 || c.clock.Now().Sub(meta.ExpiresAt) > c.staleIfError {
+		return false
+	}
+	var dnsErr *net.DNSError
+	return IsRetryable(err) || errors.As(err, &dnsErr) || errors.Is(err, context.DeadlineExceeded)
+}
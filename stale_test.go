@@ -0,0 +1,264 @@
+package openplantbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// expireEntry marks a cached response as past its TTL
+func expireEntry(cache Cache, key string) {
+	past := time.Now().Add(-time.Minute)
+	cache.Set(metaKey(key), cacheMeta{FetchedAt: past.Add(-time.Hour), ExpiresAt: past}.encode(), time.Hour)
+}
+
+func TestClient_StaleIfError(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code := int(status.Load()); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	newClient := func(t *testing.T, opts ...Option) (*Client, *InMemoryCache) {
+		cache := NewInMemoryCache()
+		t.Cleanup(cache.Close)
+		client, err := New(append([]Option{
+			WithAPIKey("test-key"),
+			WithBaseURL(server.URL),
+			WithCache(cache),
+			DisableRateLimit(),
+		}, opts...)...)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client, cache
+	}
+	key := detailCacheKey("monstera-deliciosa", nil)
+	ctx := context.Background()
+
+	t.Run("serves stale entry on server error", func(t *testing.T) {
+		status.Store(http.StatusOK)
+		client, cache := newClient(t, WithStaleIfError(time.Hour))
+
+		want, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+		expireEntry(cache, key)
+
+		status.Store(http.StatusServiceUnavailable)
+		got, err := client.GetPlantDetailsWithMeta(ctx, "monstera-deliciosa", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		if !got.Stale {
+			t.Error("Stale = false, want true")
+		}
+		if got.Details.PID != want.PID {
+			t.Errorf("Details.PID = %q, want %q", got.Details.PID, want.PID)
+		}
+	})
+
+	t.Run("refreshes expired entry when API is up", func(t *testing.T) {
+		status.Store(http.StatusOK)
+		client, cache := newClient(t, WithStaleIfError(time.Hour))
+
+		client.GetPlantDetails(ctx, "monstera-deliciosa", nil)
+		expireEntry(cache, key)
+
+		got, err := client.GetPlantDetailsWithMeta(ctx, "monstera-deliciosa", nil)
+		if err != nil {
+			t.Fatalf("GetPlantDetailsWithMeta() unexpected error: %v", err)
+		}
+		if got.Stale {
+			t.Error("Stale = true for a fresh fetch")
+		}
+		if _, meta, _ := client.cacheGet(key, true); !meta.fresh(time.Now()) {
+			t.Error("cache entry not refreshed")
+		}
+	})
+
+	t.Run("returns API answers", func(t *testing.T) {
+		status.Store(http.StatusOK)
+		client, cache := newClient(t, WithStaleIfError(time.Hour))
+
+		client.GetPlantDetails(ctx, "monstera-deliciosa", nil)
+		expireEntry(cache, key)
+
+		status.Store(http.StatusNotFound)
+		if _, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetPlantDetails() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		status.Store(http.StatusOK)
+		client, cache := newClient(t)
+
+		client.GetPlantDetails(ctx, "monstera-deliciosa", nil)
+		expireEntry(cache, key)
+		cache.Delete(key) // expired entries are not kept without WithStaleIfError
+
+		status.Store(http.StatusServiceUnavailable)
+		if _, err := client.GetPlantDetails(ctx, "monstera-deliciosa", nil); err == nil {
+			t.Error("GetPlantDetails() expected error, got nil")
+		}
+	})
+}
+
+func TestClient_StaleIfError_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count":1,"next":null,"previous":null,"results":[{"pid":"monstera deliciosa","alias":"Monstera"}]}`))
+	}))
+
+	cache := NewInMemoryCache()
+	defer cache.Close()
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithCache(cache),
+		WithStaleIfError(time.Hour),
+		DisableRateLimit(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.SearchPlants(context.Background(), "monstera", nil); err != nil {
+		t.Fatalf("SearchPlants() unexpected error: %v", err)
+	}
+	expireEntry(cache, searchCacheKey("monstera", nil))
+
+	// Connections are now refused
+	server.Close()
+
+	got, err := client.SearchPlantsWithMeta(context.Background(), "monstera", nil)
+	if err != nil {
+		t.Fatalf("SearchPlantsWithMeta() unexpected error: %v", err)
+	}
+	if !got.Stale || len(got.Results) != 1 {
+		t.Errorf("SearchPlantsWithMeta() = %+v, want 1 stale result", got)
+	}
+}
+
+func TestCacheMeta_Encode(t *testing.T) {
+	want := cacheMeta{
+		FetchedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+	}
+	got, ok := decodeCacheMeta(want.encode())
+	if !ok || !got.FetchedAt.Equal(want.FetchedAt) || !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("round trip = %+v, %v; want %+v", got, ok, want)
+	}
+	if _, ok := decodeCacheMeta([]byte("short")); ok {
+		t.Error("decodeCacheMeta() accepted malformed input")
+	}
+}
+
+func TestWithStaleIfError_Invalid(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithStaleIfError(0)); err == nil {
+		t.Error("WithStaleIfError(0) expected error, got nil")
+	}
+}
+
+func TestWithOnUpdate(t *testing.T) {
+	var maxTemp atomic.Int64
+	maxTemp.Store(30)
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(PlantDetails{PID: "monstera", MinTemp: 15, MaxTemp: float64(maxTemp.Load())})
+	}))
+	defer server.Close()
+
+	type update struct {
+		pid      string
+		old, new *PlantDetails
+	}
+	var updates []update
+
+	cache := NewInMemoryCacheWithOptions(context.Background(), 0, 0)
+	client, err := New(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		DisableRateLimit(),
+		WithCache(cache),
+		WithStaleIfError(time.Hour),
+		WithOnUpdate(func(pid string, old, new *PlantDetails) {
+			updates = append(updates, update{pid, old, new})
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	key := detailCacheKey("monstera", nil)
+
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if len(updates) != 0 {
+		t.Fatalf("first fetch reported %d updates, want 0", len(updates))
+	}
+
+	// Unchanged after expiry: no update
+	expireEntry(cache, key)
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if len(updates) != 0 {
+		t.Fatalf("unchanged refresh reported %d updates, want 0", len(updates))
+	}
+
+	// Changed upstream after expiry: one update with both versions
+	maxTemp.Store(28)
+	expireEntry(cache, key)
+	if _, err := client.GetPlantDetails(ctx, "monstera", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("changed refresh reported %d updates, want 1", len(updates))
+	}
+	u := updates[0]
+	if u.pid != "monstera" || u.old.MaxTemp != 30 || u.new.MaxTemp != 28 {
+		t.Errorf("update = %s %v -> %v, want monstera 30 -> 28", u.pid, u.old.MaxTemp, u.new.MaxTemp)
+	}
+
+	t.Run("retained entries are not served past the staleness bound", func(t *testing.T) {
+		past := time.Now().Add(-2 * time.Hour)
+		cache.Set(metaKey(key), cacheMeta{FetchedAt: past.Add(-24 * time.Hour), ExpiresAt: past}.encode(), time.Hour)
+		failing.Store(true)
+		defer failing.Store(false)
+
+		if _, err := client.GetPlantDetails(ctx, "monstera", nil); err == nil {
+			t.Error("GetPlantDetails() served an entry expired beyond WithStaleIfError's bound")
+		}
+	})
+}
+
+func TestWithOnUpdate_Nil(t *testing.T) {
+	if _, err := New(WithAPIKey("test-key"), WithOnUpdate(nil)); err == nil {
+		t.Error("New() with nil update callback expected error, got nil")
+	}
+}
@@ -0,0 +1,154 @@
+package openplantbook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status summarizes a client's configuration and usage, for diagnostics
+type Status struct {
+	BaseURL string `json:"base_url"`
+
+	// Auth is "api-key", "oauth2", "oauth2-token-source" (WithTokenSource),
+	// or "none" for a custom HTTP client; credentials are never included
+	Auth string `json:"auth"`
+
+	Cache CacheStatus `json:"cache"`
+	Quota QuotaStatus `json:"quota"`
+}
+
+// CacheStatus reports cache usage by this client
+type CacheStatus struct {
+	Enabled bool `json:"enabled"`
+
+	// Entries is the number of entries in the cache (including metadata
+	// entries), or -1 when the cache cannot report its size
+	Entries int `json:"entries"`
+
+	// Hits and Misses count search and detail lookups by this client
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// QuotaStatus reports client-side quota usage
+// Only requests sent by this client, or by clients sharing its Limiter, are
+// counted; other processes using the same credentials draw from the same
+// server-side quota.
+type QuotaStatus struct {
+	// Limit is the configured requests per day: the client-wide or shared
+	// limit, or the sum of the WithRateLimits quotas when every endpoint
+	// class has a smaller one; 0 when rate limiting is disabled
+	Limit int `json:"limit"`
+
+	// Used is the number of requests sent in the last 24 hours, counted
+	// even when rate limiting is disabled
+	Used int `json:"used"`
+
+	// Remaining is Limit minus Used (never negative; 0 when unlimited)
+	Remaining int `json:"remaining"`
+}
+
+// Status reports the client's configuration, cache usage and quota usage
+func (c *Client) Status() Status {
+	s := Status{
+		BaseURL: c.baseURL,
+		Auth:    c.authMethod(),
+		Cache: CacheStatus{
+			Enabled: c.cacheEnabled(),
+			Entries: -1,
+			Hits:    c.cacheHits.Load(),
+			Misses:  c.cacheMisses.Load(),
+		},
+		Quota: c.quota(),
+	}
+
+	if lc, ok := c.cache.(interface{ Len() int }); ok {
+		s.Cache.Entries = lc.Len()
+	}
+	return s
+}
+
+// quota reports the client's quota usage
+func (c *Client) quota() QuotaStatus {
+	now := c.clock.Now()
+	q := QuotaStatus{Used: c.requests.count(now)}
+	if c.sharedLimiter != nil {
+		q.Used = c.sharedLimiter.requests.count(now)
+	}
+	if c.rateLimiter != nil {
+		q.Limit = c.dailyLimit
+	}
+	// Class quotas only cap the total when every class has one
+	if search, details := c.classLimit(EndpointSearch), c.classLimit(EndpointDetails); search > 0 && details > 0 {
+		if q.Limit == 0 || search+details < q.Limit {
+			q.Limit = search + details
+		}
+	}
+	if q.Limit > 0 {
+		q.Remaining = max(q.Limit-q.Used, 0)
+	}
+	return q
+}
+
+// Ping checks that the API is reachable and accepts the client's
+// credentials, returning the round-trip time
+// Ping sends a minimal search request, bypassing the cache, so it counts
+// against the quota.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := c.clock.Now()
+	if _, _, err := c.fetchSearch(ctx, "monstera", &SearchOptions{Limit: 1}); err != nil {
+		return 0, err
+	}
+	return c.clock.Now().Sub(start), nil
+}
+
+// authMethod names the configured authentication method
+func (c *Client) authMethod() string {
+	switch {
+	case c.apiKey != "":
+		return "api-key"
+	case c.clientID != "":
+		return "oauth2"
+	case c.tokenSource != nil:
+		return "oauth2-token-source"
+	}
+	return "none"
+}
+
+// requestLog counts requests over the last 24 hours in hourly buckets
+type requestLog struct {
+	mu      sync.Mutex
+	buckets [24]requestBucket
+}
+
+type requestBucket struct {
+	hour int64 // hours since the Unix epoch
+	n    int
+}
+
+func (l *requestLog) add(now time.Time) {
+	hour := now.Unix() / 3600
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := &l.buckets[hour%int64(len(l.buckets))]
+	if b.hour != hour {
+		*b = requestBucket{hour: hour}
+	}
+	b.n++
+}
+
+func (l *requestLog) count(now time.Time) int {
+	hour := now.Unix() / 3600
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := 0
+	for _, b := range l.buckets {
+		if hour-b.hour < int64(len(l.buckets)) {
+			n += b.n
+		}
+	}
+	return n
+}
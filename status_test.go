@@ -0,0 +1,125 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestClient_Status(t *testing.T) {
+	detailData, err := os.ReadFile("testdata/detail_response.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(detailData)
+	}))
+	defer server.Close()
+
+	client, err := New(WithAPIKey("secret-api-key"), WithBaseURL(server.URL), WithRateLimit(1000))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetPlantDetails(context.Background(), "monstera-deliciosa", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+
+	got := client.Status()
+	want := Status{
+		BaseURL: server.URL,
+		Auth:    "api-key",
+		Cache:   CacheStatus{Enabled: true, Entries: 2, Hits: 2, Misses: 1},
+		Quota:   QuotaStatus{Limit: 1000, Used: 1, Remaining: 999},
+	}
+	if got != want {
+		t.Errorf("Status() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_Status_Unlimited(t *testing.T) {
+	client, err := New(WithAPIKey("test-key"), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	got := client.Status()
+	if got.Quota != (QuotaStatus{}) {
+		t.Errorf("Quota = %+v, want zero value when unlimited", got.Quota)
+	}
+	if got.Cache.Enabled || got.Cache.Entries != -1 {
+		t.Errorf("Cache = %+v, want disabled with unknown size", got.Cache)
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("limit") != "1" {
+				t.Errorf("limit = %q, want 1", r.URL.Query().Get("limit"))
+			}
+			w.Write([]byte(`{"count":0,"next":null,"previous":null,"results":[]}`))
+		}))
+		defer server.Close()
+
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.Ping(context.Background()); err != nil {
+			t.Errorf("Ping() unexpected error: %v", err)
+		}
+		if quota := client.Status().Quota; quota != (QuotaStatus{Used: 1}) {
+			t.Errorf("Quota = %+v, want 1 used and no limit", quota)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client, err := New(WithAPIKey("test-key"), WithBaseURL(server.URL), DisableRateLimit())
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.Ping(context.Background()); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("Ping() error = %v, want ErrUnauthorized", err)
+		}
+	})
+}
+
+func TestRequestLog(t *testing.T) {
+	var l requestLog
+	start := time.Date(2025, 6, 1, 0, 30, 0, 0, time.UTC)
+
+	l.add(start)
+	l.add(start.Add(time.Hour))
+	l.add(start.Add(time.Hour))
+	if n := l.count(start.Add(time.Hour)); n != 3 {
+		t.Errorf("count() = %d, want 3", n)
+	}
+
+	// The first request leaves the window after 24 hours
+	if n := l.count(start.Add(24 * time.Hour)); n != 2 {
+		t.Errorf("count() after 24h = %d, want 2", n)
+	}
+
+	// A bucket reused a day later starts from zero
+	l.add(start.Add(25 * time.Hour))
+	if n := l.count(start.Add(25 * time.Hour)); n != 1 {
+		t.Errorf("count() after reuse = %d, want 1", n)
+	}
+}
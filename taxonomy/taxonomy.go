@@ -0,0 +1,98 @@
+// Package taxonomy parses OpenPlantbook plant names into genus, species and
+// cultivar, and groups plants for tree-style browsing.
+//
+// Plantbook's DisplayPID holds the scientific name as curated ("Epipremnum
+// aureum 'Marble Queen'"), while the PID is its lowercase form with quotes
+// removed ("epipremnum aureum marble queen"). Parsing follows botanical
+// conventions: species epithets are lowercase, cultivar names are quoted or
+// capitalized, and hybrids are marked with "×" (or "x").
+package taxonomy
+
+import (
+	"sort"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+	"github.com/rmrfslashbin/openplantbook-go/internal/botanical"
+)
+
+// Name is a parsed scientific plant name
+type Name struct {
+	Genus string `json:"genus"`
+
+	// Species is the specific epithet ("deliciosa"); empty for genus-only
+	// names and unspecified species ("sp.")
+	Species string `json:"species,omitempty"`
+
+	// Rank and Infraspecific hold a variety, subspecies or form, e.g.
+	// "var." and "laurentii"
+	Rank          string `json:"rank,omitempty"`
+	Infraspecific string `json:"infraspecific,omitempty"`
+
+	// Cultivar is the cultivar name without quotes ("Marble Queen")
+	Cultivar string `json:"cultivar,omitempty"`
+
+	// Hybrid is true for names marked as hybrids
+	Hybrid bool `json:"hybrid,omitempty"`
+}
+
+// Parse splits a scientific name into its parts
+// Unparseable input yields a Name with only Genus set to the first word.
+func Parse(name string) Name {
+	return Name(botanical.Parse(name))
+}
+
+// ParsePlant parses a search result's DisplayPID, falling back to its PID
+func ParsePlant(p openplantbook.PlantSearchResult) Name {
+	if p.DisplayPID != "" {
+		return Parse(p.DisplayPID)
+	}
+	return Parse(p.PID)
+}
+
+// Binomial returns "Genus species", or just the genus when the species is
+// unknown
+func (n Name) Binomial() string {
+	return botanical.Name(n).Binomial()
+}
+
+// String formats the name in botanical style, e.g.
+// "Sansevieria trifasciata var. laurentii" or "Epipremnum aureum 'Marble Queen'"
+func (n Name) String() string {
+	return botanical.Name(n).String()
+}
+
+// Group is a genus and the plants that belong to it
+type Group struct {
+	Genus  string                            `json:"genus"`
+	Plants []openplantbook.PlantSearchResult `json:"plants"`
+}
+
+// GroupByGenus groups plants by genus
+// Groups are sorted by genus, and plants within a group by their parsed
+// name. Plants whose name cannot be parsed are grouped under an empty genus.
+func GroupByGenus(results []openplantbook.PlantSearchResult) []Group {
+	names := make(map[string]Name, len(results))
+	index := make(map[string]int)
+	var groups []Group
+
+	for _, r := range results {
+		name := ParsePlant(r)
+		names[r.PID] = name
+
+		i, ok := index[name.Genus]
+		if !ok {
+			i = len(groups)
+			index[name.Genus] = i
+			groups = append(groups, Group{Genus: name.Genus})
+		}
+		groups[i].Plants = append(groups[i].Plants, r)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Genus < groups[j].Genus })
+	for _, g := range groups {
+		sort.SliceStable(g.Plants, func(i, j int) bool {
+			return names[g.Plants[i].PID].String() < names[g.Plants[j].PID].String()
+		})
+	}
+	return groups
+}
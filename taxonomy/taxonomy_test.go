@@ -0,0 +1,70 @@
+package taxonomy
+
+import (
+	"testing"
+
+	openplantbook "github.com/rmrfslashbin/openplantbook-go"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   Name
+		format string
+	}{
+		{"Monstera deliciosa", Name{Genus: "Monstera", Species: "deliciosa"}, "Monstera deliciosa"},
+		{"monstera deliciosa", Name{Genus: "Monstera", Species: "deliciosa"}, "Monstera deliciosa"},
+		{"Epipremnum aureum 'Marble Queen'",
+			Name{Genus: "Epipremnum", Species: "aureum", Cultivar: "Marble Queen"}, "Epipremnum aureum 'Marble Queen'"},
+		{"epipremnum aureum marble queen",
+			Name{Genus: "Epipremnum", Species: "aureum", Cultivar: "Marble Queen"}, "Epipremnum aureum 'Marble Queen'"},
+		{"Philodendron ‘Pink Princess’",
+			Name{Genus: "Philodendron", Cultivar: "Pink Princess"}, "Philodendron 'Pink Princess'"},
+		{"Philodendron Birkin", Name{Genus: "Philodendron", Cultivar: "Birkin"}, "Philodendron 'Birkin'"},
+		{"Sansevieria trifasciata var. laurentii",
+			Name{Genus: "Sansevieria", Species: "trifasciata", Rank: "var.", Infraspecific: "laurentii"},
+			"Sansevieria trifasciata var. laurentii"},
+		{"Begonia × hybrida", Name{Genus: "Begonia", Species: "hybrida", Hybrid: true}, "Begonia × hybrida"},
+		{"x Alcantarea", Name{Genus: "Alcantarea", Hybrid: true}, "Alcantarea"},
+		{"Calathea sp.", Name{Genus: "Calathea"}, "Calathea"},
+		{"Ficus elastica cv. Tineke", Name{Genus: "Ficus", Species: "elastica", Cultivar: "Tineke"}, "Ficus elastica 'Tineke'"},
+		{"", Name{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Parse(tt.input)
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			if s := got.String(); s != tt.format {
+				t.Errorf("String() = %q, want %q", s, tt.format)
+			}
+		})
+	}
+}
+
+func TestGroupByGenus(t *testing.T) {
+	results := []openplantbook.PlantSearchResult{
+		{PID: "monstera deliciosa", DisplayPID: "Monstera deliciosa"},
+		{PID: "ficus lyrata", DisplayPID: "Ficus lyrata"},
+		{PID: "monstera adansonii", DisplayPID: "Monstera adansonii"},
+		{PID: "ficus elastica"},
+	}
+
+	groups := GroupByGenus(results)
+	if len(groups) != 2 {
+		t.Fatalf("GroupByGenus() returned %d groups, want 2", len(groups))
+	}
+	if groups[0].Genus != "Ficus" || groups[1].Genus != "Monstera" {
+		t.Errorf("genera = %q, %q, want Ficus, Monstera", groups[0].Genus, groups[1].Genus)
+	}
+
+	monstera := groups[1].Plants
+	if len(monstera) != 2 || monstera[0].PID != "monstera adansonii" || monstera[1].PID != "monstera deliciosa" {
+		t.Errorf("Monstera plants = %+v, want adansonii then deliciosa", monstera)
+	}
+	if groups[0].Plants[0].PID != "ficus elastica" {
+		t.Errorf("first Ficus = %q, want ficus elastica (parsed from PID)", groups[0].Plants[0].PID)
+	}
+}
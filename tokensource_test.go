@@ -0,0 +1,105 @@
+package openplantbook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// countingTokenSource hands out a fixed token, counting calls
+type countingTokenSource struct {
+	calls atomic.Int32
+}
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	s.calls.Add(1)
+	return &oauth2.Token{AccessToken: "delegated-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func TestWithTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer delegated-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer server.Close()
+
+	ts := &countingTokenSource{}
+	client, err := New(WithTokenSource(ts), WithBaseURL(server.URL), DisableRateLimit(), DisableCache())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPlantDetails(context.Background(), "test", nil); err != nil {
+			t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+		}
+	}
+	if n := ts.calls.Load(); n != 1 {
+		t.Errorf("token source calls = %d, want 1 (token reused until expiry)", n)
+	}
+	if auth := client.Status().Auth; auth != "oauth2-token-source" {
+		t.Errorf("Status().Auth = %q, want oauth2-token-source", auth)
+	}
+}
+
+func TestWithTokenSource_Conflicts(t *testing.T) {
+	ts := &countingTokenSource{}
+	if _, err := New(WithTokenSource(ts), WithAPIKey("key")); !errors.Is(err, ErrMultipleAuthMethods) {
+		t.Errorf("token source with API key: error = %v, want ErrMultipleAuthMethods", err)
+	}
+	if _, err := New(WithTokenSource(ts), WithOAuth2Scopes("read")); err == nil {
+		t.Error("scopes with a token source: expected error")
+	}
+	if _, err := New(WithTokenSource(nil)); err == nil {
+		t.Error("nil token source: expected error")
+	}
+}
+
+func TestWithOAuth2Scopes(t *testing.T) {
+	var scope atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token/" {
+			r.ParseForm()
+			scope.Store(r.PostForm.Get("scope"))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"t","token_type":"bearer","expires_in":3600}`))
+			return
+		}
+		w.Write([]byte(`{"pid":"test"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(WithOAuth2("id", "secret"), WithOAuth2Scopes("read", "write"),
+		WithBaseURL(server.URL), DisableRateLimit())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.GetPlantDetails(context.Background(), "test", nil); err != nil {
+		t.Fatalf("GetPlantDetails() unexpected error: %v", err)
+	}
+	if got, _ := scope.Load().(string); strings.Join(strings.Fields(got), " ") != "read write" {
+		t.Errorf("requested scope = %q, want \"read write\"", got)
+	}
+
+	for _, scopes := range [][]string{nil, {""}} {
+		if _, err := New(WithOAuth2("id", "secret"), WithOAuth2Scopes(scopes...)); err == nil {
+			t.Errorf("WithOAuth2Scopes(%q): expected error", scopes)
+		}
+	}
+	if _, err := New(WithAPIKey("key"), WithOAuth2Scopes("read")); err == nil {
+		t.Error("scopes with an API key: expected error")
+	}
+}
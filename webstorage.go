@@ -0,0 +1,131 @@
+package openplantbook
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// DefaultWebStoragePrefix is the key prefix of NewWebStorageCache when none
+// is given
+const DefaultWebStoragePrefix = "openplantbook:"
+
+// WebStorage is a string key/value store with the semantics of the browser
+// Web Storage API (window.localStorage and window.sessionStorage)
+//
+// Under GOOS=js, LocalStorage and SessionStorage return the browser's
+// stores; other implementations can back a WebStorageCache in tests or in
+// other JavaScript hosts.
+type WebStorage interface {
+	// GetItem returns the value of key and whether it exists
+	GetItem(key string) (string, bool)
+
+	// SetItem stores value under key; it fails when the storage quota is
+	// exceeded
+	SetItem(key, value string) error
+
+	// RemoveItem deletes key
+	RemoveItem(key string)
+
+	// Keys returns every key in the store
+	Keys() []string
+}
+
+// WebStorageCache implements Cache on a WebStorage, so a client compiled to
+// WebAssembly keeps its cached responses across page loads
+//
+// Web Storage holds strings, so entries are stored as JSON with their expiry
+// (values are base64-encoded). Keys are prefixed, and Clear only removes
+// prefixed keys, so the cache can share a store with the rest of a page.
+// Expired entries are removed when read, and when a write exceeds the
+// storage quota, after which the write is retried once; failed writes are
+// ignored, as with any cache miss.
+type WebStorageCache struct {
+	storage WebStorage
+	prefix  string
+}
+
+// webStorageEntry is the stored form of a cache entry
+type webStorageEntry struct {
+	Value     []byte    `json:"v"`
+	ExpiresAt time.Time `json:"e"`
+}
+
+// NewWebStorageCache creates a cache on storage, storing keys under prefix
+// (DefaultWebStoragePrefix if empty)
+func NewWebStorageCache(storage WebStorage, prefix string) (*WebStorageCache, error) {
+	if storage == nil {
+		return nil, ErrInvalidConfig("web storage cannot be nil")
+	}
+	if prefix == "" {
+		prefix = DefaultWebStoragePrefix
+	}
+	return &WebStorageCache{storage: storage, prefix: prefix}, nil
+}
+
+// Get retrieves a value from the cache
+func (c *WebStorageCache) Get(key string) ([]byte, bool) {
+	raw, ok := c.storage.GetItem(c.prefix + key)
+	if !ok {
+		return nil, false
+	}
+	var entry webStorageEntry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil || time.Now().After(entry.ExpiresAt) {
+		c.storage.RemoveItem(c.prefix + key)
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Set stores a value in the cache with a TTL
+func (c *WebStorageCache) Set(key string, value []byte, ttl time.Duration) {
+	raw, err := json.Marshal(webStorageEntry{Value: value, ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return
+	}
+	if c.storage.SetItem(c.prefix+key, string(raw)) != nil {
+		c.removeExpired()
+		c.storage.SetItem(c.prefix+key, string(raw))
+	}
+}
+
+// Delete removes a value from the cache
+func (c *WebStorageCache) Delete(key string) {
+	c.storage.RemoveItem(c.prefix + key)
+}
+
+// Len returns the number of entries, including expired entries that have
+// not been read since they expired
+func (c *WebStorageCache) Len() int {
+	return len(c.keys())
+}
+
+// Clear removes all values from the cache, leaving keys without the prefix
+func (c *WebStorageCache) Clear() {
+	for _, key := range c.keys() {
+		c.storage.RemoveItem(key)
+	}
+}
+
+// keys returns the stored keys with the cache's prefix
+func (c *WebStorageCache) keys() []string {
+	var keys []string
+	for _, key := range c.storage.Keys() {
+		if strings.HasPrefix(key, c.prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// removeExpired frees space by removing expired and unreadable entries
+func (c *WebStorageCache) removeExpired() {
+	now := time.Now()
+	for _, key := range c.keys() {
+		raw, _ := c.storage.GetItem(key)
+		var entry webStorageEntry
+		if json.Unmarshal([]byte(raw), &entry) != nil || now.After(entry.ExpiresAt) {
+			c.storage.RemoveItem(key)
+		}
+	}
+}
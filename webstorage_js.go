@@ -0,0 +1,84 @@
+//go:build js && wasm
+
+package openplantbook
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// LocalStorage returns the browser's window.localStorage, which persists
+// across page loads and is shared by the pages of an origin
+// It fails where the store is unavailable, e.g. in a Web Worker, under
+// Node.js, or when the user has disabled site data.
+//
+//	storage, err := openplantbook.LocalStorage()
+//	cache, err := openplantbook.NewWebStorageCache(storage, "")
+//	client, err := openplantbook.New(openplantbook.WithAPIKey(apiKey), openplantbook.WithCache(cache))
+func LocalStorage() (WebStorage, error) {
+	return webStorage("localStorage")
+}
+
+// SessionStorage returns the browser's window.sessionStorage, which lasts
+// as long as the tab
+func SessionStorage() (WebStorage, error) {
+	return webStorage("sessionStorage")
+}
+
+// webStorage looks up a global Web Storage object
+// Reading it throws a SecurityError when site data is disabled.
+func webStorage(name string) (s WebStorage, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s, err = nil, ErrInvalidConfig(fmt.Sprintf("%s is not available: %v", name, r))
+		}
+	}()
+	v := js.Global().Get(name)
+	if v.IsUndefined() || v.IsNull() {
+		return nil, ErrInvalidConfig(name + " is not available")
+	}
+	return jsStorage{v}, nil
+}
+
+// jsStorage adapts a JavaScript Storage object to WebStorage
+type jsStorage struct {
+	v js.Value
+}
+
+// GetItem implements WebStorage
+func (s jsStorage) GetItem(key string) (string, bool) {
+	v := s.v.Call("getItem", key)
+	if v.IsNull() {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// SetItem implements WebStorage
+// setItem throws a QuotaExceededError when the store is full.
+func (s jsStorage) SetItem(key, value string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("web storage: %v", r)
+		}
+	}()
+	s.v.Call("setItem", key, value)
+	return nil
+}
+
+// RemoveItem implements WebStorage
+func (s jsStorage) RemoveItem(key string) {
+	s.v.Call("removeItem", key)
+}
+
+// Keys implements WebStorage
+func (s jsStorage) Keys() []string {
+	n := s.v.Get("length").Int()
+	keys := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		if k := s.v.Call("key", i); !k.IsNull() {
+			keys = append(keys, k.String())
+		}
+	}
+	return keys
+}
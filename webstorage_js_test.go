@@ -0,0 +1,67 @@
+//go:build js && wasm
+
+package openplantbook
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// installStorage defines a global Web Storage stand-in under name, which
+// throws like a full store when an item exceeds limit characters
+func installStorage(t *testing.T, name string, limit int) {
+	t.Helper()
+	js.Global().Call("eval", `(function(name, limit) {
+		const m = new Map();
+		globalThis[name] = {
+			getItem: (k) => m.has(k) ? m.get(k) : null,
+			setItem: (k, v) => {
+				if (String(v).length > limit) throw new Error("QuotaExceededError");
+				m.set(k, String(v));
+			},
+			removeItem: (k) => { m.delete(k); },
+			key: (i) => { const keys = [...m.keys()]; return i < keys.length ? keys[i] : null; },
+			get length() { return m.size; },
+		};
+	})`).Invoke(name, limit)
+	t.Cleanup(func() { js.Global().Delete(name) })
+}
+
+func TestLocalStorage(t *testing.T) {
+	installStorage(t, "localStorage", 1000)
+	storage, err := LocalStorage()
+	if err != nil {
+		t.Fatalf("LocalStorage() unexpected error: %v", err)
+	}
+	cache, _ := NewWebStorageCache(storage, "")
+
+	cache.Set("detail:monstera", []byte(`{"pid":"monstera"}`), time.Hour)
+	if got, ok := cache.Get("detail:monstera"); !ok || string(got) != `{"pid":"monstera"}` {
+		t.Errorf("Get() = %q, %v", got, ok)
+	}
+	if n := cache.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+
+	// A QuotaExceededError is a failed write, not a panic
+	if err := storage.SetItem("big", string(make([]byte, 2000))); err == nil {
+		t.Error("SetItem() over quota expected error, got nil")
+	}
+	cache.Set("big", make([]byte, 2000), time.Hour)
+	if _, ok := cache.Get("big"); ok {
+		t.Error("Get() returned an entry exceeding the quota")
+	}
+
+	cache.Clear()
+	if n := cache.Len(); n != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", n)
+	}
+}
+
+func TestSessionStorage_Unavailable(t *testing.T) {
+	js.Global().Delete("sessionStorage")
+	if _, err := SessionStorage(); err == nil {
+		t.Error("SessionStorage() expected error without a sessionStorage global, got nil")
+	}
+}
@@ -0,0 +1,124 @@
+package openplantbook
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// memStorage is a WebStorage holding at most quota bytes of values
+type memStorage struct {
+	items map[string]string
+	quota int
+}
+
+func newMemStorage(quota int) *memStorage {
+	return &memStorage{items: make(map[string]string), quota: quota}
+}
+
+func (s *memStorage) GetItem(key string) (string, bool) {
+	v, ok := s.items[key]
+	return v, ok
+}
+
+func (s *memStorage) SetItem(key, value string) error {
+	used := len(value)
+	for k, v := range s.items {
+		if k != key {
+			used += len(v)
+		}
+	}
+	if s.quota > 0 && used > s.quota {
+		return errors.New("QuotaExceededError")
+	}
+	s.items[key] = value
+	return nil
+}
+
+func (s *memStorage) RemoveItem(key string) {
+	delete(s.items, key)
+}
+
+func (s *memStorage) Keys() []string {
+	keys := make([]string, 0, len(s.items))
+	for k := range s.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestWebStorageCache(t *testing.T) {
+	storage := newMemStorage(0)
+	storage.SetItem("theme", "dark") // the page's own data
+	cache, err := NewWebStorageCache(storage, "")
+	if err != nil {
+		t.Fatalf("NewWebStorageCache() unexpected error: %v", err)
+	}
+
+	key := "detail:monstera deliciosa:<nil>"
+	if _, ok := cache.Get(key); ok {
+		t.Error("Get() returned true for non-existent key")
+	}
+	value := []byte("{\"pid\":\"monstera deliciosa\"}\x00\xff")
+	cache.Set(key, value, time.Hour)
+	if got, ok := cache.Get(key); !ok || string(got) != string(value) {
+		t.Errorf("Get() = %q, %v; want %q", got, ok, value)
+	}
+	if _, ok := storage.GetItem(DefaultWebStoragePrefix + key); !ok {
+		t.Errorf("entry not stored under the prefix; keys %q", storage.Keys())
+	}
+
+	cache.Set("short", []byte("v"), -time.Second)
+	if _, ok := cache.Get("short"); ok {
+		t.Error("Get() returned an expired entry")
+	}
+	if _, ok := storage.GetItem(DefaultWebStoragePrefix + "short"); ok {
+		t.Error("expired entry not removed on read")
+	}
+
+	storage.SetItem(DefaultWebStoragePrefix+"corrupt", "{")
+	if _, ok := cache.Get("corrupt"); ok {
+		t.Error("Get() returned a corrupt entry")
+	}
+
+	cache.Set("other", []byte("v"), time.Hour)
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+	cache.Delete("other")
+	cache.Clear()
+	if keys := storage.Keys(); !slices.Equal(keys, []string{"theme"}) {
+		t.Errorf("keys after Clear() = %q, want only the page's own", keys)
+	}
+}
+
+func TestWebStorageCache_Quota(t *testing.T) {
+	storage := newMemStorage(200)
+	cache, _ := NewWebStorageCache(storage, "opb:")
+
+	cache.Set("old", make([]byte, 60), -time.Second) // expired, never read
+	cache.Set("new", make([]byte, 60), time.Hour)
+	if _, ok := cache.Get("new"); !ok {
+		t.Fatal("write over quota not retried after removing expired entries")
+	}
+	if _, ok := storage.GetItem("opb:old"); ok {
+		t.Error("expired entry kept")
+	}
+
+	// A write that cannot fit is dropped without disturbing live entries
+	cache.Set("huge", make([]byte, 500), time.Hour)
+	if _, ok := cache.Get("huge"); ok {
+		t.Error("Get() returned an entry exceeding the quota")
+	}
+	if _, ok := cache.Get("new"); !ok {
+		t.Error("live entry removed to make room")
+	}
+}
+
+func TestNewWebStorageCache_Nil(t *testing.T) {
+	var configErr *ConfigError
+	if _, err := NewWebStorageCache(nil, ""); !errors.As(err, &configErr) {
+		t.Errorf("NewWebStorageCache(nil) error = %v, want *ConfigError", err)
+	}
+}